@@ -1,18 +1,24 @@
 package main
 
 import (
+	"context"
 	"database/sql"
 	_ "database/sql"
+	"fmt"
 	"github.com/asaskevich/govalidator"
 	teleHandlers "github.com/calendar-bot/pkg/bots/telegram/handlers"
 	"github.com/calendar-bot/pkg/config"
 	eRepo "github.com/calendar-bot/pkg/events/repository"
 	eUsecase "github.com/calendar-bot/pkg/events/usecase"
+	"github.com/calendar-bot/pkg/jobs"
 	"github.com/calendar-bot/pkg/log"
 	"github.com/calendar-bot/pkg/middlewares"
+	"github.com/calendar-bot/pkg/services/callbacks"
 	"github.com/calendar-bot/pkg/services/db"
+	"github.com/calendar-bot/pkg/services/keyring"
 	"github.com/calendar-bot/pkg/services/oauth"
 	redisService "github.com/calendar-bot/pkg/services/redis"
+	"github.com/calendar-bot/pkg/services/sessions"
 	uHandlers "github.com/calendar-bot/pkg/users/handlers"
 	uRepo "github.com/calendar-bot/pkg/users/repository"
 	uUsecase "github.com/calendar-bot/pkg/users/usecase"
@@ -23,34 +29,65 @@ import (
 	_ "github.com/lib/pq"
 	"go.uber.org/zap"
 	tb "gopkg.in/tucnak/telebot.v2"
+	"os"
+	"time"
+)
+
+const (
+	migrateCommand         = "migrate"
+	reencryptTokensCommand = "reencrypt-tokens"
 )
 
 type RequestHandlers struct {
 	userHandlers             uHandlers.UserHandlers
 	telegramBaseHandlers     teleHandlers.BaseHandlers
-	telegramCalendarHandlers teleHandlers.CalendarHandlers
+	telegramCalendarHandlers *teleHandlers.CalendarHandlers
+	telegramSettingsCache    *teleHandlers.SettingsCache
+	backgroundJobs           []jobs.Job
 }
 
-func newRequestHandler(db *sql.DB, client *redis.Client, botClient *redis.Client, conf *config.AppConfig) RequestHandlers {
+func newRequestHandler(db *sql.DB, client *redis.Client, botClient *redis.Client, bot *tb.Bot,
+	keyRing *keyring.KeyRing, conf *config.AppConfig) RequestHandlers {
+
 	oauthService := oauth.NewService(&conf.OAuth, client)
 
-	userStorage := uRepo.NewUserRepository(db)
-	userUseCase := uUsecase.NewUserUseCase(userStorage, &oauthService)
+	userStorage := uRepo.NewUserRepository(db, keyRing)
+	userUseCase := uUsecase.NewUserUseCase(userStorage, &oauthService, conf.BotDefaultUserTimezone)
 	userHandlers := uHandlers.NewUserHandlers(userUseCase)
 
 	eventStorage := eRepo.NewEventStorage(db)
-	eventUseCase := eUsecase.NewEventUseCase(eventStorage)
+	eventUseCase := eUsecase.NewEventUseCase(eventStorage, conf.CallAPIURL)
 
-	teleBaseHandlers := teleHandlers.NewBaseHandlers(eventUseCase, userUseCase, conf.ParseAddress)
-	teleCalendarHandler := teleHandlers.NewCalendarHandlers(eventUseCase, userUseCase, botClient, conf.ParseAddress)
+	settingsCache := teleHandlers.NewSettingsCache(userUseCase, botClient)
+	teleBaseHandlers := teleHandlers.NewBaseHandlers(eventUseCase, userUseCase, settingsCache, conf.ParseAddress)
+	callbackStore := callbacks.NewStore(&conf.CallbackStore, botClient)
+	callbackSigner := callbacks.NewSigner(&conf.CallbackStore, &callbackStore)
+	teleCalendarHandler := teleHandlers.NewCalendarHandlers(eventUseCase, userUseCase, botClient, callbackStore,
+		callbackSigner, sessions.NewRedisSessionStore(&conf.Sessions, botClient), settingsCache, conf.ParseAddress,
+		conf.Rooms)
 
 	return RequestHandlers{
 		userHandlers:             userHandlers,
 		telegramBaseHandlers:     teleBaseHandlers,
-		telegramCalendarHandlers: teleCalendarHandler,
+		telegramCalendarHandlers: &teleCalendarHandler,
+		telegramSettingsCache:    settingsCache,
+		backgroundJobs: []jobs.Job{
+			exclusiveJob(db, "focus time job", jobs.NewFocusTimeJob(eventUseCase, userUseCase, &teleCalendarHandler)),
+			exclusiveJob(db, "invitation sync job",
+				jobs.NewInvitationSyncJob(eventUseCase, userUseCase, bot, &teleCalendarHandler)),
+			exclusiveJob(db, "posted events sync job",
+				jobs.NewPostedEventsSyncJob(eventUseCase, userUseCase, &teleCalendarHandler)),
+			exclusiveJob(db, "notification job",
+				jobs.NewNotificationJob(eventUseCase, userUseCase, &teleCalendarHandler)),
+		},
 	}
 }
 
+// exclusiveJob runs the job on a single instance of the bot, the lock is shared by all instances using the db
+func exclusiveJob(dbConnection *sql.DB, name string, job jobs.Job) jobs.Job {
+	return jobs.Exclusive(name, job, db.NewAdvisoryLock(dbConnection, "calendar-bot "+name))
+}
+
 func init() {
 	// nickeskov: error != nil if no .env file
 	dotenvErr := godotenv.Load()
@@ -68,11 +105,25 @@ func init() {
 }
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == migrateCommand {
+		runMigrate(os.Args[2:])
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == reencryptTokensCommand {
+		runReencryptTokens()
+		return
+	}
+
 	appConf, err := config.LoadAppConfig()
 	if err != nil {
 		zap.S().Fatalf("cannot load APP config: %v", err)
 	}
 
+	keyRing, err := keyring.NewKeyRing(&appConf.TokenKeys)
+	if err != nil {
+		zap.S().Fatalf("cannot create token key ring: %v", err)
+	}
+
 	webhook := &tb.Webhook{
 		Listen:   appConf.BotAddress,
 		Endpoint: &tb.WebhookEndpoint{PublicURL: appConf.BotWebhookUrl},
@@ -105,6 +156,12 @@ func main() {
 		}
 	}()
 
+	if appConf.DB.AutoMigrate {
+		if err := migrateUp(dbConnection); err != nil {
+			zap.S().Fatalf("failed to migrate db, %v", err)
+		}
+	}
+
 	redisClient, err := redisService.ConnectToRedis(&appConf.Redis)
 	if err != nil {
 		zap.S().Fatalf("failed to connect to redis, %v", err)
@@ -115,17 +172,132 @@ func main() {
 		zap.S().Fatalf("failed to connect to bot redis, %v", err)
 	}
 
-	allHandler := newRequestHandler(dbConnection, redisClient, botRedisClient, &appConf)
+	allHandler := newRequestHandler(dbConnection, redisClient, botRedisClient, bot, &keyRing, &appConf)
 
 	server.Use(middlewares.LogErrorMiddleware)
 
 	allHandler.userHandlers.InitHandlers(server)
 	allHandler.telegramBaseHandlers.InitHandlers(bot)
 	allHandler.telegramCalendarHandlers.InitHandlers(bot)
+	bot.Poller = tb.NewMiddlewarePoller(bot.Poller, allHandler.telegramSettingsCache.LoadForUpdate)
+	bot.Poller = tb.NewMiddlewarePoller(bot.Poller, allHandler.telegramCalendarHandlers.TrackChatMembers)
 
 	echoProm.NewPrometheus("http", nil).Use(server)
 
+	jobsCtx, stopJobs := context.WithCancel(context.Background())
+	defer stopJobs()
+	for _, job := range allHandler.backgroundJobs {
+		go job.Run(jobsCtx)
+	}
+
 	go func() { zap.S().Fatal(server.Start(appConf.Address)) }()
 
 	bot.Start()
 }
+
+// runMigrate handles "migrate up|down|status", down reverts the last applied migration only
+func runMigrate(args []string) {
+	if len(args) != 1 {
+		zap.S().Fatalf("usage: %s %s up|down|status", os.Args[0], migrateCommand)
+	}
+
+	dbConf, err := db.LoadDBConfig()
+	if err != nil {
+		zap.S().Fatalf("cannot load DB config: %v", err)
+	}
+	dbConnection, err := db.ConnectToPostgresDB(&dbConf)
+	if err != nil {
+		zap.S().Fatalf("failed to connect to db, %v", err)
+	}
+	defer func() {
+		err := dbConnection.Close()
+		if err != nil {
+			zap.S().Errorf("failed to close db connection, %v", err)
+		}
+	}()
+
+	migrations, err := db.Migrations()
+	if err != nil {
+		zap.S().Fatalf("failed to load migrations, %v", err)
+	}
+	migrator := db.NewMigrator(dbConnection, migrations)
+
+	switch args[0] {
+	case "up":
+		err = migrateUp(dbConnection)
+	case "down":
+		var reverted *db.Migration
+		reverted, err = migrator.Down(context.Background())
+		if err == nil && reverted == nil {
+			zap.S().Info("no migrations to revert")
+		} else if err == nil {
+			zap.S().Infof("reverted migration %d_%s", reverted.Version, reverted.Name)
+		}
+	case "status":
+		var statuses []db.MigrationStatus
+		statuses, err = migrator.Status(context.Background())
+		for _, status := range statuses {
+			state := "pending"
+			if status.AppliedAt != nil {
+				state = "applied at " + status.AppliedAt.Format(time.RFC3339)
+			}
+			fmt.Printf("%04d %-30s %s\n", status.Version, status.Name, state)
+		}
+	default:
+		zap.S().Fatalf("unknown migrate command %q, expected up, down or status", args[0])
+	}
+	if err != nil {
+		zap.S().Fatalf("failed to migrate db, %v", err)
+	}
+}
+
+func migrateUp(dbConnection *sql.DB) error {
+	migrations, err := db.Migrations()
+	if err != nil {
+		return err
+	}
+	migrator := db.NewMigrator(dbConnection, migrations)
+
+	applied, err := migrator.Up(context.Background())
+	for _, migration := range applied {
+		zap.S().Infof("applied migration %d_%s", migration.Version, migration.Name)
+	}
+	if err == nil && len(applied) == 0 {
+		zap.S().Info("db schema is up to date")
+	}
+	return err
+}
+
+// runReencryptTokens encrypts the refresh tokens with the active key after it is rotated
+func runReencryptTokens() {
+	dbConf, err := db.LoadDBConfig()
+	if err != nil {
+		zap.S().Fatalf("cannot load DB config: %v", err)
+	}
+	tokenKeysConf, err := keyring.LoadTokenKeysConfig()
+	if err != nil {
+		zap.S().Fatalf("cannot load token keys config: %v", err)
+	}
+	keyRing, err := keyring.NewKeyRing(&tokenKeysConf)
+	if err != nil {
+		zap.S().Fatalf("cannot create token key ring: %v", err)
+	}
+
+	dbConnection, err := db.ConnectToPostgresDB(&dbConf)
+	if err != nil {
+		zap.S().Fatalf("failed to connect to db, %v", err)
+	}
+	defer func() {
+		err := dbConnection.Close()
+		if err != nil {
+			zap.S().Errorf("failed to close db connection, %v", err)
+		}
+	}()
+
+	userStorage := uRepo.NewUserRepository(dbConnection, &keyRing)
+	reencrypted, err := userStorage.ReencryptRefreshTokens()
+	zap.S().Infof("reencrypted %d refresh tokens with key %q", reencrypted, keyRing.ActiveKeyID())
+	if err != nil {
+		zap.S().Fatalf("failed to reencrypt refresh tokens, %v", err)
+	}
+}
@@ -1,6 +1,6 @@
 module github.com/calendar-bot
 
-go 1.15
+go 1.16
 
 require (
 	github.com/asaskevich/govalidator v0.0.0-20210307081110-f21760c49a8d
@@ -0,0 +1,13 @@
+package fsm
+
+import "fmt"
+
+// InvalidTransition is returned when the event is not allowed in the current state
+type InvalidTransition struct {
+	State State
+	Event Event
+}
+
+func (e InvalidTransition) Error() string {
+	return fmt.Sprintf("event %q is not allowed in state %q", e.Event, e.State)
+}
@@ -0,0 +1,158 @@
+package fsm
+
+import "github.com/pkg/errors"
+
+// State is a named step of a conversation
+type State string
+
+// Event moves the conversation from one state to another
+type Event string
+
+// Action is run on the subject of the conversation, e.g. the session, when it enters or leaves a state
+type Action func(subject interface{})
+
+// StateDef declares a state with its entry and exit actions, both are optional
+type StateDef struct {
+	Name    State
+	OnEnter Action
+	OnExit  Action
+}
+
+// Transition allows the event in the listed states
+type Transition struct {
+	Event Event
+	From  []State
+	To    State
+}
+
+// Definition declares the machine. The cancel event is allowed in every state and leads to the initial one
+type Definition struct {
+	Initial     State
+	Cancel      Event
+	States      []StateDef
+	Transitions []Transition
+}
+
+// Machine checks the transitions of a conversation and runs the actions of the states.
+// It keeps no state itself, the current state is stored by the caller
+type Machine struct {
+	initial     State
+	cancel      Event
+	states      map[State]StateDef
+	transitions map[State]map[Event]State
+}
+
+// New builds the machine, it fails when the definition refers to undeclared states
+func New(def Definition) (*Machine, error) {
+	m := &Machine{
+		initial:     def.Initial,
+		cancel:      def.Cancel,
+		states:      make(map[State]StateDef, len(def.States)),
+		transitions: make(map[State]map[Event]State),
+	}
+	for _, state := range def.States {
+		if _, ok := m.states[state.Name]; ok {
+			return nil, errors.Errorf("state %q is declared twice", state.Name)
+		}
+		m.states[state.Name] = state
+		m.transitions[state.Name] = make(map[Event]State)
+	}
+	if _, ok := m.states[def.Initial]; !ok {
+		return nil, errors.Errorf("initial state %q is not declared", def.Initial)
+	}
+
+	for _, t := range def.Transitions {
+		if t.Event == def.Cancel {
+			return nil, errors.Errorf("cancel event %q can not be redefined", t.Event)
+		}
+		if _, ok := m.states[t.To]; !ok {
+			return nil, errors.Errorf("event %q leads to undeclared state %q", t.Event, t.To)
+		}
+		for _, from := range t.From {
+			events, ok := m.transitions[from]
+			if !ok {
+				return nil, errors.Errorf("event %q starts in undeclared state %q", t.Event, from)
+			}
+			if _, ok := events[t.Event]; ok {
+				return nil, errors.Errorf("event %q is declared twice in state %q", t.Event, from)
+			}
+			events[t.Event] = t.To
+		}
+	}
+	return m, nil
+}
+
+// MustNew is New for the package level definitions
+func MustNew(def Definition) *Machine {
+	m, err := New(def)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+// Initial is the state of a new conversation
+func (m *Machine) Initial() State {
+	return m.initial
+}
+
+// Current maps the zero state of a fresh subject to the initial one
+func (m *Machine) Current(state State) State {
+	if state == "" {
+		return m.initial
+	}
+	return state
+}
+
+// Can reports whether the event is allowed in the state
+func (m *Machine) Can(state State, event Event) bool {
+	return m.Check(state, event) == nil
+}
+
+// Check returns InvalidTransition when the event is not allowed in the state
+func (m *Machine) Check(state State, event Event) error {
+	state = m.Current(state)
+	if _, ok := m.next(state, event); !ok {
+		return errors.WithStack(InvalidTransition{State: state, Event: event})
+	}
+	return nil
+}
+
+// next also cancels the states left by an older definition of the machine
+func (m *Machine) next(state State, event Event) (State, bool) {
+	if m.cancel != "" && event == m.cancel {
+		return m.initial, true
+	}
+	next, ok := m.transitions[state][event]
+	return next, ok
+}
+
+// Is reports whether the state is one of the listed
+func (m *Machine) Is(state State, states ...State) bool {
+	state = m.Current(state)
+	for _, s := range states {
+		if s == state {
+			return true
+		}
+	}
+	return false
+}
+
+// Fire runs the exit action of the current state and the entry action of the next one and returns the next state.
+// A transition to the same state runs both actions again. Not allowed events return InvalidTransition
+// and leave the subject untouched
+func (m *Machine) Fire(state State, event Event, subject interface{}) (State, error) {
+	state = m.Current(state)
+	next, ok := m.next(state, event)
+	if !ok {
+		return state, m.Check(state, event)
+	}
+
+	if exit := m.states[state].OnExit; exit != nil {
+		exit(subject)
+	}
+	if enter := m.states[next].OnEnter; enter != nil {
+		enter(subject)
+	}
+	return next, nil
+}
@@ -0,0 +1,129 @@
+package fsm
+
+import (
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+const (
+	idle    State = "idle"
+	editing State = "editing"
+	saving  State = "saving"
+
+	edit   Event = "edit"
+	save   Event = "save"
+	cancel Event = "cancel"
+)
+
+func newTestMachine(t *testing.T, log *[]string) *Machine {
+	record := func(name string) Action {
+		return func(subject interface{}) {
+			*log = append(*log, name+":"+subject.(string))
+		}
+	}
+	m, err := New(Definition{
+		Initial: idle,
+		Cancel:  cancel,
+		States: []StateDef{
+			{Name: idle, OnEnter: record("enter idle")},
+			{Name: editing, OnEnter: record("enter editing"), OnExit: record("exit editing")},
+			{Name: saving},
+		},
+		Transitions: []Transition{
+			{Event: edit, From: []State{idle, editing}, To: editing},
+			{Event: save, From: []State{editing}, To: saving},
+		},
+	})
+	require.NoError(t, err)
+	return m
+}
+
+func TestMachineFire(t *testing.T) {
+	var log []string
+	m := newTestMachine(t, &log)
+
+	state, err := m.Fire("", edit, "s")
+	require.NoError(t, err)
+	assert.Equal(t, editing, state)
+
+	state, err = m.Fire(state, edit, "s")
+	require.NoError(t, err)
+	assert.Equal(t, editing, state)
+
+	state, err = m.Fire(state, save, "s")
+	require.NoError(t, err)
+	assert.Equal(t, saving, state)
+
+	assert.Equal(t, []string{"enter editing:s", "exit editing:s", "enter editing:s", "exit editing:s"}, log)
+}
+
+func TestMachineInvalidTransition(t *testing.T) {
+	var log []string
+	m := newTestMachine(t, &log)
+
+	state, err := m.Fire(idle, save, "s")
+	assert.Equal(t, InvalidTransition{State: idle, Event: save}, errors.Cause(err))
+	assert.Equal(t, idle, state)
+	assert.Empty(t, log)
+	assert.False(t, m.Can("", save))
+	assert.True(t, m.Can("", edit))
+}
+
+func TestMachineCancel(t *testing.T) {
+	var log []string
+	m := newTestMachine(t, &log)
+
+	for _, state := range []State{"", idle, editing, saving, "removed"} {
+		assert.True(t, m.Can(state, cancel))
+		next, err := m.Fire(state, cancel, "s")
+		require.NoError(t, err)
+		assert.Equal(t, idle, next)
+	}
+	assert.Equal(t, []string{"enter idle:s", "enter idle:s", "exit editing:s", "enter idle:s",
+		"enter idle:s", "enter idle:s"}, log)
+
+	_, err := m.Fire("removed", edit, "s")
+	assert.Error(t, err)
+}
+
+func TestMachineIs(t *testing.T) {
+	var log []string
+	m := newTestMachine(t, &log)
+
+	assert.True(t, m.Is("", idle))
+	assert.True(t, m.Is(saving, editing, saving))
+	assert.False(t, m.Is(idle, editing, saving))
+}
+
+func TestNewRejectsBrokenDefinitions(t *testing.T) {
+	states := []StateDef{{Name: idle}, {Name: editing}}
+
+	_, err := New(Definition{Initial: saving, States: states})
+	assert.Error(t, err)
+
+	_, err = New(Definition{Initial: idle, States: append(states, StateDef{Name: idle})})
+	assert.Error(t, err)
+
+	_, err = New(Definition{Initial: idle, States: states, Transitions: []Transition{
+		{Event: edit, From: []State{idle}, To: saving},
+	}})
+	assert.Error(t, err)
+
+	_, err = New(Definition{Initial: idle, States: states, Transitions: []Transition{
+		{Event: edit, From: []State{saving}, To: editing},
+	}})
+	assert.Error(t, err)
+
+	_, err = New(Definition{Initial: idle, States: states, Transitions: []Transition{
+		{Event: edit, From: []State{idle}, To: editing},
+		{Event: edit, From: []State{idle}, To: idle},
+	}})
+	assert.Error(t, err)
+
+	_, err = New(Definition{Initial: idle, Cancel: cancel, States: states, Transitions: []Transition{
+		{Event: cancel, From: []State{editing}, To: editing},
+	}})
+	assert.Error(t, err)
+}
@@ -1,48 +1,77 @@
 package telegram
 
 const (
-	ShowFullEvent     = "SFE"
-	ShowShortEvent    = "SSE"
-	CreateEvent       = "CRE"
-	CancelCreateEvent = "CCE"
-	AlertCallbackYes  = "ACY"
-	AlertCallbackNo   = "ACN"
-	GroupGo           = "GG"
-	GroupNotGo        = "GNG"
-	GroupFindTimeYes  = "GFTY"
-	GroupFindTimeNo   = "GFTN"
-	FindTimeDayPart   = "FTDP"
-	FindTimeLength    = "FTL"
-	FindTimeAdd       = "FTA"
-	FindTimeFind      = "FTF"
-	FindTimeBack      = "FTB"
-	FindTimeCreate    = "FTC"
+	ShowFullEvent        = "SFE"
+	ShowShortEvent       = "SSE"
+	CreateEvent          = "CRE"
+	CancelCreateEvent    = "CCE"
+	AlertCallbackYes     = "ACY"
+	AlertCallbackNo      = "ACN"
+	GroupGo              = "GG"
+	GroupNotGo           = "GNG"
+	GroupFindTimeYes     = "GFTY"
+	GroupFindTimeNo      = "GFTN"
+	FindTimeDayPart      = "FTDP"
+	FindTimeLength       = "FTL"
+	FindTimeAdd          = "FTA"
+	FindTimeFind         = "FTF"
+	FindTimeBack         = "FTB"
+	FindTimeCreate       = "FTC"
+	AvailabilityWeek     = "AVW"
+	AvailabilityJoin     = "AVJ"
+	FocusDuration        = "FCD"
+	FocusMorning         = "FCM"
+	FocusOff             = "FCO"
+	InvitationRuleDelete = "IRD"
+	RoomSelect           = "RMS"
+	EventVenue           = "EVV"
+	TemplateSave         = "TPS"
+	TemplateApply        = "TPA"
+	TemplateDelete       = "TPD"
+	ShareEvent           = "SHE"
+	ShareEventGroup      = "SHG"
+	BindCalendarSelect   = "BNC"
+	BindCalendarRemove   = "BNR"
+	LanguageSelect       = "LNS"
+	SettingsOpen         = "STO"
+	SettingsSet          = "STS"
+	SettingsReset        = "STR"
 
 	HandleGroupText = "HGT"
 
-	Today = "/today"
-	Next  = "/next"
-	Date  = "/date"
+	Today        = "/today"
+	Week         = "/week"
+	Next         = "/next"
+	Now          = "/now"
+	Date         = "/date"
+	Holidays     = "/holidays"
+	Availability = "/availability"
+	Images       = "/images"
+	Focus        = "/focus"
+	Vacation     = "/vacation"
+	Rules        = "/rules"
+	Templates    = "/templates"
+	BindCalendar = "/bindcalendar"
+	Members      = "/members"
+	Language     = "/language"
+	Settings     = "/settings"
 
 	CalendarInternalEmail = "calendar@internal"
 	MailRuDomain          = "mail.ru"
 	MailRuCalendarName    = "Календарь Mail"
 
-	CalendarTypePersonal = "PERSONAL"
-	CalendarTypeHoliday  = "HOLIDAYS"
+	PostedMessagePrivate = "PRIVATE"
+	PostedMessageGroup   = "GROUP"
+	PostedMessageInline  = "INLINE"
 )
 
+// options of /settings, the data of the settings buttons
 const (
-	StepCreateInit int = iota
-	StepCreateFrom
-	StepCreateTo
-	StepCreateTitle
-	StepCreateDesc
-	StepCreateUser
-	StepCreateLocation
-
-	RoleRequired      = "REQUIRED"
-	StatusNeedsAction = "NEEDS_ACTION"
-	StatusAccepted    = "ACCEPTED"
-	StatusDeclined    = "DECLINED"
+	SettingTimezone      = "tz"
+	SettingLanguage      = "lang"
+	SettingEventDuration = "duration"
+	SettingCalendar      = "calendar"
+	SettingReminder      = "reminder"
+	SettingDigest        = "digest"
+	SettingImageMode     = "image"
 )
@@ -0,0 +1,150 @@
+package handlers
+
+import (
+	"github.com/calendar-bot/pkg/bots/telegram/messages"
+	"github.com/calendar-bot/pkg/bots/telegram/messages/calendarMessages"
+	"github.com/calendar-bot/pkg/customerrors"
+	"github.com/calendar-bot/pkg/i18n"
+	"github.com/pkg/errors"
+	tb "gopkg.in/tucnak/telebot.v2"
+	"strconv"
+	"time"
+)
+
+func (ch *CalendarHandlers) HandleAvailability(m *tb.Message) {
+	lang := ch.lang(m.Sender)
+	if m.Chat.Type == tb.ChatPrivate {
+		_, err := ch.handler.bot.Send(m.Chat, lang.T(messages.ErrorCommandIsOnlyForGroupChat))
+		if err != nil {
+			customerrors.HandlerError(err, &m.Chat.ID, &m.ID)
+		}
+		return
+	}
+	if !ch.AuthMiddleware(m.Sender, m.Chat) {
+		return
+	}
+
+	text, err := ch.availabilityText(lang, m.Sender.ID, m.Chat.ID, 0)
+	if err != nil {
+		customerrors.HandlerError(err, &m.Chat.ID, &m.ID)
+		ch.handler.SendError(m.Chat, err)
+		return
+	}
+
+	_, err = ch.handler.bot.Send(m.Chat, text, &tb.SendOptions{
+		ParseMode: tb.ModeHTML,
+		ReplyTo:   m,
+		ReplyMarkup: &tb.ReplyMarkup{
+			InlineKeyboard: ch.keyboards.AvailabilityButtons(lang, 0),
+		},
+	})
+	if err != nil {
+		customerrors.HandlerError(err, &m.Chat.ID, &m.ID)
+	}
+}
+
+func (ch *CalendarHandlers) HandleAvailabilityWeek(c *tb.Callback) {
+	if !ch.AuthMiddleware(c.Sender, c.Message.Chat) {
+		err := ch.handler.bot.Respond(c, &tb.CallbackResponse{
+			CallbackID: c.ID,
+		})
+		if err != nil {
+			customerrors.HandlerError(err, &c.Message.Chat.ID, &c.Message.ID)
+		}
+		return
+	}
+
+	err := ch.handler.bot.Respond(c, &tb.CallbackResponse{
+		CallbackID: c.ID,
+	})
+	if err != nil {
+		customerrors.HandlerError(err, &c.Message.Chat.ID, &c.Message.ID)
+	}
+
+	weekOffset, err := strconv.Atoi(c.Data)
+	if err != nil {
+		customerrors.HandlerError(err, &c.Message.Chat.ID, &c.Message.ID)
+		return
+	}
+
+	ch.editAvailability(c, weekOffset)
+}
+
+func (ch *CalendarHandlers) HandleAvailabilityJoin(c *tb.Callback) {
+	if !ch.AuthMiddleware(c.Sender, c.Message.Chat) {
+		err := ch.handler.bot.Respond(c, &tb.CallbackResponse{
+			CallbackID: c.ID,
+		})
+		if err != nil {
+			customerrors.HandlerError(err, &c.Message.Chat.ID, &c.Message.ID)
+		}
+		return
+	}
+
+	weekOffset, err := strconv.Atoi(c.Data)
+	if err != nil {
+		customerrors.HandlerError(err, &c.Message.Chat.ID, &c.Message.ID)
+		return
+	}
+
+	// the member is already in the group registry, the authenticated sender is shown after the refresh
+	err = ch.handler.bot.Respond(c, &tb.CallbackResponse{
+		CallbackID: c.ID,
+	})
+	if err != nil {
+		customerrors.HandlerError(err, &c.Message.Chat.ID, &c.Message.ID)
+	}
+
+	ch.editAvailability(c, weekOffset)
+}
+
+func (ch *CalendarHandlers) editAvailability(c *tb.Callback, weekOffset int) {
+	lang := ch.lang(c.Sender)
+	text, err := ch.availabilityText(lang, c.Sender.ID, c.Message.Chat.ID, weekOffset)
+	if err != nil {
+		customerrors.HandlerError(err, &c.Message.Chat.ID, &c.Message.ID)
+		ch.handler.SendError(c.Message.Chat, err)
+		return
+	}
+
+	_, err = ch.handler.bot.Edit(c.Message, text, &tb.SendOptions{
+		ParseMode: tb.ModeHTML,
+		ReplyMarkup: &tb.ReplyMarkup{
+			InlineKeyboard: ch.keyboards.AvailabilityButtons(lang, weekOffset),
+		},
+	})
+	if err != nil {
+		customerrors.HandlerError(err, &c.Message.Chat.ID, &c.Message.ID)
+	}
+}
+
+func (ch *CalendarHandlers) availabilityText(lang i18n.Lang, senderID int, chatID int64, weekOffset int) (string, error) {
+	members, err := ch.getChatMembers(chatID)
+	if err != nil {
+		return "", err
+	}
+
+	emails, err := ch.userUseCase.TryGetUsersEmailsByTelegramUserIDs(members)
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+
+	token, err := ch.userUseCase.GetOrRefreshOAuthAccessTokenByTelegramUserID(int64(senderID))
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+
+	start := weekStart(ch.userNow(int64(senderID)), weekOffset)
+	rows, err := ch.eventUseCase.GetUsersAvailability(token, emails, start)
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+
+	return calendarMessages.GetAvailabilityText(lang, start, rows), nil
+}
+
+// weekStart returns monday 00:00 of the week shifted by weekOffset weeks from t
+func weekStart(t time.Time, weekOffset int) time.Time {
+	daysSinceMonday := (int(t.Weekday()) + 6) % 7
+	return time.Date(t.Year(), t.Month(), t.Day()-daysSinceMonday+7*weekOffset, 0, 0, 0, 0, t.Location())
+}
@@ -17,9 +17,10 @@ type BaseHandlers struct {
 	userUseCase  uUseCase.UserUseCase
 }
 
-func NewBaseHandlers(eventUC eUseCase.EventUseCase, userUC uUseCase.UserUseCase, parseAddress string) BaseHandlers {
+func NewBaseHandlers(eventUC eUseCase.EventUseCase, userUC uUseCase.UserUseCase, settingsCache *SettingsCache,
+	parseAddress string) BaseHandlers {
 	return BaseHandlers{eventUseCase: eventUC, userUseCase: userUC,
-		handler: Handler{bot: nil, parseAddress: parseAddress}}
+		handler: Handler{bot: nil, parseAddress: parseAddress, settingsCache: settingsCache}}
 }
 
 func (bh *BaseHandlers) InitHandlers(bot *tb.Bot) {
@@ -31,8 +32,9 @@ func (bh *BaseHandlers) InitHandlers(bot *tb.Bot) {
 }
 
 func (bh *BaseHandlers) HandleStart(m *tb.Message) {
+	lang := bh.handler.lang(m.Sender)
 	if m.Chat.Type != tb.ChatPrivate {
-		_, err := bh.handler.bot.Send(m.Chat, messages.ErrorCommandIsNotAllowedInGroupChat)
+		_, err := bh.handler.bot.Send(m.Chat, lang.T(messages.ErrorCommandIsNotAllowedInGroupChat))
 		if err != nil {
 			customerrors.HandlerError(err, &m.Chat.ID, &m.ID)
 		}
@@ -53,12 +55,12 @@ func (bh *BaseHandlers) HandleStart(m *tb.Message) {
 			return
 		}
 
-		_, err = bh.handler.bot.Send(m.Chat, baseMessages.StartNoRegText(),
+		_, err = bh.handler.bot.Send(m.Chat, baseMessages.StartNoRegText(lang),
 			&tb.SendOptions{
 				ParseMode: tb.ModeHTML,
 				ReplyMarkup: &tb.ReplyMarkup{
 					ReplyKeyboardRemove: true,
-					InlineKeyboard:      baseInlineKeyboards.StartInlineKeyboard(link),
+					InlineKeyboard:      baseInlineKeyboards.StartInlineKeyboard(lang, link),
 				},
 			})
 		if err != nil {
@@ -81,7 +83,7 @@ func (bh *BaseHandlers) HandleStart(m *tb.Message) {
 		}
 
 		_, err = bh.handler.bot.Send(m.Sender,
-			baseMessages.StartRegText(info),
+			baseMessages.StartRegText(lang, info),
 			&tb.SendOptions{
 				ParseMode: tb.ModeHTML,
 				ReplyMarkup: &tb.ReplyMarkup{
@@ -96,11 +98,12 @@ func (bh *BaseHandlers) HandleStart(m *tb.Message) {
 }
 
 func (bh *BaseHandlers) HandleHelp(m *tb.Message) {
+	lang := bh.handler.lang(m.Sender)
 	var replyKeyboard [][]tb.ReplyButton = nil
 	if m.Chat.Type == tb.ChatPrivate {
 		replyKeyboard = baseKeyboards.HelpCommandKeyboard()
 	}
-	_, err := bh.handler.bot.Send(m.Chat, baseMessages.HelpInfoText(),
+	_, err := bh.handler.bot.Send(m.Chat, baseMessages.HelpInfoText(lang),
 
 		&tb.SendOptions{
 			ParseMode: tb.ModeHTML,
@@ -117,7 +120,8 @@ func (bh *BaseHandlers) HandleHelp(m *tb.Message) {
 }
 
 func (bh *BaseHandlers) HandleAbout(m *tb.Message) {
-	_, err := bh.handler.bot.Send(m.Chat, baseMessages.AboutText(), &tb.SendOptions{
+	lang := bh.handler.lang(m.Sender)
+	_, err := bh.handler.bot.Send(m.Chat, baseMessages.AboutText(lang), &tb.SendOptions{
 		ParseMode: tb.ModeHTML,
 		ReplyMarkup: &tb.ReplyMarkup{
 			ReplyKeyboardRemove: true,
@@ -130,8 +134,9 @@ func (bh *BaseHandlers) HandleAbout(m *tb.Message) {
 }
 
 func (bh *BaseHandlers) HandleStop(m *tb.Message) {
+	lang := bh.handler.lang(m.Sender)
 	if m.Chat.Type != tb.ChatPrivate {
-		_, err := bh.handler.bot.Send(m.Chat, messages.ErrorCommandIsNotAllowedInGroupChat)
+		_, err := bh.handler.bot.Send(m.Chat, lang.T(messages.ErrorCommandIsNotAllowedInGroupChat))
 		if err != nil {
 			customerrors.HandlerError(err, &m.Chat.ID, &m.ID)
 		}
@@ -139,13 +144,12 @@ func (bh *BaseHandlers) HandleStop(m *tb.Message) {
 	}
 	err := bh.userUseCase.DeleteLocalAuthenticatedUserByTelegramUserID(int64(m.Sender.ID))
 	if err != nil {
-		_, err = bh.handler.bot.Send(m.Chat, "Вы не авторизованны в боте. Для авторизации воспользуйтесь"+
-			" командой /start в личном чате с ботом")
+		_, err = bh.handler.bot.Send(m.Chat, baseMessages.StopNotAuthText(lang))
 		if err != nil {
 			customerrors.HandlerError(err, &m.Chat.ID, &m.ID)
 		}
 	} else {
-		_, err = bh.handler.bot.Send(m.Chat, "Вы успешно разлогинились")
+		_, err = bh.handler.bot.Send(m.Chat, baseMessages.StopText(lang))
 		if err != nil {
 			customerrors.HandlerError(err, &m.Chat.ID, &m.ID)
 		}
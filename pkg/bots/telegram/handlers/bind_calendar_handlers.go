@@ -0,0 +1,229 @@
+package handlers
+
+import (
+	"github.com/calendar-bot/pkg/bots/telegram/messages/calendarMessages"
+	"github.com/calendar-bot/pkg/customerrors"
+	eUseCase "github.com/calendar-bot/pkg/events/usecase"
+	"github.com/calendar-bot/pkg/types"
+	tb "gopkg.in/tucnak/telebot.v2"
+)
+
+// HandleBindCalendar lets the group admin pick one of their calendars for the group
+func (ch *CalendarHandlers) HandleBindCalendar(m *tb.Message) {
+	lang := ch.lang(m.Sender)
+	if m.Chat.Type != tb.ChatGroup && m.Chat.Type != tb.ChatSuperGroup {
+		_, err := ch.handler.bot.Send(m.Chat, lang.T(calendarMessages.BindCalendarGroupOnly))
+		if err != nil {
+			customerrors.HandlerError(err, &m.Chat.ID, &m.ID)
+		}
+		return
+	}
+	if !ch.AuthMiddleware(m.Sender, m.Chat) {
+		return
+	}
+	if !ch.isChatAdmin(m.Chat, m.Sender) {
+		_, err := ch.handler.bot.Send(m.Chat, lang.T(calendarMessages.BindCalendarNotAdmin), &tb.SendOptions{
+			ReplyTo: m,
+		})
+		if err != nil {
+			customerrors.HandlerError(err, &m.Chat.ID, &m.ID)
+		}
+		return
+	}
+
+	calendars, err := ch.bindableCalendars(m.Sender)
+	if err != nil {
+		customerrors.HandlerError(err, &m.Chat.ID, &m.ID)
+		ch.handler.SendError(m.Chat, err)
+		return
+	}
+	if len(calendars) == 0 {
+		_, err := ch.handler.bot.Send(m.Chat, lang.T(calendarMessages.BindCalendarNoCalendars), &tb.SendOptions{
+			ReplyTo: m,
+		})
+		if err != nil {
+			customerrors.HandlerError(err, &m.Chat.ID, &m.ID)
+		}
+		return
+	}
+
+	current, err := ch.eventUseCase.GetGroupCalendar(m.Chat.ID)
+	if err != nil {
+		customerrors.HandlerError(err, &m.Chat.ID, &m.ID)
+		ch.handler.SendError(m.Chat, err)
+		return
+	}
+
+	_, err = ch.handler.bot.Send(m.Chat, calendarMessages.GetBindCalendarText(lang, current), &tb.SendOptions{
+		ParseMode: tb.ModeHTML,
+		ReplyTo:   m,
+		ReplyMarkup: &tb.ReplyMarkup{
+			InlineKeyboard: ch.keyboards.BindCalendarButtons(lang, calendars, current != nil),
+		},
+	})
+	if err != nil {
+		customerrors.HandlerError(err, &m.Chat.ID, &m.ID)
+	}
+}
+
+// HandleBindCalendarSelect binds the chosen calendar, only the admin who asked can choose
+func (ch *CalendarHandlers) HandleBindCalendarSelect(c *tb.Callback) {
+	lang := ch.lang(c.Sender)
+	if !ch.isBindCalendarCaller(c) {
+		return
+	}
+
+	calendars, err := ch.bindableCalendars(c.Sender)
+	if err != nil {
+		customerrors.HandlerError(err, &c.Message.Chat.ID, &c.Message.ID)
+		ch.handler.SendError(c.Message.Chat, err)
+		return
+	}
+	calendar := eUseCase.FindCalendar(calendars, c.Data)
+	if calendar == nil {
+		ch.respondAlert(c, lang.T(calendarMessages.BindCalendarNotFound))
+		return
+	}
+
+	groupCalendar := types.GroupCalendar{
+		ChatID:         c.Message.Chat.ID,
+		TelegramUserID: int64(c.Sender.ID),
+		CalendarUID:    calendar.UID,
+		CalendarTitle:  calendar.Title,
+	}
+	if err := ch.eventUseCase.SetGroupCalendar(groupCalendar); err != nil {
+		customerrors.HandlerError(err, &c.Message.Chat.ID, &c.Message.ID)
+		ch.handler.SendError(c.Message.Chat, err)
+		return
+	}
+
+	err = ch.handler.bot.Respond(c, &tb.CallbackResponse{
+		CallbackID: c.ID,
+	})
+	if err != nil {
+		customerrors.HandlerError(err, &c.Message.Chat.ID, &c.Message.ID)
+	}
+
+	_, err = ch.handler.bot.Edit(c.Message, calendarMessages.GetBindCalendarDoneText(lang, groupCalendar), &tb.SendOptions{
+		ParseMode: tb.ModeHTML,
+	})
+	if err != nil {
+		customerrors.HandlerError(err, &c.Message.Chat.ID, &c.Message.ID)
+	}
+}
+
+func (ch *CalendarHandlers) HandleBindCalendarRemove(c *tb.Callback) {
+	lang := ch.lang(c.Sender)
+	if !ch.isBindCalendarCaller(c) {
+		return
+	}
+
+	if err := ch.eventUseCase.DeleteGroupCalendar(c.Message.Chat.ID); err != nil {
+		customerrors.HandlerError(err, &c.Message.Chat.ID, &c.Message.ID)
+		ch.handler.SendError(c.Message.Chat, err)
+		return
+	}
+
+	err := ch.handler.bot.Respond(c, &tb.CallbackResponse{
+		CallbackID: c.ID,
+	})
+	if err != nil {
+		customerrors.HandlerError(err, &c.Message.Chat.ID, &c.Message.ID)
+	}
+
+	_, err = ch.handler.bot.Edit(c.Message, lang.T(calendarMessages.BindCalendarRemoved))
+	if err != nil {
+		customerrors.HandlerError(err, &c.Message.Chat.ID, &c.Message.ID)
+	}
+}
+
+// isBindCalendarCaller checks that the callback comes from the admin who asked for /bindcalendar
+func (ch *CalendarHandlers) isBindCalendarCaller(c *tb.Callback) bool {
+	lang := ch.lang(c.Sender)
+	if c.Message.ReplyTo != nil && c.Sender.ID != c.Message.ReplyTo.Sender.ID {
+		ch.respondAlert(c, calendarMessages.GetUserNotAllow(lang))
+		return false
+	}
+	if !ch.AuthMiddleware(c.Sender, c.Message.Chat) {
+		return false
+	}
+	if !ch.isChatAdmin(c.Message.Chat, c.Sender) {
+		ch.respondAlert(c, lang.T(calendarMessages.BindCalendarNotAdmin))
+		return false
+	}
+	return true
+}
+
+func (ch *CalendarHandlers) isChatAdmin(chat *tb.Chat, u *tb.User) bool {
+	member, err := ch.handler.bot.ChatMemberOf(chat, u)
+	if err != nil {
+		customerrors.HandlerError(err, &chat.ID, nil)
+		return false
+	}
+	return member.Role == tb.Creator || member.Role == tb.Administrator
+}
+
+// canCreateInGroupCalendar checks that the user may create events with the token of the owner of the group calendar
+func (ch *CalendarHandlers) canCreateInGroupCalendar(chat *tb.Chat, u *tb.User, groupCalendar *types.GroupCalendar) bool {
+	return int64(u.ID) == groupCalendar.TelegramUserID || ch.isChatAdmin(chat, u)
+}
+
+func (ch *CalendarHandlers) bindableCalendars(u *tb.User) ([]types.Calendar, error) {
+	token, err := ch.userUseCase.GetOrRefreshOAuthAccessTokenByTelegramUserID(int64(u.ID))
+	if err != nil {
+		return nil, err
+	}
+	calendars, err := ch.eventUseCase.GetCalendars(token)
+	if err != nil {
+		return nil, err
+	}
+	return eUseCase.BindableCalendars(calendars), nil
+}
+
+// groupCalendar returns the calendar bound to the group chat, nil for private chats and unbound groups
+func (ch *CalendarHandlers) groupCalendar(chat *tb.Chat) *types.GroupCalendar {
+	if chat.Type != tb.ChatGroup && chat.Type != tb.ChatSuperGroup {
+		return nil
+	}
+	groupCalendar, err := ch.eventUseCase.GetGroupCalendar(chat.ID)
+	if err != nil {
+		customerrors.HandlerError(err, &chat.ID, nil)
+		return nil
+	}
+	return groupCalendar
+}
+
+// scheduleToken returns the token to read the schedule asked in the message with.
+// A group with a bound calendar is read with the token of the calendar owner and without the group confirmation,
+// the returned groupCalendar is nil otherwise. ok is false if the command should not be answered
+func (ch *CalendarHandlers) scheduleToken(m *tb.Message) (token string, groupCalendar *types.GroupCalendar, ok bool) {
+	groupCalendar = ch.groupCalendar(m.Chat)
+	if groupCalendar == nil {
+		if !ch.AuthMiddleware(m.Sender, m.Chat) {
+			return "", nil, false
+		}
+		if ch.GroupMiddleware(m) {
+			return "", nil, false
+		}
+	}
+
+	telegramUserID := int64(m.Sender.ID)
+	if groupCalendar != nil {
+		telegramUserID = groupCalendar.TelegramUserID
+	}
+	token, err := ch.userUseCase.GetOrRefreshOAuthAccessTokenByTelegramUserID(telegramUserID)
+	if err != nil {
+		customerrors.HandlerError(err, &m.Chat.ID, &m.ID)
+		ch.handler.SendError(m.Chat, err)
+		return "", nil, false
+	}
+	return token, groupCalendar, true
+}
+
+// scheduleName is added to schedule titles in groups, it is the bound calendar or the sender
+func scheduleName(m *tb.Message, groupCalendar *types.GroupCalendar) string {
+	if groupCalendar != nil {
+		return groupCalendar.CalendarTitle
+	}
+	return m.Sender.FirstName + " " + m.Sender.LastName
+}
@@ -2,16 +2,21 @@ package handlers
 
 import (
 	"bytes"
-	"context"
 	"encoding/json"
 	"fmt"
+	"github.com/calendar-bot/pkg/bots/fsm"
 	"github.com/calendar-bot/pkg/bots/telegram"
 	"github.com/calendar-bot/pkg/bots/telegram/inline_keyboards/calendarInlineKeyboards"
 	"github.com/calendar-bot/pkg/bots/telegram/keyboards/calendarKeyboards"
 	"github.com/calendar-bot/pkg/bots/telegram/messages/calendarMessages"
 	"github.com/calendar-bot/pkg/bots/telegram/utils"
+	"github.com/calendar-bot/pkg/bots/telegram/wizard"
 	"github.com/calendar-bot/pkg/customerrors"
 	eUseCase "github.com/calendar-bot/pkg/events/usecase"
+	"github.com/calendar-bot/pkg/i18n"
+	"github.com/calendar-bot/pkg/render"
+	"github.com/calendar-bot/pkg/services/callbacks"
+	"github.com/calendar-bot/pkg/services/sessions"
 	"github.com/calendar-bot/pkg/types"
 	uUseCase "github.com/calendar-bot/pkg/users/usecase"
 	"github.com/go-redis/redis/v8"
@@ -29,16 +34,26 @@ import (
 )
 
 type CalendarHandlers struct {
-	handler      Handler
-	eventUseCase eUseCase.EventUseCase
-	userUseCase  uUseCase.UserUseCase
-	redisDB      *redis.Client
+	handler         Handler
+	eventUseCase    eUseCase.EventUseCase
+	userUseCase     uUseCase.UserUseCase
+	redisDB         *redis.Client
+	callbackStore   callbacks.Store
+	callbackSigner  callbacks.Signer
+	keyboards       calendarInlineKeyboards.Keyboards
+	sessionStore    sessions.SessionStore
+	rooms           []types.Room
+	inlineKeyboards *inlineKeyboardCache
 }
 
 func NewCalendarHandlers(eventUC eUseCase.EventUseCase, userUC uUseCase.UserUseCase, redis *redis.Client,
-	parseAddress string) CalendarHandlers {
+	callbackStore callbacks.Store, callbackSigner callbacks.Signer, sessionStore sessions.SessionStore,
+	settingsCache *SettingsCache, parseAddress string, rooms []types.Room) CalendarHandlers {
 	return CalendarHandlers{eventUseCase: eventUC, userUseCase: userUC,
-		handler: Handler{bot: nil, parseAddress: parseAddress}, redisDB: redis}
+		handler: Handler{bot: nil, parseAddress: parseAddress, settingsCache: settingsCache},
+		redisDB: redis, callbackStore: callbackStore,
+		callbackSigner: callbackSigner, keyboards: calendarInlineKeyboards.NewKeyboards(callbackSigner),
+		sessionStore: sessionStore, rooms: rooms, inlineKeyboards: newInlineKeyboardCache()}
 }
 
 func (ch *CalendarHandlers) InitHandlers(bot *tb.Bot) {
@@ -47,63 +62,102 @@ func (ch *CalendarHandlers) InitHandlers(bot *tb.Bot) {
 	bot.Handle("/next", ch.HandleNext)
 	bot.Handle("/date", ch.HandleDate)
 	bot.Handle("/create", ch.HandleCreate)
-
-	bot.Handle(calendarMessages.CreateEventAddTitleButton, ch.HandleTitleChange)
-	bot.Handle(calendarMessages.CreateEventChangeTitleButton, ch.HandleTitleChange)
-	bot.Handle(calendarMessages.CreateEventAddDescButton, ch.HandleDescChange)
-	bot.Handle(calendarMessages.CreateEventChangeDescButton, ch.HandleDescChange)
-	bot.Handle(calendarMessages.CreateEventAddLocationButton, ch.HandleLocationChange)
-	bot.Handle(calendarMessages.CreateEventChangeLocationButton, ch.HandleLocationChange)
-	bot.Handle(calendarMessages.CreateEventChangeStopTimeButton, ch.HandleStopTimeChange)
-	bot.Handle(calendarMessages.CreateEventChangeStartTimeButton, ch.HandleStartTimeChange)
-	bot.Handle(calendarMessages.CreateEventAddUser, ch.HandleUserChange)
-	bot.Handle(calendarMessages.GetCreateFullDay(), ch.HandleFullDayChange)
-
-	bot.Handle("\f"+telegram.ShowFullEvent, ch.HandleShowMore)
-	bot.Handle("\f"+telegram.ShowShortEvent, ch.HandleShowLess)
-	bot.Handle("\f"+telegram.AlertCallbackYes, ch.HandleAlertYes)
-	bot.Handle("\f"+telegram.AlertCallbackNo, ch.HandleAlertNo)
-	bot.Handle("\f"+telegram.CancelCreateEvent, ch.HandleCancelCreateEvent)
-	bot.Handle("\f"+telegram.CreateEvent, ch.HandleCreateEvent)
-	bot.Handle("\f"+telegram.GroupGo, ch.HandleGroupGo)
-	bot.Handle("\f"+telegram.GroupNotGo, ch.HandleGroupNotGo)
-	bot.Handle("\f"+telegram.GroupFindTimeNo, ch.HandleGroupFindTimeNo)
-	bot.Handle("\f"+telegram.GroupFindTimeYes, ch.HandleGroupFindTimeYes)
-	bot.Handle("\f"+telegram.FindTimeDayPart, ch.HandleFindTimeDayPart)
-	bot.Handle("\f"+telegram.FindTimeLength, ch.HandleFindTimeLength)
-	bot.Handle("\f"+telegram.FindTimeAdd, ch.FindTimeAdd)
-	bot.Handle("\f"+telegram.FindTimeCreate, ch.FindTimeCreate)
-	bot.Handle("\f"+telegram.HandleGroupText, ch.HandleGroupText)
-	bot.Handle("\f"+telegram.FindTimeFind, ch.HandleFindTimeFind)
-	bot.Handle("\f"+telegram.FindTimeBack, ch.HandleFindTimeBack)
+	bot.Handle(telegram.Week, ch.HandleWeek)
+	bot.Handle(telegram.Now, ch.HandleNow)
+	bot.Handle(telegram.Focus, ch.HandleFocus)
+	bot.Handle(telegram.Vacation, ch.HandleVacation)
+	bot.Handle(telegram.Rules, ch.HandleRules)
+	bot.Handle(telegram.Templates, ch.HandleTemplates)
+	bot.Handle(telegram.BindCalendar, ch.HandleBindCalendar)
+	bot.Handle(telegram.Members, ch.HandleMembers)
+	bot.Handle(telegram.Images, ch.HandleImageMode)
+	bot.Handle(telegram.Holidays, ch.HandleHolidays)
+	bot.Handle(telegram.Availability, ch.HandleAvailability)
+	bot.Handle(telegram.Language, ch.HandleLanguage)
+	bot.Handle(telegram.Settings, ch.HandleSettings)
+
+	handleButton(bot, calendarMessages.CreateEventAddTitleButton, ch.HandleTitleChange)
+	handleButton(bot, calendarMessages.CreateEventChangeTitleButton, ch.HandleTitleChange)
+	handleButton(bot, calendarMessages.CreateEventAddDescButton, ch.HandleDescChange)
+	handleButton(bot, calendarMessages.CreateEventChangeDescButton, ch.HandleDescChange)
+	handleButton(bot, calendarMessages.CreateEventAddLocationButton, ch.HandleLocationChange)
+	handleButton(bot, calendarMessages.CreateEventChangeLocationButton, ch.HandleLocationChange)
+	handleButton(bot, calendarMessages.CreateEventChangeStopTimeButton, ch.HandleStopTimeChange)
+	handleButton(bot, calendarMessages.CreateEventChangeStartTimeButton, ch.HandleStartTimeChange)
+	handleButton(bot, calendarMessages.CreateEventAddUser, ch.HandleUserChange)
+	handleButton(bot, calendarMessages.CreateEventRoomButton, ch.HandleRoomChange)
+	handleButton(bot, calendarMessages.CreateEventAddCallButton, ch.HandleCallToggle)
+	handleButton(bot, calendarMessages.CreateEventRemoveCallButton, ch.HandleCallToggle)
+	for _, text := range calendarMessages.GetCreateFullDayAll() {
+		bot.Handle(text, ch.HandleFullDayChange)
+	}
+
+	ch.handleCallback(bot, telegram.ShowFullEvent, ch.HandleShowMore)
+	ch.handleCallback(bot, telegram.ShowShortEvent, ch.HandleShowLess)
+	ch.handleCallback(bot, telegram.AlertCallbackYes, ch.HandleAlertYes)
+	ch.handleCallback(bot, telegram.AlertCallbackNo, ch.HandleAlertNo)
+	ch.handleCallback(bot, telegram.CancelCreateEvent, ch.HandleCancelCreateEvent)
+	ch.handleCallback(bot, telegram.CreateEvent, ch.HandleCreateEvent)
+	ch.handleCallback(bot, telegram.GroupGo, ch.HandleGroupGo)
+	ch.handleCallback(bot, telegram.GroupNotGo, ch.HandleGroupNotGo)
+	ch.handleCallback(bot, telegram.GroupFindTimeNo, ch.HandleGroupFindTimeNo)
+	ch.handleCallback(bot, telegram.GroupFindTimeYes, ch.HandleGroupFindTimeYes)
+	ch.handleCallback(bot, telegram.FindTimeDayPart, ch.HandleFindTimeDayPart)
+	ch.handleCallback(bot, telegram.FindTimeLength, ch.HandleFindTimeLength)
+	ch.handleCallback(bot, telegram.FindTimeAdd, ch.FindTimeAdd)
+	ch.handleCallback(bot, telegram.FindTimeCreate, ch.FindTimeCreate)
+	ch.handleCallback(bot, telegram.HandleGroupText, ch.HandleGroupText)
+	ch.handleCallback(bot, telegram.FindTimeFind, ch.HandleFindTimeFind)
+	ch.handleCallback(bot, telegram.FindTimeBack, ch.HandleFindTimeBack)
+	ch.handleCallback(bot, telegram.AvailabilityWeek, ch.HandleAvailabilityWeek)
+	ch.handleCallback(bot, telegram.AvailabilityJoin, ch.HandleAvailabilityJoin)
+	ch.handleCallback(bot, telegram.FocusDuration, ch.HandleFocusDuration)
+	ch.handleCallback(bot, telegram.FocusMorning, ch.HandleFocusMorning)
+	ch.handleCallback(bot, telegram.FocusOff, ch.HandleFocusOff)
+	ch.handleCallback(bot, telegram.InvitationRuleDelete, ch.HandleInvitationRuleDelete)
+	ch.handleCallback(bot, telegram.RoomSelect, ch.HandleRoomSelect)
+	ch.handleCallback(bot, telegram.EventVenue, ch.HandleEventVenue)
+	ch.handleCallback(bot, telegram.TemplateSave, ch.HandleTemplateSave)
+	ch.handleCallback(bot, telegram.TemplateApply, ch.HandleTemplateApply)
+	ch.handleCallback(bot, telegram.TemplateDelete, ch.HandleTemplateDelete)
+	ch.handleCallback(bot, telegram.ShareEvent, ch.HandleShareEvent)
+	ch.handleCallback(bot, telegram.ShareEventGroup, ch.HandleShareEventGroup)
+	ch.handleCallback(bot, telegram.BindCalendarSelect, ch.HandleBindCalendarSelect)
+	ch.handleCallback(bot, telegram.BindCalendarRemove, ch.HandleBindCalendarRemove)
+	ch.handleCallback(bot, telegram.LanguageSelect, ch.HandleLanguageSelect)
+	ch.handleCallback(bot, telegram.SettingsOpen, ch.HandleSettingsOpen)
+	ch.handleCallback(bot, telegram.SettingsSet, ch.HandleSettingsSet)
+	ch.handleCallback(bot, telegram.SettingsReset, ch.HandleSettingsReset)
+	bot.Handle(tb.OnUserJoined, ch.HandleUserJoined)
+	bot.Handle(tb.OnUserLeft, ch.HandleUserLeft)
+	bot.Handle(tb.OnLocation, ch.HandleSharedLocation)
+	bot.Handle(tb.OnVenue, ch.HandleSharedLocation)
+	bot.Handle(tb.OnQuery, ch.HandleInlineQuery)
 	bot.Handle(tb.OnText, ch.HandleText)
 }
 
 func (ch *CalendarHandlers) HandleToday(m *tb.Message) {
-	if !ch.AuthMiddleware(m.Sender, m.Chat) {
-		return
-	}
-	if ch.GroupMiddleware(m) {
-		return
-	}
-	token, err := ch.userUseCase.GetOrRefreshOAuthAccessTokenByTelegramUserID(int64(m.Sender.ID))
-	if err != nil {
-		customerrors.HandlerError(err, &m.Chat.ID, &m.ID)
-		ch.handler.SendError(m.Chat, err)
+	lang := ch.lang(m.Sender)
+	token, groupCalendar, ok := ch.scheduleToken(m)
+	if !ok {
 		return
 	}
 
-	events, err := ch.eventUseCase.GetEventsToday(token)
+	now := ch.userNow(int64(m.Sender.ID))
+	events, err := ch.eventUseCase.GetEventsToday(token, now)
 	if err != nil {
 		customerrors.HandlerError(err, &m.Chat.ID, &m.ID)
 		ch.handler.SendError(m.Chat, err)
 		return
 	}
+	if events != nil && groupCalendar != nil {
+		events.Data.Events = eUseCase.FilterCalendarEvents(events.Data.Events, groupCalendar.CalendarUID)
+	}
 
 	if events != nil {
 		i := 0
 		for _, event := range events.Data.Events {
-			if !event.FullDay || event.From.Day() != time.Now().Day()-1 || event.To.Day() != time.Now().Day() {
+			if !event.FullDay || event.From.Day() != now.Day()-1 || event.To.Day() != now.Day() {
 				events.Data.Events[i] = event
 				i++
 			}
@@ -111,12 +165,17 @@ func (ch *CalendarHandlers) HandleToday(m *tb.Message) {
 		events.Data.Events = events.Data.Events[:i]
 	}
 
-	title := calendarMessages.GetTodayTitle()
+	title := calendarMessages.GetTodayTitle(lang)
 	if m.Chat.Type != tb.ChatPrivate {
-		title += calendarMessages.AddNameBold(m.Sender.FirstName + " " + m.Sender.LastName)
+		title += calendarMessages.AddNameBold(scheduleName(m, groupCalendar))
 	}
 
 	if events != nil && len(events.Data.Events) > 0 {
+		if ch.isImageMode(m.Sender.ID) {
+			ch.sendSchedulePhoto(m.Chat, render.DayTimeline(events.Data.Events, now, lang.MondayLocale()), title)
+			return
+		}
+
 		_, err := ch.handler.bot.Send(m.Chat, title, &tb.SendOptions{
 			ParseMode: tb.ModeHTML,
 			ReplyMarkup: &tb.ReplyMarkup{
@@ -127,11 +186,11 @@ func (ch *CalendarHandlers) HandleToday(m *tb.Message) {
 			customerrors.HandlerError(err, &m.Chat.ID, &m.ID)
 		}
 
-		ch.sendShortEvents(&events.Data.Events, m.Chat)
+		ch.sendShortEvents(lang, &events.Data.Events, m.Chat)
 	} else {
-		title := calendarMessages.GetTodayNotFound()
+		title := calendarMessages.GetTodayNotFound(lang)
 		if m.Chat.Type != tb.ChatPrivate {
-			title += calendarMessages.AddName(m.Sender.FirstName + " " + m.Sender.LastName)
+			title += calendarMessages.AddName(scheduleName(m, groupCalendar))
 		}
 		_, err := ch.handler.bot.Send(m.Chat, title, &tb.SendOptions{
 			ParseMode: tb.ModeHTML,
@@ -146,20 +205,19 @@ func (ch *CalendarHandlers) HandleToday(m *tb.Message) {
 
 }
 func (ch *CalendarHandlers) HandleNext(m *tb.Message) {
-	if !ch.AuthMiddleware(m.Sender, m.Chat) {
-		return
-	}
-	if ch.GroupMiddleware(m) {
-		return
-	}
-	token, err := ch.userUseCase.GetOrRefreshOAuthAccessTokenByTelegramUserID(int64(m.Sender.ID))
-	if err != nil {
-		customerrors.HandlerError(err, &m.Chat.ID, &m.ID)
-		ch.handler.SendError(m.Chat, err)
+	lang := ch.lang(m.Sender)
+	token, groupCalendar, ok := ch.scheduleToken(m)
+	if !ok {
 		return
 	}
 
-	event, err := ch.eventUseCase.GetClosestEvent(token)
+	var event *types.Event
+	var err error
+	if groupCalendar != nil {
+		event, err = ch.eventUseCase.GetClosestCalendarEvent(token, groupCalendar.CalendarUID, ch.userNow(int64(m.Sender.ID)))
+	} else {
+		event, err = ch.eventUseCase.GetClosestEvent(token, ch.userNow(int64(m.Sender.ID)))
+	}
 	if err != nil {
 		customerrors.HandlerError(err, &m.Chat.ID, &m.ID)
 		ch.handler.SendError(m.Chat, err)
@@ -167,9 +225,9 @@ func (ch *CalendarHandlers) HandleNext(m *tb.Message) {
 	}
 
 	if event != nil {
-		title := calendarMessages.GetNextTitle()
+		title := calendarMessages.GetNextTitle(lang)
 		if m.Chat.Type != tb.ChatPrivate {
-			title += calendarMessages.AddNameBold(m.Sender.FirstName + " " + m.Sender.LastName)
+			title += calendarMessages.AddNameBold(scheduleName(m, groupCalendar))
 		}
 		_, err := ch.handler.bot.Send(m.Chat, title, &tb.SendOptions{
 			ParseMode: tb.ModeHTML,
@@ -183,12 +241,12 @@ func (ch *CalendarHandlers) HandleNext(m *tb.Message) {
 
 		var inlineKeyboard [][]tb.InlineButton = nil
 		if m.Chat.Type == tb.ChatPrivate {
-			inlineKeyboard, err = calendarInlineKeyboards.EventShowMoreInlineKeyboard(event, ch.redisDB)
+			inlineKeyboard, err = ch.keyboards.EventShowMoreInlineKeyboard(lang, event, &ch.callbackStore)
 			if err != nil {
 				customerrors.HandlerError(err, &m.Chat.ID, &m.ID)
 			}
 		}
-		_, err = ch.handler.bot.Send(m.Chat, calendarMessages.SingleEventShortText(event, true), &tb.SendOptions{
+		_, err = ch.handler.bot.Send(m.Chat, calendarMessages.SingleEventShortText(lang, event, true), &tb.SendOptions{
 			ParseMode: tb.ModeHTML,
 			ReplyMarkup: &tb.ReplyMarkup{
 				InlineKeyboard: inlineKeyboard,
@@ -198,9 +256,9 @@ func (ch *CalendarHandlers) HandleNext(m *tb.Message) {
 			customerrors.HandlerError(err, &m.Chat.ID, &m.ID)
 		}
 	} else {
-		title := calendarMessages.NoClosestEvents()
+		title := calendarMessages.NoClosestEvents(lang)
 		if m.Chat.Type != tb.ChatPrivate {
-			title += calendarMessages.AddName(m.Sender.FirstName + " " + m.Sender.LastName)
+			title += calendarMessages.AddName(scheduleName(m, groupCalendar))
 		}
 		_, err = ch.handler.bot.Send(m.Chat, title, &tb.SendOptions{
 			ParseMode: tb.ModeHTML,
@@ -214,6 +272,7 @@ func (ch *CalendarHandlers) HandleNext(m *tb.Message) {
 	}
 }
 func (ch *CalendarHandlers) HandleDate(m *tb.Message) {
+	lang := ch.lang(m.Sender)
 	if !ch.AuthMiddleware(m.Sender, m.Chat) {
 		return
 	}
@@ -246,24 +305,23 @@ func (ch *CalendarHandlers) HandleDate(m *tb.Message) {
 		}
 	}
 
-	currSession = &types.BotRedisSession{}
-	currSession.IsDate = true
-	currSession.IsCreate = false
+	currSession = sessions.Reset(currSession)
+	ch.fire(currSession, wizard.AskDate, m.Chat)
 
 	replyMarkup := tb.ReplyMarkup{}
 	var replyTo *tb.Message = nil
 	if m.Chat.Type != tb.ChatPrivate {
 		replyMarkup = tb.ReplyMarkup{
-			InlineKeyboard: calendarInlineKeyboards.GetDateFastCommand(false),
+			InlineKeyboard: ch.keyboards.GetDateFastCommand(lang, false, ch.userNow(int64(m.Sender.ID))),
 		}
 		replyTo = m.ReplyTo
 	} else {
 		replyMarkup = tb.ReplyMarkup{
-			ReplyKeyboard: calendarKeyboards.GetDateFastCommand(false),
+			ReplyKeyboard: calendarKeyboards.GetDateFastCommand(lang, false, ch.userNow(int64(m.Sender.ID))),
 		}
 	}
 
-	msg, err := ch.handler.bot.Send(m.Chat, calendarMessages.GetInitDateMessage(), &tb.SendOptions{
+	msg, err := ch.handler.bot.Send(m.Chat, calendarMessages.GetInitDateMessage(lang), &tb.SendOptions{
 		ParseMode:   tb.ModeHTML,
 		ReplyMarkup: &replyMarkup,
 		ReplyTo:     replyTo,
@@ -276,10 +334,12 @@ func (ch *CalendarHandlers) HandleDate(m *tb.Message) {
 	}
 	err = ch.setSession(currSession, m.Sender, m.Chat)
 	if err != nil {
+		ch.sessionError(err, m.Chat, &m.ID)
 		return
 	}
 }
 func (ch *CalendarHandlers) HandleCreate(m *tb.Message) {
+	lang := ch.lang(m.Sender)
 	if !ch.AuthMiddleware(m.Sender, m.Chat) {
 		return
 	}
@@ -291,7 +351,6 @@ func (ch *CalendarHandlers) HandleCreate(m *tb.Message) {
 	}
 
 	if session.PollMsg.ChatID != 0 {
-		session.FindTimeDone = false
 		err = ch.handler.bot.Delete(&session.PollMsg)
 		if err != nil {
 			customerrors.HandlerError(err, &m.Chat.ID, &m.ID)
@@ -306,26 +365,24 @@ func (ch *CalendarHandlers) HandleCreate(m *tb.Message) {
 	}
 
 	if session.InfoMsg.ChatID != 0 {
-		session.FindTimeDone = false
 		err = ch.handler.bot.Delete(&session.InfoMsg)
 		if err != nil {
 			customerrors.HandlerError(err, &m.Chat.ID, &m.ID)
 		}
 	}
 
-	if (m.Chat.Type == tb.ChatGroup || m.Chat.Type == tb.ChatSuperGroup) && !session.FindTimeDone {
-		session = &types.BotRedisSession{}
+	if m.Chat.Type == tb.ChatGroup || m.Chat.Type == tb.ChatSuperGroup {
+		session = sessions.Reset(session)
 		err = ch.setSession(session, m.Sender, m.Chat)
 		if err != nil {
-			ch.handler.SendError(m.Chat, err)
-			customerrors.HandlerError(err, &m.Chat.ID, &m.ID)
+			ch.sessionError(err, m.Chat, &m.ID)
 			return
 		}
 
-		_, err = ch.handler.bot.Send(m.Chat, calendarMessages.CreateEventFindTimeMessage, &tb.SendOptions{
+		_, err = ch.handler.bot.Send(m.Chat, lang.T(calendarMessages.CreateEventFindTimeMessage), &tb.SendOptions{
 			ParseMode: tb.ModeHTML,
 			ReplyMarkup: &tb.ReplyMarkup{
-				InlineKeyboard: calendarInlineKeyboards.GroupFindTimeButtons(),
+				InlineKeyboard: ch.keyboards.GroupFindTimeButtons(lang),
 			},
 			ReplyTo: m,
 		})
@@ -336,9 +393,16 @@ func (ch *CalendarHandlers) HandleCreate(m *tb.Message) {
 		}
 	}
 
-	session = &types.BotRedisSession{}
-	session.IsCreate = true
-	session.FindTimeDone = true
+	ch.startCreate(m, session)
+}
+
+// startCreate asks for the fields of the new event one by one
+func (ch *CalendarHandlers) startCreate(m *tb.Message, session *types.BotRedisSession) {
+	lang := ch.lang(m.Sender)
+	session = sessions.Reset(session)
+	if !ch.fire(session, wizard.StartCreate, m.Chat) {
+		return
+	}
 
 	token, err := ch.userUseCase.GetOrRefreshOAuthAccessTokenByTelegramUserID(int64(m.Sender.ID))
 	if err != nil {
@@ -353,30 +417,28 @@ func (ch *CalendarHandlers) HandleCreate(m *tb.Message) {
 			organizerAttendee := types.AttendeeEvent{
 				Email:  userInfo.Email,
 				Name:   userInfo.Name,
-				Role:   telegram.RoleRequired,
-				Status: telegram.StatusAccepted,
+				Role:   types.RoleRequired,
+				Status: types.StatusAccepted,
 			}
 			session.Event.Organizer = organizerAttendee
 			session.Event.Attendees = append(session.Event.Attendees, organizerAttendee)
 		}
 	}
 
-	session.Step = telegram.StepCreateFrom
-
 	replyMarkup := tb.ReplyMarkup{}
 	var replyTo *tb.Message = nil
 	if m.Chat.Type != tb.ChatPrivate {
 		replyMarkup = tb.ReplyMarkup{
-			InlineKeyboard: calendarInlineKeyboards.GetCreateFastCommand(),
+			InlineKeyboard: ch.keyboards.GetCreateFastCommand(lang),
 		}
 		replyTo = m
 	} else {
 		replyMarkup = tb.ReplyMarkup{
-			ReplyKeyboard: calendarKeyboards.GetCreateFastCommand(),
+			ReplyKeyboard: calendarKeyboards.GetCreateFastCommand(lang),
 		}
 	}
 
-	msg, err := ch.handler.bot.Send(m.Chat, calendarMessages.GetCreateInitText(), &tb.SendOptions{
+	msg, err := ch.handler.bot.Send(m.Chat, calendarMessages.GetCreateInitText(lang), &tb.SendOptions{
 		ParseMode:   tb.ModeHTML,
 		ReplyMarkup: &replyMarkup,
 		ReplyTo:     replyTo,
@@ -390,22 +452,22 @@ func (ch *CalendarHandlers) HandleCreate(m *tb.Message) {
 
 	err = ch.setSession(session, m.Sender, m.Chat)
 	if err != nil {
-		customerrors.HandlerError(err, &m.Chat.ID, &m.ID)
-		ch.handler.SendError(m.Chat, err)
+		ch.sessionError(err, m.Chat, &m.ID)
 		return
 	}
 
 }
 func (ch *CalendarHandlers) HandleText(m *tb.Message) {
+	lang := ch.lang(m.Sender)
 
-	if strings.ToLower(m.Text) == calendarMessages.ShowTodayTasks ||
-		strings.ToLower(m.Text) == calendarMessages.ShowTodayPhrase {
+	if strings.ToLower(m.Text) == lang.T(calendarMessages.ShowTodayTasks) ||
+		strings.ToLower(m.Text) == lang.T(calendarMessages.ShowTodayPhrase) {
 		ch.HandleToday(m)
 		return
 	}
 
-	if strings.ToLower(m.Text) == calendarMessages.ShowNextTask ||
-		strings.ToLower(m.Text) == calendarMessages.ShowNextPhrase {
+	if strings.ToLower(m.Text) == lang.T(calendarMessages.ShowNextTask) ||
+		strings.ToLower(m.Text) == lang.T(calendarMessages.ShowNextPhrase) {
 		ch.HandleNext(m)
 		return
 	}
@@ -415,150 +477,163 @@ func (ch *CalendarHandlers) HandleText(m *tb.Message) {
 		return
 	}
 
-	if session.IsDate {
+	if m.Chat.Type == tb.ChatPrivate && isForwarded(m) {
+		ch.createEventFromText(m, session)
+		return
+	}
+
+	switch {
+	case wizard.Is(session, wizard.Date):
 		ch.handleDateText(m, session)
-	} else if session.IsCreate && session.FindTimeDone {
+	case wizard.IsCreating(session):
 		ch.handleCreateText(m, session)
-	} else if session.IsCreate && !session.FindTimeDone {
+	case wizard.Is(session, wizard.FindTime):
 		ch.handleFindTimeText(m, session)
-	} else {
-		if m.Chat.Type == tb.ChatPrivate {
+	case m.Chat.Type == tb.ChatPrivate:
+		ch.createEventFromText(m, session)
+	}
+}
 
-			data := ch.ParseEvent(m)
+// createEventFromText opens the create confirmation for the event found in the text by the parser backend
+func (ch *CalendarHandlers) createEventFromText(m *tb.Message, session *types.BotRedisSession) {
+	lang := ch.lang(m.Sender)
+	data := ch.ParseEvent(m)
 
-			if data == nil || data.EventStart.IsZero() {
-				_, err = ch.handler.bot.Send(m.Chat, calendarMessages.EventNoEventDataFound, &tb.SendOptions{
-					ParseMode: tb.ModeHTML,
-					ReplyMarkup: &tb.ReplyMarkup{
-						ReplyKeyboardRemove: true,
-					},
-				})
-				if err != nil {
-					customerrors.HandlerError(err, &m.Chat.ID, &m.ID)
-				}
+	if data == nil || data.EventStart.IsZero() {
+		_, err := ch.handler.bot.Send(m.Chat, lang.T(calendarMessages.EventNoEventDataFound), &tb.SendOptions{
+			ParseMode: tb.ModeHTML,
+			ReplyMarkup: &tb.ReplyMarkup{
+				ReplyKeyboardRemove: true,
+			},
+		})
+		if err != nil {
+			customerrors.HandlerError(err, &m.Chat.ID, &m.ID)
+		}
 
-				return
-			}
+		return
+	}
 
-			session.IsCreate = true
-			session.FromTextCreate = true
-			session.FindTimeDone = true
-			session.Event = types.Event{}
-			session.Step = telegram.StepCreateInit
-			session.Event.From = data.EventStart
-			session.Event.To = data.EventEnd
-			session.Event.Title = data.EventName
+	ch.fire(session, wizard.Cancel, m.Chat)
+	if !ch.fire(session, wizard.StartFromText, m.Chat) {
+		return
+	}
+	session.FromTextCreate = true
+	session.Event.From = data.EventStart
+	session.Event.To = data.EventEnd
+	session.Event.Title = data.EventName
 
-			token, err := ch.userUseCase.GetOrRefreshOAuthAccessTokenByTelegramUserID(int64(m.Sender.ID))
-			if err != nil {
-				customerrors.HandlerError(err, &m.Chat.ID, &m.ID)
-				ch.handler.SendError(m.Chat, err)
-				return
-			} else {
-				userInfo, err := ch.userUseCase.GetMailruUserInfo(token)
-				if err != nil {
-					customerrors.HandlerError(err, &m.Chat.ID, &m.ID)
-				} else {
-					organizerAttendee := types.AttendeeEvent{
-						Email:  userInfo.Email,
-						Name:   userInfo.Name,
-						Role:   telegram.RoleRequired,
-						Status: telegram.StatusAccepted,
-					}
-					session.Event.Organizer = organizerAttendee
-					session.Event.Attendees = append(session.Event.Attendees, organizerAttendee)
-				}
+	token, err := ch.userUseCase.GetOrRefreshOAuthAccessTokenByTelegramUserID(int64(m.Sender.ID))
+	if err != nil {
+		customerrors.HandlerError(err, &m.Chat.ID, &m.ID)
+		ch.handler.SendError(m.Chat, err)
+		return
+	} else {
+		userInfo, err := ch.userUseCase.GetMailruUserInfo(token)
+		if err != nil {
+			customerrors.HandlerError(err, &m.Chat.ID, &m.ID)
+		} else {
+			organizerAttendee := types.AttendeeEvent{
+				Email:  userInfo.Email,
+				Name:   userInfo.Name,
+				Role:   types.RoleRequired,
+				Status: types.StatusAccepted,
 			}
+			session.Event.Organizer = organizerAttendee
+			session.Event.Attendees = append(session.Event.Attendees, organizerAttendee)
+		}
+	}
 
-			newMsg, err := ch.handler.bot.Send(m.Chat,
-				calendarMessages.GetCreateEventHeader()+calendarMessages.SingleEventFullText(&session.Event),
-				&tb.SendOptions{
-					ParseMode: tb.ModeHTML,
-					ReplyTo:   m,
-					ReplyMarkup: &tb.ReplyMarkup{
-						InlineKeyboard: calendarInlineKeyboards.CreateEventButtons(session.Event),
-					},
-				})
+	if isForwarded(m) {
+		ch.fillForwardedEvent(m, &session.Event)
+	}
 
-			if err != nil {
-				customerrors.HandlerError(err, &m.Chat.ID, &m.ID)
-			}
+	newMsg, err := ch.handler.bot.Send(m.Chat,
+		calendarMessages.GetCreateEventHeader(lang)+calendarMessages.SingleEventFullText(lang, &session.Event),
+		&tb.SendOptions{
+			ParseMode: tb.ModeHTML,
+			ReplyTo:   m,
+			ReplyMarkup: &tb.ReplyMarkup{
+				InlineKeyboard: ch.keyboards.CreateEventButtons(lang, session.Event),
+			},
+		})
 
-			session.InfoMsg = utils.InitCustomEditable(newMsg.MessageSig())
+	if err != nil {
+		customerrors.HandlerError(err, &m.Chat.ID, &m.ID)
+	}
 
-			if data.EventEnd.IsZero() {
-				session.Step = telegram.StepCreateTo
+	session.InfoMsg = utils.InitCustomEditable(newMsg.MessageSig())
 
-				_, err = ch.handler.bot.Send(m.Chat, calendarMessages.GetCreateEventToText(), &tb.SendOptions{
-					ParseMode: tb.ModeHTML,
-					ReplyMarkup: &tb.ReplyMarkup{
-						ReplyKeyboard:       calendarKeyboards.GetCreateDuration(),
-						ResizeReplyKeyboard: true,
-					},
-				})
+	if data.EventEnd.IsZero() {
+		ch.fire(session, wizard.EditTo, m.Chat)
 
-				if err != nil {
-					customerrors.HandlerError(err, &m.Chat.ID, &m.ID)
-				}
+		_, err = ch.handler.bot.Send(m.Chat, calendarMessages.GetCreateEventToText(lang), &tb.SendOptions{
+			ParseMode: tb.ModeHTML,
+			ReplyMarkup: &tb.ReplyMarkup{
+				ReplyKeyboard:       calendarKeyboards.GetCreateDuration(lang, ch.userSettings(m.Sender).EventDuration),
+				ResizeReplyKeyboard: true,
+			},
+		})
 
-			} else {
-				if data.EventName == "" {
-					session.Step = telegram.StepCreateTitle
-
-					_, err = ch.handler.bot.Send(m.Chat, calendarMessages.GetCreateEventTitle(), &tb.SendOptions{
-						ParseMode: tb.ModeHTML,
-						ReplyMarkup: &tb.ReplyMarkup{
-							ReplyKeyboard:   calendarKeyboards.GetCreateOptionButtons(session),
-							OneTimeKeyboard: true,
-						},
-					})
+		if err != nil {
+			customerrors.HandlerError(err, &m.Chat.ID, &m.ID)
+		}
 
-					if err != nil {
-						customerrors.HandlerError(err, &m.Chat.ID, &m.ID)
-					}
-				} else {
-					session.Step = telegram.StepCreateDesc
-
-					_, err = ch.handler.bot.Send(m.Chat, calendarMessages.CreateEventDescText, &tb.SendOptions{
-						ParseMode: tb.ModeHTML,
-						ReplyMarkup: &tb.ReplyMarkup{
-							ReplyKeyboard:   calendarKeyboards.GetCreateOptionButtons(session),
-							OneTimeKeyboard: true,
-						},
-					})
+	} else {
+		if data.EventName == "" {
+			ch.fire(session, wizard.EditTitle, m.Chat)
 
-					if err != nil {
-						customerrors.HandlerError(err, &m.Chat.ID, &m.ID)
-					}
-				}
-			}
+			_, err = ch.handler.bot.Send(m.Chat, calendarMessages.GetCreateEventTitle(lang), &tb.SendOptions{
+				ParseMode: tb.ModeHTML,
+				ReplyMarkup: &tb.ReplyMarkup{
+					ReplyKeyboard:   calendarKeyboards.GetCreateOptionButtons(lang, session),
+					OneTimeKeyboard: true,
+				},
+			})
 
-			err = ch.setSession(session, m.Sender, m.Chat)
-			if err == nil {
-				return
+			if err != nil {
+				customerrors.HandlerError(err, &m.Chat.ID, &m.ID)
 			}
+		} else {
+			ch.fire(session, wizard.EditDesc, m.Chat)
+
+			_, err = ch.handler.bot.Send(m.Chat, lang.T(calendarMessages.CreateEventDescText), &tb.SendOptions{
+				ParseMode: tb.ModeHTML,
+				ReplyMarkup: &tb.ReplyMarkup{
+					ReplyKeyboard:   calendarKeyboards.GetCreateOptionButtons(lang, session),
+					OneTimeKeyboard: true,
+				},
+			})
 
+			if err != nil {
+				customerrors.HandlerError(err, &m.Chat.ID, &m.ID)
+			}
 		}
 	}
+
+	err = ch.setSession(session, m.Sender, m.Chat)
+	if err != nil {
+		ch.sessionError(err, m.Chat, &m.ID)
+	}
 }
 
 func (ch *CalendarHandlers) HandleDescChange(m *tb.Message) {
+	lang := ch.lang(m.Sender)
 	session, err := ch.getSession(m.Sender, m.Chat)
 	if err != nil {
 		return
 	}
 
-	if session.IsCreate {
-		session.Step = telegram.StepCreateDesc
+	if wizard.IsCreating(session) {
+		ch.fire(session, wizard.EditDesc, m.Chat)
 		var replyMarkup *tb.ReplyMarkup = nil
 		var replyTo *tb.Message = nil
 		if m.Chat.Type != tb.ChatPrivate {
 			replyMarkup = &tb.ReplyMarkup{
-				InlineKeyboard: calendarInlineKeyboards.GetCreateOptionButtons(session),
+				InlineKeyboard: ch.keyboards.GetCreateOptionButtons(lang, session),
 			}
 			replyTo = m
 		}
-		msg, err := ch.handler.bot.Send(m.Chat, calendarMessages.CreateEventDescText, &tb.SendOptions{
+		msg, err := ch.handler.bot.Send(m.Chat, lang.T(calendarMessages.CreateEventDescText), &tb.SendOptions{
 			ParseMode:   tb.ModeHTML,
 			ReplyMarkup: replyMarkup,
 			ReplyTo:     replyTo,
@@ -574,30 +649,30 @@ func (ch *CalendarHandlers) HandleDescChange(m *tb.Message) {
 
 		err = ch.setSession(session, m.Sender, m.Chat)
 		if err != nil {
-			ch.handler.SendError(m.Chat, err)
-			customerrors.HandlerError(err, &m.Chat.ID, &m.ID)
+			ch.sessionError(err, m.Chat, &m.ID)
 		}
 	} else {
 		ch.HandleText(m)
 	}
 }
 func (ch *CalendarHandlers) HandleTitleChange(m *tb.Message) {
+	lang := ch.lang(m.Sender)
 	session, err := ch.getSession(m.Sender, m.Chat)
 	if err != nil {
 		return
 	}
 
-	if session.IsCreate {
-		session.Step = telegram.StepCreateTitle
+	if wizard.IsCreating(session) {
+		ch.fire(session, wizard.EditTitle, m.Chat)
 		var replyMarkup *tb.ReplyMarkup = nil
 		var replyTo *tb.Message = nil
 		if m.Chat.Type != tb.ChatPrivate {
 			replyMarkup = &tb.ReplyMarkup{
-				InlineKeyboard: calendarInlineKeyboards.GetCreateOptionButtons(session),
+				InlineKeyboard: ch.keyboards.GetCreateOptionButtons(lang, session),
 			}
 			replyTo = m
 		}
-		msg, err := ch.handler.bot.Send(m.Chat, calendarMessages.GetCreateEventTitle(), &tb.SendOptions{
+		msg, err := ch.handler.bot.Send(m.Chat, calendarMessages.GetCreateEventTitle(lang), &tb.SendOptions{
 			ParseMode:   tb.ModeHTML,
 			ReplyMarkup: replyMarkup,
 			ReplyTo:     replyTo,
@@ -613,31 +688,31 @@ func (ch *CalendarHandlers) HandleTitleChange(m *tb.Message) {
 
 		err = ch.setSession(session, m.Sender, m.Chat)
 		if err != nil {
-			ch.handler.SendError(m.Chat, err)
-			customerrors.HandlerError(err, &m.Chat.ID, &m.ID)
+			ch.sessionError(err, m.Chat, &m.ID)
 		}
 	} else {
 		ch.HandleText(m)
 	}
 }
 func (ch *CalendarHandlers) HandleUserChange(m *tb.Message) {
+	lang := ch.lang(m.Sender)
 	session, err := ch.getSession(m.Sender, m.Chat)
 	if err != nil {
 		return
 	}
 
-	if session.IsCreate {
-		session.Step = telegram.StepCreateUser
+	if wizard.IsCreating(session) {
+		ch.fire(session, wizard.EditUser, m.Chat)
 		var replyMarkup *tb.ReplyMarkup = nil
 		var replyTo *tb.Message = nil
 		if m.Chat.Type != tb.ChatPrivate {
 			replyMarkup = &tb.ReplyMarkup{
-				InlineKeyboard: calendarInlineKeyboards.GetCreateOptionButtons(session),
+				InlineKeyboard: ch.keyboards.GetCreateOptionButtons(lang, session),
 			}
 			replyTo = m
 		}
 
-		msg, err := ch.handler.bot.Send(m.Chat, calendarMessages.CreateEventUserText, &tb.SendOptions{
+		msg, err := ch.handler.bot.Send(m.Chat, lang.T(calendarMessages.CreateEventUserText), &tb.SendOptions{
 			ParseMode:   tb.ModeHTML,
 			ReplyMarkup: replyMarkup,
 			ReplyTo:     replyTo,
@@ -653,30 +728,30 @@ func (ch *CalendarHandlers) HandleUserChange(m *tb.Message) {
 
 		err = ch.setSession(session, m.Sender, m.Chat)
 		if err != nil {
-			ch.handler.SendError(m.Chat, err)
-			customerrors.HandlerError(err, &m.Chat.ID, &m.ID)
+			ch.sessionError(err, m.Chat, &m.ID)
 		}
 	} else {
 		ch.HandleText(m)
 	}
 }
 func (ch *CalendarHandlers) HandleStartTimeChange(m *tb.Message) {
+	lang := ch.lang(m.Sender)
 	session, err := ch.getSession(m.Sender, m.Chat)
 	if err != nil {
 		return
 	}
 
-	if session.IsCreate {
-		session.Step = telegram.StepCreateFrom
+	if wizard.IsCreating(session) {
+		ch.fire(session, wizard.EditFrom, m.Chat)
 		var replyMarkup *tb.ReplyMarkup = nil
 		var replyTo *tb.Message = nil
 		if m.Chat.Type != tb.ChatPrivate {
 			replyMarkup = &tb.ReplyMarkup{
-				InlineKeyboard: calendarInlineKeyboards.GetCreateOptionButtons(session),
+				InlineKeyboard: ch.keyboards.GetCreateOptionButtons(lang, session),
 			}
 			replyTo = m
 		}
-		msg, err := ch.handler.bot.Send(m.Chat, calendarMessages.GetCreateInitText(), &tb.SendOptions{
+		msg, err := ch.handler.bot.Send(m.Chat, calendarMessages.GetCreateInitText(lang), &tb.SendOptions{
 			ParseMode:   tb.ModeHTML,
 			ReplyMarkup: replyMarkup,
 			ReplyTo:     replyTo,
@@ -692,31 +767,31 @@ func (ch *CalendarHandlers) HandleStartTimeChange(m *tb.Message) {
 
 		err = ch.setSession(session, m.Sender, m.Chat)
 		if err != nil {
-			ch.handler.SendError(m.Chat, err)
-			customerrors.HandlerError(err, &m.Chat.ID, &m.ID)
+			ch.sessionError(err, m.Chat, &m.ID)
 		}
 	} else {
 		ch.HandleText(m)
 	}
 }
 func (ch *CalendarHandlers) HandleStopTimeChange(m *tb.Message) {
+	lang := ch.lang(m.Sender)
 	session, err := ch.getSession(m.Sender, m.Chat)
 	if err != nil {
 		return
 	}
 
-	if session.IsCreate {
-		session.Step = telegram.StepCreateTo
+	if wizard.IsCreating(session) {
+		ch.fire(session, wizard.EditTo, m.Chat)
 		var replyMarkup *tb.ReplyMarkup = nil
 		var replyTo *tb.Message = nil
 		if m.Chat.Type != tb.ChatPrivate {
 			replyMarkup = &tb.ReplyMarkup{
-				InlineKeyboard: calendarInlineKeyboards.GetCreateOptionButtons(session),
+				InlineKeyboard: ch.keyboards.GetCreateOptionButtons(lang, session),
 			}
 			replyTo = m
 		}
 
-		msg, err := ch.handler.bot.Send(m.Chat, calendarMessages.GetCreateEventToText(), &tb.SendOptions{
+		msg, err := ch.handler.bot.Send(m.Chat, calendarMessages.GetCreateEventToText(lang), &tb.SendOptions{
 			ParseMode:   tb.ModeHTML,
 			ReplyMarkup: replyMarkup,
 			ReplyTo:     replyTo,
@@ -732,31 +807,37 @@ func (ch *CalendarHandlers) HandleStopTimeChange(m *tb.Message) {
 
 		err = ch.setSession(session, m.Sender, m.Chat)
 		if err != nil {
-			ch.handler.SendError(m.Chat, err)
-			customerrors.HandlerError(err, &m.Chat.ID, &m.ID)
+			ch.sessionError(err, m.Chat, &m.ID)
 		}
 	} else {
 		ch.HandleText(m)
 	}
 }
 func (ch *CalendarHandlers) HandleLocationChange(m *tb.Message) {
+	lang := ch.lang(m.Sender)
 	session, err := ch.getSession(m.Sender, m.Chat)
 	if err != nil {
 		return
 	}
 
-	if session.IsCreate {
-		session.Step = telegram.StepCreateLocation
+	if wizard.IsCreating(session) {
+		ch.fire(session, wizard.EditLocation, m.Chat)
 		var replyMarkup *tb.ReplyMarkup = nil
 		var replyTo *tb.Message = nil
 		if m.Chat.Type != tb.ChatPrivate {
 			replyMarkup = &tb.ReplyMarkup{
-				InlineKeyboard: calendarInlineKeyboards.GetCreateOptionButtons(session),
+				InlineKeyboard: ch.keyboards.GetCreateOptionButtons(lang, session),
 			}
 			replyTo = m
+		} else {
+			replyMarkup = &tb.ReplyMarkup{
+				ReplyKeyboard:       calendarKeyboards.GetCreateLocationButtons(lang),
+				ResizeReplyKeyboard: true,
+				OneTimeKeyboard:     true,
+			}
 		}
 
-		msg, err := ch.handler.bot.Send(m.Chat, calendarMessages.CreateEventLocationText, &tb.SendOptions{
+		msg, err := ch.handler.bot.Send(m.Chat, lang.T(calendarMessages.CreateEventLocationText), &tb.SendOptions{
 			ParseMode:   tb.ModeHTML,
 			ReplyMarkup: replyMarkup,
 			ReplyTo:     replyTo,
@@ -772,20 +853,20 @@ func (ch *CalendarHandlers) HandleLocationChange(m *tb.Message) {
 
 		err = ch.setSession(session, m.Sender, m.Chat)
 		if err != nil {
-			ch.handler.SendError(m.Chat, err)
-			customerrors.HandlerError(err, &m.Chat.ID, &m.ID)
+			ch.sessionError(err, m.Chat, &m.ID)
 		}
 	} else {
 		ch.HandleText(m)
 	}
 }
 func (ch *CalendarHandlers) HandleFullDayChange(m *tb.Message) {
+	lang := ch.lang(m.Sender)
 	session, err := ch.getSession(m.Sender, m.Chat)
 	if err != nil {
 		return
 	}
 
-	if session.IsCreate {
+	if wizard.IsCreating(session) {
 		session.Event.FullDay = true
 		session.Event.To = session.Event.From.Add(24 * time.Hour)
 		if session.InfoMsg.ChatID != 0 {
@@ -796,12 +877,12 @@ func (ch *CalendarHandlers) HandleFullDayChange(m *tb.Message) {
 		}
 
 		newMsg, err := ch.handler.bot.Send(m.Chat,
-			calendarMessages.GetCreateEventHeader()+calendarMessages.SingleEventFullText(&session.Event),
+			calendarMessages.GetCreateEventHeader(lang)+calendarMessages.SingleEventFullText(lang, &session.Event),
 			&tb.SendOptions{
 				ParseMode: tb.ModeHTML,
 				ReplyTo:   m,
 				ReplyMarkup: &tb.ReplyMarkup{
-					InlineKeyboard: calendarInlineKeyboards.CreateEventButtons(session.Event),
+					InlineKeyboard: ch.keyboards.CreateEventButtons(lang, session.Event),
 				},
 			})
 
@@ -814,17 +895,17 @@ func (ch *CalendarHandlers) HandleFullDayChange(m *tb.Message) {
 			var replyTo *tb.Message = nil
 			if m.Chat.Type != tb.ChatPrivate {
 				replyMarkup = tb.ReplyMarkup{
-					InlineKeyboard: calendarInlineKeyboards.GetCreateOptionButtons(session),
+					InlineKeyboard: ch.keyboards.GetCreateOptionButtons(lang, session),
 				}
 				replyTo = m
 			} else {
 				replyMarkup = tb.ReplyMarkup{
-					ReplyKeyboard:   calendarKeyboards.GetCreateOptionButtons(session),
+					ReplyKeyboard:   calendarKeyboards.GetCreateOptionButtons(lang, session),
 					OneTimeKeyboard: true,
 				}
 			}
 
-			msg, err := ch.handler.bot.Send(m.Chat, calendarMessages.GetCreateEventTitle(), &tb.SendOptions{
+			msg, err := ch.handler.bot.Send(m.Chat, calendarMessages.GetCreateEventTitle(lang), &tb.SendOptions{
 				ParseMode:   tb.ModeHTML,
 				ReplyMarkup: &replyMarkup,
 				ReplyTo:     replyTo,
@@ -838,6 +919,7 @@ func (ch *CalendarHandlers) HandleFullDayChange(m *tb.Message) {
 				session.InlineMsg = utils.InitCustomEditable(msg.MessageSig())
 				err = ch.setSession(session, m.Sender, m.Chat)
 				if err != nil {
+					ch.sessionError(err, m.Chat, &m.ID)
 					return
 				}
 			}
@@ -847,8 +929,7 @@ func (ch *CalendarHandlers) HandleFullDayChange(m *tb.Message) {
 
 		err = ch.setSession(session, m.Sender, m.Chat)
 		if err != nil {
-			ch.handler.SendError(m.Chat, err)
-			customerrors.HandlerError(err, &m.Chat.ID, &m.ID)
+			ch.sessionError(err, m.Chat, &m.ID)
 		}
 	} else {
 		ch.HandleText(m)
@@ -856,6 +937,7 @@ func (ch *CalendarHandlers) HandleFullDayChange(m *tb.Message) {
 }
 
 func (ch *CalendarHandlers) HandleShowMore(c *tb.Callback) {
+	lang := ch.lang(c.Sender)
 	if !ch.AuthMiddleware(c.Sender, c.Message.Chat) {
 		err := ch.handler.bot.Respond(c, &tb.CallbackResponse{
 			CallbackID: c.ID,
@@ -872,17 +954,18 @@ func (ch *CalendarHandlers) HandleShowMore(c *tb.Callback) {
 
 	err := ch.handler.bot.Respond(c, &tb.CallbackResponse{
 		CallbackID: c.ID,
-		Text:       calendarMessages.CallbackResponseHeader(event),
+		Text:       calendarMessages.CallbackResponseHeader(lang, event),
 	})
 	if err != nil {
 		customerrors.HandlerError(err, &c.Message.Chat.ID, &c.Message.ID)
 	}
 
-	_, err = ch.handler.bot.Edit(c.Message, calendarMessages.SingleEventFullText(event),
+	_, err = ch.handler.bot.Edit(c.Message, calendarMessages.SingleEventFullText(lang, event),
 		&tb.SendOptions{
 			ParseMode: tb.ModeHTML,
 			ReplyMarkup: &tb.ReplyMarkup{
-				InlineKeyboard: calendarInlineKeyboards.EventShowLessInlineKeyboard(event),
+				InlineKeyboard: ch.keyboards.EventShowLessInlineKeyboard(lang, event,
+					c.Message.Chat.Type == tb.ChatPrivate),
 			},
 		})
 	if err != nil {
@@ -891,6 +974,7 @@ func (ch *CalendarHandlers) HandleShowMore(c *tb.Callback) {
 
 }
 func (ch *CalendarHandlers) HandleShowLess(c *tb.Callback) {
+	lang := ch.lang(c.Sender)
 	if !ch.AuthMiddleware(c.Sender, c.Message.Chat) {
 		err := ch.handler.bot.Respond(c, &tb.CallbackResponse{
 			CallbackID: c.ID,
@@ -912,11 +996,11 @@ func (ch *CalendarHandlers) HandleShowLess(c *tb.Callback) {
 		customerrors.HandlerError(err, &c.Message.Chat.ID, &c.Message.ID)
 	}
 
-	inlineKeyboard, err := calendarInlineKeyboards.EventShowMoreInlineKeyboard(event, ch.redisDB)
+	inlineKeyboard, err := ch.keyboards.EventShowMoreInlineKeyboard(lang, event, &ch.callbackStore)
 	if err != nil {
 		customerrors.HandlerError(err, &c.Message.Chat.ID, &c.Message.ID)
 	}
-	_, err = ch.handler.bot.Edit(c.Message, calendarMessages.SingleEventShortText(event, false),
+	_, err = ch.handler.bot.Edit(c.Message, calendarMessages.SingleEventShortText(lang, event, false),
 		&tb.SendOptions{
 			ParseMode: tb.ModeHTML,
 			ReplyMarkup: &tb.ReplyMarkup{
@@ -928,10 +1012,11 @@ func (ch *CalendarHandlers) HandleShowLess(c *tb.Callback) {
 	}
 }
 func (ch *CalendarHandlers) HandleAlertYes(c *tb.Callback) {
+	lang := ch.lang(c.Sender)
 	if c.Sender.ID != c.Message.ReplyTo.Sender.ID {
 		err := ch.handler.bot.Respond(c, &tb.CallbackResponse{
 			CallbackID: c.ID,
-			Text:       calendarMessages.GetUserNotAllow(),
+			Text:       calendarMessages.GetUserNotAllow(lang),
 			ShowAlert:  true,
 		})
 		if err != nil {
@@ -965,10 +1050,11 @@ func (ch *CalendarHandlers) HandleAlertYes(c *tb.Callback) {
 
 }
 func (ch *CalendarHandlers) HandleAlertNo(c *tb.Callback) {
+	lang := ch.lang(c.Sender)
 	if c.Sender.ID != c.Message.ReplyTo.Sender.ID {
 		err := ch.handler.bot.Respond(c, &tb.CallbackResponse{
 			CallbackID: c.ID,
-			Text:       calendarMessages.GetUserNotAllow(),
+			Text:       calendarMessages.GetUserNotAllow(lang),
 			ShowAlert:  true,
 		})
 		if err != nil {
@@ -989,11 +1075,12 @@ func (ch *CalendarHandlers) HandleAlertNo(c *tb.Callback) {
 	}
 }
 func (ch *CalendarHandlers) HandleCancelCreateEvent(c *tb.Callback) {
+	lang := ch.lang(c.Sender)
 
 	if c.Sender.ID != c.Message.ReplyTo.Sender.ID {
 		err := ch.handler.bot.Respond(c, &tb.CallbackResponse{
 			CallbackID: c.ID,
-			Text:       calendarMessages.GetUserNotAllow(),
+			Text:       calendarMessages.GetUserNotAllow(lang),
 			ShowAlert:  true,
 		})
 		if err != nil {
@@ -1018,16 +1105,12 @@ func (ch *CalendarHandlers) HandleCancelCreateEvent(c *tb.Callback) {
 
 	err = ch.handler.bot.Respond(c, &tb.CallbackResponse{
 		CallbackID: c.ID,
-		Text:       calendarMessages.GetCreateCanceledText(),
+		Text:       calendarMessages.GetCreateCanceledText(lang),
 	})
 	if err != nil {
 		customerrors.HandlerError(err, &c.Message.Chat.ID, &c.Message.ID)
 	}
-	session.IsCreate = false
-	session.IsDate = false
-	session.FindTimeDone = false
-	session.Step = telegram.StepCreateInit
-	session.Event = types.Event{}
+	ch.fire(session, wizard.Cancel, c.Message.Chat)
 
 	if session.InfoMsg.ChatID != 0 {
 		err := ch.handler.bot.Delete(&session.InfoMsg)
@@ -1040,10 +1123,11 @@ func (ch *CalendarHandlers) HandleCancelCreateEvent(c *tb.Callback) {
 
 	err = ch.setSession(session, c.Sender, c.Message.Chat)
 	if err != nil {
+		ch.sessionError(err, c.Message.Chat, &c.Message.ID)
 		return
 	}
 
-	_, err = ch.handler.bot.Send(c.Message.Chat, calendarMessages.GetCreateCanceledText(), &tb.SendOptions{
+	_, err = ch.handler.bot.Send(c.Message.Chat, calendarMessages.GetCreateCanceledText(lang), &tb.SendOptions{
 		ParseMode: tb.ModeHTML,
 		ReplyMarkup: &tb.ReplyMarkup{
 			ReplyKeyboardRemove: true,
@@ -1055,10 +1139,11 @@ func (ch *CalendarHandlers) HandleCancelCreateEvent(c *tb.Callback) {
 	}
 }
 func (ch *CalendarHandlers) HandleCreateEvent(c *tb.Callback) {
+	lang := ch.lang(c.Sender)
 	if c.Sender.ID != c.Message.ReplyTo.Sender.ID {
 		err := ch.handler.bot.Respond(c, &tb.CallbackResponse{
 			CallbackID: c.ID,
-			Text:       calendarMessages.GetUserNotAllow(),
+			Text:       calendarMessages.GetUserNotAllow(lang),
 			ShowAlert:  true,
 		})
 		if err != nil {
@@ -1071,17 +1156,69 @@ func (ch *CalendarHandlers) HandleCreateEvent(c *tb.Callback) {
 	if err != nil {
 		return
 	}
+	if !ch.canFire(session, wizard.Created, c.Message.Chat) {
+		err = ch.handler.bot.Respond(c, &tb.CallbackResponse{
+			CallbackID: c.ID,
+			Text:       lang.T(calendarMessages.CallbackExpired),
+			ShowAlert:  true,
+		})
+		if err != nil {
+			customerrors.HandlerError(err, &c.Message.Chat.ID, &c.Message.ID)
+		}
+		return
+	}
 
-	token, err := ch.userUseCase.GetOrRefreshOAuthAccessTokenByTelegramUserID(int64(c.Sender.ID))
+	// events of a group with a bound calendar are created in it by the calendar owner,
+	// other members can use the token of the owner only if they are group admins
+	organizerID := c.Sender.ID
+	groupCalendar := ch.groupCalendar(c.Message.Chat)
+	if groupCalendar != nil {
+		if !ch.canCreateInGroupCalendar(c.Message.Chat, c.Sender, groupCalendar) {
+			ch.respondAlert(c, lang.T(calendarMessages.BindCalendarCreateDenied))
+			return
+		}
+		organizerID = int(groupCalendar.TelegramUserID)
+	}
+
+	token, err := ch.userUseCase.GetOrRefreshOAuthAccessTokenByTelegramUserID(int64(organizerID))
 	if err != nil {
 		ch.handler.SendError(c.Message.Chat, err)
 		customerrors.HandlerError(err, &c.Message.Chat.ID, &c.Message.ID)
 		return
 	}
 
-	session.Event.Uid = uuid.NewString()
+	// the wizard is finished before the event is created, so a second click on the button
+	// loses the race on the session instead of creating the event twice
+	event := session.Event
+	users := session.Users
+	event.Uid = uuid.NewString()
+	session.LastCreated = &event
+	ch.fire(session, wizard.Created, c.Message.Chat)
+	err = ch.setSession(session, c.Sender, c.Message.Chat)
+	if err != nil {
+		ch.sessionError(err, c.Message.Chat, &c.Message.ID)
+		return
+	}
 
-	inpEvent := EventToEventInput(session.Event)
+	organizerSettings := ch.handler.settings(int64(organizerID))
+	inpEvent := EventToEventInput(lang, event, settingsLocation(organizerSettings))
+	if groupCalendar == nil && organizerSettings.CalendarUID != "" {
+		inpEvent.Calendar = &organizerSettings.CalendarUID
+	}
+	if groupCalendar != nil {
+		inpEvent.Calendar = &groupCalendar.CalendarUID
+		if organizerID != c.Sender.ID && event.Organizer.Email != "" {
+			attendees := types.Attendees{}
+			if inpEvent.Attendees != nil {
+				attendees = *inpEvent.Attendees
+			}
+			attendees = append(attendees, types.Attendee{
+				Email: event.Organizer.Email,
+				Role:  types.RoleRequired,
+			})
+			inpEvent.Attendees = &attendees
+		}
+	}
 	info, err := ch.eventUseCase.CreateEvent(token, inpEvent)
 
 	if err != nil {
@@ -1098,17 +1235,17 @@ func (ch *CalendarHandlers) HandleCreateEvent(c *tb.Callback) {
 
 	err = ch.handler.bot.Respond(c, &tb.CallbackResponse{
 		CallbackID: c.ID,
-		Text:       calendarMessages.GetEventCreatedText(),
+		Text:       calendarMessages.GetEventCreatedText(lang),
 	})
 	if err != nil {
 		customerrors.HandlerError(err, &c.Message.Chat.ID, &c.Message.ID)
 	}
 
-	session.Event.Calendar = respInfo.Data.CreateEvent.Calendar
+	event.Calendar = respInfo.Data.CreateEvent.Calendar
 
-	if len(session.Users) > 1 {
-		for _, userId := range session.Users {
-			if int64(c.Sender.ID) == userId {
+	if len(users) > 1 {
+		for _, userId := range users {
+			if int64(organizerID) == userId {
 				continue
 			}
 			userToken, err := ch.userUseCase.GetOrRefreshOAuthAccessTokenByTelegramUserID(userId)
@@ -1123,7 +1260,7 @@ func (ch *CalendarHandlers) HandleCreateEvent(c *tb.Callback) {
 				continue
 			}
 
-			events, err := ch.eventUseCase.GetEventsByDate(userToken, session.Event.From)
+			events, err := ch.eventUseCase.GetEventsByDate(userToken, event.From)
 			if err != nil {
 				customerrors.HandlerError(err, &c.Message.Chat.ID, &c.Message.ID)
 				continue
@@ -1146,7 +1283,7 @@ func (ch *CalendarHandlers) HandleCreateEvent(c *tb.Callback) {
 			_, err = ch.eventUseCase.ChangeStatus(userToken, types.ChangeStatus{
 				EventID:    *inpEvent.Uid,
 				CalendarID: userCalId,
-				Status:     telegram.StatusAccepted,
+				Status:     types.StatusAccepted,
 			})
 
 			if err != nil {
@@ -1154,10 +1291,10 @@ func (ch *CalendarHandlers) HandleCreateEvent(c *tb.Callback) {
 				continue
 			}
 
-			for idx, attendee := range session.Event.Attendees {
+			for idx, attendee := range event.Attendees {
 				if attendee.Email == userInfo.Email {
-					session.Event.Attendees[idx].Name = userInfo.Name
-					session.Event.Attendees[idx].Status = telegram.StatusAccepted
+					event.Attendees[idx].Name = userInfo.Name
+					event.Attendees[idx].Status = types.StatusAccepted
 					break
 				}
 			}
@@ -1165,7 +1302,7 @@ func (ch *CalendarHandlers) HandleCreateEvent(c *tb.Callback) {
 	}
 
 	_, err = ch.handler.bot.Send(c.Message.Chat,
-		calendarMessages.GetCreatedEventHeader(), &tb.SendOptions{
+		calendarMessages.GetCreatedEventHeader(lang), &tb.SendOptions{
 			ParseMode: tb.ModeHTML,
 			ReplyMarkup: &tb.ReplyMarkup{
 				ReplyKeyboardRemove: true,
@@ -1178,30 +1315,40 @@ func (ch *CalendarHandlers) HandleCreateEvent(c *tb.Callback) {
 
 	var groupButtons [][]tb.InlineButton = nil
 	if c.Message.Chat.Type == tb.ChatGroup || c.Message.Chat.Type == tb.ChatSuperGroup {
-		groupButtons, err = calendarInlineKeyboards.GroupChatButtons(&session.Event, ch.redisDB, c.Sender.ID)
+		groupButtons, err = ch.keyboards.GroupChatButtons(lang, &event, &ch.callbackStore, organizerID)
 		if err != nil {
 			customerrors.HandlerError(err, &c.Message.Chat.ID, &c.Message.ID)
 		}
 	}
 
-	_, err = ch.handler.bot.Send(c.Message.Chat,
-		calendarMessages.SingleEventFullText(&session.Event),
+	eventMsg, err := ch.handler.bot.Send(c.Message.Chat,
+		calendarMessages.SingleEventFullText(lang, &event),
 		&tb.SendOptions{
 			ParseMode: tb.ModeHTML,
 			ReplyMarkup: &tb.ReplyMarkup{
-				InlineKeyboard: groupButtons,
+				InlineKeyboard: calendarInlineKeyboards.WithCallButton(lang, &event, groupButtons),
 			},
 		})
 
 	if err != nil {
 		customerrors.HandlerError(err, &c.Message.Chat.ID, &c.Message.ID)
+	} else if groupButtons != nil {
+		ch.trackPostedMessage(eventMsg, &event, organizerID, telegram.PostedMessageGroup)
+		ch.replyVacations(lang, eventMsg, &event, c.Sender.ID)
+	} else {
+		ch.trackPostedMessage(eventMsg, &event, organizerID, telegram.PostedMessagePrivate)
 	}
 
-	session.IsCreate = false
-	session.IsDate = false
-	session.FindTimeDone = false
-	session.Step = telegram.StepCreateInit
-	session.Event = types.Event{}
+	_, err = ch.handler.bot.Send(c.Message.Chat, lang.T(calendarMessages.TemplateSaveText), &tb.SendOptions{
+		ParseMode: tb.ModeHTML,
+		ReplyTo:   c.Message.ReplyTo,
+		ReplyMarkup: &tb.ReplyMarkup{
+			InlineKeyboard: ch.keyboards.TemplateSaveButtons(lang, &event),
+		},
+	})
+	if err != nil {
+		customerrors.HandlerError(err, &c.Message.Chat.ID, &c.Message.ID)
+	}
 
 	if session.InfoMsg.ChatID != 0 {
 		err = ch.handler.bot.Delete(&session.InfoMsg)
@@ -1222,20 +1369,22 @@ func (ch *CalendarHandlers) HandleCreateEvent(c *tb.Callback) {
 
 	err = ch.setSession(session, c.Sender, c.Message.Chat)
 	if err != nil {
+		ch.sessionError(err, c.Message.Chat, &c.Message.ID)
 		return
 	}
 }
 func (ch *CalendarHandlers) HandleGroupGo(c *tb.Callback) {
-	ch.handleGroup(c, telegram.StatusAccepted)
+	ch.handleGroup(c, types.StatusAccepted)
 }
 func (ch *CalendarHandlers) HandleGroupNotGo(c *tb.Callback) {
-	ch.handleGroup(c, telegram.StatusDeclined)
+	ch.handleGroup(c, types.StatusDeclined)
 }
 func (ch *CalendarHandlers) HandleGroupFindTimeYes(c *tb.Callback) {
+	lang := ch.lang(c.Sender)
 	if c.Sender.ID != c.Message.ReplyTo.Sender.ID {
 		err := ch.handler.bot.Respond(c, &tb.CallbackResponse{
 			CallbackID: c.ID,
-			Text:       calendarMessages.GetUserNotAllow(),
+			Text:       calendarMessages.GetUserNotAllow(lang),
 			ShowAlert:  true,
 		})
 		if err != nil {
@@ -1251,18 +1400,27 @@ func (ch *CalendarHandlers) HandleGroupFindTimeYes(c *tb.Callback) {
 		return
 	}
 
-	session.IsCreate = true
-	session.IsDate = false
+	if !ch.fire(session, wizard.StartFindTime, c.Message.Chat) {
+		err = ch.handler.bot.Respond(c, &tb.CallbackResponse{
+			CallbackID: c.ID,
+			Text:       lang.T(calendarMessages.CallbackExpired),
+			ShowAlert:  true,
+		})
+		if err != nil {
+			customerrors.HandlerError(err, &c.Message.Chat.ID, &c.Message.ID)
+		}
+		return
+	}
 
 	err = ch.handler.bot.Delete(c.Message)
 	if err != nil {
 		customerrors.HandlerError(err, &c.Message.Chat.ID, &c.Message.ID)
 	}
 
-	msg, err := ch.handler.bot.Send(c.Message.Chat, calendarMessages.GetFindTimeStartText(), &tb.SendOptions{
+	msg, err := ch.handler.bot.Send(c.Message.Chat, calendarMessages.GetFindTimeStartText(lang), &tb.SendOptions{
 		ParseMode: tb.ModeHTML,
 		ReplyMarkup: &tb.ReplyMarkup{
-			InlineKeyboard: calendarInlineKeyboards.GetDateFastCommand(true),
+			InlineKeyboard: ch.keyboards.GetDateFastCommand(lang, true, ch.userNow(int64(c.Sender.ID))),
 		},
 		ReplyTo: c.Message.ReplyTo,
 	})
@@ -1274,16 +1432,16 @@ func (ch *CalendarHandlers) HandleGroupFindTimeYes(c *tb.Callback) {
 
 	err = ch.setSession(session, c.Sender, c.Message.Chat)
 	if err != nil {
-		ch.handler.SendError(c.Message.Chat, err)
-		customerrors.HandlerError(err, &c.Message.Chat.ID, &c.Message.ID)
+		ch.sessionError(err, c.Message.Chat, &c.Message.ID)
 		return
 	}
 }
 func (ch *CalendarHandlers) HandleGroupFindTimeNo(c *tb.Callback) {
+	lang := ch.lang(c.Sender)
 	if c.Sender.ID != c.Message.ReplyTo.Sender.ID {
 		err := ch.handler.bot.Respond(c, &tb.CallbackResponse{
 			CallbackID: c.ID,
-			Text:       calendarMessages.GetUserNotAllow(),
+			Text:       calendarMessages.GetUserNotAllow(lang),
 			ShowAlert:  true,
 		})
 		if err != nil {
@@ -1299,14 +1457,6 @@ func (ch *CalendarHandlers) HandleGroupFindTimeNo(c *tb.Callback) {
 		return
 	}
 
-	session.FindTimeDone = true
-	err = ch.setSession(session, c.Sender, c.Message.Chat)
-	if err != nil {
-		ch.handler.SendError(c.Message.Chat, err)
-		customerrors.HandlerError(err, &c.Message.Chat.ID, &c.Message.ID)
-		return
-	}
-
 	err = ch.handler.bot.Respond(c, &tb.CallbackResponse{
 		CallbackID: c.ID,
 	})
@@ -1319,13 +1469,14 @@ func (ch *CalendarHandlers) HandleGroupFindTimeNo(c *tb.Callback) {
 		customerrors.HandlerError(err, &c.Message.Chat.ID, &c.Message.ID)
 	}
 
-	ch.HandleCreate(c.Message.ReplyTo)
+	ch.startCreate(c.Message.ReplyTo, session)
 }
 func (ch *CalendarHandlers) HandleFindTimeDayPart(c *tb.Callback) {
+	lang := ch.lang(c.Sender)
 	if c.Sender.ID != c.Message.ReplyTo.Sender.ID {
 		err := ch.handler.bot.Respond(c, &tb.CallbackResponse{
 			CallbackID: c.ID,
-			Text:       calendarMessages.GetUserNotAllow(),
+			Text:       calendarMessages.GetUserNotAllow(lang),
 			ShowAlert:  true,
 		})
 		if err != nil {
@@ -1347,14 +1498,8 @@ func (ch *CalendarHandlers) HandleFindTimeDayPart(c *tb.Callback) {
 		customerrors.HandlerError(err, &c.Message.Chat.ID, &c.Message.ID)
 	}
 
-	if c.Data == calendarMessages.GetCreateCancelText() {
-		session.IsCreate = false
-		session.IsDate = false
-		session.FindTimeDone = false
-		session.Step = telegram.StepCreateInit
-		session.Event = types.Event{}
-		session.FreeBusy = types.FreeBusy{}
-		session.FindTimeDayPart = nil
+	if c.Data == calendarMessages.GetCreateCancelText(lang) {
+		ch.fire(session, wizard.Cancel, c.Message.Chat)
 
 		if session.InfoMsg.ChatID != 0 {
 			err := ch.handler.bot.Delete(&session.InfoMsg)
@@ -1375,10 +1520,11 @@ func (ch *CalendarHandlers) HandleFindTimeDayPart(c *tb.Callback) {
 
 		err = ch.setSession(session, c.Sender, c.Message.Chat)
 		if err != nil {
+			ch.sessionError(err, c.Message.Chat, &c.Message.ID)
 			return
 		}
 
-		_, err = ch.handler.bot.Send(c.Message.Chat, calendarMessages.GetCreateCanceledText(), &tb.SendOptions{
+		_, err = ch.handler.bot.Send(c.Message.Chat, calendarMessages.GetCreateCanceledText(lang), &tb.SendOptions{
 			ParseMode: tb.ModeHTML,
 			ReplyMarkup: &tb.ReplyMarkup{
 				ReplyKeyboardRemove: true,
@@ -1400,8 +1546,7 @@ func (ch *CalendarHandlers) HandleFindTimeDayPart(c *tb.Callback) {
 		session.FindTimeDayPart = nil
 		err = ch.setSession(session, c.Sender, c.Message.Chat)
 		if err != nil {
-			ch.handler.SendError(c.Message.Chat, err)
-			customerrors.HandlerError(err, &c.Message.Chat.ID, &c.Message.ID)
+			ch.sessionError(err, c.Message.Chat, &c.Message.ID)
 		}
 
 		err = ch.handler.bot.Delete(c.Message)
@@ -1409,11 +1554,11 @@ func (ch *CalendarHandlers) HandleFindTimeDayPart(c *tb.Callback) {
 			customerrors.HandlerError(err, &c.Message.Chat.ID, &c.Message.ID)
 		}
 
-		_, err = ch.handler.bot.Send(c.Message.Chat, calendarMessages.FindTimeChooseLengthHeader,
+		_, err = ch.handler.bot.Send(c.Message.Chat, lang.T(calendarMessages.FindTimeChooseLengthHeader),
 			&tb.SendOptions{
 				ParseMode: tb.ModeHTML,
 				ReplyMarkup: &tb.ReplyMarkup{
-					InlineKeyboard: calendarInlineKeyboards.FindTimeLengthButtons(),
+					InlineKeyboard: ch.keyboards.FindTimeLengthButtons(lang),
 				},
 				ReplyTo: c.Message.ReplyTo,
 			})
@@ -1446,8 +1591,7 @@ func (ch *CalendarHandlers) HandleFindTimeDayPart(c *tb.Callback) {
 
 	err = ch.setSession(session, c.Sender, c.Message.Chat)
 	if err != nil {
-		ch.handler.SendError(c.Message.Chat, err)
-		customerrors.HandlerError(err, &c.Message.Chat.ID, &c.Message.ID)
+		ch.sessionError(err, c.Message.Chat, &c.Message.ID)
 		return
 	}
 
@@ -1456,11 +1600,11 @@ func (ch *CalendarHandlers) HandleFindTimeDayPart(c *tb.Callback) {
 		customerrors.HandlerError(err, &c.Message.Chat.ID, &c.Message.ID)
 	}
 
-	_, err = ch.handler.bot.Send(c.Message.Chat, calendarMessages.FindTimeChooseLengthHeader,
+	_, err = ch.handler.bot.Send(c.Message.Chat, lang.T(calendarMessages.FindTimeChooseLengthHeader),
 		&tb.SendOptions{
 			ParseMode: tb.ModeHTML,
 			ReplyMarkup: &tb.ReplyMarkup{
-				InlineKeyboard: calendarInlineKeyboards.FindTimeLengthButtons(),
+				InlineKeyboard: ch.keyboards.FindTimeLengthButtons(lang),
 			},
 			ReplyTo: c.Message.ReplyTo,
 		})
@@ -1469,10 +1613,11 @@ func (ch *CalendarHandlers) HandleFindTimeDayPart(c *tb.Callback) {
 	}
 }
 func (ch *CalendarHandlers) HandleFindTimeLength(c *tb.Callback) {
+	lang := ch.lang(c.Sender)
 	if c.Sender.ID != c.Message.ReplyTo.Sender.ID {
 		err := ch.handler.bot.Respond(c, &tb.CallbackResponse{
 			CallbackID: c.ID,
-			Text:       calendarMessages.GetUserNotAllow(),
+			Text:       calendarMessages.GetUserNotAllow(lang),
 			ShowAlert:  true,
 		})
 		if err != nil {
@@ -1494,8 +1639,8 @@ func (ch *CalendarHandlers) HandleFindTimeLength(c *tb.Callback) {
 		customerrors.HandlerError(err, &c.Message.Chat.ID, &c.Message.ID)
 	}
 
-	if c.Data == calendarMessages.GetCreateCancelText() {
-		session = &types.BotRedisSession{}
+	if c.Data == calendarMessages.GetCreateCancelText(lang) {
+		session = sessions.Reset(session)
 
 		if session.InfoMsg.ChatID != 0 {
 			err := ch.handler.bot.Delete(&session.InfoMsg)
@@ -1516,10 +1661,11 @@ func (ch *CalendarHandlers) HandleFindTimeLength(c *tb.Callback) {
 
 		err = ch.setSession(session, c.Sender, c.Message.Chat)
 		if err != nil {
+			ch.sessionError(err, c.Message.Chat, &c.Message.ID)
 			return
 		}
 
-		_, err = ch.handler.bot.Send(c.Message.Chat, calendarMessages.GetCreateCanceledText(), &tb.SendOptions{
+		_, err = ch.handler.bot.Send(c.Message.Chat, calendarMessages.GetCreateCanceledText(lang), &tb.SendOptions{
 			ParseMode: tb.ModeHTML,
 			ReplyMarkup: &tb.ReplyMarkup{
 				ReplyKeyboardRemove: true,
@@ -1545,8 +1691,7 @@ func (ch *CalendarHandlers) HandleFindTimeLength(c *tb.Callback) {
 
 	err = ch.setSession(session, c.Sender, c.Message.Chat)
 	if err != nil {
-		ch.handler.SendError(c.Message.Chat, err)
-		customerrors.HandlerError(err, &c.Message.Chat.ID, &c.Message.ID)
+		ch.sessionError(err, c.Message.Chat, &c.Message.ID)
 	}
 
 	err = ch.handler.bot.Delete(c.Message)
@@ -1559,7 +1704,7 @@ func (ch *CalendarHandlers) HandleFindTimeLength(c *tb.Callback) {
 		customerrors.HandlerError(err, &c.Message.Chat.ID, &c.Message.ID)
 	}
 
-	msg, err := ch.handler.bot.Send(c.Message.Chat, calendarMessages.GetFindTimeInfoTextWithRange(
+	msg, err := ch.handler.bot.Send(c.Message.Chat, calendarMessages.GetFindTimeInfoTextWithRange(lang,
 		session.FreeBusy.From, session.FreeBusy.To, data[1]), &tb.SendOptions{
 		ParseMode: tb.ModeHTML,
 	})
@@ -1570,14 +1715,26 @@ func (ch *CalendarHandlers) HandleFindTimeLength(c *tb.Callback) {
 		session.FindTimeInfoMsg = utils.InitCustomEditable(msg.MessageSig())
 		err = ch.setSession(session, c.Sender, c.Message.Chat)
 		if err != nil {
-			ch.handler.SendError(c.Message.Chat, err)
-			customerrors.HandlerError(err, &c.Message.Chat.ID, &c.Message.ID)
+			ch.sessionError(err, c.Message.Chat, &c.Message.ID)
+		}
+	}
+
+	// authenticated members of the group are taken into account from the start, the sender is added by the vote
+	members, err := ch.getChatMembers(c.Message.Chat.ID)
+	if err != nil {
+		customerrors.HandlerError(err, &c.Message.Chat.ID, &c.Message.ID)
+	}
+	session.Users = make([]int64, 0, len(members))
+	for _, member := range members {
+		if member != int64(c.Sender.ID) {
+			session.Users = append(session.Users, member)
 		}
 	}
 
 	ch.sendOrUpdateVote(session, c.Message.Chat, c.Sender, c.Sender, c.Message.ReplyTo, false)
 }
 func (ch *CalendarHandlers) FindTimeAdd(c *tb.Callback) {
+	lang := ch.lang(c.Sender)
 
 	if !ch.AuthMiddleware(c.Sender, c.Message.Chat) {
 		err := ch.handler.bot.Respond(c, &tb.CallbackResponse{
@@ -1607,7 +1764,7 @@ func (ch *CalendarHandlers) FindTimeAdd(c *tb.Callback) {
 		if uId == int64(c.Sender.ID) {
 			err = ch.handler.bot.Respond(c, &tb.CallbackResponse{
 				CallbackID: c.ID,
-				Text:       calendarMessages.FindTimeExist,
+				Text:       lang.T(calendarMessages.FindTimeExist),
 			})
 			if err != nil {
 				customerrors.HandlerError(err, &c.Message.Chat.ID, &c.Message.ID)
@@ -1619,10 +1776,11 @@ func (ch *CalendarHandlers) FindTimeAdd(c *tb.Callback) {
 	ch.sendOrUpdateVote(session, c.Message.Chat, c.Sender, c.Message.ReplyTo.Sender, c.Message.ReplyTo, false)
 }
 func (ch *CalendarHandlers) HandleFindTimeFind(c *tb.Callback) {
+	lang := ch.lang(c.Sender)
 	if c.Sender.ID != c.Message.ReplyTo.Sender.ID {
 		err := ch.handler.bot.Respond(c, &tb.CallbackResponse{
 			CallbackID: c.ID,
-			Text:       calendarMessages.GetUserNotAllow(),
+			Text:       calendarMessages.GetUserNotAllow(lang),
 			ShowAlert:  true,
 		})
 		if err != nil {
@@ -1647,10 +1805,11 @@ func (ch *CalendarHandlers) HandleFindTimeFind(c *tb.Callback) {
 	ch.sendOrUpdateVote(session, c.Message.Chat, c.Sender, c.Message.ReplyTo.Sender, c.Message.ReplyTo, true)
 }
 func (ch *CalendarHandlers) HandleFindTimeBack(c *tb.Callback) {
+	lang := ch.lang(c.Sender)
 	if c.Sender.ID != c.Message.ReplyTo.Sender.ID {
 		err := ch.handler.bot.Respond(c, &tb.CallbackResponse{
 			CallbackID: c.ID,
-			Text:       calendarMessages.GetUserNotAllow(),
+			Text:       calendarMessages.GetUserNotAllow(lang),
 			ShowAlert:  true,
 		})
 		if err != nil {
@@ -1679,18 +1838,18 @@ func (ch *CalendarHandlers) HandleFindTimeBack(c *tb.Callback) {
 
 	err = ch.setSession(session, c.Sender, c.Message.Chat)
 	if err != nil {
-		ch.handler.SendError(c.Message.Chat, err)
-		customerrors.HandlerError(err, &c.Message.Chat.ID, &c.Message.ID)
+		ch.sessionError(err, c.Message.Chat, &c.Message.ID)
 		return
 	}
 
 	ch.HandleGroupFindTimeYes(c)
 }
 func (ch *CalendarHandlers) FindTimeCreate(c *tb.Callback) {
+	lang := ch.lang(c.Sender)
 	if c.Sender.ID != c.Message.ReplyTo.Sender.ID {
 		err := ch.handler.bot.Respond(c, &tb.CallbackResponse{
 			CallbackID: c.ID,
-			Text:       calendarMessages.GetUserNotAllow(),
+			Text:       calendarMessages.GetUserNotAllow(lang),
 			ShowAlert:  true,
 		})
 		if err != nil {
@@ -1712,7 +1871,7 @@ func (ch *CalendarHandlers) FindTimeCreate(c *tb.Callback) {
 		customerrors.HandlerError(err, &c.Message.Chat.ID, &c.Message.ID)
 	}
 
-	if c.Data == calendarMessages.GetCreateCancelText() {
+	if c.Data == calendarMessages.GetCreateCancelText(lang) {
 		if session.PollMsg.ChatID != 0 {
 			err = ch.handler.bot.Delete(&session.PollMsg)
 			if err != nil {
@@ -1734,14 +1893,13 @@ func (ch *CalendarHandlers) FindTimeCreate(c *tb.Callback) {
 			}
 		}
 
-		session = &types.BotRedisSession{}
+		session = sessions.Reset(session)
 		err = ch.setSession(session, c.Sender, c.Message.Chat)
 		if err != nil {
-			ch.handler.SendError(c.Message.Chat, err)
-			customerrors.HandlerError(err, &c.Message.Chat.ID, &c.Message.ID)
+			ch.sessionError(err, c.Message.Chat, &c.Message.ID)
 		}
 
-		_, err = ch.handler.bot.Send(c.Message.Chat, calendarMessages.GetCreateCanceledText())
+		_, err = ch.handler.bot.Send(c.Message.Chat, calendarMessages.GetCreateCanceledText(lang))
 		if err != nil {
 			customerrors.HandlerError(err, &c.Message.Chat.ID, &c.Message.ID)
 		}
@@ -1749,6 +1907,10 @@ func (ch *CalendarHandlers) FindTimeCreate(c *tb.Callback) {
 		return
 	}
 
+	if !ch.canFire(session, wizard.TimeFound, c.Message.Chat) {
+		return
+	}
+
 	text := ""
 	vc := 0
 	for _, options := range c.Message.Poll.Options {
@@ -1784,15 +1946,14 @@ func (ch *CalendarHandlers) FindTimeCreate(c *tb.Callback) {
 		customerrors.HandlerError(err, &c.Message.Chat.ID, &c.Message.ID)
 	}
 
-	session.FindTimeDone = true
-	session.IsCreate = true
+	ch.fire(session, wizard.TimeFound, c.Message.Chat)
 	session.Event.From = resp.EventStart
 	session.Event.To = resp.EventEnd
 	session.Event.Organizer = types.AttendeeEvent{
 		Email:  userInfo.Email,
 		Name:   userInfo.Name,
-		Role:   telegram.RoleRequired,
-		Status: telegram.StatusAccepted,
+		Role:   types.RoleRequired,
+		Status: types.StatusAccepted,
 	}
 	users, err := ch.userUseCase.TryGetUsersEmailsByTelegramUserIDs(session.Users)
 	if err != nil {
@@ -1801,34 +1962,35 @@ func (ch *CalendarHandlers) FindTimeCreate(c *tb.Callback) {
 		for _, user := range users {
 			session.Event.Attendees = append(session.Event.Attendees, types.AttendeeEvent{
 				Email:  user,
-				Role:   telegram.RoleRequired,
-				Status: telegram.StatusAccepted,
+				Role:   types.RoleRequired,
+				Status: types.StatusAccepted,
 			})
 		}
 	}
 
 	newMsg, err := ch.handler.bot.Send(c.Message.Chat,
-		calendarMessages.GetCreateEventHeader()+calendarMessages.SingleEventFullText(&session.Event),
+		calendarMessages.GetCreateEventHeader(lang)+calendarMessages.SingleEventFullText(lang, &session.Event),
 		&tb.SendOptions{
 			ParseMode: tb.ModeHTML,
 			ReplyTo:   c.Message.ReplyTo,
 			ReplyMarkup: &tb.ReplyMarkup{
-				InlineKeyboard: calendarInlineKeyboards.CreateEventButtons(session.Event),
+				InlineKeyboard: ch.keyboards.CreateEventButtons(lang, session.Event),
 			},
 		})
 
 	if err != nil {
 		customerrors.HandlerError(err, &c.Message.Chat.ID, &c.Message.ID)
+	} else {
+		ch.replyVacations(lang, newMsg, &session.Event, c.Sender.ID)
 	}
 
 	session.InfoMsg = utils.InitCustomEditable(newMsg.MessageSig())
 
-	session.Step = telegram.StepCreateTitle
-
-	msg, err := ch.handler.bot.Send(c.Message.Chat, calendarMessages.GetCreateEventTitle(), &tb.SendOptions{
+	msg, err := ch.handler.bot.Send(c.Message.Chat, calendarMessages.GetCreateEventTitle(lang), &tb.SendOptions{
 		ParseMode: tb.ModeHTML,
+		ReplyTo:   c.Message.ReplyTo,
 		ReplyMarkup: &tb.ReplyMarkup{
-			InlineKeyboard: calendarInlineKeyboards.GetCreateOptionButtons(session),
+			InlineKeyboard: ch.keyboards.GetCreateOptionButtons(lang, session),
 		},
 	})
 
@@ -1840,15 +2002,15 @@ func (ch *CalendarHandlers) FindTimeCreate(c *tb.Callback) {
 
 	err = ch.setSession(session, c.Sender, c.Message.Chat)
 	if err != nil {
-		ch.handler.SendError(c.Message.Chat, err)
-		customerrors.HandlerError(err, &c.Message.Chat.ID, &c.Message.ID)
+		ch.sessionError(err, c.Message.Chat, &c.Message.ID)
 	}
 }
 func (ch *CalendarHandlers) HandleGroupText(c *tb.Callback) {
+	lang := ch.lang(c.Sender)
 	if c.Sender.ID != c.Message.ReplyTo.Sender.ID {
 		err := ch.handler.bot.Respond(c, &tb.CallbackResponse{
 			CallbackID: c.ID,
-			Text:       calendarMessages.GetUserNotAllow(),
+			Text:       calendarMessages.GetUserNotAllow(lang),
 			ShowAlert:  true,
 		})
 		if err != nil {
@@ -1870,19 +2032,23 @@ func (ch *CalendarHandlers) HandleGroupText(c *tb.Callback) {
 	c.Message.ReplyTo.Text = c.Data
 
 	switch c.Data {
-	case calendarMessages.CreateEventAddDescButton, calendarMessages.CreateEventChangeDescButton:
+	case lang.T(calendarMessages.CreateEventAddDescButton), lang.T(calendarMessages.CreateEventChangeDescButton):
 		ch.HandleDescChange(c.Message.ReplyTo)
-	case calendarMessages.CreateEventAddTitleButton, calendarMessages.CreateEventChangeTitleButton:
+	case lang.T(calendarMessages.CreateEventAddTitleButton), lang.T(calendarMessages.CreateEventChangeTitleButton):
 		ch.HandleTitleChange(c.Message.ReplyTo)
-	case calendarMessages.CreateEventAddLocationButton, calendarMessages.CreateEventChangeLocationButton:
+	case lang.T(calendarMessages.CreateEventAddLocationButton), lang.T(calendarMessages.CreateEventChangeLocationButton):
 		ch.HandleLocationChange(c.Message.ReplyTo)
-	case calendarMessages.CreateEventAddUser:
+	case lang.T(calendarMessages.CreateEventAddUser):
 		ch.HandleUserChange(c.Message.ReplyTo)
-	case calendarMessages.CreateEventChangeStartTimeButton:
+	case lang.T(calendarMessages.CreateEventRoomButton):
+		ch.HandleRoomChange(c.Message.ReplyTo)
+	case lang.T(calendarMessages.CreateEventAddCallButton), lang.T(calendarMessages.CreateEventRemoveCallButton):
+		ch.HandleCallToggle(c.Message.ReplyTo)
+	case lang.T(calendarMessages.CreateEventChangeStartTimeButton):
 		ch.HandleStartTimeChange(c.Message.ReplyTo)
-	case calendarMessages.CreateEventChangeStopTimeButton:
+	case lang.T(calendarMessages.CreateEventChangeStopTimeButton):
 		ch.HandleStopTimeChange(c.Message.ReplyTo)
-	case calendarMessages.GetCreateFullDay():
+	case calendarMessages.GetCreateFullDay(lang):
 		ch.HandleFullDayChange(c.Message.ReplyTo)
 	default:
 		ch.HandleText(c.Message.ReplyTo)
@@ -1890,70 +2056,64 @@ func (ch *CalendarHandlers) HandleGroupText(c *tb.Callback) {
 }
 
 func (ch *CalendarHandlers) getSession(user *tb.User, chat *tb.Chat) (*types.BotRedisSession, error) {
-	resp, err := ch.redisDB.Get(context.TODO(), strconv.Itoa(int(chat.ID))+strconv.Itoa(user.ID)).Result()
-	if err != nil {
-		newSession := &types.BotRedisSession{}
-		err = ch.setSession(newSession, user, chat)
-
-		if err != nil {
-			customerrors.HandlerError(err, &chat.ID, nil)
-			_, err = ch.handler.bot.Send(user, calendarMessages.RedisSessionNotFound(), &tb.SendOptions{
-				ParseMode: tb.ModeHTML,
-				ReplyMarkup: &tb.ReplyMarkup{
-					ReplyKeyboardRemove: true,
-				},
-			})
-			if err != nil {
-				customerrors.HandlerError(err, &chat.ID, nil)
-			}
-			return nil, err
-		}
-
-		return newSession, nil
-	}
-
-	session := &types.BotRedisSession{}
-	err = json.Unmarshal([]byte(resp), session)
+	session, err := ch.sessionStore.Get(sessions.NewKey(chat.ID, int64(user.ID)))
 	if err != nil {
 		customerrors.HandlerError(err, &chat.ID, nil)
-		_, err := ch.handler.bot.Send(user, calendarMessages.RedisSessionNotFound(), &tb.SendOptions{
+		_, sendErr := ch.handler.bot.Send(user, calendarMessages.RedisSessionNotFound(ch.lang(user)), &tb.SendOptions{
 			ParseMode: tb.ModeHTML,
 			ReplyMarkup: &tb.ReplyMarkup{
 				ReplyKeyboardRemove: true,
 			},
 		})
-		if err != nil {
-			customerrors.HandlerError(err, &chat.ID, nil)
+		if sendErr != nil {
+			customerrors.HandlerError(sendErr, &chat.ID, nil)
 		}
 		return nil, err
 	}
 
 	return session, nil
 }
+
+// setSession stores the session read by getSession, it fails with sessions.VersionConflict if another update has changed the session since then
 func (ch *CalendarHandlers) setSession(session *types.BotRedisSession, user *tb.User, chat *tb.Chat) error {
-	b, err := json.Marshal(session)
-	if err != nil {
-		customerrors.HandlerError(err, &chat.ID, nil)
-		ch.handler.SendError(user, err)
-		return err
+	return ch.sessionStore.Set(sessions.NewKey(chat.ID, int64(user.ID)), session)
+}
+
+// sessionError reports a failed setSession, a lost race with another update of the same session is not an error for the user
+func (ch *CalendarHandlers) sessionError(err error, chat *tb.Chat, msgID *int) {
+	if errors.Cause(err) == sessions.VersionConflict {
+		zap.S().Infof("session in chat=%d was changed concurrently", chat.ID)
+		return
 	}
-	err = ch.redisDB.Set(context.TODO(), strconv.Itoa(int(chat.ID))+strconv.Itoa(user.ID), b, 0).Err()
-	if err != nil {
+	customerrors.HandlerError(err, &chat.ID, msgID)
+	ch.handler.SendError(chat, err)
+}
+
+// fire moves the wizard of the session, invalid transitions are logged and leave the session as is
+func (ch *CalendarHandlers) fire(session *types.BotRedisSession, event fsm.Event, chat *tb.Chat) bool {
+	if err := wizard.Fire(session, event); err != nil {
 		customerrors.HandlerError(err, &chat.ID, nil)
-		ch.handler.SendError(user, err)
-		return err
+		return false
 	}
+	return true
+}
 
-	return nil
+// canFire checks the transition before the side effects of the handler, invalid transitions are logged
+func (ch *CalendarHandlers) canFire(session *types.BotRedisSession, event fsm.Event, chat *tb.Chat) bool {
+	if err := wizard.Machine.Check(wizard.Current(session), event); err != nil {
+		customerrors.HandlerError(err, &chat.ID, nil)
+		return false
+	}
+	return true
 }
-func (ch *CalendarHandlers) sendShortEvents(events *types.Events, chat *tb.Chat) {
+func (ch *CalendarHandlers) sendShortEvents(lang i18n.Lang, events *types.Events, chat *tb.Chat) {
 	*events = ch.sortEvents(*events)
 	prevCalendarName := ""
 	for _, event := range *events {
 		var err error
 		var keyboard [][]tb.InlineButton = nil
 		if chat.Type == tb.ChatPrivate {
-			keyboard, err = calendarInlineKeyboards.EventShowMoreInlineKeyboard(&event, ch.redisDB)
+			keyboard, err = ch.keyboards.EventShowMoreInlineKeyboard(lang, &event, &ch.callbackStore)
 			if err != nil {
 				zap.S().Errorf("Can't set calendarId=%v for eventId=%v. Err: %v",
 					event.Calendar.UID, event.Uid, err)
@@ -1962,7 +2122,7 @@ func (ch *CalendarHandlers) sendShortEvents(events *types.Events, chat *tb.Chat)
 		if event.Calendar.Title != prevCalendarName {
 			_, err = ch.handler.bot.Send(
 				chat,
-				fmt.Sprintf(calendarMessages.EventCalendarText, event.Calendar.Title),
+				fmt.Sprintf(lang.T(calendarMessages.EventCalendarText), event.Calendar.Title),
 				&tb.SendOptions{ParseMode: tb.ModeHTML},
 			)
 			if err != nil {
@@ -1970,7 +2130,7 @@ func (ch *CalendarHandlers) sendShortEvents(events *types.Events, chat *tb.Chat)
 			}
 			prevCalendarName = event.Calendar.Title
 		}
-		_, err = ch.handler.bot.Send(chat, calendarMessages.SingleEventShortText(&event, false), &tb.SendOptions{
+		_, err = ch.handler.bot.Send(chat, calendarMessages.SingleEventShortText(lang, &event, false), &tb.SendOptions{
 			ParseMode: tb.ModeHTML,
 			ReplyMarkup: &tb.ReplyMarkup{
 				InlineKeyboard: keyboard,
@@ -1992,9 +2152,9 @@ func (ch *CalendarHandlers) sortEvents(events types.Events) types.Events {
 
 	for _, event := range events {
 		switch event.Calendar.Type {
-		case telegram.CalendarTypePersonal:
+		case types.CalendarTypePersonal:
 			personalEvents = append(personalEvents, event)
-		case telegram.CalendarTypeHoliday:
+		case types.CalendarTypeHoliday:
 			holidayEvents = append(holidayEvents, event)
 		default:
 			publicEvents = append(publicEvents, event)
@@ -2009,12 +2169,18 @@ func (ch *CalendarHandlers) sortEvents(events types.Events) types.Events {
 	return output
 }
 func (ch *CalendarHandlers) getEventByIdForCallback(c *tb.Callback, senderID int) *types.Event {
-	calUid, err := ch.redisDB.Get(context.TODO(), c.Data).Result()
+	lang := ch.lang(c.Sender)
+	calUid, err := ch.callbackStore.GetEventCalendar(c.Data)
 	if err != nil {
-		customerrors.HandlerError(err, &c.Message.Chat.ID, &c.Message.ID)
+		text := calendarMessages.RedisNotFoundMessage(lang)
+		if errors.Cause(err) == callbacks.KeyDoesNotExist {
+			text = lang.T(calendarMessages.CallbackExpired)
+		} else {
+			customerrors.HandlerError(err, &c.Message.Chat.ID, &c.Message.ID)
+		}
 		err = ch.handler.bot.Respond(c, &tb.CallbackResponse{
 			CallbackID: c.ID,
-			Text:       calendarMessages.RedisNotFoundMessage(),
+			Text:       text,
 			ShowAlert:  true,
 		})
 		if err != nil {
@@ -2054,7 +2220,8 @@ func (ch *CalendarHandlers) getEventByIdForCallback(c *tb.Callback, senderID int
 	return &resp.Data.Event
 }
 func (ch *CalendarHandlers) handleCreateText(m *tb.Message, session *types.BotRedisSession) {
-	if calendarMessages.GetCreateCancelText() == m.Text {
+	lang := ch.lang(m.Sender)
+	if calendarMessages.GetCreateCancelText(lang) == m.Text {
 		if session.InfoMsg.ChatID != 0 {
 			err := ch.handler.bot.Delete(&session.InfoMsg)
 			if err != nil {
@@ -2069,17 +2236,18 @@ func (ch *CalendarHandlers) handleCreateText(m *tb.Message, session *types.BotRe
 			}
 		}
 
-		session = &types.BotRedisSession{}
+		session = sessions.Reset(session)
 
 		session.InfoMsg = utils.InitCustomEditable("", 0)
 		session.InlineMsg = utils.InitCustomEditable("", 0)
 
 		err := ch.setSession(session, m.Sender, m.Chat)
 		if err != nil {
+			ch.sessionError(err, m.Chat, &m.ID)
 			return
 		}
 
-		_, err = ch.handler.bot.Send(m.Chat, calendarMessages.GetCreateCanceledText(), &tb.SendOptions{
+		_, err = ch.handler.bot.Send(m.Chat, calendarMessages.GetCreateCanceledText(lang), &tb.SendOptions{
 			ParseMode: tb.ModeHTML,
 			ReplyMarkup: &tb.ReplyMarkup{
 				ReplyKeyboardRemove: true,
@@ -2094,72 +2262,45 @@ func (ch *CalendarHandlers) handleCreateText(m *tb.Message, session *types.BotRe
 	}
 
 Step:
-	switch session.Step {
-	case telegram.StepCreateFrom:
+	switch wizard.Current(session) {
+	case wizard.CreateFrom:
 		parsedDate := ch.ParseDate(m)
 		if parsedDate == nil {
 			return
 		}
 
 		if parsedDate.Date.IsZero() {
-			_, err := ch.handler.bot.Send(m.Chat, calendarMessages.GetDateNotParsed())
+			_, err := ch.handler.bot.Send(m.Chat, calendarMessages.GetDateNotParsed(lang))
 			if err != nil {
 				customerrors.HandlerError(err, &m.Chat.ID, &m.ID)
 			}
 			return
 		}
 
-		if session.Event.To.IsZero() {
-			session.Step = telegram.StepCreateTo
-		} else {
+		switch {
+		case !session.Event.To.IsZero():
 			session.Event.To = parsedDate.Date.Add(session.Event.To.Sub(session.Event.From))
+		case session.TemplateDuration != 0:
+			session.Event.To = parsedDate.Date.Add(session.TemplateDuration)
+		default:
+			ch.fire(session, wizard.EditTo, m.Chat)
 		}
 		session.Event.From = parsedDate.Date
 		break Step
-	case telegram.StepCreateTo:
+	case wizard.CreateTo:
 		session.Event.FullDay = false
-		switch m.Text {
-		case calendarMessages.GetCreateEventHalfHour():
-			session.Event.To = session.Event.From.Add(30 * time.Minute)
-			if session.Event.Title == "" {
-				session.Step = telegram.StepCreateTitle
-			}
-			break Step
-		case calendarMessages.GetCreateEventHour():
-			session.Event.To = session.Event.From.Add(1 * time.Hour)
-			if session.Event.Title == "" {
-				session.Step = telegram.StepCreateTitle
-			}
-			break Step
-		case calendarMessages.GetCreateEventHourAndHalf():
-			session.Event.To = session.Event.From.Add(1 * time.Hour).Add(30 * time.Minute)
-			if session.Event.Title == "" {
-				session.Step = telegram.StepCreateTitle
-			}
-			break Step
-		case calendarMessages.GetCreateEventTwoHours():
-			session.Event.To = session.Event.From.Add(2 * time.Hour)
-			if session.Event.Title == "" {
-				session.Step = telegram.StepCreateTitle
-			}
-			break Step
-		case calendarMessages.GetCreateEventFourHours():
-			session.Event.To = session.Event.From.Add(4 * time.Hour)
+		if d, ok := calendarMessages.ParseCreateDuration(lang, m.Text, ch.userSettings(m.Sender).EventDuration); ok {
+			session.Event.To = session.Event.From.Add(d)
 			if session.Event.Title == "" {
-				session.Step = telegram.StepCreateTitle
+				ch.fire(session, wizard.EditTitle, m.Chat)
 			}
 			break Step
-		case calendarMessages.GetCreateEventSixHours():
-			session.Event.To = session.Event.From.Add(6 * time.Hour)
-			if session.Event.Title == "" {
-				session.Step = telegram.StepCreateTitle
-			}
-			break Step
-		case calendarMessages.GetCreateFullDay():
+		}
+		if m.Text == calendarMessages.GetCreateFullDay(lang) {
 			session.Event.FullDay = true
 			session.Event.To = session.Event.From.Add(24 * time.Hour)
 			if session.Event.Title == "" {
-				session.Step = telegram.StepCreateTitle
+				ch.fire(session, wizard.EditTitle, m.Chat)
 			}
 			break Step
 		}
@@ -2170,7 +2311,7 @@ Step:
 		}
 
 		if parsedDate.Date.IsZero() {
-			_, err := ch.handler.bot.Send(m.Chat, calendarMessages.GetDateNotParsed())
+			_, err := ch.handler.bot.Send(m.Chat, calendarMessages.GetDateNotParsed(lang))
 			if err != nil {
 				customerrors.HandlerError(err, &m.Chat.ID, &m.ID)
 			}
@@ -2178,7 +2319,7 @@ Step:
 		}
 
 		if parsedDate.Date.Before(session.Event.From) {
-			_, err := ch.handler.bot.Send(m.Chat, calendarMessages.EventDateToIsBeforeFrom, &tb.SendOptions{
+			_, err := ch.handler.bot.Send(m.Chat, lang.T(calendarMessages.EventDateToIsBeforeFrom), &tb.SendOptions{
 				ParseMode: tb.ModeHTML,
 			})
 			if err != nil {
@@ -2188,28 +2329,29 @@ Step:
 		}
 
 		if session.Event.Title == "" {
-			session.Step = telegram.StepCreateTitle
+			ch.fire(session, wizard.EditTitle, m.Chat)
 		}
 
 		session.Event.To = parsedDate.Date
 		break Step
-	case telegram.StepCreateTitle:
+	case wizard.CreateTitle:
 		session.Event.Title = m.Text
 		break Step
-	case telegram.StepCreateDesc:
+	case wizard.CreateDesc:
 		session.Event.Description = m.Text
 		break Step
-	case telegram.StepCreateUser:
+	case wizard.CreateUser:
 		attendeesEmails := strings.Split(m.Text, ",")
 		for _, email := range attendeesEmails {
 			session.Event.Attendees = append(session.Event.Attendees, types.AttendeeEvent{
 				Email:  strings.Trim(email, " "),
-				Role:   telegram.RoleRequired,
-				Status: telegram.StatusNeedsAction,
+				Role:   types.RoleRequired,
+				Status: types.StatusNeedsAction,
 			})
 		}
-	case telegram.StepCreateLocation:
+	case wizard.CreateLocation:
 		session.Event.Location.Description = m.Text
+		session.Event.Location.Geo = sharedGeo(m)
 		break Step
 	}
 
@@ -2227,12 +2369,12 @@ Step:
 	}
 
 	newMsg, err := ch.handler.bot.Send(m.Chat,
-		calendarMessages.GetCreateEventHeader()+calendarMessages.SingleEventFullText(&session.Event),
+		calendarMessages.GetCreateEventHeader(lang)+calendarMessages.SingleEventFullText(lang, &session.Event),
 		&tb.SendOptions{
 			ParseMode: tb.ModeHTML,
 			ReplyTo:   m,
 			ReplyMarkup: &tb.ReplyMarkup{
-				InlineKeyboard: calendarInlineKeyboards.CreateEventButtons(session.Event),
+				InlineKeyboard: ch.keyboards.CreateEventButtons(lang, session.Event),
 			},
 		})
 
@@ -2244,26 +2386,28 @@ Step:
 
 	err = ch.setSession(session, m.Sender, m.Chat)
 	if err != nil {
+		ch.sessionError(err, m.Chat, &m.ID)
 		return
 	}
 
 	if session.Event.To.IsZero() {
 
+		defaultDuration := ch.userSettings(m.Sender).EventDuration
 		replyMarkup := tb.ReplyMarkup{}
 		var replyTo *tb.Message = nil
 		if m.Chat.Type != tb.ChatPrivate {
 			replyMarkup = tb.ReplyMarkup{
-				InlineKeyboard: calendarInlineKeyboards.GetCreateDuration(),
+				InlineKeyboard: ch.keyboards.GetCreateDuration(lang, defaultDuration),
 			}
 			replyTo = m
 		} else {
 			replyMarkup = tb.ReplyMarkup{
-				ReplyKeyboard:       calendarKeyboards.GetCreateDuration(),
+				ReplyKeyboard:       calendarKeyboards.GetCreateDuration(lang, defaultDuration),
 				ResizeReplyKeyboard: true,
 			}
 		}
 
-		msg, err := ch.handler.bot.Send(m.Chat, calendarMessages.GetCreateEventToText(), &tb.SendOptions{
+		msg, err := ch.handler.bot.Send(m.Chat, calendarMessages.GetCreateEventToText(lang), &tb.SendOptions{
 			ParseMode:   tb.ModeHTML,
 			ReplyMarkup: &replyMarkup,
 			ReplyTo:     replyTo,
@@ -2277,6 +2421,7 @@ Step:
 			session.InlineMsg = utils.InitCustomEditable(msg.MessageSig())
 			err = ch.setSession(session, m.Sender, m.Chat)
 			if err != nil {
+				ch.sessionError(err, m.Chat, &m.ID)
 				return
 			}
 		}
@@ -2284,14 +2429,14 @@ Step:
 		return
 	}
 
-	if e.Title == "" && e.Description == "" && e.Location.Description == "" && len(e.Attendees) < 2 && session.Step == telegram.StepCreateTitle {
+	if e.Title == "" && e.Description == "" && e.Location.Description == "" && len(e.Attendees) < 2 && wizard.Is(session, wizard.CreateTitle) {
 		session.FromTextCreate = false
 
 		if m.Chat.Type == tb.ChatPrivate {
-			_, err := ch.handler.bot.Send(m.Chat, calendarMessages.GetCreateEventTitle(), &tb.SendOptions{
+			_, err := ch.handler.bot.Send(m.Chat, calendarMessages.GetCreateEventTitle(lang), &tb.SendOptions{
 				ParseMode: tb.ModeHTML,
 				ReplyMarkup: &tb.ReplyMarkup{
-					ReplyKeyboard:   calendarKeyboards.GetCreateOptionButtons(session),
+					ReplyKeyboard:   calendarKeyboards.GetCreateOptionButtons(lang, session),
 					OneTimeKeyboard: true,
 				},
 			})
@@ -2304,6 +2449,7 @@ Step:
 
 		err = ch.setSession(session, m.Sender, m.Chat)
 		if err != nil {
+			ch.sessionError(err, m.Chat, &m.ID)
 			return
 		}
 
@@ -2312,21 +2458,21 @@ Step:
 
 	if e.Title != "" && !e.To.IsZero() && !e.From.IsZero() && session.FromTextCreate {
 		session.FromTextCreate = false
-		session.Step = telegram.StepCreateDesc
+		ch.fire(session, wizard.EditDesc, m.Chat)
 		replyMarkup := tb.ReplyMarkup{}
 		var replyTo *tb.Message = nil
 		if m.Chat.Type != tb.ChatPrivate {
 			replyMarkup = tb.ReplyMarkup{
-				InlineKeyboard: calendarInlineKeyboards.GetCreateOptionButtons(session),
+				InlineKeyboard: ch.keyboards.GetCreateOptionButtons(lang, session),
 			}
 			replyTo = m
 		} else {
 			replyMarkup = tb.ReplyMarkup{
-				ReplyKeyboard:   calendarKeyboards.GetCreateOptionButtons(session),
+				ReplyKeyboard:   calendarKeyboards.GetCreateOptionButtons(lang, session),
 				OneTimeKeyboard: true,
 			}
 		}
-		msg, err := ch.handler.bot.Send(m.Chat, calendarMessages.CreateEventDescText, &tb.SendOptions{
+		msg, err := ch.handler.bot.Send(m.Chat, lang.T(calendarMessages.CreateEventDescText), &tb.SendOptions{
 			ParseMode:   tb.ModeHTML,
 			ReplyMarkup: &replyMarkup,
 			ReplyTo:     replyTo,
@@ -2342,6 +2488,7 @@ Step:
 
 		err = ch.setSession(session, m.Sender, m.Chat)
 		if err != nil {
+			ch.sessionError(err, m.Chat, &m.ID)
 			return
 		}
 
@@ -2350,7 +2497,8 @@ Step:
 
 }
 func (ch *CalendarHandlers) handleFindTimeText(m *tb.Message, session *types.BotRedisSession) {
-	if calendarMessages.GetCreateCancelText() == m.Text {
+	lang := ch.lang(m.Sender)
+	if calendarMessages.GetCreateCancelText(lang) == m.Text {
 		if session.InfoMsg.ChatID != 0 {
 			err := ch.handler.bot.Delete(&session.InfoMsg)
 			if err != nil {
@@ -2365,17 +2513,18 @@ func (ch *CalendarHandlers) handleFindTimeText(m *tb.Message, session *types.Bot
 			}
 		}
 
-		session = &types.BotRedisSession{}
+		session = sessions.Reset(session)
 
 		session.InfoMsg = utils.InitCustomEditable("", 0)
 		session.InlineMsg = utils.InitCustomEditable("", 0)
 
 		err := ch.setSession(session, m.Sender, m.Chat)
 		if err != nil {
+			ch.sessionError(err, m.Chat, &m.ID)
 			return
 		}
 
-		_, err = ch.handler.bot.Send(m.Chat, calendarMessages.GetCreateCanceledText(), &tb.SendOptions{
+		_, err = ch.handler.bot.Send(m.Chat, calendarMessages.GetCreateCanceledText(lang), &tb.SendOptions{
 			ParseMode: tb.ModeHTML,
 			ReplyMarkup: &tb.ReplyMarkup{
 				ReplyKeyboardRemove: true,
@@ -2399,7 +2548,7 @@ func (ch *CalendarHandlers) handleFindTimeText(m *tb.Message, session *types.Bot
 	}
 
 	if resp.Date.IsZero() {
-		_, err := ch.handler.bot.Send(m.Chat, calendarMessages.GetDateNotParsed())
+		_, err := ch.handler.bot.Send(m.Chat, calendarMessages.GetDateNotParsed(lang))
 		if err != nil {
 			customerrors.HandlerError(err, &m.Chat.ID, &m.ID)
 		}
@@ -2412,16 +2561,15 @@ func (ch *CalendarHandlers) handleFindTimeText(m *tb.Message, session *types.Bot
 
 		err := ch.setSession(session, m.Sender, m.Chat)
 		if err != nil {
-			ch.handler.SendError(m.Chat, err)
-			customerrors.HandlerError(err, &m.Chat.ID, &m.ID)
+			ch.sessionError(err, m.Chat, &m.ID)
 			return
 		}
 
-		_, err = ch.handler.bot.Send(m.Chat, calendarMessages.GetFindTimeStopText(session.FreeBusy.From),
+		_, err = ch.handler.bot.Send(m.Chat, calendarMessages.GetFindTimeStopText(lang, session.FreeBusy.From),
 			&tb.SendOptions{
 				ParseMode: tb.ModeHTML,
 				ReplyMarkup: &tb.ReplyMarkup{
-					InlineKeyboard: calendarInlineKeyboards.GetDateFastCommand(true),
+					InlineKeyboard: ch.keyboards.GetDateFastCommand(lang, true, ch.userNow(int64(m.Sender.ID))),
 				},
 				ReplyTo: m,
 			})
@@ -2433,7 +2581,7 @@ func (ch *CalendarHandlers) handleFindTimeText(m *tb.Message, session *types.Bot
 
 	if session.FreeBusy.To.IsZero() {
 		if resp.Date.Before(session.FreeBusy.From) {
-			_, err := ch.handler.bot.Send(m.Chat, calendarMessages.EventDateToIsBeforeFrom, &tb.SendOptions{
+			_, err := ch.handler.bot.Send(m.Chat, lang.T(calendarMessages.EventDateToIsBeforeFrom), &tb.SendOptions{
 				ParseMode: tb.ModeHTML,
 			})
 			if err != nil {
@@ -2444,7 +2592,7 @@ func (ch *CalendarHandlers) handleFindTimeText(m *tb.Message, session *types.Bot
 			if t.Sub(session.FreeBusy.From).Hours() > 346 {
 				t = session.FreeBusy.From
 				session.FreeBusy.To = time.Date(t.Year(), t.Month(), t.Day()+14, 23, 59, 59, 0, t.Location())
-				_, err := ch.handler.bot.Send(m.Chat, calendarMessages.FindTimePeriodIsTooLong)
+				_, err := ch.handler.bot.Send(m.Chat, lang.T(calendarMessages.FindTimePeriodIsTooLong))
 				if err != nil {
 					customerrors.HandlerError(err, &m.Chat.ID, &m.ID)
 				}
@@ -2454,12 +2602,11 @@ func (ch *CalendarHandlers) handleFindTimeText(m *tb.Message, session *types.Bot
 
 			err := ch.setSession(session, m.Sender, m.Chat)
 			if err != nil {
-				ch.handler.SendError(m.Chat, err)
-				customerrors.HandlerError(err, &m.Chat.ID, &m.ID)
+				ch.sessionError(err, m.Chat, &m.ID)
 				return
 			}
 
-			msg, err := ch.handler.bot.Send(m.Chat, calendarMessages.GetFindTimeInfoText(session.FreeBusy.From,
+			msg, err := ch.handler.bot.Send(m.Chat, calendarMessages.GetFindTimeInfoText(lang, session.FreeBusy.From,
 				session.FreeBusy.To),
 				&tb.SendOptions{
 					ParseMode: tb.ModeHTML,
@@ -2473,16 +2620,15 @@ func (ch *CalendarHandlers) handleFindTimeText(m *tb.Message, session *types.Bot
 				session.FindTimeInfoMsg = utils.InitCustomEditable(msg.MessageSig())
 				err = ch.setSession(session, m.Sender, m.Chat)
 				if err != nil {
-					ch.handler.SendError(m.Chat, err)
-					customerrors.HandlerError(err, &m.Chat.ID, &m.ID)
+					ch.sessionError(err, m.Chat, &m.ID)
 				}
 			}
 
-			_, err = ch.handler.bot.Send(m.Chat, calendarMessages.FindTimeChooseDayPartHeader,
+			_, err = ch.handler.bot.Send(m.Chat, lang.T(calendarMessages.FindTimeChooseDayPartHeader),
 				&tb.SendOptions{
 					ParseMode: tb.ModeHTML,
 					ReplyMarkup: &tb.ReplyMarkup{
-						InlineKeyboard: calendarInlineKeyboards.FindTimeDayPartButtons(session.FreeBusy.From),
+						InlineKeyboard: ch.keyboards.FindTimeDayPartButtons(lang, session.FreeBusy.From),
 					},
 					ReplyTo: m,
 				})
@@ -2494,14 +2640,16 @@ func (ch *CalendarHandlers) handleFindTimeText(m *tb.Message, session *types.Bot
 
 }
 func (ch *CalendarHandlers) handleDateText(m *tb.Message, session *types.BotRedisSession) {
-	if calendarMessages.GetCancelDateReplyButton() == m.Text {
-		session = &types.BotRedisSession{}
+	lang := ch.lang(m.Sender)
+	if calendarMessages.GetCancelDateReplyButton(lang) == m.Text {
+		session = sessions.Reset(session)
 		err := ch.setSession(session, m.Sender, m.Chat)
 		if err != nil {
+			ch.sessionError(err, m.Chat, &m.ID)
 			return
 		}
 
-		_, err = ch.handler.bot.Send(m.Chat, calendarMessages.GetCancelDate(), &tb.SendOptions{
+		_, err = ch.handler.bot.Send(m.Chat, calendarMessages.GetCancelDate(lang), &tb.SendOptions{
 			ParseMode: tb.ModeHTML,
 			ReplyMarkup: &tb.ReplyMarkup{
 				ReplyKeyboardRemove: true,
@@ -2521,9 +2669,10 @@ func (ch *CalendarHandlers) handleDateText(m *tb.Message, session *types.BotRedi
 	}
 
 	if !parseDate.Date.IsZero() {
-		session.IsDate = false
+		ch.fire(session, wizard.DateShown, m.Chat)
 		err := ch.setSession(session, m.Sender, m.Chat)
 		if err != nil {
+			ch.sessionError(err, m.Chat, &m.ID)
 			return
 		}
 
@@ -2533,7 +2682,9 @@ func (ch *CalendarHandlers) handleDateText(m *tb.Message, session *types.BotRedi
 			ch.handler.SendError(m.Chat, err)
 			return
 		}
-		events, err := ch.eventUseCase.GetEventsByDate(token, parseDate.Date)
+		// the parsed date is shown as the day of the user
+		date := parseDate.Date.In(ch.userLocation(int64(m.Sender.ID)))
+		events, err := ch.eventUseCase.GetEventsByDate(token, date)
 		if err != nil {
 			customerrors.HandlerError(err, &m.Chat.ID, &m.ID)
 			ch.handler.SendError(m.Chat, err)
@@ -2543,7 +2694,7 @@ func (ch *CalendarHandlers) handleDateText(m *tb.Message, session *types.BotRedi
 		if events != nil {
 			i := 0
 			for _, event := range events.Data.Events {
-				if !event.FullDay || event.From.Day() != time.Now().Day()-1 || event.To.Day() != time.Now().Day() {
+				if !event.FullDay || event.From.Day() != date.Day()-1 || event.To.Day() != date.Day() {
 					events.Data.Events[i] = event
 					i++
 				}
@@ -2553,7 +2704,7 @@ func (ch *CalendarHandlers) handleDateText(m *tb.Message, session *types.BotRedi
 		}
 
 		if events != nil && len(events.Data.Events) > 0 {
-			title := calendarMessages.GetDateTitle(parseDate.Date)
+			title := calendarMessages.GetDateTitle(lang, parseDate.Date)
 			if m.Chat.Type != tb.ChatPrivate {
 				title += calendarMessages.AddNameBold(m.Sender.FirstName + " " + m.Sender.LastName)
 			}
@@ -2567,9 +2718,9 @@ func (ch *CalendarHandlers) handleDateText(m *tb.Message, session *types.BotRedi
 			if err != nil {
 				customerrors.HandlerError(err, &m.Chat.ID, &m.ID)
 			}
-			ch.sendShortEvents(&events.Data.Events, m.Chat)
+			ch.sendShortEvents(lang, &events.Data.Events, m.Chat)
 		} else {
-			title := calendarMessages.GetDateEventsNotFound()
+			title := calendarMessages.GetDateEventsNotFound(lang)
 			if m.Chat.Type != tb.ChatPrivate {
 				title += calendarMessages.AddName(m.Sender.FirstName + " " + m.Sender.LastName)
 			}
@@ -2586,7 +2737,7 @@ func (ch *CalendarHandlers) handleDateText(m *tb.Message, session *types.BotRedi
 		}
 
 	} else {
-		_, err := ch.handler.bot.Send(m.Chat, calendarMessages.GetDateNotParsed(), &tb.SendOptions{
+		_, err := ch.handler.bot.Send(m.Chat, calendarMessages.GetDateNotParsed(lang), &tb.SendOptions{
 			ParseMode: tb.ModeHTML,
 		})
 		if err != nil {
@@ -2595,6 +2746,10 @@ func (ch *CalendarHandlers) handleDateText(m *tb.Message, session *types.BotRedi
 	}
 }
 func (ch *CalendarHandlers) handleGroup(c *tb.Callback, status string) {
+	lang := ch.lang(c.Sender)
+	editable := callbackEditable(c)
+	c.Message = callbackMessage(c)
+
 	if !ch.AuthMiddleware(c.Sender, c.Message.Chat) {
 		err := ch.handler.bot.Respond(c, &tb.CallbackResponse{
 			CallbackID: c.ID,
@@ -2640,7 +2795,7 @@ func (ch *CalendarHandlers) handleGroup(c *tb.Callback, status string) {
 	if event.Organizer.Email == userInfo.Email {
 		err = ch.handler.bot.Respond(c, &tb.CallbackResponse{
 			CallbackID: c.ID,
-			Text:       calendarMessages.CreateEventAlreadyOrganize,
+			Text:       lang.T(calendarMessages.CreateEventAlreadyOrganize),
 		})
 		if err != nil {
 			customerrors.HandlerError(err, &c.Message.Chat.ID, &c.Message.ID)
@@ -2652,10 +2807,10 @@ func (ch *CalendarHandlers) handleGroup(c *tb.Callback, status string) {
 		if attendee.Email == userInfo.Email {
 			if attendee.Status == status {
 				text := ""
-				if status == telegram.StatusAccepted {
-					text = calendarMessages.CreateEventAlreadyGo
+				if status == types.StatusAccepted {
+					text = lang.T(calendarMessages.CreateEventAlreadyGo)
 				} else {
-					text = calendarMessages.CreateEventAlreadyNotGo
+					text = lang.T(calendarMessages.CreateEventAlreadyNotGo)
 				}
 				err = ch.handler.bot.Respond(c, &tb.CallbackResponse{
 					CallbackID: c.ID,
@@ -2665,12 +2820,12 @@ func (ch *CalendarHandlers) handleGroup(c *tb.Callback, status string) {
 					customerrors.HandlerError(err, &c.Message.Chat.ID, &c.Message.ID)
 				}
 			} else {
-				if attendee.Status == telegram.StatusDeclined {
+				if attendee.Status == types.StatusDeclined {
 					_, err = ch.eventUseCase.AddAttendee(eventToken, types.AddAttendee{
 						EventID:    event.Uid,
 						CalendarID: event.Calendar.UID,
 						Email:      userInfo.Email,
-						Role:       telegram.RoleRequired,
+						Role:       types.RoleRequired,
 					})
 
 					if err != nil {
@@ -2700,7 +2855,7 @@ func (ch *CalendarHandlers) handleGroup(c *tb.Callback, status string) {
 
 				event.Attendees[idx].Status = status
 
-				inlineKeyboard, err := calendarInlineKeyboards.GroupChatButtons(event, ch.redisDB, userId)
+				inlineKeyboard, err := ch.keyboards.GroupChatButtons(lang, event, &ch.callbackStore, userId)
 
 				if err != nil {
 					ch.handler.SendError(c.Message.Chat, err)
@@ -2708,17 +2863,20 @@ func (ch *CalendarHandlers) handleGroup(c *tb.Callback, status string) {
 					return
 				}
 
-				_, err = ch.handler.bot.Edit(c.Message, calendarMessages.SingleEventFullText(event), &tb.SendOptions{
+				_, err = ch.handler.bot.Edit(editable, calendarMessages.SingleEventFullText(lang, event), &tb.SendOptions{
 					ParseMode: tb.ModeHTML,
 					ReplyMarkup: &tb.ReplyMarkup{
-						InlineKeyboard: inlineKeyboard,
+						InlineKeyboard: calendarInlineKeyboards.WithCallButton(lang, event, inlineKeyboard),
 					},
 				})
 
 				if err != nil {
 					customerrors.HandlerError(err, &c.Message.Chat.ID, &c.Message.ID)
+					return
 				}
 
+				ch.trackPostedMessage(editable, event, userId, callbackPostedType(c))
+				ch.syncPostedEvent(event)
 				return
 			}
 
@@ -2730,7 +2888,7 @@ func (ch *CalendarHandlers) handleGroup(c *tb.Callback, status string) {
 		EventID:    event.Uid,
 		CalendarID: event.Calendar.UID,
 		Email:      userInfo.Email,
-		Role:       telegram.RoleRequired,
+		Role:       types.RoleRequired,
 	})
 
 	if err != nil {
@@ -2753,11 +2911,11 @@ func (ch *CalendarHandlers) handleGroup(c *tb.Callback, status string) {
 	event.Attendees = append(event.Attendees, types.AttendeeEvent{
 		Email:  userInfo.Email,
 		Name:   userInfo.Name,
-		Role:   telegram.RoleRequired,
+		Role:   types.RoleRequired,
 		Status: status,
 	})
 
-	inlineKeyboard, err := calendarInlineKeyboards.GroupChatButtons(event, ch.redisDB, userId)
+	inlineKeyboard, err := ch.keyboards.GroupChatButtons(lang, event, &ch.callbackStore, userId)
 
 	if err != nil {
 		ch.handler.SendError(c.Message.Chat, err)
@@ -2765,21 +2923,27 @@ func (ch *CalendarHandlers) handleGroup(c *tb.Callback, status string) {
 		return
 	}
 
-	_, err = ch.handler.bot.Edit(c.Message, calendarMessages.SingleEventFullText(event), &tb.SendOptions{
+	_, err = ch.handler.bot.Edit(editable, calendarMessages.SingleEventFullText(lang, event), &tb.SendOptions{
 		ParseMode: tb.ModeHTML,
 		ReplyMarkup: &tb.ReplyMarkup{
-			InlineKeyboard: inlineKeyboard,
+			InlineKeyboard: calendarInlineKeyboards.WithCallButton(lang, event, inlineKeyboard),
 		},
 	})
 
 	if err != nil {
 		customerrors.HandlerError(err, &c.Message.Chat.ID, &c.Message.ID)
+		return
 	}
+
+	ch.trackPostedMessage(editable, event, userId, callbackPostedType(c))
+	ch.syncPostedEvent(event)
 }
 func (ch *CalendarHandlers) ParseDate(m *tb.Message) *types.ParseDateResp {
+	// Кнопки на других языках парсер не понимает, переводим их на русский
+	m.Text = calendarMessages.ParserText(m.Text, ch.userNow(int64(m.Sender.ID)))
 	// Удаляет текст Сегодня, Завтра из даты
 	m.Text = strings.Split(m.Text, ",")[0]
-	reqData := &types.ParseDateReq{Timezone: "Europe/Moscow", Text: m.Text}
+	reqData := &types.ParseDateReq{Timezone: ch.userSettings(m.Sender).Timezone, Text: m.Text}
 	b, err := json.Marshal(reqData)
 	if err != nil {
 		customerrors.HandlerError(err, &m.Chat.ID, &m.ID)
@@ -2827,7 +2991,7 @@ func (ch *CalendarHandlers) ParseDate(m *tb.Message) *types.ParseDateResp {
 	return parseDate
 }
 func (ch *CalendarHandlers) ParseEvent(m *tb.Message) *types.ParseEventResp {
-	reqData := &types.ParseDateReq{Timezone: "Europe/Moscow", Text: m.Text}
+	reqData := &types.ParseDateReq{Timezone: ch.userSettings(m.Sender).Timezone, Text: m.Text}
 	b, err := json.Marshal(reqData)
 	if err != nil {
 		customerrors.HandlerError(err, &m.Chat.ID, &m.ID)
@@ -2876,6 +3040,7 @@ func (ch *CalendarHandlers) ParseEvent(m *tb.Message) *types.ParseEventResp {
 	return parseDate
 }
 func (ch *CalendarHandlers) sendOrUpdateVote(session *types.BotRedisSession, c *tb.Chat, userAdd *tb.User, userInit *tb.User, msgToReply *tb.Message, sendPoll bool) {
+	lang := ch.lang(userInit)
 
 	if !sendPoll {
 
@@ -2891,11 +3056,11 @@ func (ch *CalendarHandlers) sendOrUpdateVote(session *types.BotRedisSession, c *
 		session.FreeBusy.Users = emails
 
 		if session.PollMsg.ChatID == 0 {
-			msg, err := ch.handler.bot.Send(c, calendarMessages.GenFindTimePollHeader(emails), &tb.SendOptions{
+			msg, err := ch.handler.bot.Send(c, calendarMessages.GenFindTimePollHeader(lang, emails), &tb.SendOptions{
 				ParseMode: tb.ModeHTML,
 				ReplyTo:   msgToReply,
 				ReplyMarkup: &tb.ReplyMarkup{
-					InlineKeyboard: calendarInlineKeyboards.FindTimeAddUser(userInit.ID),
+					InlineKeyboard: ch.keyboards.FindTimeAddUser(lang, userInit.ID),
 				},
 			})
 
@@ -2909,18 +3074,17 @@ func (ch *CalendarHandlers) sendOrUpdateVote(session *types.BotRedisSession, c *
 
 			err = ch.setSession(session, userInit, c)
 			if err != nil {
-				ch.handler.SendError(c, err)
-				customerrors.HandlerError(err, &c.ID, &msgToReply.ID)
+				ch.sessionError(err, c, &msgToReply.ID)
 			}
 
 			return
 		}
 
-		_, err = ch.handler.bot.Edit(&session.PollMsg, calendarMessages.GenFindTimePollHeader(emails), &tb.SendOptions{
+		_, err = ch.handler.bot.Edit(&session.PollMsg, calendarMessages.GenFindTimePollHeader(lang, emails), &tb.SendOptions{
 			ParseMode: tb.ModeHTML,
 			ReplyTo:   msgToReply,
 			ReplyMarkup: &tb.ReplyMarkup{
-				InlineKeyboard: calendarInlineKeyboards.FindTimeAddUser(userInit.ID),
+				InlineKeyboard: ch.keyboards.FindTimeAddUser(lang, userInit.ID),
 			},
 		})
 
@@ -2931,8 +3095,7 @@ func (ch *CalendarHandlers) sendOrUpdateVote(session *types.BotRedisSession, c *
 
 		err = ch.setSession(session, userInit, c)
 		if err != nil {
-			ch.handler.SendError(c, err)
-			customerrors.HandlerError(err, &c.ID, &msgToReply.ID)
+			ch.sessionError(err, c, &msgToReply.ID)
 		}
 
 		return
@@ -2951,6 +3114,7 @@ func (ch *CalendarHandlers) sendOrUpdateVote(session *types.BotRedisSession, c *
 		DayPart:                session.FindTimeDayPart,
 		StretchBusyIntervalsBy: &stretchBusyIntervalsBy,
 		SplitFreeIntervalsBy:   &session.FindTimeDuration,
+		HolidaysAsBusy:         true,
 	})
 
 	if err != nil {
@@ -2960,7 +3124,7 @@ func (ch *CalendarHandlers) sendOrUpdateVote(session *types.BotRedisSession, c *
 	}
 
 	if len(spans) < 1 {
-		_, err = ch.handler.bot.Send(c, calendarMessages.FindTimeNotFound)
+		_, err = ch.handler.bot.Send(c, lang.T(calendarMessages.FindTimeNotFound))
 		if err != nil {
 			customerrors.HandlerError(err, &c.ID, &msgToReply.ID)
 		}
@@ -2974,6 +3138,10 @@ func (ch *CalendarHandlers) sendOrUpdateVote(session *types.BotRedisSession, c *
 		}
 	}
 
+	if ch.isImageMode(userInit.ID) {
+		ch.sendFreeBusyHeatmap(lang, token, session, c)
+	}
+
 	emails, err := ch.userUseCase.TryGetUsersEmailsByTelegramUserIDs(session.Users)
 	if err != nil {
 		ch.handler.SendError(c, err)
@@ -2983,7 +3151,7 @@ func (ch *CalendarHandlers) sendOrUpdateVote(session *types.BotRedisSession, c *
 
 	poll := tb.Poll{
 		Type:            tb.PollRegular,
-		Question:        calendarMessages.GenFindTimePollHeader(emails),
+		Question:        calendarMessages.GenFindTimePollHeader(lang, emails),
 		MultipleAnswers: true,
 		ParseMode:       tb.ModeHTML,
 	}
@@ -2993,7 +3161,7 @@ func (ch *CalendarHandlers) sendOrUpdateVote(session *types.BotRedisSession, c *
 	pollMsg, err := poll.Send(ch.handler.bot, c, &tb.SendOptions{
 		ParseMode: tb.ModeHTML,
 		ReplyMarkup: &tb.ReplyMarkup{
-			InlineKeyboard: calendarInlineKeyboards.FindTimePollButtons(),
+			InlineKeyboard: ch.keyboards.FindTimePollButtons(lang),
 		},
 		ReplyTo: msgToReply,
 	})
@@ -3006,8 +3174,7 @@ func (ch *CalendarHandlers) sendOrUpdateVote(session *types.BotRedisSession, c *
 
 	err = ch.setSession(session, userInit, c)
 	if err != nil {
-		ch.handler.SendError(c, err)
-		customerrors.HandlerError(err, &c.ID, &msgToReply.ID)
+		ch.sessionError(err, c, &msgToReply.ID)
 	}
 }
 
@@ -3023,7 +3190,7 @@ func (ch *CalendarHandlers) AuthMiddleware(u *tb.User, c *tb.Chat) bool {
 		if c.Type != tb.ChatPrivate {
 			msg += calendarMessages.AddNameStartBold(u.FirstName + " " + u.LastName)
 		}
-		msg += calendarMessages.GetUserNotAuth()
+		msg += calendarMessages.GetUserNotAuth(ch.lang(u))
 		_, err = ch.handler.bot.Send(c, msg, &tb.SendOptions{
 			ParseMode: tb.ModeHTML,
 			ReplyMarkup: &tb.ReplyMarkup{
@@ -3033,20 +3200,23 @@ func (ch *CalendarHandlers) AuthMiddleware(u *tb.User, c *tb.Chat) bool {
 		if err != nil {
 			customerrors.HandlerError(err, &c.ID, nil)
 		}
+		return false
 	}
 
-	return isAuth
+	ch.rememberUserGroup(u, c)
+	return true
 }
 func (ch *CalendarHandlers) GroupMiddleware(m *tb.Message) bool {
-	if strings.Contains(m.Text, calendarMessages.GetMessageAlertBase()) {
+	lang := ch.lang(m.Sender)
+	if strings.Contains(m.Text, calendarMessages.GetMessageAlertBase(lang)) {
 		return false
 	}
 	if m.Chat.Type != tb.ChatPrivate {
-		_, err := ch.handler.bot.Send(m.Chat, calendarMessages.GetGroupAlertMessage(m.Text), &tb.SendOptions{
+		_, err := ch.handler.bot.Send(m.Chat, calendarMessages.GetGroupAlertMessage(lang, m.Text), &tb.SendOptions{
 			ParseMode: tb.ModeHTML,
 			ReplyTo:   m,
 			ReplyMarkup: &tb.ReplyMarkup{
-				InlineKeyboard: calendarInlineKeyboards.GroupAlertsButtons(m.Text),
+				InlineKeyboard: ch.keyboards.GroupAlertsButtons(lang, m.Text),
 			},
 		})
 		if err != nil {
@@ -3058,11 +3228,12 @@ func (ch *CalendarHandlers) GroupMiddleware(m *tb.Message) bool {
 	return false
 }
 func (ch *CalendarHandlers) ChangeStatusCallback(c *tb.Callback, token string, event *types.Event, status string) error {
+	lang := ch.lang(c.Sender)
 	userInfo, err := ch.userUseCase.GetMailruUserInfo(token)
 	if err != nil {
 		return err
 	}
-	userCalId, err := ch.redisDB.Get(context.TODO(), userInfo.Email+event.Uid).Result()
+	userCalId, err := ch.callbackStore.GetAttendeeCalendar(userInfo.Email, event.Uid)
 	if err != nil {
 		events, err := ch.eventUseCase.GetEventsByDate(token, event.From)
 		if err != nil {
@@ -3083,7 +3254,7 @@ func (ch *CalendarHandlers) ChangeStatusCallback(c *tb.Callback, token string, e
 	if userCalId == "" {
 		err = ch.handler.bot.Respond(c, &tb.CallbackResponse{
 			CallbackID: c.ID,
-			Text:       calendarMessages.CreateEventCannotAdd,
+			Text:       lang.T(calendarMessages.CreateEventCannotAdd),
 			ShowAlert:  true,
 		})
 		if err != nil {
@@ -3093,7 +3264,7 @@ func (ch *CalendarHandlers) ChangeStatusCallback(c *tb.Callback, token string, e
 		return errors.New("Calendar UID not found")
 	}
 
-	err = ch.redisDB.Set(context.TODO(), userInfo.Email+event.Uid, userCalId, 0).Err()
+	err = ch.callbackStore.SetAttendeeCalendar(userInfo.Email, event.Uid, userCalId)
 
 	if err != nil {
 		customerrors.HandlerError(err, &c.Message.Chat.ID, &c.Message.ID)
@@ -3113,11 +3284,10 @@ func (ch *CalendarHandlers) ChangeStatusCallback(c *tb.Callback, token string, e
 	return nil
 }
 
-func EventToEventInput(event types.Event) types.EventInput {
+func EventToEventInput(lang i18n.Lang, event types.Event, location *time.Location) types.EventInput {
 	ret := types.EventInput{}
 
 	id := event.Uid
-	location, _ := time.LoadLocation("Europe/Moscow")
 	from := event.From.In(location).Format(time.RFC3339)
 	to := event.To.In(location).Format(time.RFC3339)
 
@@ -3129,7 +3299,7 @@ func EventToEventInput(event types.Event) types.EventInput {
 	if event.Title != "" {
 		ret.Title = &event.Title
 	} else {
-		title := "Без названия"
+		title := calendarMessages.GetEventNoTitleText(lang)
 		ret.Title = &title
 	}
 
@@ -3140,12 +3310,21 @@ func EventToEventInput(event types.Event) types.EventInput {
 		ret.Description = &desc
 	}
 
-	if event.Location.Description != "" {
+	if event.Location.Description != "" || len(event.Location.Confrooms) > 0 || event.Location.Geo.Latitude != "" {
 		loc := &types.Location{}
 		loc.Description = event.Location.Description
+		loc.Confrooms = event.Location.Confrooms
+		if event.Location.Geo.Latitude != "" {
+			geo := event.Location.Geo
+			loc.Geo = &geo
+		}
 		ret.Location = loc
 	}
 
+	if event.Call != "" {
+		ret.Call = &event.Call
+	}
+
 	if len(event.Attendees) > 0 {
 		attendees := types.Attendees{}
 		for _, attendee := range event.Attendees {
@@ -3165,27 +3344,28 @@ func EventToEventInput(event types.Event) types.EventInput {
 func (ch CalendarHandlers) updateInlineMsg(m *tb.Message, session *types.BotRedisSession) {
 	var replyMarkup *tb.ReplyMarkup = nil
 	var replyTo *tb.Message = nil
-	text := "Выберите шаг:"
+	lang := ch.lang(m.Sender)
+	text := calendarMessages.GetChooseStepText(lang)
 	if m.Chat.Type != tb.ChatPrivate {
 		replyMarkup = &tb.ReplyMarkup{
-			InlineKeyboard: calendarInlineKeyboards.GetCreateOptionButtons(session),
+			InlineKeyboard: ch.keyboards.GetCreateOptionButtons(lang, session),
 		}
 		replyTo = m
 	}
 
-	switch session.Step {
-	case telegram.StepCreateDesc:
-		text = calendarMessages.CreateEventDescText
-	case telegram.StepCreateTitle:
-		text = calendarMessages.GetCreateEventTitle()
-	case telegram.StepCreateTo:
+	switch wizard.Current(session) {
+	case wizard.CreateDesc:
+		text = lang.T(calendarMessages.CreateEventDescText)
+	case wizard.CreateTitle:
+		text = calendarMessages.GetCreateEventTitle(lang)
+	case wizard.CreateTo:
 		return
-	case telegram.StepCreateFrom:
+	case wizard.CreateFrom:
 		return
-	case telegram.StepCreateLocation:
-		text = calendarMessages.CreateEventLocationText
-	case telegram.StepCreateUser:
-		text = calendarMessages.CreateEventUserText
+	case wizard.CreateLocation:
+		text = lang.T(calendarMessages.CreateEventLocationText)
+	case wizard.CreateUser:
+		text = lang.T(calendarMessages.CreateEventUserText)
 	}
 
 	if session.InlineMsg.ChatID != 0 {
@@ -3209,3 +3389,33 @@ func (ch CalendarHandlers) updateInlineMsg(m *tb.Message, session *types.BotRedi
 		session.InlineMsg = utils.InitCustomEditable(msg.MessageSig())
 	}
 }
+
+// handleButton registers the handler of the reply button for its text in every language
+func handleButton(bot *tb.Bot, id i18n.ID, handler func(m *tb.Message)) {
+	for _, text := range i18n.All(id) {
+		bot.Handle(text, handler)
+	}
+}
+
+// handleCallback registers the handler of the inline button with unique, the handler receives verified data only
+func (ch *CalendarHandlers) handleCallback(bot *tb.Bot, unique string, handler func(c *tb.Callback)) {
+	bot.Handle("\f"+unique, func(c *tb.Callback) {
+		data, err := ch.callbackSigner.Verify(unique, c.Data)
+		if err != nil {
+			if errors.Cause(err) != callbacks.KeyDoesNotExist {
+				zap.S().Warnf("rejected callback unique=%s from telegramUserID=%d: %v", unique, c.Sender.ID, err)
+			}
+			err = ch.handler.bot.Respond(c, &tb.CallbackResponse{
+				CallbackID: c.ID,
+				Text:       ch.lang(c.Sender).T(calendarMessages.CallbackExpired),
+				ShowAlert:  true,
+			})
+			if err != nil {
+				customerrors.HandlerError(err, nil, nil)
+			}
+			return
+		}
+		c.Data = data
+		handler(c)
+	})
+}
@@ -0,0 +1,92 @@
+package handlers
+
+import (
+	"github.com/calendar-bot/pkg/bots/telegram/keyboards/calendarKeyboards"
+	"github.com/calendar-bot/pkg/bots/telegram/messages/calendarMessages"
+	"github.com/calendar-bot/pkg/bots/telegram/utils"
+	"github.com/calendar-bot/pkg/bots/telegram/wizard"
+	"github.com/calendar-bot/pkg/customerrors"
+	tb "gopkg.in/tucnak/telebot.v2"
+)
+
+// HandleCallToggle creates a video call room for the created event, or removes the call if it is already added
+func (ch *CalendarHandlers) HandleCallToggle(m *tb.Message) {
+	lang := ch.lang(m.Sender)
+	session, err := ch.getSession(m.Sender, m.Chat)
+	if err != nil {
+		return
+	}
+	if !wizard.IsCreating(session) {
+		ch.HandleText(m)
+		return
+	}
+
+	if session.Event.Call == "" {
+		token, err := ch.userUseCase.GetOrRefreshOAuthAccessTokenByTelegramUserID(int64(m.Sender.ID))
+		if err != nil {
+			customerrors.HandlerError(err, &m.Chat.ID, &m.ID)
+			ch.handler.SendError(m.Chat, err)
+			return
+		}
+
+		link, err := ch.eventUseCase.MailCallLink(token)
+		if err != nil {
+			customerrors.HandlerError(err, &m.Chat.ID, &m.ID)
+			ch.handler.SendError(m.Chat, err)
+			return
+		}
+		session.Event.Call = link
+	} else {
+		session.Event.Call = ""
+	}
+
+	if session.InfoMsg.ChatID != 0 {
+		if err := ch.handler.bot.Delete(&session.InfoMsg); err != nil {
+			customerrors.HandlerError(err, &m.Chat.ID, &m.ID)
+		}
+	}
+
+	newMsg, err := ch.handler.bot.Send(m.Chat,
+		calendarMessages.GetCreateEventHeader(lang)+calendarMessages.SingleEventFullText(lang, &session.Event),
+		&tb.SendOptions{
+			ParseMode: tb.ModeHTML,
+			ReplyTo:   m,
+			ReplyMarkup: &tb.ReplyMarkup{
+				InlineKeyboard: ch.keyboards.CreateEventButtons(lang, session.Event),
+			},
+		})
+	if err != nil {
+		customerrors.HandlerError(err, &m.Chat.ID, &m.ID)
+		return
+	}
+	session.InfoMsg = utils.InitCustomEditable(newMsg.MessageSig())
+
+	replyMarkup := tb.ReplyMarkup{}
+	var replyTo *tb.Message = nil
+	if m.Chat.Type != tb.ChatPrivate {
+		replyMarkup = tb.ReplyMarkup{
+			InlineKeyboard: ch.keyboards.GetCreateOptionButtons(lang, session),
+		}
+		replyTo = m
+	} else {
+		replyMarkup = tb.ReplyMarkup{
+			ReplyKeyboard:   calendarKeyboards.GetCreateOptionButtons(lang, session),
+			OneTimeKeyboard: true,
+		}
+	}
+
+	msg, err := ch.handler.bot.Send(m.Chat, calendarMessages.GetCreateCallText(lang, session.Event.Call), &tb.SendOptions{
+		ParseMode:   tb.ModeHTML,
+		ReplyMarkup: &replyMarkup,
+		ReplyTo:     replyTo,
+	})
+	if err != nil {
+		customerrors.HandlerError(err, &m.Chat.ID, &m.ID)
+	} else if m.Chat.Type != tb.ChatPrivate {
+		session.InlineMsg = utils.InitCustomEditable(msg.MessageSig())
+	}
+
+	if err := ch.setSession(session, m.Sender, m.Chat); err != nil {
+		ch.sessionError(err, m.Chat, &m.ID)
+	}
+}
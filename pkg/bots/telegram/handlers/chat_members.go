@@ -0,0 +1,158 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"github.com/calendar-bot/pkg/bots/telegram/messages"
+	"github.com/calendar-bot/pkg/bots/telegram/messages/calendarMessages"
+	"github.com/calendar-bot/pkg/customerrors"
+	"github.com/calendar-bot/pkg/types"
+	uUseCase "github.com/calendar-bot/pkg/users/usecase"
+	tb "gopkg.in/tucnak/telebot.v2"
+	"strings"
+	"time"
+)
+
+// members of a group seen by the poller are kept in Redis, so only new members and changed names reach Postgres.
+// The TTL only frees the memory of groups which went quiet
+const seenChatMembersTTL = 24 * time.Hour
+
+// TrackChatMembers is the poller filter which records everyone active in groups, it never drops updates
+func (ch *CalendarHandlers) TrackChatMembers(upd *tb.Update) bool {
+	switch {
+	case upd.Message != nil && upd.Message.UserLeft == nil:
+		ch.trackChatMember(upd.Message.Sender, upd.Message.Chat)
+	case upd.Callback != nil && upd.Callback.Message != nil:
+		ch.trackChatMember(upd.Callback.Sender, upd.Callback.Message.Chat)
+	}
+	return true
+}
+
+func (ch *CalendarHandlers) HandleUserJoined(m *tb.Message) {
+	if m.UserJoined == nil {
+		return
+	}
+	ch.touchChatMember(m.UserJoined, m.Chat)
+}
+
+// HandleUserLeft forgets the group of the user who left it, or the whole group if the bot was removed
+func (ch *CalendarHandlers) HandleUserLeft(m *tb.Message) {
+	if m.UserLeft == nil {
+		return
+	}
+	if m.UserLeft.ID == ch.handler.bot.Me.ID {
+		ch.forgetGroup(m.Chat.ID)
+		return
+	}
+	ch.forgetSeenChatMember(m.Chat.ID, int64(m.UserLeft.ID))
+	if err := ch.userUseCase.LeaveChatMember(m.Chat.ID, int64(m.UserLeft.ID)); err != nil {
+		customerrors.HandlerError(err, &m.Chat.ID, &m.ID)
+	}
+	if err := ch.userUseCase.DeleteUserGroup(int64(m.UserLeft.ID), m.Chat.ID); err != nil {
+		customerrors.HandlerError(err, &m.Chat.ID, &m.ID)
+	}
+}
+
+// HandleMembers shows who of the group members has not connected the calendar yet
+func (ch *CalendarHandlers) HandleMembers(m *tb.Message) {
+	lang := ch.lang(m.Sender)
+	if m.Chat.Type != tb.ChatGroup && m.Chat.Type != tb.ChatSuperGroup {
+		_, err := ch.handler.bot.Send(m.Chat, lang.T(messages.ErrorCommandIsOnlyForGroupChat))
+		if err != nil {
+			customerrors.HandlerError(err, &m.Chat.ID, &m.ID)
+		}
+		return
+	}
+
+	members, err := ch.userUseCase.GetChatMembers(m.Chat.ID)
+	if err != nil {
+		customerrors.HandlerError(err, &m.Chat.ID, &m.ID)
+		ch.handler.SendError(m.Chat, err)
+		return
+	}
+
+	authenticated, notAuthenticated := uUseCase.SplitMembersByAuthentication(members)
+	var keyboard [][]tb.InlineButton
+	if len(notAuthenticated) > 0 {
+		keyboard = [][]tb.InlineButton{{{
+			Text: lang.T(calendarMessages.MembersLoginButton),
+			URL:  "https://t.me/" + ch.handler.bot.Me.Username + "?start",
+		}}}
+	}
+
+	_, err = ch.handler.bot.Send(m.Chat, calendarMessages.GetMembersText(lang, authenticated, notAuthenticated),
+		&tb.SendOptions{
+			ParseMode: tb.ModeHTML,
+			ReplyMarkup: &tb.ReplyMarkup{
+				InlineKeyboard: keyboard,
+			},
+		})
+	if err != nil {
+		customerrors.HandlerError(err, &m.Chat.ID, &m.ID)
+	}
+}
+
+// trackChatMember is touchChatMember skipping members already recorded with the same name
+func (ch *CalendarHandlers) trackChatMember(u *tb.User, chat *tb.Chat) {
+	if !isTrackedChatMember(u, chat) {
+		return
+	}
+	key := seenChatMembersKey(chat.ID)
+	field := fmt.Sprint(u.ID)
+	profile := u.FirstName + " " + u.LastName + " @" + u.Username
+	if seen, err := ch.redisDB.HGet(context.TODO(), key, field).Result(); err == nil && seen == profile {
+		return
+	}
+
+	if !ch.touchChatMember(u, chat) {
+		return
+	}
+	pipe := ch.redisDB.TxPipeline()
+	pipe.HSet(context.TODO(), key, field, profile)
+	pipe.Expire(context.TODO(), key, seenChatMembersTTL)
+	if _, err := pipe.Exec(context.TODO()); err != nil {
+		customerrors.HandlerError(err, &chat.ID, nil)
+	}
+}
+
+// touchChatMember reports whether the member is recorded
+func (ch *CalendarHandlers) touchChatMember(u *tb.User, chat *tb.Chat) bool {
+	if !isTrackedChatMember(u, chat) {
+		return false
+	}
+	err := ch.userUseCase.TouchChatMember(types.ChatMember{
+		ChatID:         chat.ID,
+		TelegramUserID: int64(u.ID),
+		Name:           strings.TrimSpace(u.FirstName + " " + u.LastName),
+		Username:       u.Username,
+	})
+	if err != nil {
+		customerrors.HandlerError(err, &chat.ID, nil)
+		return false
+	}
+	return true
+}
+
+// forgetSeenChatMember makes the next update of the user recorded again, it is called when the membership changes
+func (ch *CalendarHandlers) forgetSeenChatMember(chatID int64, telegramUserID int64) {
+	if err := ch.redisDB.HDel(context.TODO(), seenChatMembersKey(chatID), fmt.Sprint(telegramUserID)).Err(); err != nil {
+		customerrors.HandlerError(err, &chatID, nil)
+	}
+}
+
+func isTrackedChatMember(u *tb.User, chat *tb.Chat) bool {
+	return u != nil && chat != nil && !u.IsBot && (chat.Type == tb.ChatGroup || chat.Type == tb.ChatSuperGroup)
+}
+
+func seenChatMembersKey(chatID int64) string {
+	return fmt.Sprintf("chat_members_seen:%d", chatID)
+}
+
+// getChatMembers returns telegram IDs of authenticated members of the group
+func (ch *CalendarHandlers) getChatMembers(chatID int64) ([]int64, error) {
+	members, err := ch.userUseCase.GetChatMembers(chatID)
+	if err != nil {
+		return nil, err
+	}
+	return uUseCase.AuthenticatedMemberIDs(members), nil
+}
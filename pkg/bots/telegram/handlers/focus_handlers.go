@@ -0,0 +1,158 @@
+package handlers
+
+import (
+	"github.com/calendar-bot/pkg/bots/telegram/messages"
+	"github.com/calendar-bot/pkg/bots/telegram/messages/calendarMessages"
+	"github.com/calendar-bot/pkg/customerrors"
+	"github.com/calendar-bot/pkg/i18n"
+	"github.com/calendar-bot/pkg/types"
+	"github.com/pkg/errors"
+	tb "gopkg.in/tucnak/telebot.v2"
+	"strconv"
+	"time"
+)
+
+func (ch *CalendarHandlers) HandleFocus(m *tb.Message) {
+	lang := ch.lang(m.Sender)
+	if m.Chat.Type != tb.ChatPrivate {
+		_, err := ch.handler.bot.Send(m.Chat, lang.T(messages.ErrorCommandIsNotAllowedInGroupChat))
+		if err != nil {
+			customerrors.HandlerError(err, &m.Chat.ID, &m.ID)
+		}
+		return
+	}
+	if !ch.AuthMiddleware(m.Sender, m.Chat) {
+		return
+	}
+
+	rule, err := ch.eventUseCase.GetFocusRule(int64(m.Sender.ID))
+	if err != nil {
+		customerrors.HandlerError(err, &m.Chat.ID, &m.ID)
+		ch.handler.SendError(m.Chat, err)
+		return
+	}
+
+	_, err = ch.handler.bot.Send(m.Chat, calendarMessages.GetFocusRuleText(lang, rule), &tb.SendOptions{
+		ParseMode: tb.ModeHTML,
+		ReplyMarkup: &tb.ReplyMarkup{
+			InlineKeyboard: ch.keyboards.FocusRuleButtons(lang, rule),
+		},
+	})
+	if err != nil {
+		customerrors.HandlerError(err, &m.Chat.ID, &m.ID)
+	}
+}
+
+func (ch *CalendarHandlers) HandleFocusDuration(c *tb.Callback) {
+	minutes, err := strconv.Atoi(c.Data)
+	if err != nil {
+		customerrors.HandlerError(err, &c.Message.Chat.ID, &c.Message.ID)
+		return
+	}
+
+	ch.updateFocusRule(c, func(rule *types.FocusRule) {
+		rule.Duration = time.Duration(minutes) * time.Minute
+		rule.Enabled = true
+	})
+}
+
+func (ch *CalendarHandlers) HandleFocusMorning(c *tb.Callback) {
+	preferMorning := c.Data == "1"
+	ch.updateFocusRule(c, func(rule *types.FocusRule) {
+		rule.PreferMorning = preferMorning
+	})
+}
+
+func (ch *CalendarHandlers) HandleFocusOff(c *tb.Callback) {
+	if !ch.respondFocusCallback(c) {
+		return
+	}
+
+	token, err := ch.userUseCase.GetOrRefreshOAuthAccessTokenByTelegramUserID(int64(c.Sender.ID))
+	if err != nil {
+		customerrors.HandlerError(err, &c.Message.Chat.ID, &c.Message.ID)
+		ch.handler.SendError(c.Message.Chat, err)
+		return
+	}
+
+	if err := ch.eventUseCase.DisableFocusRule(token, int64(c.Sender.ID)); err != nil {
+		customerrors.HandlerError(err, &c.Message.Chat.ID, &c.Message.ID)
+		ch.handler.SendError(c.Message.Chat, err)
+		return
+	}
+
+	ch.editFocusRule(c)
+}
+
+// updateFocusRule saves the changed rule and plans focus time for today right away if the rule is enabled
+func (ch *CalendarHandlers) updateFocusRule(c *tb.Callback, change func(rule *types.FocusRule)) {
+	if !ch.respondFocusCallback(c) {
+		return
+	}
+
+	rule, err := ch.eventUseCase.GetFocusRule(int64(c.Sender.ID))
+	if err != nil {
+		customerrors.HandlerError(err, &c.Message.Chat.ID, &c.Message.ID)
+		ch.handler.SendError(c.Message.Chat, err)
+		return
+	}
+
+	change(&rule)
+	if err := ch.eventUseCase.SetFocusRule(rule); err != nil {
+		customerrors.HandlerError(err, &c.Message.Chat.ID, &c.Message.ID)
+		ch.handler.SendError(c.Message.Chat, err)
+		return
+	}
+
+	if rule.Enabled {
+		if err := ch.planFocusTimeToday(ch.lang(c.Sender), rule); err != nil {
+			customerrors.HandlerError(err, &c.Message.Chat.ID, &c.Message.ID)
+		}
+	}
+
+	ch.editFocusRule(c)
+}
+
+func (ch *CalendarHandlers) planFocusTimeToday(lang i18n.Lang, rule types.FocusRule) error {
+	token, err := ch.userUseCase.GetOrRefreshOAuthAccessTokenByTelegramUserID(rule.TelegramUserID)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	email, err := ch.userUseCase.GetUserEmailByTelegramUserID(rule.TelegramUserID)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	_, err = ch.eventUseCase.PlanFocusTime(token, email, rule, lang.T(calendarMessages.FocusEventDescription),
+		ch.userNow(rule.TelegramUserID))
+	return err
+}
+
+func (ch *CalendarHandlers) respondFocusCallback(c *tb.Callback) bool {
+	err := ch.handler.bot.Respond(c, &tb.CallbackResponse{
+		CallbackID: c.ID,
+	})
+	if err != nil {
+		customerrors.HandlerError(err, &c.Message.Chat.ID, &c.Message.ID)
+	}
+	return ch.AuthMiddleware(c.Sender, c.Message.Chat)
+}
+
+func (ch *CalendarHandlers) editFocusRule(c *tb.Callback) {
+	lang := ch.lang(c.Sender)
+	rule, err := ch.eventUseCase.GetFocusRule(int64(c.Sender.ID))
+	if err != nil {
+		customerrors.HandlerError(err, &c.Message.Chat.ID, &c.Message.ID)
+		ch.handler.SendError(c.Message.Chat, err)
+		return
+	}
+
+	_, err = ch.handler.bot.Edit(c.Message, calendarMessages.GetFocusRuleText(lang, rule), &tb.SendOptions{
+		ParseMode: tb.ModeHTML,
+		ReplyMarkup: &tb.ReplyMarkup{
+			InlineKeyboard: ch.keyboards.FocusRuleButtons(lang, rule),
+		},
+	})
+	if err != nil {
+		customerrors.HandlerError(err, &c.Message.Chat.ID, &c.Message.ID)
+	}
+}
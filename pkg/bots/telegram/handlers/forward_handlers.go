@@ -0,0 +1,74 @@
+package handlers
+
+import (
+	"fmt"
+	"github.com/calendar-bot/pkg/bots/telegram/messages/calendarMessages"
+	"github.com/calendar-bot/pkg/customerrors"
+	"github.com/calendar-bot/pkg/types"
+	tb "gopkg.in/tucnak/telebot.v2"
+	"strings"
+)
+
+// ids of supergroups and channels are "-100" followed by the id used in t.me/c links
+const privateChatLinkIDOffset = 1000000000000
+
+// isForwarded also detects forwards from users who hide their account
+func isForwarded(m *tb.Message) bool {
+	return m.IsForwarded() || m.OriginalSenderName != ""
+}
+
+// fillForwardedEvent puts the author and the link of the forwarded message into the description
+// and invites the author if they use the bot
+func (ch *CalendarHandlers) fillForwardedEvent(m *tb.Message, event *types.Event) {
+	lang := ch.lang(m.Sender)
+	event.Description = calendarMessages.GetForwardedDescription(lang, forwardedSenderName(m), forwardedMessageLink(m))
+
+	if m.OriginalSender == nil || m.OriginalSender.ID == m.Sender.ID {
+		return
+	}
+	emails, err := ch.userUseCase.TryGetUsersEmailsByTelegramUserIDs([]int64{int64(m.OriginalSender.ID)})
+	if err != nil {
+		customerrors.HandlerError(err, &m.Chat.ID, &m.ID)
+		return
+	}
+	for _, email := range emails {
+		if email == event.Organizer.Email {
+			continue
+		}
+		event.Attendees = append(event.Attendees, types.AttendeeEvent{
+			Email:  email,
+			Name:   forwardedSenderName(m),
+			Role:   types.RoleRequired,
+			Status: types.StatusNeedsAction,
+		})
+	}
+}
+
+func forwardedSenderName(m *tb.Message) string {
+	switch {
+	case m.OriginalSender != nil:
+		return strings.TrimSpace(m.OriginalSender.FirstName + " " + m.OriginalSender.LastName)
+	case m.OriginalSenderName != "":
+		return m.OriginalSenderName
+	case m.OriginalSignature != "":
+		return m.OriginalSignature
+	case m.OriginalChat != nil:
+		return m.OriginalChat.Title
+	}
+	return ""
+}
+
+// forwardedMessageLink is empty for messages forwarded from private chats, they can not be linked
+func forwardedMessageLink(m *tb.Message) string {
+	chat := m.OriginalChat
+	if chat == nil || m.OriginalMessageID == 0 {
+		return ""
+	}
+	if chat.Username != "" {
+		return fmt.Sprintf("https://t.me/%s/%d", chat.Username, m.OriginalMessageID)
+	}
+	if chat.ID < -privateChatLinkIDOffset {
+		return fmt.Sprintf("https://t.me/c/%d/%d", -chat.ID-privateChatLinkIDOffset, m.OriginalMessageID)
+	}
+	return ""
+}
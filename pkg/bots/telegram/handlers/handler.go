@@ -8,16 +8,18 @@ import (
 )
 
 type Handler struct {
-	bot          *tb.Bot
-	parseAddress string
+	bot           *tb.Bot
+	parseAddress  string
+	settingsCache *SettingsCache
 }
 
 func (h *Handler) SendError(sender tb.Recipient, outerErr error) {
-	_, err := h.bot.Send(sender, messages.MessageUnexpectedError(outerErr.Error()),
+	lang := h.recipientLang(sender)
+	_, err := h.bot.Send(sender, messages.MessageUnexpectedError(lang, outerErr.Error()),
 		&tb.SendOptions{
 			ReplyMarkup: &tb.ReplyMarkup{
 				ReplyKeyboardRemove: true,
-				InlineKeyboard:      inline_keyboards.ReportBugKeyboard(),
+				InlineKeyboard:      inline_keyboards.ReportBugKeyboard(lang),
 			},
 		})
 
@@ -27,7 +29,7 @@ func (h *Handler) SendError(sender tb.Recipient, outerErr error) {
 }
 
 func (h *Handler) SendAuthError(sender tb.Recipient, outerErr error) {
-	_, err := h.bot.Send(sender, messages.MessageAuthError(outerErr.Error()),
+	_, err := h.bot.Send(sender, messages.MessageAuthError(h.recipientLang(sender), outerErr.Error()),
 		&tb.SendOptions{
 			ParseMode: tb.ModeHTML,
 			ReplyMarkup: &tb.ReplyMarkup{
@@ -0,0 +1,50 @@
+package handlers
+
+import (
+	"github.com/calendar-bot/pkg/bots/telegram/messages/calendarMessages"
+	"github.com/calendar-bot/pkg/customerrors"
+	tb "gopkg.in/tucnak/telebot.v2"
+	"time"
+)
+
+const holidaysLookaheadDays = 90
+
+func (ch *CalendarHandlers) HandleHolidays(m *tb.Message) {
+	lang := ch.lang(m.Sender)
+	if !ch.AuthMiddleware(m.Sender, m.Chat) {
+		return
+	}
+
+	token, err := ch.userUseCase.GetOrRefreshOAuthAccessTokenByTelegramUserID(int64(m.Sender.ID))
+	if err != nil {
+		customerrors.HandlerError(err, &m.Chat.ID, &m.ID)
+		ch.handler.SendError(m.Chat, err)
+		return
+	}
+
+	now := ch.userNow(int64(m.Sender.ID))
+	from := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	to := from.AddDate(0, 0, holidaysLookaheadDays)
+
+	holidays, err := ch.eventUseCase.GetHolidays(token, from, to)
+	if err != nil {
+		customerrors.HandlerError(err, &m.Chat.ID, &m.ID)
+		ch.handler.SendError(m.Chat, err)
+		return
+	}
+
+	text := calendarMessages.GetHolidaysNotFound(lang, holidaysLookaheadDays)
+	if len(holidays) > 0 {
+		text = calendarMessages.GetHolidaysText(lang, holidays)
+	}
+
+	_, err = ch.handler.bot.Send(m.Chat, text, &tb.SendOptions{
+		ParseMode: tb.ModeHTML,
+		ReplyMarkup: &tb.ReplyMarkup{
+			ReplyKeyboardRemove: true,
+		},
+	})
+	if err != nil {
+		customerrors.HandlerError(err, &m.Chat.ID, &m.ID)
+	}
+}
@@ -0,0 +1,133 @@
+package handlers
+
+import (
+	"bytes"
+	"github.com/calendar-bot/pkg/bots/telegram/messages/calendarMessages"
+	"github.com/calendar-bot/pkg/customerrors"
+	eUseCase "github.com/calendar-bot/pkg/events/usecase"
+	"github.com/calendar-bot/pkg/i18n"
+	"github.com/calendar-bot/pkg/render"
+	"github.com/calendar-bot/pkg/types"
+	tb "gopkg.in/tucnak/telebot.v2"
+	"image"
+)
+
+func (ch *CalendarHandlers) HandleImageMode(m *tb.Message) {
+	lang := ch.lang(m.Sender)
+	if !ch.AuthMiddleware(m.Sender, m.Chat) {
+		return
+	}
+
+	enabled := !ch.isImageMode(m.Sender.ID)
+	err := ch.setImageMode(m.Sender.ID, enabled)
+	if err != nil {
+		customerrors.HandlerError(err, &m.Chat.ID, &m.ID)
+		ch.handler.SendError(m.Chat, err)
+		return
+	}
+
+	_, err = ch.handler.bot.Send(m.Chat, calendarMessages.GetImageModeText(lang, enabled), &tb.SendOptions{
+		ReplyTo: m,
+	})
+	if err != nil {
+		customerrors.HandlerError(err, &m.Chat.ID, &m.ID)
+	}
+}
+
+func (ch *CalendarHandlers) HandleWeek(m *tb.Message) {
+	lang := ch.lang(m.Sender)
+	token, groupCalendar, ok := ch.scheduleToken(m)
+	if !ok {
+		return
+	}
+
+	from := weekStart(ch.userNow(int64(m.Sender.ID)), 0)
+	to := from.AddDate(0, 0, 7)
+	events, err := ch.eventUseCase.GetEventsByRange(token, from, to)
+	if err != nil {
+		customerrors.HandlerError(err, &m.Chat.ID, &m.ID)
+		ch.handler.SendError(m.Chat, err)
+		return
+	}
+	if events != nil && groupCalendar != nil {
+		events.Data.Events = eUseCase.FilterCalendarEvents(events.Data.Events, groupCalendar.CalendarUID)
+	}
+
+	if events == nil || len(events.Data.Events) == 0 {
+		_, err := ch.handler.bot.Send(m.Chat, calendarMessages.GetWeekNotFound(lang), &tb.SendOptions{
+			ReplyMarkup: &tb.ReplyMarkup{
+				ReplyKeyboardRemove: true,
+			},
+		})
+		if err != nil {
+			customerrors.HandlerError(err, &m.Chat.ID, &m.ID)
+		}
+		return
+	}
+
+	title := calendarMessages.GetWeekTitle(lang, from, to.AddDate(0, 0, -1))
+	if groupCalendar != nil {
+		title += calendarMessages.AddNameBold(groupCalendar.CalendarTitle)
+	}
+	if ch.isImageMode(m.Sender.ID) {
+		ch.sendSchedulePhoto(m.Chat, render.WeekTimeline(events.Data.Events, from, lang.MondayLocale()), title)
+		return
+	}
+
+	_, err = ch.handler.bot.Send(m.Chat, title, &tb.SendOptions{
+		ParseMode: tb.ModeHTML,
+		ReplyMarkup: &tb.ReplyMarkup{
+			ReplyKeyboardRemove: true,
+		},
+	})
+	if err != nil {
+		customerrors.HandlerError(err, &m.Chat.ID, &m.ID)
+	}
+
+	ch.sendShortEvents(lang, &events.Data.Events, m.Chat)
+}
+
+// sendFreeBusyHeatmap shows how busy the find time participants are, errors are only logged
+// because the poll is sent anyway
+func (ch *CalendarHandlers) sendFreeBusyHeatmap(lang i18n.Lang, token string, session *types.BotRedisSession, c *tb.Chat) {
+	response, err := ch.eventUseCase.GetUsersBusyIntervals(token, session.FreeBusy)
+	if err != nil {
+		customerrors.HandlerError(err, &c.ID, nil)
+		return
+	}
+
+	img := render.FreeBusyHeatmap(response.Data, session.FreeBusy.Users, session.FreeBusy.From,
+		session.FreeBusy.To, session.FindTimeDayPart, lang.MondayLocale())
+	ch.sendSchedulePhoto(c, img, lang.T(calendarMessages.FindTimeHeatmapCaption))
+}
+
+func (ch *CalendarHandlers) sendSchedulePhoto(chat *tb.Chat, img image.Image, caption string) {
+	data, err := render.EncodePNG(img)
+	if err != nil {
+		customerrors.HandlerError(err, &chat.ID, nil)
+		ch.handler.SendError(chat, err)
+		return
+	}
+
+	photo := &tb.Photo{
+		File:    tb.FromReader(bytes.NewReader(data)),
+		Caption: caption,
+	}
+	_, err = ch.handler.bot.Send(chat, photo, &tb.SendOptions{
+		ParseMode: tb.ModeHTML,
+	})
+	if err != nil {
+		customerrors.HandlerError(err, &chat.ID, nil)
+	}
+}
+
+func (ch *CalendarHandlers) isImageMode(userID int) bool {
+	return ch.handler.settings(int64(userID)).ImageMode
+}
+
+func (ch *CalendarHandlers) setImageMode(userID int, enabled bool) error {
+	return ch.handler.updateSettings(int64(userID), func(settings *types.UserSettings) error {
+		settings.ImageMode = enabled
+		return nil
+	})
+}
@@ -0,0 +1,226 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/calendar-bot/pkg/bots/telegram/inline_keyboards/calendarInlineKeyboards"
+	"github.com/calendar-bot/pkg/bots/telegram/messages/calendarMessages"
+	"github.com/calendar-bot/pkg/customerrors"
+	eUseCase "github.com/calendar-bot/pkg/events/usecase"
+	"github.com/calendar-bot/pkg/i18n"
+	"github.com/calendar-bot/pkg/types"
+	"github.com/pkg/errors"
+	tb "gopkg.in/tucnak/telebot.v2"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	// telegram shows at most 50 results for an inline query
+	inlineResultsLimit = 50
+	// events are cached, since telegram sends a query for each typed letter
+	inlineEventsCacheTTL   = time.Minute
+	inlineResultsCacheTime = 30
+	inlineLoginParameter   = "inline"
+)
+
+// HandleInlineQuery shares events of the user in any chat, "@bot tomorrow standup" finds standups of tomorrow
+func (ch *CalendarHandlers) HandleInlineQuery(q *tb.Query) {
+	lang := ch.lang(&q.From)
+	telegramUserID := int64(q.From.ID)
+	isAuth, err := ch.userUseCase.IsUserAuthenticatedByTelegramUserID(telegramUserID)
+	if err != nil {
+		customerrors.HandlerError(err, nil, nil)
+		return
+	}
+	if !isAuth {
+		err := ch.handler.bot.Answer(q, &tb.QueryResponse{
+			Results:           tb.Results{},
+			IsPersonal:        true,
+			SwitchPMText:      lang.T(calendarMessages.InlineLoginText),
+			SwitchPMParameter: inlineLoginParameter,
+		})
+		if err != nil {
+			customerrors.HandlerError(err, nil, nil)
+		}
+		return
+	}
+
+	location := ch.userLocation(telegramUserID)
+	now := time.Now().In(location)
+	query := eUseCase.ParseInlineQuery(q.Text, now)
+
+	events, err := ch.getInlineEvents(telegramUserID, query.From, query.To)
+	if err != nil {
+		customerrors.HandlerError(err, nil, nil)
+		return
+	}
+	events = eUseCase.FilterInlineEvents(events, query.Search, now)
+	if len(events) > inlineResultsLimit {
+		events = events[:inlineResultsLimit]
+	}
+
+	results := make(tb.Results, 0, len(events))
+	for i := range events {
+		event := &events[i]
+		event.From = event.From.In(location)
+		event.To = event.To.In(location)
+
+		keyboard, err := ch.inlineEventButtons(lang, event, q.From.ID)
+		if err != nil {
+			customerrors.HandlerError(err, nil, nil)
+			return
+		}
+
+		result := &tb.ArticleResult{
+			Title:       calendarMessages.GetInlineResultTitle(lang, event),
+			Description: calendarMessages.GetInlineResultDescription(lang, event),
+		}
+		result.SetResultID(strconv.Itoa(i))
+		result.SetContent(&tb.InputTextMessageContent{
+			Text:      calendarMessages.SingleEventFullText(lang, event),
+			ParseMode: tb.ModeHTML,
+		})
+		result.SetReplyMarkup(calendarInlineKeyboards.WithCallButton(lang, event, keyboard))
+		results = append(results, result)
+	}
+
+	err = ch.handler.bot.Answer(q, &tb.QueryResponse{
+		Results:    results,
+		CacheTime:  inlineResultsCacheTime,
+		IsPersonal: true,
+	})
+	if err != nil {
+		customerrors.HandlerError(err, nil, nil)
+	}
+}
+
+// inlineEventButtons are the RSVP buttons of the inline result, they are signed once per event for a while
+func (ch *CalendarHandlers) inlineEventButtons(lang i18n.Lang, event *types.Event,
+	senderID int) ([][]tb.InlineButton, error) {
+
+	key := fmt.Sprintf("%d:%s:%s:%s", senderID, lang, event.Uid, event.Calendar.UID)
+	if keyboard, ok := ch.inlineKeyboards.get(key); ok {
+		return keyboard, nil
+	}
+	keyboard, err := ch.keyboards.GroupChatButtons(lang, event, &ch.callbackStore, senderID)
+	if err != nil {
+		return nil, err
+	}
+	ch.inlineKeyboards.set(key, keyboard)
+	return keyboard, nil
+}
+
+func (ch *CalendarHandlers) getInlineEvents(telegramUserID int64, from time.Time, to time.Time) (types.Events, error) {
+	key := fmt.Sprintf("inline:%d:%d:%d", telegramUserID, from.Unix(), to.Unix())
+	if cached, err := ch.redisDB.Get(context.TODO(), key).Bytes(); err == nil {
+		events := types.Events{}
+		if err := json.Unmarshal(cached, &events); err == nil {
+			return events, nil
+		}
+	}
+
+	token, err := ch.userUseCase.GetOrRefreshOAuthAccessTokenByTelegramUserID(telegramUserID)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	response, err := ch.eventUseCase.GetEventsByRange(token, from, to)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	events := types.Events{}
+	if response != nil {
+		events = response.Data.Events
+	}
+
+	cached, err := json.Marshal(events)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	if err := ch.redisDB.Set(context.TODO(), key, cached, inlineEventsCacheTTL).Err(); err != nil {
+		customerrors.HandlerError(err, nil, nil)
+	}
+	return events, nil
+}
+
+// userLocation is the timezone chosen by the user in /settings
+func (ch *CalendarHandlers) userLocation(telegramUserID int64) *time.Location {
+	return settingsLocation(ch.handler.settings(telegramUserID))
+}
+
+// userNow is the current time in the user's timezone, the days of the user start and end in it
+func (ch *CalendarHandlers) userNow(telegramUserID int64) time.Time {
+	return time.Now().In(ch.userLocation(telegramUserID))
+}
+
+// callbackMessage is the message of the callback. Buttons of messages sent in inline mode have no message,
+// errors about them go to the private chat with the user
+func callbackMessage(c *tb.Callback) *tb.Message {
+	if c.Message != nil {
+		return c.Message
+	}
+	return &tb.Message{Chat: &tb.Chat{ID: int64(c.Sender.ID), Type: tb.ChatPrivate}}
+}
+
+// callbackEditable is the message to edit in reply to the callback
+func callbackEditable(c *tb.Callback) tb.Editable {
+	if c.IsInline() {
+		return tb.StoredMessage{MessageID: c.MessageID}
+	}
+	return c.Message
+}
+
+// inlineKeyboardCache keeps the signed buttons of the inline results. Telegram sends a query for each typed letter,
+// without the cache the buttons of every found event would be signed and stored again for each of them
+type inlineKeyboardCache struct {
+	mu        sync.Mutex
+	keyboards map[string]cachedKeyboard
+	lastSweep time.Time
+}
+
+type cachedKeyboard struct {
+	keyboard [][]tb.InlineButton
+	signedAt time.Time
+}
+
+func newInlineKeyboardCache() *inlineKeyboardCache {
+	return &inlineKeyboardCache{keyboards: map[string]cachedKeyboard{}}
+}
+
+// get returns a copy of the keyboard, telebot rewrites the callback data of the sent buttons in place
+func (c *inlineKeyboardCache) get(key string) ([][]tb.InlineButton, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	cached, ok := c.keyboards[key]
+	if !ok || time.Since(cached.signedAt) >= inlineEventsCacheTTL {
+		return nil, false
+	}
+	return copyKeyboard(cached.keyboard), true
+}
+
+func (c *inlineKeyboardCache) set(key string, keyboard [][]tb.InlineButton) {
+	now := time.Now()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.keyboards[key] = cachedKeyboard{keyboard: copyKeyboard(keyboard), signedAt: now}
+	if now.Sub(c.lastSweep) < inlineEventsCacheTTL {
+		return
+	}
+	for key, cached := range c.keyboards {
+		if now.Sub(cached.signedAt) >= inlineEventsCacheTTL {
+			delete(c.keyboards, key)
+		}
+	}
+	c.lastSweep = now
+}
+
+func copyKeyboard(keyboard [][]tb.InlineButton) [][]tb.InlineButton {
+	copied := make([][]tb.InlineButton, len(keyboard))
+	for i := range keyboard {
+		copied[i] = append([]tb.InlineButton(nil), keyboard[i]...)
+	}
+	return copied
+}
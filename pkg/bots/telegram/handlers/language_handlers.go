@@ -0,0 +1,99 @@
+package handlers
+
+import (
+	"github.com/calendar-bot/pkg/bots/telegram/messages/calendarMessages"
+	"github.com/calendar-bot/pkg/customerrors"
+	"github.com/calendar-bot/pkg/i18n"
+	"github.com/calendar-bot/pkg/types"
+	"github.com/pkg/errors"
+	tb "gopkg.in/tucnak/telebot.v2"
+	"strconv"
+)
+
+func (ch *CalendarHandlers) HandleLanguage(m *tb.Message) {
+	lang := ch.lang(m.Sender)
+	_, err := ch.handler.bot.Send(m.Chat, calendarMessages.GetLanguageText(lang), &tb.SendOptions{
+		ParseMode: tb.ModeHTML,
+		ReplyTo:   m,
+		ReplyMarkup: &tb.ReplyMarkup{
+			InlineKeyboard: ch.keyboards.LanguageButtons(lang),
+		},
+	})
+	if err != nil {
+		customerrors.HandlerError(err, &m.Chat.ID, &m.ID)
+	}
+}
+
+func (ch *CalendarHandlers) HandleLanguageSelect(c *tb.Callback) {
+	lang, ok := i18n.Parse(c.Data)
+	if !ok {
+		customerrors.HandlerError(errors.Errorf("unknown language %q", c.Data), &c.Message.Chat.ID, &c.Message.ID)
+		return
+	}
+
+	err := ch.handler.setLang(c.Sender.ID, lang)
+	if err != nil {
+		customerrors.HandlerError(err, &c.Message.Chat.ID, &c.Message.ID)
+		ch.handler.SendError(c.Message.Chat, err)
+		return
+	}
+
+	err = ch.handler.bot.Respond(c, &tb.CallbackResponse{
+		CallbackID: c.ID,
+		Text:       lang.T(calendarMessages.LanguageSelected),
+	})
+	if err != nil {
+		customerrors.HandlerError(err, &c.Message.Chat.ID, &c.Message.ID)
+	}
+
+	_, err = ch.handler.bot.Edit(c.Message, calendarMessages.GetLanguageChangedText(lang))
+	if err != nil {
+		customerrors.HandlerError(err, &c.Message.Chat.ID, &c.Message.ID)
+	}
+}
+
+// lang is the language chosen by the user with /language or /settings, otherwise the language of the Telegram client
+func (ch *CalendarHandlers) lang(user *tb.User) i18n.Lang {
+	return ch.handler.lang(user)
+}
+
+// UserLang is the language of the messages sent to the user by the background jobs
+func (ch *CalendarHandlers) UserLang(telegramUserID int64) i18n.Lang {
+	if lang, ok := ch.handler.storedLang(telegramUserID); ok {
+		return lang
+	}
+	return i18n.Default
+}
+
+func (h *Handler) lang(user *tb.User) i18n.Lang {
+	if user == nil {
+		return i18n.Default
+	}
+	if lang, ok := h.storedLang(int64(user.ID)); ok {
+		return lang
+	}
+	return i18n.FromLanguageCode(user.LanguageCode)
+}
+
+// recipientLang is the language of the private chat, group chats get the default language
+func (h *Handler) recipientLang(recipient tb.Recipient) i18n.Lang {
+	id, err := strconv.ParseInt(recipient.Recipient(), 10, 64)
+	if err != nil || id <= 0 {
+		return i18n.Default
+	}
+	if lang, ok := h.storedLang(id); ok {
+		return lang
+	}
+	return i18n.Default
+}
+
+func (h *Handler) storedLang(userID int64) (i18n.Lang, bool) {
+	return i18n.Parse(h.settings(userID).Language)
+}
+
+func (h *Handler) setLang(userID int, lang i18n.Lang) error {
+	return h.updateSettings(int64(userID), func(settings *types.UserSettings) error {
+		settings.Language = string(lang)
+		return nil
+	})
+}
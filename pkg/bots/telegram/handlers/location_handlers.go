@@ -0,0 +1,99 @@
+package handlers
+
+import (
+	"fmt"
+	"github.com/calendar-bot/pkg/bots/telegram/wizard"
+	"github.com/calendar-bot/pkg/customerrors"
+	eUseCase "github.com/calendar-bot/pkg/events/usecase"
+	"github.com/calendar-bot/pkg/types"
+	tb "gopkg.in/tucnak/telebot.v2"
+)
+
+// HandleSharedLocation takes a location or venue shared on the location step of the create wizard as the event place
+func (ch *CalendarHandlers) HandleSharedLocation(m *tb.Message) {
+	if m.Location == nil && m.Venue == nil {
+		return
+	}
+
+	session, err := ch.getSession(m.Sender, m.Chat)
+	if err != nil {
+		return
+	}
+	if !wizard.Is(session, wizard.CreateLocation) {
+		return
+	}
+
+	m.Text = sharedLocationDescription(m)
+	ch.handleCreateText(m, session)
+}
+
+// HandleEventVenue sends the place of the event as a venue message
+func (ch *CalendarHandlers) HandleEventVenue(c *tb.Callback) {
+	if !ch.AuthMiddleware(c.Sender, c.Message.Chat) {
+		err := ch.handler.bot.Respond(c, &tb.CallbackResponse{
+			CallbackID: c.ID,
+		})
+		if err != nil {
+			customerrors.HandlerError(err, &c.Message.Chat.ID, &c.Message.ID)
+		}
+		return
+	}
+	event := ch.getEventByIdForCallback(c, c.Sender.ID)
+	if event == nil {
+		return
+	}
+
+	err := ch.handler.bot.Respond(c, &tb.CallbackResponse{
+		CallbackID: c.ID,
+	})
+	if err != nil {
+		customerrors.HandlerError(err, &c.Message.Chat.ID, &c.Message.ID)
+	}
+
+	latitude, longitude, ok := eUseCase.GeoCoordinates(event.Location.Geo)
+	if !ok {
+		return
+	}
+
+	title := event.Title
+	if title == "" {
+		title = event.Location.Description
+	}
+	venue := &tb.Venue{
+		Location: tb.Location{Lat: latitude, Lng: longitude},
+		Title:    title,
+		Address:  event.Location.Description,
+	}
+	_, err = ch.handler.bot.Send(c.Message.Chat, venue, &tb.SendOptions{
+		ReplyTo: c.Message,
+	})
+	if err != nil {
+		customerrors.HandlerError(err, &c.Message.Chat.ID, &c.Message.ID)
+	}
+}
+
+// sharedGeo returns coordinates of the shared location or venue, empty geo for a text message
+func sharedGeo(m *tb.Message) types.Geo {
+	switch {
+	case m.Venue != nil:
+		return eUseCase.GeoFromCoordinates(m.Venue.Location.Lat, m.Venue.Location.Lng)
+	case m.Location != nil:
+		return eUseCase.GeoFromCoordinates(m.Location.Lat, m.Location.Lng)
+	default:
+		return types.Geo{}
+	}
+}
+
+func sharedLocationDescription(m *tb.Message) string {
+	if m.Venue != nil {
+		switch {
+		case m.Venue.Address == "":
+			return m.Venue.Title
+		case m.Venue.Title == "":
+			return m.Venue.Address
+		default:
+			return m.Venue.Title + ", " + m.Venue.Address
+		}
+	}
+	return fmt.Sprintf("%.6f, %.6f", m.Location.Lat, m.Location.Lng)
+}
@@ -0,0 +1,58 @@
+package handlers
+
+import (
+	"github.com/calendar-bot/pkg/bots/telegram/messages/calendarMessages"
+	"github.com/calendar-bot/pkg/customerrors"
+	"github.com/calendar-bot/pkg/render"
+	"github.com/calendar-bot/pkg/types"
+	tb "gopkg.in/tucnak/telebot.v2"
+	"time"
+)
+
+// SendReminder reminds the user about the event starting in the reminder lead time of the settings
+func (ch *CalendarHandlers) SendReminder(settings types.UserSettings, event types.Event) {
+	lang := ch.UserLang(settings.TelegramUserID)
+	chat := notificationChat(settings)
+
+	keyboard, err := ch.keyboards.EventShowMoreInlineKeyboard(lang, &event, &ch.callbackStore)
+	if err != nil {
+		customerrors.HandlerError(err, &chat.ID, nil)
+	}
+
+	_, err = ch.handler.bot.Send(chat, calendarMessages.GetReminderText(lang, settings.ReminderLeadTime, &event),
+		&tb.SendOptions{
+			ParseMode: tb.ModeHTML,
+			ReplyMarkup: &tb.ReplyMarkup{
+				InlineKeyboard: keyboard,
+			},
+		})
+	if err != nil {
+		customerrors.HandlerError(err, &chat.ID, nil)
+	}
+}
+
+// SendDigest sends the schedule of the day at the digest time of the settings, as an image if the user prefers it
+func (ch *CalendarHandlers) SendDigest(settings types.UserSettings, day time.Time, events types.Events) {
+	lang := ch.UserLang(settings.TelegramUserID)
+	chat := notificationChat(settings)
+
+	title := calendarMessages.GetTodayTitle(lang)
+	if settings.ImageMode {
+		ch.sendSchedulePhoto(chat, render.DayTimeline(events, day, lang.MondayLocale()), title)
+		return
+	}
+
+	_, err := ch.handler.bot.Send(chat, title, &tb.SendOptions{
+		ParseMode: tb.ModeHTML,
+	})
+	if err != nil {
+		customerrors.HandlerError(err, &chat.ID, nil)
+		return
+	}
+	ch.sendShortEvents(lang, &events, chat)
+}
+
+// notificationChat is the private chat with the user
+func notificationChat(settings types.UserSettings) *tb.Chat {
+	return &tb.Chat{ID: settings.TelegramUserID, Type: tb.ChatPrivate}
+}
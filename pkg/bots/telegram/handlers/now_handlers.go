@@ -0,0 +1,110 @@
+package handlers
+
+import (
+	"github.com/calendar-bot/pkg/bots/telegram/messages/calendarMessages"
+	"github.com/calendar-bot/pkg/customerrors"
+	tb "gopkg.in/tucnak/telebot.v2"
+)
+
+func (ch *CalendarHandlers) HandleNow(m *tb.Message) {
+	if !ch.AuthMiddleware(m.Sender, m.Chat) {
+		return
+	}
+	if m.Chat.Type == tb.ChatPrivate {
+		ch.sendCurrentEvent(m)
+		return
+	}
+	ch.sendUsersStatus(m)
+}
+
+func (ch *CalendarHandlers) sendCurrentEvent(m *tb.Message) {
+	lang := ch.lang(m.Sender)
+	token, err := ch.userUseCase.GetOrRefreshOAuthAccessTokenByTelegramUserID(int64(m.Sender.ID))
+	if err != nil {
+		customerrors.HandlerError(err, &m.Chat.ID, &m.ID)
+		ch.handler.SendError(m.Chat, err)
+		return
+	}
+
+	now := ch.userNow(int64(m.Sender.ID))
+	current, next, err := ch.eventUseCase.GetCurrentEvent(token, now)
+	if err != nil {
+		customerrors.HandlerError(err, &m.Chat.ID, &m.ID)
+		ch.handler.SendError(m.Chat, err)
+		return
+	}
+
+	var keyboard [][]tb.InlineButton
+	if current != nil {
+		keyboard, err = ch.keyboards.EventNowInlineKeyboard(lang, current, &ch.callbackStore)
+		if err != nil {
+			customerrors.HandlerError(err, &m.Chat.ID, &m.ID)
+		}
+	}
+	_, err = ch.handler.bot.Send(m.Chat, calendarMessages.GetNowCurrentText(lang, current, now), &tb.SendOptions{
+		ParseMode: tb.ModeHTML,
+		ReplyMarkup: &tb.ReplyMarkup{
+			InlineKeyboard: keyboard,
+		},
+	})
+	if err != nil {
+		customerrors.HandlerError(err, &m.Chat.ID, &m.ID)
+	}
+
+	keyboard = nil
+	if next != nil {
+		keyboard, err = ch.keyboards.EventShowMoreInlineKeyboard(lang, next, &ch.callbackStore)
+		if err != nil {
+			customerrors.HandlerError(err, &m.Chat.ID, &m.ID)
+		}
+	}
+	_, err = ch.handler.bot.Send(m.Chat, calendarMessages.GetNowNextText(lang, next), &tb.SendOptions{
+		ParseMode: tb.ModeHTML,
+		ReplyMarkup: &tb.ReplyMarkup{
+			InlineKeyboard: keyboard,
+		},
+	})
+	if err != nil {
+		customerrors.HandlerError(err, &m.Chat.ID, &m.ID)
+	}
+}
+
+func (ch *CalendarHandlers) sendUsersStatus(m *tb.Message) {
+	lang := ch.lang(m.Sender)
+	members, err := ch.getChatMembers(m.Chat.ID)
+	if err != nil {
+		customerrors.HandlerError(err, &m.Chat.ID, &m.ID)
+		ch.handler.SendError(m.Chat, err)
+		return
+	}
+
+	emails, err := ch.userUseCase.TryGetUsersEmailsByTelegramUserIDs(members)
+	if err != nil {
+		customerrors.HandlerError(err, &m.Chat.ID, &m.ID)
+		ch.handler.SendError(m.Chat, err)
+		return
+	}
+
+	token, err := ch.userUseCase.GetOrRefreshOAuthAccessTokenByTelegramUserID(int64(m.Sender.ID))
+	if err != nil {
+		customerrors.HandlerError(err, &m.Chat.ID, &m.ID)
+		ch.handler.SendError(m.Chat, err)
+		return
+	}
+
+	now := ch.userNow(int64(m.Sender.ID))
+	statuses, err := ch.eventUseCase.GetUsersStatus(token, emails, now)
+	if err != nil {
+		customerrors.HandlerError(err, &m.Chat.ID, &m.ID)
+		ch.handler.SendError(m.Chat, err)
+		return
+	}
+
+	_, err = ch.handler.bot.Send(m.Chat, calendarMessages.GetUsersStatusText(lang, now, statuses), &tb.SendOptions{
+		ParseMode: tb.ModeHTML,
+		ReplyTo:   m,
+	})
+	if err != nil {
+		customerrors.HandlerError(err, &m.Chat.ID, &m.ID)
+	}
+}
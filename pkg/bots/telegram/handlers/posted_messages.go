@@ -0,0 +1,133 @@
+package handlers
+
+import (
+	"github.com/calendar-bot/pkg/bots/telegram"
+	"github.com/calendar-bot/pkg/bots/telegram/inline_keyboards/calendarInlineKeyboards"
+	"github.com/calendar-bot/pkg/bots/telegram/messages/calendarMessages"
+	"github.com/calendar-bot/pkg/customerrors"
+	eUseCase "github.com/calendar-bot/pkg/events/usecase"
+	"github.com/calendar-bot/pkg/i18n"
+	"github.com/calendar-bot/pkg/types"
+	tb "gopkg.in/tucnak/telebot.v2"
+	"strings"
+)
+
+// trackPostedMessage remembers the message showing the full event card to keep it in sync with the event.
+// telegramUserID is the user whose token reads the event, the go/not go buttons of the card act on their behalf
+func (ch *CalendarHandlers) trackPostedMessage(msg tb.Editable, event *types.Event, telegramUserID int, postedType string) {
+	lang := ch.UserLang(int64(telegramUserID))
+	messageID, chatID := msg.MessageSig()
+	err := ch.eventUseCase.AddPostedMessage(types.PostedMessage{
+		ChatID:         chatID,
+		MessageID:      messageID,
+		EventUID:       event.Uid,
+		CalendarUID:    event.Calendar.UID,
+		TelegramUserID: int64(telegramUserID),
+		Type:           postedType,
+		TextHash:       eUseCase.PostedMessageTextHash(calendarMessages.SingleEventFullText(lang, event)),
+		EventTo:        event.To,
+	})
+	if err != nil {
+		customerrors.HandlerError(err, &chatID, nil)
+	}
+}
+
+// syncPostedEvent re-renders all posted copies of the changed event
+func (ch *CalendarHandlers) syncPostedEvent(event *types.Event) {
+	posted, err := ch.eventUseCase.GetPostedMessages(event.Uid)
+	if err != nil {
+		customerrors.HandlerError(err, nil, nil)
+		return
+	}
+	ch.EditPostedMessages(event, posted)
+}
+
+// EditPostedMessages edits the posted copies of the event which show outdated text,
+// a copy is shown in the language of the user it was posted by
+func (ch *CalendarHandlers) EditPostedMessages(event *types.Event, posted []types.PostedMessage) {
+	for _, msg := range posted {
+		lang := ch.UserLang(msg.TelegramUserID)
+		text := calendarMessages.SingleEventFullText(lang, event)
+		textHash := eUseCase.PostedMessageTextHash(text)
+		if msg.TextHash == textHash {
+			continue
+		}
+
+		keyboard, err := ch.postedMessageKeyboard(lang, event, msg)
+		if err != nil {
+			customerrors.HandlerError(err, &msg.ChatID, nil)
+			continue
+		}
+		_, err = ch.handler.bot.Edit(tb.StoredMessage{MessageID: msg.MessageID, ChatID: msg.ChatID}, text,
+			&tb.SendOptions{
+				ParseMode: tb.ModeHTML,
+				ReplyMarkup: &tb.ReplyMarkup{
+					InlineKeyboard: keyboard,
+				},
+			})
+		if err != nil && !isMessageNotModifiedError(err) {
+			ch.handlePostedMessageError(msg, err)
+			continue
+		}
+
+		if err := ch.eventUseCase.UpdatePostedMessageHash(msg.ChatID, msg.MessageID, textHash); err != nil {
+			customerrors.HandlerError(err, &msg.ChatID, nil)
+		}
+	}
+}
+
+// CancelPostedMessages replaces the posted copies of a deleted event with the notice and stops tracking them
+func (ch *CalendarHandlers) CancelPostedMessages(posted []types.PostedMessage) {
+	for _, msg := range posted {
+		_, err := ch.handler.bot.Edit(tb.StoredMessage{MessageID: msg.MessageID, ChatID: msg.ChatID},
+			ch.UserLang(msg.TelegramUserID).T(calendarMessages.PostedEventCancelled), &tb.SendOptions{
+				ParseMode: tb.ModeHTML,
+			})
+		if err != nil && !isMessageNotModifiedError(err) && !isMessageGoneError(err) {
+			customerrors.HandlerError(err, &msg.ChatID, nil)
+			continue
+		}
+		if err := ch.eventUseCase.DeletePostedMessage(msg.ChatID, msg.MessageID); err != nil {
+			customerrors.HandlerError(err, &msg.ChatID, nil)
+		}
+	}
+}
+
+func (ch *CalendarHandlers) postedMessageKeyboard(lang i18n.Lang, event *types.Event, msg types.PostedMessage) ([][]tb.InlineButton, error) {
+	if msg.Type == telegram.PostedMessagePrivate {
+		return calendarInlineKeyboards.WithCallButton(lang, event, nil), nil
+	}
+	keyboard, err := ch.keyboards.GroupChatButtons(lang, event, &ch.callbackStore, int(msg.TelegramUserID))
+	if err != nil {
+		return nil, err
+	}
+	return calendarInlineKeyboards.WithCallButton(lang, event, keyboard), nil
+}
+
+// handlePostedMessageError stops tracking messages which can not be edited anymore
+func (ch *CalendarHandlers) handlePostedMessageError(msg types.PostedMessage, err error) {
+	if !isMessageGoneError(err) {
+		customerrors.HandlerError(err, &msg.ChatID, nil)
+		return
+	}
+	if err := ch.eventUseCase.DeletePostedMessage(msg.ChatID, msg.MessageID); err != nil {
+		customerrors.HandlerError(err, &msg.ChatID, nil)
+	}
+}
+
+// callbackPostedType is the type of the posted message the go/not go callback came from
+func callbackPostedType(c *tb.Callback) string {
+	if c.IsInline() {
+		return telegram.PostedMessageInline
+	}
+	return telegram.PostedMessageGroup
+}
+
+func isMessageNotModifiedError(err error) bool {
+	return err == tb.ErrMessageNotModified || err == tb.ErrSameMessageContent
+}
+
+func isMessageGoneError(err error) bool {
+	return err == tb.ErrCantEditMessage || isBotRemovedError(err) ||
+		strings.Contains(err.Error(), "message to edit not found")
+}
@@ -0,0 +1,130 @@
+package handlers
+
+import (
+	"fmt"
+	"github.com/calendar-bot/pkg/bots/telegram/messages/calendarMessages"
+	"github.com/calendar-bot/pkg/bots/telegram/utils"
+	"github.com/calendar-bot/pkg/bots/telegram/wizard"
+	"github.com/calendar-bot/pkg/customerrors"
+	eUseCase "github.com/calendar-bot/pkg/events/usecase"
+	tb "gopkg.in/tucnak/telebot.v2"
+)
+
+// HandleRoomChange offers free rooms from the directory which fit the attendees of the created event
+func (ch *CalendarHandlers) HandleRoomChange(m *tb.Message) {
+	lang := ch.lang(m.Sender)
+	session, err := ch.getSession(m.Sender, m.Chat)
+	if err != nil {
+		return
+	}
+	if !wizard.IsCreating(session) {
+		ch.HandleText(m)
+		return
+	}
+
+	attendees := eUseCase.Headcount(session.Event)
+
+	var text string
+	var keyboard [][]tb.InlineButton
+	switch {
+	case len(ch.rooms) == 0:
+		text = lang.T(calendarMessages.CreateEventRoomNoRooms)
+	case session.Event.From.IsZero() || session.Event.To.IsZero():
+		text = lang.T(calendarMessages.CreateEventRoomNeedsTime)
+	default:
+		token, err := ch.userUseCase.GetOrRefreshOAuthAccessTokenByTelegramUserID(int64(m.Sender.ID))
+		if err != nil {
+			customerrors.HandlerError(err, &m.Chat.ID, &m.ID)
+			ch.handler.SendError(m.Chat, err)
+			return
+		}
+
+		rooms, err := ch.eventUseCase.GetFreeRooms(token, ch.rooms, attendees, session.Event.From, session.Event.To)
+		if err != nil {
+			customerrors.HandlerError(err, &m.Chat.ID, &m.ID)
+			ch.handler.SendError(m.Chat, err)
+			return
+		}
+
+		if len(rooms) == 0 {
+			text = fmt.Sprintf(lang.T(calendarMessages.CreateEventRoomNoFreeRooms), attendees)
+		} else {
+			text = fmt.Sprintf(lang.T(calendarMessages.CreateEventRoomText), attendees)
+			keyboard = ch.keyboards.RoomButtons(lang, rooms)
+		}
+	}
+
+	_, err = ch.handler.bot.Send(m.Chat, text, &tb.SendOptions{
+		ParseMode: tb.ModeHTML,
+		ReplyTo:   m,
+		ReplyMarkup: &tb.ReplyMarkup{
+			InlineKeyboard: keyboard,
+		},
+	})
+	if err != nil {
+		customerrors.HandlerError(err, &m.Chat.ID, &m.ID)
+	}
+}
+
+// HandleRoomSelect puts the chosen room into the created event, empty data removes the room
+func (ch *CalendarHandlers) HandleRoomSelect(c *tb.Callback) {
+	lang := ch.lang(c.Sender)
+	if c.Message.ReplyTo != nil && c.Sender.ID != c.Message.ReplyTo.Sender.ID {
+		err := ch.handler.bot.Respond(c, &tb.CallbackResponse{
+			CallbackID: c.ID,
+			Text:       calendarMessages.GetUserNotAllow(lang),
+			ShowAlert:  true,
+		})
+		if err != nil {
+			customerrors.HandlerError(err, &c.Message.Chat.ID, &c.Message.ID)
+		}
+		return
+	}
+
+	err := ch.handler.bot.Respond(c, &tb.CallbackResponse{
+		CallbackID: c.ID,
+		Text:       lang.T(calendarMessages.CreateEventRoomSelectedText),
+	})
+	if err != nil {
+		customerrors.HandlerError(err, &c.Message.Chat.ID, &c.Message.ID)
+	}
+
+	session, err := ch.getSession(c.Sender, c.Message.Chat)
+	if err != nil || !wizard.IsCreating(session) {
+		return
+	}
+
+	if c.Data == "" {
+		session.Event.Location.Confrooms = nil
+	} else {
+		session.Event.Location.Confrooms = []string{c.Data}
+	}
+
+	if err := ch.handler.bot.Delete(c.Message); err != nil {
+		customerrors.HandlerError(err, &c.Message.Chat.ID, &c.Message.ID)
+	}
+	if session.InfoMsg.ChatID != 0 {
+		if err := ch.handler.bot.Delete(&session.InfoMsg); err != nil {
+			customerrors.HandlerError(err, &c.Message.Chat.ID, &c.Message.ID)
+		}
+	}
+
+	newMsg, err := ch.handler.bot.Send(c.Message.Chat,
+		calendarMessages.GetCreateEventHeader(lang)+calendarMessages.SingleEventFullText(lang, &session.Event),
+		&tb.SendOptions{
+			ParseMode: tb.ModeHTML,
+			ReplyTo:   c.Message.ReplyTo,
+			ReplyMarkup: &tb.ReplyMarkup{
+				InlineKeyboard: ch.keyboards.CreateEventButtons(lang, session.Event),
+			},
+		})
+	if err != nil {
+		customerrors.HandlerError(err, &c.Message.Chat.ID, &c.Message.ID)
+		return
+	}
+
+	session.InfoMsg = utils.InitCustomEditable(newMsg.MessageSig())
+	if err := ch.setSession(session, c.Sender, c.Message.Chat); err != nil {
+		ch.sessionError(err, c.Message.Chat, &c.Message.ID)
+	}
+}
@@ -0,0 +1,188 @@
+package handlers
+
+import (
+	"github.com/calendar-bot/pkg/bots/telegram/messages"
+	"github.com/calendar-bot/pkg/bots/telegram/messages/calendarMessages"
+	"github.com/calendar-bot/pkg/customerrors"
+	"github.com/calendar-bot/pkg/types"
+	"github.com/pkg/errors"
+	tb "gopkg.in/tucnak/telebot.v2"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var errBadInvitationRule = errors.New("bad invitation rule")
+
+// HandleRules shows the rules of the user, "/rules <accept|decline> [conditions]" adds a new one
+func (ch *CalendarHandlers) HandleRules(m *tb.Message) {
+	lang := ch.lang(m.Sender)
+	if m.Chat.Type != tb.ChatPrivate {
+		_, err := ch.handler.bot.Send(m.Chat, lang.T(messages.ErrorCommandIsNotAllowedInGroupChat))
+		if err != nil {
+			customerrors.HandlerError(err, &m.Chat.ID, &m.ID)
+		}
+		return
+	}
+	if !ch.AuthMiddleware(m.Sender, m.Chat) {
+		return
+	}
+
+	telegramUserID := int64(m.Sender.ID)
+	if payload := strings.TrimSpace(m.Payload); payload != "" {
+		rule, err := parseInvitationRule(payload)
+		if err != nil {
+			_, err := ch.handler.bot.Send(m.Chat, calendarMessages.GetInvitationRuleBadFormat(lang), &tb.SendOptions{
+				ParseMode: tb.ModeHTML,
+			})
+			if err != nil {
+				customerrors.HandlerError(err, &m.Chat.ID, &m.ID)
+			}
+			return
+		}
+
+		rule.TelegramUserID = telegramUserID
+		if _, err := ch.eventUseCase.AddInvitationRule(rule); err != nil {
+			customerrors.HandlerError(err, &m.Chat.ID, &m.ID)
+			ch.handler.SendError(m.Chat, err)
+			return
+		}
+
+		_, err = ch.handler.bot.Send(m.Chat, lang.T(calendarMessages.InvitationRuleAdded))
+		if err != nil {
+			customerrors.HandlerError(err, &m.Chat.ID, &m.ID)
+		}
+	}
+
+	rules, err := ch.eventUseCase.GetInvitationRules(telegramUserID)
+	if err != nil {
+		customerrors.HandlerError(err, &m.Chat.ID, &m.ID)
+		ch.handler.SendError(m.Chat, err)
+		return
+	}
+
+	_, err = ch.handler.bot.Send(m.Chat, calendarMessages.GetInvitationRulesText(lang, rules), &tb.SendOptions{
+		ParseMode: tb.ModeHTML,
+		ReplyMarkup: &tb.ReplyMarkup{
+			InlineKeyboard: ch.keyboards.InvitationRulesButtons(lang, rules),
+		},
+	})
+	if err != nil {
+		customerrors.HandlerError(err, &m.Chat.ID, &m.ID)
+	}
+}
+
+func (ch *CalendarHandlers) HandleInvitationRuleDelete(c *tb.Callback) {
+	lang := ch.lang(c.Sender)
+	err := ch.handler.bot.Respond(c, &tb.CallbackResponse{
+		CallbackID: c.ID,
+		Text:       lang.T(calendarMessages.InvitationRuleDeleted),
+	})
+	if err != nil {
+		customerrors.HandlerError(err, &c.Message.Chat.ID, &c.Message.ID)
+	}
+	if !ch.AuthMiddleware(c.Sender, c.Message.Chat) {
+		return
+	}
+
+	ruleID, err := strconv.ParseInt(c.Data, 10, 64)
+	if err != nil {
+		customerrors.HandlerError(err, &c.Message.Chat.ID, &c.Message.ID)
+		return
+	}
+
+	telegramUserID := int64(c.Sender.ID)
+	if err := ch.eventUseCase.DeleteInvitationRule(telegramUserID, ruleID); err != nil {
+		customerrors.HandlerError(err, &c.Message.Chat.ID, &c.Message.ID)
+		ch.handler.SendError(c.Message.Chat, err)
+		return
+	}
+
+	rules, err := ch.eventUseCase.GetInvitationRules(telegramUserID)
+	if err != nil {
+		customerrors.HandlerError(err, &c.Message.Chat.ID, &c.Message.ID)
+		ch.handler.SendError(c.Message.Chat, err)
+		return
+	}
+
+	_, err = ch.handler.bot.Edit(c.Message, calendarMessages.GetInvitationRulesText(lang, rules), &tb.SendOptions{
+		ParseMode: tb.ModeHTML,
+		ReplyMarkup: &tb.ReplyMarkup{
+			InlineKeyboard: ch.keyboards.InvitationRulesButtons(lang, rules),
+		},
+	})
+	if err != nil {
+		customerrors.HandlerError(err, &c.Message.Chat.ID, &c.Message.ID)
+	}
+}
+
+// parseInvitationRule parses "<accept|decline> [from <email>] [calendar <title>] [after HH:MM] [before HH:MM]
+// [longer <minutes>] [shorter <minutes>] [noconflict]"
+func parseInvitationRule(payload string) (types.InvitationRule, error) {
+	fields := strings.Fields(payload)
+	if len(fields) == 0 {
+		return types.InvitationRule{}, errBadInvitationRule
+	}
+
+	var rule types.InvitationRule
+	switch strings.ToLower(fields[0]) {
+	case "accept":
+		rule.Status = types.StatusAccepted
+	case "decline":
+		rule.Status = types.StatusDeclined
+	default:
+		return types.InvitationRule{}, errBadInvitationRule
+	}
+
+	for i := 1; i < len(fields); i++ {
+		keyword := strings.ToLower(fields[i])
+		if keyword == "noconflict" {
+			rule.NoConflict = true
+			continue
+		}
+
+		if i+1 >= len(fields) {
+			return types.InvitationRule{}, errBadInvitationRule
+		}
+		i++
+		value := fields[i]
+
+		var err error
+		switch keyword {
+		case "from":
+			rule.Organizer = value
+		case "calendar":
+			rule.Calendar = value
+		case "after":
+			rule.StartsAfter, err = parseTimeOfDay(value)
+		case "before":
+			rule.EndsBefore, err = parseTimeOfDay(value)
+		case "longer":
+			rule.MinDuration, err = parseMinutes(value)
+		case "shorter":
+			rule.MaxDuration, err = parseMinutes(value)
+		default:
+			err = errBadInvitationRule
+		}
+		if err != nil {
+			return types.InvitationRule{}, err
+		}
+	}
+	return rule, nil
+}
+
+func parseTimeOfDay(value string) (time.Duration, error) {
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, errBadInvitationRule
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+func parseMinutes(value string) (time.Duration, error) {
+	minutes, err := strconv.Atoi(value)
+	if err != nil || minutes <= 0 {
+		return 0, errBadInvitationRule
+	}
+	return time.Duration(minutes) * time.Minute, nil
+}
@@ -0,0 +1,157 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"github.com/calendar-bot/pkg/customerrors"
+	"github.com/calendar-bot/pkg/i18n"
+	"github.com/calendar-bot/pkg/types"
+	uUseCase "github.com/calendar-bot/pkg/users/usecase"
+	"github.com/go-redis/redis/v8"
+	"github.com/pkg/errors"
+	tb "gopkg.in/tucnak/telebot.v2"
+	"sync"
+	"time"
+)
+
+// legacyLanguageKeyFormat is the Redis key the language was kept in before the settings,
+// it is read until the user saves the language in the settings
+const legacyLanguageKeyFormat = "language_%d"
+
+// settingsCacheTTL bounds how long the settings changed by another instance of the bot
+// are not seen by the background jobs, the settings of the sender are reloaded on every update
+const settingsCacheTTL = time.Minute
+
+type cachedSettings struct {
+	settings types.UserSettings
+	loadedAt time.Time
+}
+
+// SettingsCache loads the settings of the sender once per update, the handlers of the update read them from it
+type SettingsCache struct {
+	userUseCase uUseCase.UserUseCase
+	redisDB     *redis.Client
+
+	mu        sync.Mutex
+	settings  map[int64]cachedSettings
+	lastSweep time.Time
+}
+
+func NewSettingsCache(userUC uUseCase.UserUseCase, redis *redis.Client) *SettingsCache {
+	return &SettingsCache{userUseCase: userUC, redisDB: redis, settings: map[int64]cachedSettings{}}
+}
+
+// LoadForUpdate is the poller middleware which reloads the settings of the sender of the update
+func (sc *SettingsCache) LoadForUpdate(upd *tb.Update) bool {
+	var sender *tb.User
+	switch {
+	case upd.Message != nil:
+		sender = upd.Message.Sender
+	case upd.Callback != nil:
+		sender = upd.Callback.Sender
+	case upd.Query != nil:
+		sender = &upd.Query.From
+	case upd.ChosenInlineResult != nil:
+		sender = &upd.ChosenInlineResult.From
+	}
+	if sender != nil {
+		sc.load(int64(sender.ID))
+	}
+	return true
+}
+
+// Get returns the settings of the user, the default ones if they can not be read
+func (sc *SettingsCache) Get(telegramUserID int64) types.UserSettings {
+	sc.mu.Lock()
+	cached, ok := sc.settings[telegramUserID]
+	sc.mu.Unlock()
+	if ok && time.Since(cached.loadedAt) < settingsCacheTTL {
+		return cached.settings
+	}
+	return sc.load(telegramUserID)
+}
+
+// Update saves the settings of the user changed by change, nothing is saved if change fails
+func (sc *SettingsCache) Update(telegramUserID int64, change func(settings *types.UserSettings) error) error {
+	settings, err := sc.userUseCase.GetUserSettings(telegramUserID)
+	if err != nil {
+		return err
+	}
+	if err := change(&settings); err != nil {
+		return err
+	}
+	if err := sc.userUseCase.SetUserSettings(settings); err != nil {
+		return err
+	}
+	if settings.Language != "" {
+		sc.forgetLegacyLanguage(telegramUserID)
+	}
+	sc.store(telegramUserID, sc.withLegacyLanguage(settings))
+	return nil
+}
+
+// Reset goes back to the default settings, the language kept in Redis is dropped as well
+func (sc *SettingsCache) Reset(telegramUserID int64) error {
+	if err := sc.userUseCase.ResetUserSettings(telegramUserID); err != nil {
+		return err
+	}
+	sc.forgetLegacyLanguage(telegramUserID)
+	sc.load(telegramUserID)
+	return nil
+}
+
+func (sc *SettingsCache) load(telegramUserID int64) types.UserSettings {
+	settings, err := sc.userUseCase.GetUserSettings(telegramUserID)
+	if err != nil {
+		customerrors.HandlerError(err, nil, nil)
+		return sc.userUseCase.DefaultUserSettings(telegramUserID)
+	}
+	settings = sc.withLegacyLanguage(settings)
+	sc.store(telegramUserID, settings)
+	return settings
+}
+
+func (sc *SettingsCache) store(telegramUserID int64, settings types.UserSettings) {
+	now := time.Now()
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	sc.settings[telegramUserID] = cachedSettings{settings: settings, loadedAt: now}
+	if now.Sub(sc.lastSweep) < settingsCacheTTL {
+		return
+	}
+	for id, cached := range sc.settings {
+		if now.Sub(cached.loadedAt) >= settingsCacheTTL {
+			delete(sc.settings, id)
+		}
+	}
+	sc.lastSweep = now
+}
+
+// withLegacyLanguage fills the language chosen before the settings appeared, if the user has not saved one since then
+func (sc *SettingsCache) withLegacyLanguage(settings types.UserSettings) types.UserSettings {
+	if settings.Language != "" || sc.redisDB == nil {
+		return settings
+	}
+	code, err := sc.redisDB.Get(context.TODO(), fmt.Sprintf(legacyLanguageKeyFormat, settings.TelegramUserID)).Result()
+	if err != nil {
+		if err != redis.Nil {
+			customerrors.HandlerError(errors.Wrapf(err, "failed to get language of user %d",
+				settings.TelegramUserID), nil, nil)
+		}
+		return settings
+	}
+	if lang, ok := i18n.Parse(code); ok {
+		settings.Language = string(lang)
+	}
+	return settings
+}
+
+func (sc *SettingsCache) forgetLegacyLanguage(telegramUserID int64) {
+	if sc.redisDB == nil {
+		return
+	}
+	err := sc.redisDB.Del(context.TODO(), fmt.Sprintf(legacyLanguageKeyFormat, telegramUserID)).Err()
+	if err != nil {
+		customerrors.HandlerError(errors.Wrapf(err, "failed to delete language of user %d", telegramUserID), nil, nil)
+	}
+}
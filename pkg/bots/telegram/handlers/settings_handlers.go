@@ -0,0 +1,227 @@
+package handlers
+
+import (
+	"github.com/calendar-bot/pkg/bots/telegram"
+	"github.com/calendar-bot/pkg/bots/telegram/messages"
+	"github.com/calendar-bot/pkg/bots/telegram/messages/calendarMessages"
+	"github.com/calendar-bot/pkg/customerrors"
+	eUseCase "github.com/calendar-bot/pkg/events/usecase"
+	"github.com/calendar-bot/pkg/i18n"
+	"github.com/calendar-bot/pkg/types"
+	uUseCase "github.com/calendar-bot/pkg/users/usecase"
+	"github.com/pkg/errors"
+	tb "gopkg.in/tucnak/telebot.v2"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// HandleSettings shows the settings of the user with the buttons to change them
+func (ch *CalendarHandlers) HandleSettings(m *tb.Message) {
+	lang := ch.lang(m.Sender)
+	if m.Chat.Type != tb.ChatPrivate {
+		_, err := ch.handler.bot.Send(m.Chat, lang.T(messages.ErrorCommandIsNotAllowedInGroupChat))
+		if err != nil {
+			customerrors.HandlerError(err, &m.Chat.ID, &m.ID)
+		}
+		return
+	}
+
+	settings := ch.handler.settings(int64(m.Sender.ID))
+	_, err := ch.handler.bot.Send(m.Chat, ch.settingsText(lang, settings, m.Sender), &tb.SendOptions{
+		ParseMode: tb.ModeHTML,
+		ReplyMarkup: &tb.ReplyMarkup{
+			InlineKeyboard: ch.keyboards.SettingsButtons(lang, settings),
+		},
+	})
+	if err != nil {
+		customerrors.HandlerError(err, &m.Chat.ID, &m.ID)
+	}
+}
+
+// HandleSettingsOpen shows the values of the option, the empty option goes back to the list of settings
+func (ch *CalendarHandlers) HandleSettingsOpen(c *tb.Callback) {
+	lang := ch.lang(c.Sender)
+	settings := ch.handler.settings(int64(c.Sender.ID))
+	if c.Data == "" {
+		ch.respondSettings(c, "")
+		ch.editSettings(c, lang, settings)
+		return
+	}
+
+	var calendars []types.Calendar
+	if c.Data == telegram.SettingCalendar {
+		if !ch.AuthMiddleware(c.Sender, c.Message.Chat) {
+			return
+		}
+		var err error
+		calendars, err = ch.bindableCalendars(c.Sender)
+		if err != nil {
+			customerrors.HandlerError(err, &c.Message.Chat.ID, &c.Message.ID)
+			ch.handler.SendError(c.Message.Chat, err)
+			return
+		}
+	}
+
+	ch.respondSettings(c, "")
+	_, err := ch.handler.bot.Edit(c.Message, calendarMessages.GetSettingsChooseText(lang, c.Data), &tb.SendOptions{
+		ParseMode: tb.ModeHTML,
+		ReplyMarkup: &tb.ReplyMarkup{
+			InlineKeyboard: ch.keyboards.SettingsOptionButtons(lang, c.Data, settings, calendars, time.Now()),
+		},
+	})
+	if err != nil {
+		customerrors.HandlerError(err, &c.Message.Chat.ID, &c.Message.ID)
+	}
+}
+
+// HandleSettingsSet saves the chosen value of the option, data is "option|value"
+func (ch *CalendarHandlers) HandleSettingsSet(c *tb.Callback) {
+	lang := ch.lang(c.Sender)
+	data := strings.SplitN(c.Data, "|", 2)
+	if len(data) != 2 {
+		customerrors.HandlerError(errors.Errorf("bad settings data %q", c.Data), &c.Message.Chat.ID, &c.Message.ID)
+		return
+	}
+	option, value := data[0], data[1]
+
+	if option == telegram.SettingCalendar && value != "" {
+		if !ch.AuthMiddleware(c.Sender, c.Message.Chat) {
+			return
+		}
+		calendars, err := ch.bindableCalendars(c.Sender)
+		if err != nil {
+			customerrors.HandlerError(err, &c.Message.Chat.ID, &c.Message.ID)
+			ch.handler.SendError(c.Message.Chat, err)
+			return
+		}
+		if eUseCase.FindCalendar(calendars, value) == nil {
+			ch.respondAlert(c, lang.T(calendarMessages.BindCalendarNotFound))
+			return
+		}
+	}
+
+	err := ch.handler.updateSettings(int64(c.Sender.ID), func(settings *types.UserSettings) error {
+		return applySetting(settings, option, value)
+	})
+	switch errors.Cause(err).(type) {
+	case nil:
+	case uUseCase.SettingsError:
+		ch.respondAlert(c, lang.T(calendarMessages.SettingsBadValue))
+		return
+	default:
+		customerrors.HandlerError(err, &c.Message.Chat.ID, &c.Message.ID)
+		ch.handler.SendError(c.Message.Chat, err)
+		return
+	}
+
+	// the language may have changed just now
+	lang = ch.lang(c.Sender)
+	ch.respondSettings(c, lang.T(calendarMessages.SettingsSaved))
+	ch.editSettings(c, lang, ch.handler.settings(int64(c.Sender.ID)))
+}
+
+// HandleSettingsReset goes back to the default settings
+func (ch *CalendarHandlers) HandleSettingsReset(c *tb.Callback) {
+	if err := ch.handler.settingsCache.Reset(int64(c.Sender.ID)); err != nil {
+		customerrors.HandlerError(err, &c.Message.Chat.ID, &c.Message.ID)
+		ch.handler.SendError(c.Message.Chat, err)
+		return
+	}
+
+	lang := ch.lang(c.Sender)
+	ch.respondSettings(c, lang.T(calendarMessages.SettingsWasReset))
+	ch.editSettings(c, lang, ch.handler.settings(int64(c.Sender.ID)))
+}
+
+// applySetting changes the option of the settings, the values are validated when the settings are saved
+func applySetting(settings *types.UserSettings, option string, value string) error {
+	minutes := func() (time.Duration, error) {
+		m, err := strconv.Atoi(value)
+		if err != nil {
+			return 0, errors.Wrapf(err, "bad minutes of setting %s", option)
+		}
+		return time.Duration(m) * time.Minute, nil
+	}
+
+	var err error
+	switch option {
+	case telegram.SettingTimezone:
+		settings.Timezone = value
+	case telegram.SettingLanguage:
+		settings.Language = value
+	case telegram.SettingEventDuration:
+		settings.EventDuration, err = minutes()
+	case telegram.SettingCalendar:
+		settings.CalendarUID = value
+	case telegram.SettingReminder:
+		settings.ReminderLeadTime, err = minutes()
+	case telegram.SettingDigest:
+		settings.DigestTime, err = minutes()
+	case telegram.SettingImageMode:
+		settings.ImageMode, err = strconv.ParseBool(value)
+	default:
+		err = errors.Errorf("unknown setting %q", option)
+	}
+	return err
+}
+
+func (ch *CalendarHandlers) respondSettings(c *tb.Callback, text string) {
+	err := ch.handler.bot.Respond(c, &tb.CallbackResponse{
+		CallbackID: c.ID,
+		Text:       text,
+	})
+	if err != nil {
+		customerrors.HandlerError(err, &c.Message.Chat.ID, &c.Message.ID)
+	}
+}
+
+func (ch *CalendarHandlers) editSettings(c *tb.Callback, lang i18n.Lang, settings types.UserSettings) {
+	_, err := ch.handler.bot.Edit(c.Message, ch.settingsText(lang, settings, c.Sender), &tb.SendOptions{
+		ParseMode: tb.ModeHTML,
+		ReplyMarkup: &tb.ReplyMarkup{
+			InlineKeyboard: ch.keyboards.SettingsButtons(lang, settings),
+		},
+	})
+	if err != nil {
+		customerrors.HandlerError(err, &c.Message.Chat.ID, &c.Message.ID)
+	}
+}
+
+// settingsText shows the title of the calendar for new events, its UID if the calendar is not found
+func (ch *CalendarHandlers) settingsText(lang i18n.Lang, settings types.UserSettings, u *tb.User) string {
+	calendarTitle := settings.CalendarUID
+	if settings.CalendarUID != "" {
+		calendars, err := ch.bindableCalendars(u)
+		if err != nil {
+			customerrors.HandlerError(err, nil, nil)
+		} else if calendar := eUseCase.FindCalendar(calendars, settings.CalendarUID); calendar != nil {
+			calendarTitle = calendar.Title
+		}
+	}
+	return calendarMessages.GetSettingsText(lang, settings, calendarTitle, time.Now())
+}
+
+// userSettings are the settings of the user, the default ones if they can not be read
+func (ch *CalendarHandlers) userSettings(u *tb.User) types.UserSettings {
+	return ch.handler.settings(int64(u.ID))
+}
+
+func (h *Handler) settings(telegramUserID int64) types.UserSettings {
+	return h.settingsCache.Get(telegramUserID)
+}
+
+// updateSettings saves the settings of the user changed by change, nothing is saved if change fails
+func (h *Handler) updateSettings(telegramUserID int64, change func(settings *types.UserSettings) error) error {
+	return h.settingsCache.Update(telegramUserID, change)
+}
+
+// settingsLocation is the timezone of the user, the local one if it is unknown
+func settingsLocation(settings types.UserSettings) *time.Location {
+	location, err := time.LoadLocation(settings.Timezone)
+	if err != nil {
+		customerrors.HandlerError(err, nil, nil)
+		return time.Local
+	}
+	return location
+}
@@ -0,0 +1,214 @@
+package handlers
+
+import (
+	"context"
+	"github.com/calendar-bot/pkg/bots/telegram"
+	"github.com/calendar-bot/pkg/bots/telegram/inline_keyboards/calendarInlineKeyboards"
+	"github.com/calendar-bot/pkg/bots/telegram/messages/calendarMessages"
+	"github.com/calendar-bot/pkg/customerrors"
+	"github.com/calendar-bot/pkg/types"
+	"github.com/pkg/errors"
+	tb "gopkg.in/tucnak/telebot.v2"
+	"strconv"
+	"strings"
+)
+
+// HandleShareEvent shows groups of the organizer to post the expanded event into
+func (ch *CalendarHandlers) HandleShareEvent(c *tb.Callback) {
+	lang := ch.lang(c.Sender)
+	if !ch.AuthMiddleware(c.Sender, c.Message.Chat) {
+		return
+	}
+	event := ch.getEventByIdForCallback(c, c.Sender.ID)
+	if event == nil {
+		return
+	}
+	if !ch.isEventOrganizer(c, event) {
+		return
+	}
+
+	groups, err := ch.userUseCase.GetUserGroups(int64(c.Sender.ID))
+	if err != nil {
+		customerrors.HandlerError(err, &c.Message.Chat.ID, &c.Message.ID)
+		ch.handler.SendError(c.Message.Chat, err)
+		return
+	}
+	if len(groups) == 0 {
+		ch.respondAlert(c, lang.T(calendarMessages.ShareEventNoGroups))
+		return
+	}
+
+	err = ch.handler.bot.Respond(c, &tb.CallbackResponse{
+		CallbackID: c.ID,
+	})
+	if err != nil {
+		customerrors.HandlerError(err, &c.Message.Chat.ID, &c.Message.ID)
+	}
+
+	_, err = ch.handler.bot.EditReplyMarkup(c.Message, &tb.ReplyMarkup{
+		InlineKeyboard: ch.keyboards.ShareEventGroupsButtons(lang, event, groups),
+	})
+	if err != nil {
+		customerrors.HandlerError(err, &c.Message.Chat.ID, &c.Message.ID)
+	}
+}
+
+// HandleShareEventGroup posts the event into the chosen group with the go/not go buttons,
+// answers in the group add attendees on behalf of the organizer
+func (ch *CalendarHandlers) HandleShareEventGroup(c *tb.Callback) {
+	lang := ch.lang(c.Sender)
+	if !ch.AuthMiddleware(c.Sender, c.Message.Chat) {
+		return
+	}
+
+	data := strings.Split(c.Data, "|")
+	if len(data) != 2 {
+		customerrors.HandlerError(errors.Errorf("bad share event data %q", c.Data), &c.Message.Chat.ID, &c.Message.ID)
+		return
+	}
+	chatID, err := strconv.ParseInt(data[1], 10, 64)
+	if err != nil {
+		customerrors.HandlerError(err, &c.Message.Chat.ID, &c.Message.ID)
+		return
+	}
+
+	telegramUserID := int64(c.Sender.ID)
+	group, err := ch.userUseCase.GetUserGroup(telegramUserID, chatID)
+	if err != nil {
+		customerrors.HandlerError(err, &c.Message.Chat.ID, &c.Message.ID)
+		ch.handler.SendError(c.Message.Chat, err)
+		return
+	}
+	if group == nil || !ch.isGroupMember(c, *group) {
+		ch.respondAlert(c, lang.T(calendarMessages.ShareEventNoAccess))
+		return
+	}
+
+	c.Data = data[0]
+	event := ch.getEventByIdForCallback(c, c.Sender.ID)
+	if event == nil {
+		return
+	}
+	if !ch.isEventOrganizer(c, event) {
+		return
+	}
+
+	keyboard, err := ch.keyboards.GroupChatButtons(lang, event, &ch.callbackStore, c.Sender.ID)
+	if err != nil {
+		customerrors.HandlerError(err, &c.Message.Chat.ID, &c.Message.ID)
+		ch.handler.SendError(c.Message.Chat, err)
+		return
+	}
+	msg, err := ch.handler.bot.Send(&tb.Chat{ID: group.ChatID}, calendarMessages.SingleEventFullText(lang, event),
+		&tb.SendOptions{
+			ParseMode: tb.ModeHTML,
+			ReplyMarkup: &tb.ReplyMarkup{
+				InlineKeyboard: calendarInlineKeyboards.WithCallButton(lang, event, keyboard),
+			},
+		})
+	if err != nil {
+		customerrors.HandlerError(err, &c.Message.Chat.ID, &c.Message.ID)
+		if isBotRemovedError(err) {
+			ch.forgetGroup(group.ChatID)
+			ch.respondAlert(c, lang.T(calendarMessages.ShareEventNoAccess))
+			return
+		}
+		ch.handler.SendError(c.Message.Chat, err)
+		return
+	}
+	ch.trackPostedMessage(msg, event, c.Sender.ID, telegram.PostedMessageGroup)
+	ch.replyVacations(lang, msg, event, c.Sender.ID)
+
+	err = ch.handler.bot.Respond(c, &tb.CallbackResponse{
+		CallbackID: c.ID,
+		Text:       calendarMessages.GetShareEventSharedText(lang, *group),
+	})
+	if err != nil {
+		customerrors.HandlerError(err, &c.Message.Chat.ID, &c.Message.ID)
+	}
+
+	_, err = ch.handler.bot.EditReplyMarkup(c.Message, &tb.ReplyMarkup{
+		InlineKeyboard: ch.keyboards.EventShowLessInlineKeyboard(lang, event, true),
+	})
+	if err != nil {
+		customerrors.HandlerError(err, &c.Message.Chat.ID, &c.Message.ID)
+	}
+}
+
+// rememberUserGroup records that the authenticated user and the bot are both in the group chat
+func (ch *CalendarHandlers) rememberUserGroup(u *tb.User, c *tb.Chat) {
+	if c.Type != tb.ChatGroup && c.Type != tb.ChatSuperGroup {
+		return
+	}
+	err := ch.userUseCase.AddUserGroup(types.UserGroup{
+		TelegramUserID: int64(u.ID),
+		ChatID:         c.ID,
+		Title:          c.Title,
+	})
+	if err != nil {
+		customerrors.HandlerError(err, &c.ID, nil)
+	}
+}
+
+// forgetGroup is called when the bot is not in the group anymore
+func (ch *CalendarHandlers) forgetGroup(chatID int64) {
+	if err := ch.userUseCase.DeleteGroup(chatID); err != nil {
+		customerrors.HandlerError(err, &chatID, nil)
+	}
+	if err := ch.userUseCase.DeleteChatMembers(chatID); err != nil {
+		customerrors.HandlerError(err, &chatID, nil)
+	}
+	if err := ch.redisDB.Del(context.TODO(), seenChatMembersKey(chatID)).Err(); err != nil {
+		customerrors.HandlerError(err, &chatID, nil)
+	}
+}
+
+// isGroupMember checks in Telegram that the user is still in the group, the group is forgotten if not
+func (ch *CalendarHandlers) isGroupMember(c *tb.Callback, group types.UserGroup) bool {
+	member, err := ch.handler.bot.ChatMemberOf(&tb.Chat{ID: group.ChatID}, c.Sender)
+	switch {
+	case err != nil && isBotRemovedError(err):
+		ch.forgetGroup(group.ChatID)
+		return false
+	case err != nil:
+		customerrors.HandlerError(err, &c.Message.Chat.ID, &c.Message.ID)
+		return false
+	case member.Role == tb.Left || member.Role == tb.Kicked:
+		if err := ch.userUseCase.DeleteUserGroup(group.TelegramUserID, group.ChatID); err != nil {
+			customerrors.HandlerError(err, &c.Message.Chat.ID, &c.Message.ID)
+		}
+		return false
+	}
+	return true
+}
+
+// isEventOrganizer answers the callback with an alert if the sender is not the organizer of the event
+func (ch *CalendarHandlers) isEventOrganizer(c *tb.Callback, event *types.Event) bool {
+	lang := ch.lang(c.Sender)
+	email, err := ch.userUseCase.GetUserEmailByTelegramUserID(int64(c.Sender.ID))
+	if err != nil {
+		customerrors.HandlerError(err, &c.Message.Chat.ID, &c.Message.ID)
+		ch.handler.SendError(c.Message.Chat, err)
+		return false
+	}
+	if !strings.EqualFold(email, event.Organizer.Email) {
+		ch.respondAlert(c, lang.T(calendarMessages.ShareEventNotAllowed))
+		return false
+	}
+	return true
+}
+
+func (ch *CalendarHandlers) respondAlert(c *tb.Callback, text string) {
+	err := ch.handler.bot.Respond(c, &tb.CallbackResponse{
+		CallbackID: c.ID,
+		Text:       text,
+		ShowAlert:  true,
+	})
+	if err != nil {
+		customerrors.HandlerError(err, &c.Message.Chat.ID, &c.Message.ID)
+	}
+}
+
+func isBotRemovedError(err error) bool {
+	return err == tb.ErrBotKickedFromGroup || err == tb.ErrBotKickedFromSuperGroup || err == tb.ErrChatNotFound
+}
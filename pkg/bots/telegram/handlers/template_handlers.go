@@ -0,0 +1,253 @@
+package handlers
+
+import (
+	"github.com/calendar-bot/pkg/bots/telegram/keyboards/calendarKeyboards"
+	"github.com/calendar-bot/pkg/bots/telegram/messages"
+	"github.com/calendar-bot/pkg/bots/telegram/messages/calendarMessages"
+	"github.com/calendar-bot/pkg/bots/telegram/wizard"
+	"github.com/calendar-bot/pkg/customerrors"
+	eUseCase "github.com/calendar-bot/pkg/events/usecase"
+	"github.com/calendar-bot/pkg/services/sessions"
+	"github.com/calendar-bot/pkg/types"
+	tb "gopkg.in/tucnak/telebot.v2"
+	"strconv"
+)
+
+// HandleTemplates lists templates of the user with buttons to create an event from them or to delete them
+func (ch *CalendarHandlers) HandleTemplates(m *tb.Message) {
+	lang := ch.lang(m.Sender)
+	if m.Chat.Type != tb.ChatPrivate {
+		_, err := ch.handler.bot.Send(m.Chat, lang.T(messages.ErrorCommandIsNotAllowedInGroupChat))
+		if err != nil {
+			customerrors.HandlerError(err, &m.Chat.ID, &m.ID)
+		}
+		return
+	}
+	if !ch.AuthMiddleware(m.Sender, m.Chat) {
+		return
+	}
+
+	templates, err := ch.eventUseCase.GetEventTemplates(int64(m.Sender.ID))
+	if err != nil {
+		customerrors.HandlerError(err, &m.Chat.ID, &m.ID)
+		ch.handler.SendError(m.Chat, err)
+		return
+	}
+
+	_, err = ch.handler.bot.Send(m.Chat, calendarMessages.GetTemplatesText(lang, templates), &tb.SendOptions{
+		ParseMode: tb.ModeHTML,
+		ReplyMarkup: &tb.ReplyMarkup{
+			InlineKeyboard: ch.keyboards.TemplatesButtons(lang, templates),
+		},
+	})
+	if err != nil {
+		customerrors.HandlerError(err, &m.Chat.ID, &m.ID)
+	}
+}
+
+// HandleTemplateSave saves the event created last in the chat as a template
+func (ch *CalendarHandlers) HandleTemplateSave(c *tb.Callback) {
+	lang := ch.lang(c.Sender)
+	if c.Message.ReplyTo != nil && c.Sender.ID != c.Message.ReplyTo.Sender.ID {
+		err := ch.handler.bot.Respond(c, &tb.CallbackResponse{
+			CallbackID: c.ID,
+			Text:       calendarMessages.GetUserNotAllow(lang),
+			ShowAlert:  true,
+		})
+		if err != nil {
+			customerrors.HandlerError(err, &c.Message.Chat.ID, &c.Message.ID)
+		}
+		return
+	}
+
+	session, err := ch.getSession(c.Sender, c.Message.Chat)
+	if err != nil {
+		return
+	}
+	if session.LastCreated == nil || session.LastCreated.Uid != c.Data {
+		err := ch.handler.bot.Respond(c, &tb.CallbackResponse{
+			CallbackID: c.ID,
+			Text:       lang.T(calendarMessages.TemplateSaveOutdated),
+			ShowAlert:  true,
+		})
+		if err != nil {
+			customerrors.HandlerError(err, &c.Message.Chat.ID, &c.Message.ID)
+		}
+		return
+	}
+
+	template := eUseCase.TemplateFromEvent(*session.LastCreated)
+	template.TelegramUserID = int64(c.Sender.ID)
+	if _, err := ch.eventUseCase.AddEventTemplate(template); err != nil {
+		customerrors.HandlerError(err, &c.Message.Chat.ID, &c.Message.ID)
+		ch.handler.SendError(c.Message.Chat, err)
+		return
+	}
+
+	err = ch.handler.bot.Respond(c, &tb.CallbackResponse{
+		CallbackID: c.ID,
+		Text:       lang.T(calendarMessages.TemplateSaved),
+	})
+	if err != nil {
+		customerrors.HandlerError(err, &c.Message.Chat.ID, &c.Message.ID)
+	}
+
+	_, err = ch.handler.bot.Edit(c.Message, calendarMessages.GetTemplateSavedText(lang, template), &tb.SendOptions{
+		ParseMode: tb.ModeHTML,
+	})
+	if err != nil {
+		customerrors.HandlerError(err, &c.Message.Chat.ID, &c.Message.ID)
+	}
+
+	session.LastCreated = nil
+	if err := ch.setSession(session, c.Sender, c.Message.Chat); err != nil {
+		ch.sessionError(err, c.Message.Chat, &c.Message.ID)
+	}
+}
+
+// HandleTemplateApply starts the create wizard filled with the template, only the time is left to pick
+func (ch *CalendarHandlers) HandleTemplateApply(c *tb.Callback) {
+	lang := ch.lang(c.Sender)
+	if !ch.AuthMiddleware(c.Sender, c.Message.Chat) {
+		return
+	}
+
+	templateID, err := strconv.ParseInt(c.Data, 10, 64)
+	if err != nil {
+		customerrors.HandlerError(err, &c.Message.Chat.ID, &c.Message.ID)
+		return
+	}
+
+	telegramUserID := int64(c.Sender.ID)
+	template, err := ch.eventUseCase.GetEventTemplate(telegramUserID, templateID)
+	if err != nil {
+		customerrors.HandlerError(err, &c.Message.Chat.ID, &c.Message.ID)
+		ch.handler.SendError(c.Message.Chat, err)
+		return
+	}
+	if template == nil {
+		err := ch.handler.bot.Respond(c, &tb.CallbackResponse{
+			CallbackID: c.ID,
+			Text:       lang.T(calendarMessages.TemplateNotFound),
+			ShowAlert:  true,
+		})
+		if err != nil {
+			customerrors.HandlerError(err, &c.Message.Chat.ID, &c.Message.ID)
+		}
+		return
+	}
+
+	err = ch.handler.bot.Respond(c, &tb.CallbackResponse{
+		CallbackID: c.ID,
+		Text:       lang.T(calendarMessages.TemplateApplied),
+	})
+	if err != nil {
+		customerrors.HandlerError(err, &c.Message.Chat.ID, &c.Message.ID)
+	}
+
+	token, err := ch.userUseCase.GetOrRefreshOAuthAccessTokenByTelegramUserID(telegramUserID)
+	if err != nil {
+		customerrors.HandlerError(err, &c.Message.Chat.ID, &c.Message.ID)
+		ch.handler.SendError(c.Message.Chat, err)
+		return
+	}
+	userInfo, err := ch.userUseCase.GetMailruUserInfo(token)
+	if err != nil {
+		customerrors.HandlerError(err, &c.Message.Chat.ID, &c.Message.ID)
+		ch.handler.SendError(c.Message.Chat, err)
+		return
+	}
+
+	event := eUseCase.EventFromTemplate(*template)
+	if template.WithCall {
+		event.Call, err = ch.eventUseCase.MailCallLink(token)
+		if err != nil {
+			customerrors.HandlerError(err, &c.Message.Chat.ID, &c.Message.ID)
+			ch.handler.SendError(c.Message.Chat, err)
+			return
+		}
+	}
+	event.Organizer = types.AttendeeEvent{
+		Email:  userInfo.Email,
+		Name:   userInfo.Name,
+		Role:   types.RoleRequired,
+		Status: types.StatusAccepted,
+	}
+	event.Attendees = append(types.AttendeesEvent{event.Organizer}, event.Attendees...)
+
+	previous, err := ch.getSession(c.Sender, c.Message.Chat)
+	if err != nil {
+		return
+	}
+	if previous.InfoMsg.ChatID != 0 {
+		if err := ch.handler.bot.Delete(&previous.InfoMsg); err != nil {
+			customerrors.HandlerError(err, &c.Message.Chat.ID, &c.Message.ID)
+		}
+	}
+
+	session := sessions.Reset(previous)
+	if !ch.fire(session, wizard.StartCreate, c.Message.Chat) {
+		return
+	}
+	session.FromTextCreate = true
+	session.Event = event
+	session.TemplateDuration = template.Duration
+
+	_, err = ch.handler.bot.Send(c.Message.Chat, calendarMessages.GetTemplateAppliedText(lang, *template), &tb.SendOptions{
+		ParseMode: tb.ModeHTML,
+		ReplyMarkup: &tb.ReplyMarkup{
+			ReplyKeyboard: calendarKeyboards.GetCreateFastCommand(lang),
+		},
+	})
+	if err != nil {
+		customerrors.HandlerError(err, &c.Message.Chat.ID, &c.Message.ID)
+	}
+
+	if err := ch.setSession(session, c.Sender, c.Message.Chat); err != nil {
+		ch.sessionError(err, c.Message.Chat, &c.Message.ID)
+	}
+}
+
+func (ch *CalendarHandlers) HandleTemplateDelete(c *tb.Callback) {
+	lang := ch.lang(c.Sender)
+	err := ch.handler.bot.Respond(c, &tb.CallbackResponse{
+		CallbackID: c.ID,
+		Text:       lang.T(calendarMessages.TemplateDeleted),
+	})
+	if err != nil {
+		customerrors.HandlerError(err, &c.Message.Chat.ID, &c.Message.ID)
+	}
+	if !ch.AuthMiddleware(c.Sender, c.Message.Chat) {
+		return
+	}
+
+	templateID, err := strconv.ParseInt(c.Data, 10, 64)
+	if err != nil {
+		customerrors.HandlerError(err, &c.Message.Chat.ID, &c.Message.ID)
+		return
+	}
+
+	telegramUserID := int64(c.Sender.ID)
+	if err := ch.eventUseCase.DeleteEventTemplate(telegramUserID, templateID); err != nil {
+		customerrors.HandlerError(err, &c.Message.Chat.ID, &c.Message.ID)
+		ch.handler.SendError(c.Message.Chat, err)
+		return
+	}
+
+	templates, err := ch.eventUseCase.GetEventTemplates(telegramUserID)
+	if err != nil {
+		customerrors.HandlerError(err, &c.Message.Chat.ID, &c.Message.ID)
+		ch.handler.SendError(c.Message.Chat, err)
+		return
+	}
+
+	_, err = ch.handler.bot.Edit(c.Message, calendarMessages.GetTemplatesText(lang, templates), &tb.SendOptions{
+		ParseMode: tb.ModeHTML,
+		ReplyMarkup: &tb.ReplyMarkup{
+			InlineKeyboard: ch.keyboards.TemplatesButtons(lang, templates),
+		},
+	})
+	if err != nil {
+		customerrors.HandlerError(err, &c.Message.Chat.ID, &c.Message.ID)
+	}
+}
@@ -0,0 +1,210 @@
+package handlers
+
+import (
+	"github.com/calendar-bot/pkg/bots/telegram/messages"
+	"github.com/calendar-bot/pkg/bots/telegram/messages/calendarMessages"
+	"github.com/calendar-bot/pkg/customerrors"
+	"github.com/calendar-bot/pkg/i18n"
+	"github.com/calendar-bot/pkg/types"
+	"github.com/pkg/errors"
+	tb "gopkg.in/tucnak/telebot.v2"
+	"strings"
+	"time"
+)
+
+const vacationOffArg = "off"
+
+var errBadVacationDates = errors.New("bad vacation dates")
+
+func (ch *CalendarHandlers) HandleVacation(m *tb.Message) {
+	lang := ch.lang(m.Sender)
+	if m.Chat.Type != tb.ChatPrivate {
+		_, err := ch.handler.bot.Send(m.Chat, lang.T(messages.ErrorCommandIsNotAllowedInGroupChat))
+		if err != nil {
+			customerrors.HandlerError(err, &m.Chat.ID, &m.ID)
+		}
+		return
+	}
+	if !ch.AuthMiddleware(m.Sender, m.Chat) {
+		return
+	}
+
+	telegramUserID := int64(m.Sender.ID)
+	payload := strings.TrimSpace(m.Payload)
+	// vacation days are the days of the user's timezone
+	location := ch.userLocation(telegramUserID)
+
+	var text string
+	switch {
+	case payload == "":
+		vacation, err := ch.eventUseCase.GetVacation(telegramUserID)
+		if err != nil {
+			customerrors.HandlerError(err, &m.Chat.ID, &m.ID)
+			ch.handler.SendError(m.Chat, err)
+			return
+		}
+		if vacation != nil {
+			localVacation := vacationIn(*vacation, location)
+			vacation = &localVacation
+		}
+		text = calendarMessages.GetVacationUsage(lang, vacation)
+	case strings.EqualFold(payload, vacationOffArg):
+		vacation, err := ch.eventUseCase.GetVacation(telegramUserID)
+		if err != nil {
+			customerrors.HandlerError(err, &m.Chat.ID, &m.ID)
+			ch.handler.SendError(m.Chat, err)
+			return
+		}
+		if vacation == nil {
+			text = lang.T(calendarMessages.VacationNotSet)
+			break
+		}
+
+		token, err := ch.userUseCase.GetOrRefreshOAuthAccessTokenByTelegramUserID(telegramUserID)
+		if err != nil {
+			customerrors.HandlerError(err, &m.Chat.ID, &m.ID)
+			ch.handler.SendError(m.Chat, err)
+			return
+		}
+		if err := ch.eventUseCase.CancelVacation(token, telegramUserID); err != nil {
+			customerrors.HandlerError(err, &m.Chat.ID, &m.ID)
+			ch.handler.SendError(m.Chat, err)
+			return
+		}
+		text = lang.T(calendarMessages.VacationCancelled)
+	default:
+		vacation, err := parseVacationArgs(payload, time.Now().In(location))
+		if err != nil {
+			text = lang.T(calendarMessages.VacationBadDates)
+			break
+		}
+		vacation.TelegramUserID = telegramUserID
+
+		if err := ch.setVacation(vacation); err != nil {
+			customerrors.HandlerError(err, &m.Chat.ID, &m.ID)
+			ch.handler.SendError(m.Chat, err)
+			return
+		}
+		text = calendarMessages.GetVacationText(lang, vacation)
+	}
+
+	_, err := ch.handler.bot.Send(m.Chat, text, &tb.SendOptions{
+		ParseMode: tb.ModeHTML,
+	})
+	if err != nil {
+		customerrors.HandlerError(err, &m.Chat.ID, &m.ID)
+	}
+}
+
+// setVacation saves the vacation and declines already received invitations right away
+func (ch *CalendarHandlers) setVacation(vacation types.Vacation) error {
+	token, err := ch.userUseCase.GetOrRefreshOAuthAccessTokenByTelegramUserID(vacation.TelegramUserID)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	email, err := ch.userUseCase.GetUserEmailByTelegramUserID(vacation.TelegramUserID)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	vacation.Email = email
+
+	if err := ch.eventUseCase.SetVacation(token, vacation); err != nil {
+		return err
+	}
+	_, err = ch.eventUseCase.DeclineVacationInvitations(token, vacation, time.Now())
+	return err
+}
+
+// replyVacations tells the group which members or attendees of the posted event are on vacation at that time
+func (ch *CalendarHandlers) replyVacations(lang i18n.Lang, eventMsg *tb.Message, event *types.Event, senderID int) {
+	members, err := ch.getChatMembers(eventMsg.Chat.ID)
+	if err != nil {
+		customerrors.HandlerError(err, &eventMsg.Chat.ID, &eventMsg.ID)
+		return
+	}
+
+	telegramIDs := make([]int64, 0, len(members))
+	for _, member := range members {
+		if member != int64(senderID) {
+			telegramIDs = append(telegramIDs, member)
+		}
+	}
+	emails := make([]string, 0, len(event.Attendees))
+	for _, attendee := range event.Attendees {
+		emails = append(emails, attendee.Email)
+	}
+
+	vacations, err := ch.eventUseCase.GetVacationsOverlapping(telegramIDs, emails, event.From, event.To)
+	if err != nil {
+		customerrors.HandlerError(err, &eventMsg.Chat.ID, &eventMsg.ID)
+		return
+	}
+
+	others := make([]types.Vacation, 0, len(vacations))
+	for _, vacation := range vacations {
+		if vacation.TelegramUserID != int64(senderID) {
+			others = append(others, vacationIn(vacation, ch.userLocation(vacation.TelegramUserID)))
+		}
+	}
+	if len(others) == 0 {
+		return
+	}
+
+	_, err = ch.handler.bot.Reply(eventMsg, calendarMessages.GetVacationsGroupText(lang, others), &tb.SendOptions{
+		ParseMode: tb.ModeHTML,
+	})
+	if err != nil {
+		customerrors.HandlerError(err, &eventMsg.Chat.ID, &eventMsg.ID)
+	}
+}
+
+// vacationIn shows the vacation days in the timezone of its owner, the db returns them in UTC
+func vacationIn(vacation types.Vacation, location *time.Location) types.Vacation {
+	vacation.From = vacation.From.In(location)
+	vacation.To = vacation.To.In(location)
+	return vacation
+}
+
+// parseVacationArgs parses "<from> <to> [message]" with dates as dd.mm or dd.mm.yyyy, both days are included.
+// Dates without a year are taken in the current year, an end before the start is moved to the next year
+func parseVacationArgs(payload string, now time.Time) (types.Vacation, error) {
+	fields := strings.Fields(payload)
+	if len(fields) < 2 {
+		return types.Vacation{}, errBadVacationDates
+	}
+
+	from, _, err := parseVacationDate(fields[0], now)
+	if err != nil {
+		return types.Vacation{}, err
+	}
+	to, toHasYear, err := parseVacationDate(fields[1], now)
+	if err != nil {
+		return types.Vacation{}, err
+	}
+	if to.Before(from) && !toHasYear {
+		to = to.AddDate(1, 0, 0)
+	}
+	if to.Before(from) {
+		return types.Vacation{}, errBadVacationDates
+	}
+
+	message := strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(payload), fields[0]))
+	message = strings.TrimSpace(strings.TrimPrefix(message, fields[1]))
+
+	return types.Vacation{
+		From:    from,
+		To:      to.AddDate(0, 0, 1),
+		Message: message,
+	}, nil
+}
+
+func parseVacationDate(value string, now time.Time) (date time.Time, hasYear bool, err error) {
+	if date, err := time.ParseInLocation("02.01.2006", value, now.Location()); err == nil {
+		return date, true, nil
+	}
+	date, err = time.ParseInLocation("02.01", value, now.Location())
+	if err != nil {
+		return time.Time{}, false, errBadVacationDates
+	}
+	return time.Date(now.Year(), date.Month(), date.Day(), 0, 0, 0, 0, now.Location()), false, nil
+}
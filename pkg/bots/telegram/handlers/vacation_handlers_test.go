@@ -0,0 +1,53 @@
+package handlers
+
+import (
+	"github.com/calendar-bot/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestParseVacationArgs(t *testing.T) {
+	moscow, err := time.LoadLocation("Europe/Moscow")
+	assert.NoError(t, err)
+	now := time.Date(2021, 5, 4, 12, 0, 0, 0, moscow)
+	day := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 0, 0, 0, 0, moscow)
+	}
+
+	valid := []struct {
+		payload  string
+		expected types.Vacation
+	}{
+		{"01.06 14.06", types.Vacation{From: day(2021, 6, 1), To: day(2021, 6, 15)}},
+		{"01.06.2021 14.06.2021 On vacation, ask Bob",
+			types.Vacation{From: day(2021, 6, 1), To: day(2021, 6, 15), Message: "On vacation, ask Bob"}},
+		{"  01.06   14.06   back on Tuesday ",
+			types.Vacation{From: day(2021, 6, 1), To: day(2021, 6, 15), Message: "back on Tuesday"}},
+		{"10.05 10.05", types.Vacation{From: day(2021, 5, 10), To: day(2021, 5, 11)}},
+		{"28.12 10.01", types.Vacation{From: day(2021, 12, 28), To: day(2022, 1, 11)}},
+		{"28.12.2021 10.01.2022", types.Vacation{From: day(2021, 12, 28), To: day(2022, 1, 11)}},
+	}
+	for _, testCase := range valid {
+		vacation, err := parseVacationArgs(testCase.payload, now)
+		assert.NoError(t, err, testCase.payload)
+		assert.True(t, testCase.expected.From.Equal(vacation.From), testCase.payload)
+		assert.True(t, testCase.expected.To.Equal(vacation.To), testCase.payload)
+		assert.Equal(t, testCase.expected.Message, vacation.Message, testCase.payload)
+	}
+
+	invalid := []string{
+		"",
+		"01.06",
+		"14.06.2021 01.06.2021",
+		"14.06 01.06.2021",
+		"tomorrow 14.06",
+		"01.06 32.06",
+		"01/06 14/06",
+		"off",
+	}
+	for _, payload := range invalid {
+		_, err := parseVacationArgs(payload, now)
+		assert.Equal(t, errBadVacationDates, err, payload)
+	}
+}
@@ -2,12 +2,13 @@ package baseInlineKeyboards
 
 import (
 	"github.com/calendar-bot/pkg/bots/telegram/messages/baseMessages"
+	"github.com/calendar-bot/pkg/i18n"
 	tb "gopkg.in/tucnak/telebot.v2"
 )
 
-func StartInlineKeyboard(url string) [][]tb.InlineButton {
+func StartInlineKeyboard(lang i18n.Lang, url string) [][]tb.InlineButton {
 	inlineKeyboard := [][]tb.InlineButton{{
-		{Text: baseMessages.StartRegButtonText(), URL: url},
+		{Text: baseMessages.StartRegButtonText(lang), URL: url},
 	}}
 
 	return inlineKeyboard
@@ -1,49 +1,91 @@
 package calendarInlineKeyboards
 
 import (
-	"context"
 	"github.com/calendar-bot/pkg/bots/telegram"
 	"github.com/calendar-bot/pkg/bots/telegram/messages/calendarMessages"
+	"github.com/calendar-bot/pkg/bots/telegram/wizard"
+	eUseCase "github.com/calendar-bot/pkg/events/usecase"
+	"github.com/calendar-bot/pkg/i18n"
+	"github.com/calendar-bot/pkg/services/callbacks"
 	"github.com/calendar-bot/pkg/types"
-	"github.com/go-redis/redis/v8"
-	"github.com/goodsign/monday"
 	tb "gopkg.in/tucnak/telebot.v2"
 	"strconv"
 	"strings"
 	"time"
 )
 
-func EventShowMoreInlineKeyboard(event *types.Event, db *redis.Client) ([][]tb.InlineButton, error) {
-	err := db.Set(context.TODO(), event.Uid, event.Calendar.UID, 0).Err()
+func (k *Keyboards) EventShowMoreInlineKeyboard(lang i18n.Lang, event *types.Event,
+	store *callbacks.Store) ([][]tb.InlineButton, error) {
+
+	err := store.SetEventCalendar(event.Uid, event.Calendar.UID)
 	if err != nil {
 		return nil, err
 	}
-	return [][]tb.InlineButton{{{
-		Text:   calendarMessages.ShowMoreButton(),
+	return k.signed([][]tb.InlineButton{{{
+		Text:   calendarMessages.ShowMoreButton(lang),
 		Unique: telegram.ShowFullEvent,
 		Data:   event.Uid,
-	}}}, nil
+	}}}), nil
+}
+
+// EventNowInlineKeyboard is the show more keyboard with the call link on top, if the event has it
+func (k *Keyboards) EventNowInlineKeyboard(lang i18n.Lang, event *types.Event,
+	store *callbacks.Store) ([][]tb.InlineButton, error) {
+
+	showMore, err := k.EventShowMoreInlineKeyboard(lang, event, store)
+	if err != nil {
+		return nil, err
+	}
+	return WithCallButton(lang, event, showMore), nil
+}
+
+// WithCallButton puts the call link on top of the keyboard, if the event has it
+func WithCallButton(lang i18n.Lang, event *types.Event, keyboard [][]tb.InlineButton) [][]tb.InlineButton {
+	if event.Call == "" {
+		return keyboard
+	}
+	return append([][]tb.InlineButton{{{
+		Text: calendarMessages.CallLinkButton(lang),
+		URL:  event.Call,
+	}}}, keyboard...)
 }
 
-func EventShowLessInlineKeyboard(event *types.Event) [][]tb.InlineButton {
+// EventShowLessInlineKeyboard is the keyboard of the expanded event, share adds the button to post it into a group
+func (k *Keyboards) EventShowLessInlineKeyboard(lang i18n.Lang, event *types.Event, share bool) [][]tb.InlineButton {
 	inlineKeyboard := make([][]tb.InlineButton, 0)
 	if event.Call != "" {
 		inlineKeyboard = append(inlineKeyboard, []tb.InlineButton{{
-			Text: calendarMessages.CallLinkButton(),
+			Text: calendarMessages.CallLinkButton(lang),
 			URL:  event.Call,
 		}})
 	}
 
+	if _, _, ok := eUseCase.GeoCoordinates(event.Location.Geo); ok {
+		inlineKeyboard = append(inlineKeyboard, []tb.InlineButton{{
+			Text:   lang.T(calendarMessages.EventVenueButton),
+			Unique: telegram.EventVenue,
+			Data:   event.Uid,
+		}})
+	}
+
+	if share {
+		inlineKeyboard = append(inlineKeyboard, []tb.InlineButton{{
+			Text:   lang.T(calendarMessages.ShareEventButton),
+			Unique: telegram.ShareEvent,
+			Data:   event.Uid,
+		}})
+	}
+
 	inlineKeyboard = append(inlineKeyboard, []tb.InlineButton{{
-		Text:   calendarMessages.ShowLessButton(),
+		Text:   calendarMessages.ShowLessButton(lang),
 		Unique: telegram.ShowShortEvent,
 		Data:   event.Uid,
 	}})
 
-	return inlineKeyboard
+	return k.signed(inlineKeyboard)
 }
 
-func GroupAlertsButtons(data string) [][]tb.InlineButton {
+func (k *Keyboards) GroupAlertsButtons(lang i18n.Lang, data string) [][]tb.InlineButton {
 	inp := ""
 	if strings.Contains(data, telegram.Today) {
 		inp = telegram.Today
@@ -54,529 +96,669 @@ func GroupAlertsButtons(data string) [][]tb.InlineButton {
 	if strings.Contains(data, telegram.Date) {
 		inp = telegram.Date
 	}
-	return [][]tb.InlineButton{{
+	return k.signed([][]tb.InlineButton{{
 		{
-			Text:   "Да",
+			Text:   lang.T(calendarMessages.AlertYesButton),
 			Unique: telegram.AlertCallbackYes,
 			Data:   inp,
 		},
 		{
-			Text:   "Нет",
+			Text:   lang.T(calendarMessages.AlertNoButton),
 			Unique: telegram.AlertCallbackNo,
 		},
-	}}
+	}})
 }
 
-func CreateEventButtons(event types.Event) [][]tb.InlineButton {
-	btns := make([][]tb.InlineButton, 0)
+func (k *Keyboards) CreateEventButtons(lang i18n.Lang, event types.Event) [][]tb.InlineButton {
+	btns := WithCallButton(lang, &event, make([][]tb.InlineButton, 0))
 
 	if !event.From.IsZero() && !event.To.IsZero() {
 		btns = append(btns, []tb.InlineButton{{
-			Text:   calendarMessages.GetCreateEventCreateText(),
+			Text:   calendarMessages.GetCreateEventCreateText(lang),
 			Unique: telegram.CreateEvent,
 		}})
 	}
 
 	btns = append(btns, []tb.InlineButton{{
-		Text:   calendarMessages.GetCreateCancelText(),
+		Text:   calendarMessages.GetCreateCancelText(lang),
 		Unique: telegram.CancelCreateEvent,
 	}})
 
-	return btns
+	return k.signed(btns)
 }
 
-func GroupChatButtons(event *types.Event, db *redis.Client, senderID int) ([][]tb.InlineButton, error) {
-	err := db.Set(context.TODO(), event.Uid, event.Calendar.UID, 0).Err()
+func (k *Keyboards) GroupChatButtons(lang i18n.Lang, event *types.Event, store *callbacks.Store,
+	senderID int) ([][]tb.InlineButton, error) {
+
+	err := store.SetEventCalendar(event.Uid, event.Calendar.UID)
 	if err != nil {
 		return nil, err
 	}
-	return [][]tb.InlineButton{{
+	return k.signed([][]tb.InlineButton{{
 		{
-			Text:   calendarMessages.CreateEventGo,
+			Text:   lang.T(calendarMessages.CreateEventGo),
 			Unique: telegram.GroupGo,
 			Data:   event.Uid + "|" + strconv.Itoa(senderID),
 		},
 		{
-			Text:   calendarMessages.CreateEventNotGo,
+			Text:   lang.T(calendarMessages.CreateEventNotGo),
 			Unique: telegram.GroupNotGo,
 			Data:   event.Uid + "|" + strconv.Itoa(senderID),
 		},
-	}}, nil
+	}}), nil
 }
 
-func GroupFindTimeButtons() [][]tb.InlineButton {
-	return [][]tb.InlineButton{{
+// ShareEventGroupsButtons lists groups to post the event into, back returns to the expanded event
+func (k *Keyboards) ShareEventGroupsButtons(lang i18n.Lang, event *types.Event,
+	groups []types.UserGroup) [][]tb.InlineButton {
+
+	inlineKeyboard := make([][]tb.InlineButton, 0, len(groups)+1)
+	for _, group := range groups {
+		inlineKeyboard = append(inlineKeyboard, []tb.InlineButton{{
+			Text:   calendarMessages.GetShareEventGroupButton(group),
+			Unique: telegram.ShareEventGroup,
+			Data:   event.Uid + "|" + strconv.FormatInt(group.ChatID, 10),
+		}})
+	}
+	return k.signed(append(inlineKeyboard, []tb.InlineButton{{
+		Text:   lang.T(calendarMessages.ShareEventBackButton),
+		Unique: telegram.ShowFullEvent,
+		Data:   event.Uid,
+	}}))
+}
+
+// BindCalendarButtons lists calendars to bind to the group, the remove button is shown if one is bound
+func (k *Keyboards) BindCalendarButtons(lang i18n.Lang, calendars []types.Calendar, bound bool) [][]tb.InlineButton {
+	inlineKeyboard := make([][]tb.InlineButton, 0, len(calendars)+1)
+	for _, calendar := range calendars {
+		inlineKeyboard = append(inlineKeyboard, []tb.InlineButton{{
+			Text:   calendarMessages.GetBindCalendarButton(calendar),
+			Unique: telegram.BindCalendarSelect,
+			Data:   calendar.UID,
+		}})
+	}
+	if bound {
+		inlineKeyboard = append(inlineKeyboard, []tb.InlineButton{{
+			Text:   lang.T(calendarMessages.BindCalendarRemoveButton),
+			Unique: telegram.BindCalendarRemove,
+		}})
+	}
+	return k.signed(inlineKeyboard)
+}
+
+func (k *Keyboards) GroupFindTimeButtons(lang i18n.Lang) [][]tb.InlineButton {
+	return k.signed([][]tb.InlineButton{{
 		{
-			Text:   calendarMessages.CreateEventFindTimeYesButton,
+			Text:   lang.T(calendarMessages.CreateEventFindTimeYesButton),
 			Unique: telegram.GroupFindTimeYes,
 		},
 	},
 		{
 			{
-				Text:   calendarMessages.CreateEventFindTimeNoButton,
+				Text:   lang.T(calendarMessages.CreateEventFindTimeNoButton),
 				Unique: telegram.GroupFindTimeNo,
 			},
 		},
-	}
+	})
 }
 
-func FindTimeDayPartButtons(t time.Time) [][]tb.InlineButton {
-	return [][]tb.InlineButton{
+func (k *Keyboards) FindTimeDayPartButtons(lang i18n.Lang, t time.Time) [][]tb.InlineButton {
+	return k.signed([][]tb.InlineButton{
 		{
 			{
-				Text:   "Утром (6:00 - 13:00)",
+				Text:   lang.T(calendarMessages.FindTimeMorningButton),
 				Unique: telegram.FindTimeDayPart,
 				Data:   time.Date(t.Year(), t.Month(), t.Day(), 6, 0, 0, 0, t.Location()).Format(time.RFC3339),
 			},
 		},
 		{
 			{
-				Text:   "Днем (12:00 - 19:00)",
+				Text:   lang.T(calendarMessages.FindTimeAfternoonButton),
 				Unique: telegram.FindTimeDayPart,
 				Data:   time.Date(t.Year(), t.Month(), t.Day(), 12, 0, 0, 0, t.Location()).Format(time.RFC3339),
 			},
 		},
 		{
 			{
-				Text:   "Вечером (17:00 - 0:00)",
+				Text:   lang.T(calendarMessages.FindTimeEveningButton),
 				Unique: telegram.FindTimeDayPart,
 				Data:   time.Date(t.Year(), t.Month(), t.Day(), 17, 0, 0, 0, t.Location()).Format(time.RFC3339),
 			},
 		},
 		{
 			{
-				Text:   "В рабочее время (9:00 - 18:00)",
+				Text:   lang.T(calendarMessages.FindTimeWorkingHoursButton),
 				Unique: telegram.FindTimeDayPart,
 				Data:   time.Date(t.Year(), t.Month(), t.Day(), 9, 0, 0, 0, t.Location()).Format(time.RFC3339),
 			},
 		},
 		{
 			{
-				Text:   "В любое время",
+				Text:   lang.T(calendarMessages.FindTimeAnyTimeButton),
 				Unique: telegram.FindTimeDayPart,
 				Data:   "All day",
 			},
 
 			{
-				Text:   calendarMessages.GetCreateCancelText(),
+				Text:   calendarMessages.GetCreateCancelText(lang),
 				Unique: telegram.FindTimeDayPart,
-				Data:   calendarMessages.GetCreateCancelText(),
+				Data:   calendarMessages.GetCreateCancelText(lang),
 			},
 		},
-	}
+	})
 }
 
-func FindTimeLengthButtons() [][]tb.InlineButton {
-	return [][]tb.InlineButton{
+func (k *Keyboards) FindTimeLengthButtons(lang i18n.Lang) [][]tb.InlineButton {
+	return k.signed([][]tb.InlineButton{
 		{
 			{
-				Text:   "30 мин",
+				Text:   lang.T(calendarMessages.FindTimeLength30m),
 				Unique: telegram.FindTimeLength,
-				Data:   "30m|30 мин",
+				Data:   "30m|" + lang.T(calendarMessages.FindTimeLength30m),
 			},
 			{
-				Text:   "1 час",
+				Text:   lang.T(calendarMessages.FindTimeLength1h),
 				Unique: telegram.FindTimeLength,
-				Data:   "1h|1 час",
+				Data:   "1h|" + lang.T(calendarMessages.FindTimeLength1h),
 			},
 			{
-				Text:   "1,5 часа",
+				Text:   lang.T(calendarMessages.FindTimeLength1h30m),
 				Unique: telegram.FindTimeLength,
-				Data:   "1h30m|1,5 часа",
+				Data:   "1h30m|" + lang.T(calendarMessages.FindTimeLength1h30m),
 			},
 			{
-				Text:   "2 часа",
+				Text:   lang.T(calendarMessages.FindTimeLength2h),
 				Unique: telegram.FindTimeLength,
-				Data:   "2h|2 часа",
+				Data:   "2h|" + lang.T(calendarMessages.FindTimeLength2h),
 			},
 		},
 		{
 			{
-				Text:   "2,5 часа",
+				Text:   lang.T(calendarMessages.FindTimeLength2h30m),
 				Unique: telegram.FindTimeLength,
-				Data:   "2h30m|2,5 часа",
+				Data:   "2h30m|" + lang.T(calendarMessages.FindTimeLength2h30m),
 			},
 			{
-				Text:   "3 часа",
+				Text:   lang.T(calendarMessages.FindTimeLength3h),
 				Unique: telegram.FindTimeLength,
-				Data:   "3h|3 часа",
+				Data:   "3h|" + lang.T(calendarMessages.FindTimeLength3h),
 			},
 			{
-				Text:   "4 часа",
+				Text:   lang.T(calendarMessages.FindTimeLength4h),
 				Unique: telegram.FindTimeLength,
-				Data:   "4h|4 часа",
+				Data:   "4h|" + lang.T(calendarMessages.FindTimeLength4h),
 			},
 			{
-				Text:   "5 часов",
+				Text:   lang.T(calendarMessages.FindTimeLength5h),
 				Unique: telegram.FindTimeLength,
-				Data:   "5h|5 часов",
+				Data:   "5h|" + lang.T(calendarMessages.FindTimeLength5h),
 			},
 		},
 
 		{
 			{
-				Text:   calendarMessages.GetCreateCancelText(),
+				Text:   calendarMessages.GetCreateCancelText(lang),
 				Unique: telegram.FindTimeLength,
-				Data:   calendarMessages.GetCreateCancelText(),
+				Data:   calendarMessages.GetCreateCancelText(lang),
 			},
 		},
-	}
+	})
 }
 
-func FindTimePollButtons() [][]tb.InlineButton {
-	return [][]tb.InlineButton{
+func (k *Keyboards) FindTimePollButtons(lang i18n.Lang) [][]tb.InlineButton {
+	return k.signed([][]tb.InlineButton{
 		{
 			{
-				Text:   calendarMessages.FindTimeBack,
+				Text:   lang.T(calendarMessages.FindTimeBack),
 				Unique: telegram.FindTimeBack,
 			},
 		},
 		{
 			{
-				Text:   calendarMessages.GetCreateEventCreateText(),
+				Text:   calendarMessages.GetCreateEventCreateText(lang),
 				Unique: telegram.FindTimeCreate,
 			},
 			{
-				Text:   calendarMessages.GetCreateCancelText(),
+				Text:   calendarMessages.GetCreateCancelText(lang),
 				Unique: telegram.FindTimeCreate,
-				Data:   calendarMessages.GetCreateCancelText(),
+				Data:   calendarMessages.GetCreateCancelText(lang),
 			},
 		},
-	}
+	})
 }
 
-func FindTimeAddUser(sender int) [][]tb.InlineButton {
-	return [][]tb.InlineButton{
+func (k *Keyboards) FindTimeAddUser(lang i18n.Lang, sender int) [][]tb.InlineButton {
+	return k.signed([][]tb.InlineButton{
 		{
 			{
-				Text:   calendarMessages.FindTimeFind,
+				Text:   lang.T(calendarMessages.FindTimeFind),
 				Unique: telegram.FindTimeFind,
 				Data:   strconv.Itoa(sender),
 			},
 		},
 		{
 			{
-				Text:   calendarMessages.FindTimeBack,
+				Text:   lang.T(calendarMessages.FindTimeBack),
 				Unique: telegram.FindTimeBack,
 			},
 		},
 		{
 			{
-				Text:   calendarMessages.FindTimeAdd,
+				Text:   lang.T(calendarMessages.FindTimeAdd),
 				Unique: telegram.FindTimeAdd,
 				Data:   strconv.Itoa(sender),
 			},
 		},
-	}
+	})
 }
 
-func GetDateFastCommand(cancelText bool) [][]tb.InlineButton {
-	const (
-		formatDate = "2 January"
-		locale     = monday.LocaleRuRU
-	)
-
+// GetDateFastCommand offers the next days, now is in the user's timezone
+func (k *Keyboards) GetDateFastCommand(lang i18n.Lang, cancelText bool, now time.Time) [][]tb.InlineButton {
 	unique := telegram.HandleGroupText
-	now := time.Now()
-	ret := [][]tb.InlineButton{
-		{
-			{
-				Text:   monday.Format(now, formatDate, locale) + ", Сегодня",
-				Unique: unique,
-				Data:   monday.Format(now, formatDate, locale) + ", Сегодня",
-			},
-			{
-				Text:   monday.Format(now.AddDate(0, 0, 1), formatDate, locale) + ", Завтра",
-				Unique: unique,
-				Data:   monday.Format(now.AddDate(0, 0, 1), formatDate, locale) + ", Завтра",
-			},
-			{
-				Text:   monday.Format(now.AddDate(0, 0, 2), formatDate, locale),
-				Unique: unique,
-				Data:   monday.Format(now.AddDate(0, 0, 2), formatDate, locale),
-			},
-		},
-		{
-			{
-				Text:   monday.Format(now.AddDate(0, 0, 3), formatDate, locale),
-				Unique: unique,
-				Data:   monday.Format(now.AddDate(0, 0, 3), formatDate, locale),
-			},
-			{
-				Text:   monday.Format(now.AddDate(0, 0, 4), formatDate, locale),
-				Unique: unique,
-				Data:   monday.Format(now.AddDate(0, 0, 4), formatDate, locale),
-			},
-			{
-				Text:   monday.Format(now.AddDate(0, 0, 5), formatDate, locale),
-				Unique: unique,
-				Data:   monday.Format(now.AddDate(0, 0, 5), formatDate, locale),
-			},
-		},
+	ret := make([][]tb.InlineButton, 2)
+	for offset := 0; offset < 6; offset++ {
+		text := calendarMessages.GetDayButtonText(lang, now, offset)
+		ret[offset/3] = append(ret[offset/3], tb.InlineButton{
+			Text:   text,
+			Unique: unique,
+			Data:   text,
+		})
 	}
 
 	if !cancelText {
 		ret = append(ret, []tb.InlineButton{
 			{
-				Text:   calendarMessages.GetCancelDateReplyButton(),
+				Text:   calendarMessages.GetCancelDateReplyButton(lang),
 				Unique: unique,
-				Data:   calendarMessages.GetCancelDateReplyButton(),
+				Data:   calendarMessages.GetCancelDateReplyButton(lang),
 			},
 		})
 	} else {
 		ret = append(ret, []tb.InlineButton{
 			{
-				Text:   calendarMessages.GetCreateCancelText(),
+				Text:   calendarMessages.GetCreateCancelText(lang),
 				Unique: unique,
-				Data:   calendarMessages.GetCreateCancelText(),
+				Data:   calendarMessages.GetCreateCancelText(lang),
 			},
 		})
 	}
 
-	return ret
+	return k.signed(ret)
 }
 
-func GetCreateFastCommand() [][]tb.InlineButton {
+func (k *Keyboards) GetCreateFastCommand(lang i18n.Lang) [][]tb.InlineButton {
 	unique := telegram.HandleGroupText
-	return [][]tb.InlineButton{
+	return k.signed([][]tb.InlineButton{
 		{
 			{
-				Text:   "Через полчаса",
+				Text:   lang.T(calendarMessages.FastCommandInHalfHour),
 				Unique: unique,
-				Data:   "Через полчаса",
+				Data:   lang.T(calendarMessages.FastCommandInHalfHour),
 			},
 			{
-				Text:   "Через час",
+				Text:   lang.T(calendarMessages.FastCommandInHour),
 				Unique: unique,
-				Data:   "Через час",
+				Data:   lang.T(calendarMessages.FastCommandInHour),
 			},
 			{
-				Text:   "Через два часа",
+				Text:   lang.T(calendarMessages.FastCommandInTwoHours),
 				Unique: unique,
-				Data:   "Через два часа",
+				Data:   lang.T(calendarMessages.FastCommandInTwoHours),
 			},
 			{
-				Text:   "Через три часа",
+				Text:   lang.T(calendarMessages.FastCommandInThreeHours),
 				Unique: unique,
-				Data:   "Через три часа",
+				Data:   lang.T(calendarMessages.FastCommandInThreeHours),
 			},
 		},
 		{
 			{
-				Text:   "Сегодня в 9:00",
+				Text:   lang.T(calendarMessages.FastCommandTodayAt9),
 				Unique: unique,
-				Data:   "Сегодня в 9:00",
+				Data:   lang.T(calendarMessages.FastCommandTodayAt9),
 			},
 			{
-				Text:   "Сегодня в 12:00",
+				Text:   lang.T(calendarMessages.FastCommandTodayAt12),
 				Unique: unique,
-				Data:   "Сегодня в 12:00",
+				Data:   lang.T(calendarMessages.FastCommandTodayAt12),
 			},
 			{
-				Text:   "Сегодня в 15:00",
+				Text:   lang.T(calendarMessages.FastCommandTodayAt15),
 				Unique: unique,
-				Data:   "Сегодня в 15:00",
+				Data:   lang.T(calendarMessages.FastCommandTodayAt15),
 			},
 			{
-				Text:   "Сегодня в 18:00",
+				Text:   lang.T(calendarMessages.FastCommandTodayAt18),
 				Unique: unique,
-				Data:   "Сегодня в 18:00",
+				Data:   lang.T(calendarMessages.FastCommandTodayAt18),
 			},
 		},
 		{
 			{
-				Text:   "Завтра в 9:00",
+				Text:   lang.T(calendarMessages.FastCommandTomorrowAt9),
 				Unique: unique,
-				Data:   "Завтра в 9:00",
+				Data:   lang.T(calendarMessages.FastCommandTomorrowAt9),
 			},
 			{
-				Text:   "Завтра в 12:00",
+				Text:   lang.T(calendarMessages.FastCommandTomorrowAt12),
 				Unique: unique,
-				Data:   "Завтра в 12:00",
+				Data:   lang.T(calendarMessages.FastCommandTomorrowAt12),
 			},
 			{
-				Text:   "Завтра в 15:00",
+				Text:   lang.T(calendarMessages.FastCommandTomorrowAt15),
 				Unique: unique,
-				Data:   "Завтра в 15:00",
+				Data:   lang.T(calendarMessages.FastCommandTomorrowAt15),
 			},
 			{
-				Text:   "Завтра в 18:00",
+				Text:   lang.T(calendarMessages.FastCommandTomorrowAt18),
 				Unique: unique,
-				Data:   "Завтра в 18:00",
+				Data:   lang.T(calendarMessages.FastCommandTomorrowAt18),
 			},
 		},
 		{
 			{
-				Text:   "Через неделю в 12:00",
+				Text:   lang.T(calendarMessages.FastCommandInWeekAt12),
 				Unique: unique,
-				Data:   "Через неделю в 12:00",
+				Data:   lang.T(calendarMessages.FastCommandInWeekAt12),
 			},
 			{
-				Text:   "Через неделю в 15:00",
+				Text:   lang.T(calendarMessages.FastCommandInWeekAt15),
 				Unique: unique,
-				Data:   "Через неделю в 15:00",
+				Data:   lang.T(calendarMessages.FastCommandInWeekAt15),
 			},
 			{
-				Text:   "Через неделю в 18:00",
+				Text:   lang.T(calendarMessages.FastCommandInWeekAt18),
 				Unique: unique,
-				Data:   "Через неделю в 18:00",
+				Data:   lang.T(calendarMessages.FastCommandInWeekAt18),
 			},
 		},
 		{
 			{
-				Text:   calendarMessages.GetCreateCancelText(),
+				Text:   calendarMessages.GetCreateCancelText(lang),
 				Unique: unique,
-				Data:   calendarMessages.GetCreateCancelText(),
+				Data:   calendarMessages.GetCreateCancelText(lang),
 			},
 		},
-	}
+	})
 }
 
-func GetCreateDuration() [][]tb.InlineButton {
+// GetCreateDuration offers the durations of the new event, three in a row, the default duration of the user goes first
+func (k *Keyboards) GetCreateDuration(lang i18n.Lang, defaultDuration time.Duration) [][]tb.InlineButton {
 	unique := telegram.HandleGroupText
-	return [][]tb.InlineButton{
-		{
-			{
-				Text:   calendarMessages.GetCreateEventHalfHour(),
-				Unique: unique,
-				Data:   calendarMessages.GetCreateEventHalfHour(),
-			},
-			{
-				Text:   calendarMessages.GetCreateEventHour(),
-				Unique: unique,
-				Data:   calendarMessages.GetCreateEventHour(),
-			},
-			{
-				Text:   calendarMessages.GetCreateEventHourAndHalf(),
-				Unique: unique,
-				Data:   calendarMessages.GetCreateEventHourAndHalf(),
-			},
-		},
-		{
-			{
-				Text:   calendarMessages.GetCreateEventTwoHours(),
-				Unique: unique,
-				Data:   calendarMessages.GetCreateEventTwoHours(),
-			},
-			{
-				Text:   calendarMessages.GetCreateEventFourHours(),
-				Unique: unique,
-				Data:   calendarMessages.GetCreateEventFourHours(),
-			},
-			{
-				Text:   calendarMessages.GetCreateEventSixHours(),
-				Unique: unique,
-				Data:   calendarMessages.GetCreateEventSixHours(),
-			},
-		},
-		{
-			{
-				Text:   calendarMessages.GetCreateFullDay(),
-				Unique: unique,
-				Data:   calendarMessages.GetCreateFullDay(),
-			},
-		},
+	var keyboard [][]tb.InlineButton
+	for i, duration := range calendarMessages.GetCreateDurations(defaultDuration) {
+		if i%3 == 0 {
+			keyboard = append(keyboard, []tb.InlineButton{})
+		}
+		text := calendarMessages.GetCreateDurationText(lang, duration)
+		row := &keyboard[len(keyboard)-1]
+		*row = append(*row, tb.InlineButton{Text: text, Unique: unique, Data: text})
 	}
+	keyboard = append(keyboard, []tb.InlineButton{{
+		Text:   calendarMessages.GetCreateFullDay(lang),
+		Unique: unique,
+		Data:   calendarMessages.GetCreateFullDay(lang),
+	}})
+	return k.signed(keyboard)
 }
 
-func GetCreateOptionButtons(session *types.BotRedisSession) [][]tb.InlineButton {
-	btns := make([][]tb.InlineButton, 5)
+func (k *Keyboards) GetCreateOptionButtons(lang i18n.Lang, session *types.BotRedisSession) [][]tb.InlineButton {
+	btns := make([][]tb.InlineButton, 6)
 	for i := range btns {
 		btns[i] = make([]tb.InlineButton, 2)
 	}
 	idx := 0
 	unique := telegram.HandleGroupText
-	if session.Step != telegram.StepCreateFrom {
+	if !wizard.Is(session, wizard.CreateFrom) {
 		btns[idx/2][idx%2] = tb.InlineButton{
-			Text:   calendarMessages.CreateEventChangeStartTimeButton,
+			Text:   lang.T(calendarMessages.CreateEventChangeStartTimeButton),
 			Unique: unique,
-			Data:   calendarMessages.CreateEventChangeStartTimeButton,
+			Data:   lang.T(calendarMessages.CreateEventChangeStartTimeButton),
 		}
 		idx++
 	}
 
-	if session.Step != telegram.StepCreateTo {
+	if !wizard.Is(session, wizard.CreateTo) {
 		btns[idx/2][idx%2] = tb.InlineButton{
-			Text:   calendarMessages.CreateEventChangeStopTimeButton,
+			Text:   lang.T(calendarMessages.CreateEventChangeStopTimeButton),
 			Unique: unique,
-			Data:   calendarMessages.CreateEventChangeStopTimeButton,
+			Data:   lang.T(calendarMessages.CreateEventChangeStopTimeButton),
 		}
 		idx++
 	}
 
-	if session.Step != telegram.StepCreateTitle {
+	if !wizard.Is(session, wizard.CreateTitle) {
 		if session.Event.Title == "" {
 			btns[idx/2][idx%2] = tb.InlineButton{
-				Text:   calendarMessages.CreateEventAddTitleButton,
+				Text:   lang.T(calendarMessages.CreateEventAddTitleButton),
 				Unique: unique,
-				Data:   calendarMessages.CreateEventAddTitleButton,
+				Data:   lang.T(calendarMessages.CreateEventAddTitleButton),
 			}
 		} else {
 			btns[idx/2][idx%2] = tb.InlineButton{
-				Text:   calendarMessages.CreateEventChangeTitleButton,
+				Text:   lang.T(calendarMessages.CreateEventChangeTitleButton),
 				Unique: unique,
-				Data:   calendarMessages.CreateEventChangeTitleButton,
+				Data:   lang.T(calendarMessages.CreateEventChangeTitleButton),
 			}
 		}
 		idx++
 	}
 
-	if session.Step != telegram.StepCreateDesc {
+	if !wizard.Is(session, wizard.CreateDesc) {
 		if session.Event.Description == "" {
 			btns[idx/2][idx%2] = tb.InlineButton{
-				Text:   calendarMessages.CreateEventAddDescButton,
+				Text:   lang.T(calendarMessages.CreateEventAddDescButton),
 				Unique: unique,
-				Data:   calendarMessages.CreateEventAddDescButton,
+				Data:   lang.T(calendarMessages.CreateEventAddDescButton),
 			}
 		} else {
 			btns[idx/2][idx%2] = tb.InlineButton{
-				Text:   calendarMessages.CreateEventChangeDescButton,
+				Text:   lang.T(calendarMessages.CreateEventChangeDescButton),
 				Unique: unique,
-				Data:   calendarMessages.CreateEventChangeDescButton,
+				Data:   lang.T(calendarMessages.CreateEventChangeDescButton),
 			}
 		}
 		idx++
 	}
 
-	if session.Step != telegram.StepCreateLocation {
+	if !wizard.Is(session, wizard.CreateLocation) {
 		if session.Event.Location.Description == "" {
 			btns[idx/2][idx%2] = tb.InlineButton{
-				Text:   calendarMessages.CreateEventAddLocationButton,
+				Text:   lang.T(calendarMessages.CreateEventAddLocationButton),
 				Unique: unique,
-				Data:   calendarMessages.CreateEventAddLocationButton,
+				Data:   lang.T(calendarMessages.CreateEventAddLocationButton),
 			}
 		} else {
 			btns[idx/2][idx%2] = tb.InlineButton{
-				Text:   calendarMessages.CreateEventChangeLocationButton,
+				Text:   lang.T(calendarMessages.CreateEventChangeLocationButton),
 				Unique: unique,
-				Data:   calendarMessages.CreateEventChangeLocationButton,
+				Data:   lang.T(calendarMessages.CreateEventChangeLocationButton),
 			}
 		}
 		idx++
 	}
 
-	if session.Step != telegram.StepCreateUser {
+	if !wizard.Is(session, wizard.CreateUser) {
 		btns[idx/2][idx%2] = tb.InlineButton{
-			Text:   calendarMessages.CreateEventAddUser,
+			Text:   lang.T(calendarMessages.CreateEventAddUser),
 			Unique: unique,
-			Data:   calendarMessages.CreateEventAddUser,
+			Data:   lang.T(calendarMessages.CreateEventAddUser),
 		}
 		idx++
 	}
 
+	btns[idx/2][idx%2] = tb.InlineButton{
+		Text:   lang.T(calendarMessages.CreateEventRoomButton),
+		Unique: unique,
+		Data:   lang.T(calendarMessages.CreateEventRoomButton),
+	}
+	idx++
+
+	btns[idx/2][idx%2] = tb.InlineButton{
+		Text:   calendarMessages.GetCreateCallButton(lang, session.Event.Call),
+		Unique: unique,
+		Data:   calendarMessages.GetCreateCallButton(lang, session.Event.Call),
+	}
+	idx++
+
 	if !session.Event.FullDay {
 		btns[idx/2][idx%2] = tb.InlineButton{
-			Text:   calendarMessages.GetCreateFullDay(),
+			Text:   calendarMessages.GetCreateFullDay(lang),
 			Unique: unique,
-			Data:   calendarMessages.GetCreateFullDay(),
+			Data:   calendarMessages.GetCreateFullDay(lang),
 		}
 	}
 
-	btns[4][0] = tb.InlineButton{
-		Text:   calendarMessages.GetCreateCancelText(),
+	btns[5][0] = tb.InlineButton{
+		Text:   calendarMessages.GetCreateCancelText(lang),
 		Unique: unique,
-		Data:   calendarMessages.GetCreateCancelText(),
+		Data:   calendarMessages.GetCreateCancelText(lang),
+	}
+
+	return k.signed(btns)
+}
+
+// TemplatesButtons applies or deletes a template, one row for each of them
+func (k *Keyboards) TemplatesButtons(lang i18n.Lang, templates []types.EventTemplate) [][]tb.InlineButton {
+	keyboard := make([][]tb.InlineButton, 0, len(templates))
+	for _, template := range templates {
+		id := strconv.FormatInt(template.ID, 10)
+		keyboard = append(keyboard, []tb.InlineButton{
+			{
+				Text:   calendarMessages.GetTemplateApplyButton(lang, template),
+				Unique: telegram.TemplateApply,
+				Data:   id,
+			},
+			{
+				Text:   calendarMessages.GetTemplateDeleteButton(),
+				Unique: telegram.TemplateDelete,
+				Data:   id,
+			},
+		})
+	}
+	return k.signed(keyboard)
+}
+
+func (k *Keyboards) TemplateSaveButtons(lang i18n.Lang, event *types.Event) [][]tb.InlineButton {
+	return k.signed([][]tb.InlineButton{{{
+		Text:   lang.T(calendarMessages.TemplateSaveButton),
+		Unique: telegram.TemplateSave,
+		Data:   event.Uid,
+	}}})
+}
+
+// RoomButtons lets to pick one of the free rooms or to go without a room
+func (k *Keyboards) RoomButtons(lang i18n.Lang, rooms []types.Room) [][]tb.InlineButton {
+	keyboard := make([][]tb.InlineButton, 0, len(rooms)+1)
+	for _, room := range rooms {
+		keyboard = append(keyboard, []tb.InlineButton{{
+			Text:   calendarMessages.GetRoomButtonText(lang, room),
+			Unique: telegram.RoomSelect,
+			Data:   room.Email,
+		}})
+	}
+	keyboard = append(keyboard, []tb.InlineButton{{
+		Text:   lang.T(calendarMessages.CreateEventRoomNoneButton),
+		Unique: telegram.RoomSelect,
+	}})
+	return k.signed(keyboard)
+}
+
+func (k *Keyboards) AvailabilityButtons(lang i18n.Lang, weekOffset int) [][]tb.InlineButton {
+	return k.signed([][]tb.InlineButton{
+		{
+			{
+				Text:   lang.T(calendarMessages.AvailabilityPrevWeekButton),
+				Unique: telegram.AvailabilityWeek,
+				Data:   strconv.Itoa(weekOffset - 1),
+			},
+			{
+				Text:   lang.T(calendarMessages.AvailabilityNextWeekButton),
+				Unique: telegram.AvailabilityWeek,
+				Data:   strconv.Itoa(weekOffset + 1),
+			},
+		},
+		{
+			{
+				Text:   lang.T(calendarMessages.AvailabilityJoinButton),
+				Unique: telegram.AvailabilityJoin,
+				Data:   strconv.Itoa(weekOffset),
+			},
+		},
+	})
+}
+
+var focusDurations = []time.Duration{time.Hour, 2 * time.Hour, 3 * time.Hour, 4 * time.Hour}
+
+func (k *Keyboards) FocusRuleButtons(lang i18n.Lang, rule types.FocusRule) [][]tb.InlineButton {
+	selected := func(text string, isSelected bool) string {
+		if isSelected {
+			return calendarMessages.FocusSelectedButton + text
+		}
+		return text
+	}
+
+	durations := make([]tb.InlineButton, 0, len(focusDurations))
+	for _, duration := range focusDurations {
+		durations = append(durations, tb.InlineButton{
+			Text:   selected(calendarMessages.FormatDuration(lang, duration), rule.Enabled && rule.Duration == duration),
+			Unique: telegram.FocusDuration,
+			Data:   strconv.Itoa(int(duration / time.Minute)),
+		})
 	}
 
-	return btns
+	keyboard := [][]tb.InlineButton{
+		durations,
+		{
+			{
+				Text:   selected(lang.T(calendarMessages.FocusMorningButton), rule.PreferMorning),
+				Unique: telegram.FocusMorning,
+				Data:   "1",
+			},
+			{
+				Text:   selected(lang.T(calendarMessages.FocusAnyTimeButton), !rule.PreferMorning),
+				Unique: telegram.FocusMorning,
+				Data:   "0",
+			},
+		},
+	}
+
+	if rule.Enabled {
+		keyboard = append(keyboard, []tb.InlineButton{{
+			Text:   lang.T(calendarMessages.FocusOffButton),
+			Unique: telegram.FocusOff,
+		}})
+	}
+
+	return k.signed(keyboard)
+}
+
+// InvitationRulesButtons has a delete button for every rule, rules are numbered as in GetInvitationRulesText
+func (k *Keyboards) InvitationRulesButtons(lang i18n.Lang, rules []types.InvitationRule) [][]tb.InlineButton {
+	keyboard := make([][]tb.InlineButton, 0, len(rules))
+	for i, rule := range rules {
+		keyboard = append(keyboard, []tb.InlineButton{{
+			Text:   calendarMessages.GetInvitationRuleDeleteButton(lang, i+1),
+			Unique: telegram.InvitationRuleDelete,
+			Data:   strconv.FormatInt(rule.ID, 10),
+		}})
+	}
+	return k.signed(keyboard)
+}
+
+// LanguageButtons offers every language by its own name, the current one is marked
+func (k *Keyboards) LanguageButtons(current i18n.Lang) [][]tb.InlineButton {
+	keyboard := make([][]tb.InlineButton, 0, len(i18n.Langs))
+	for _, lang := range i18n.Langs {
+		text := calendarMessages.GetLanguageButton(lang)
+		if lang == current {
+			text = calendarMessages.FocusSelectedButton + text
+		}
+		keyboard = append(keyboard, []tb.InlineButton{{
+			Text:   text,
+			Unique: telegram.LanguageSelect,
+			Data:   string(lang),
+		}})
+	}
+	return k.signed(keyboard)
 }
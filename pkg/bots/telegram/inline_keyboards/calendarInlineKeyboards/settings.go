@@ -0,0 +1,126 @@
+package calendarInlineKeyboards
+
+import (
+	"github.com/calendar-bot/pkg/bots/telegram"
+	"github.com/calendar-bot/pkg/bots/telegram/messages/calendarMessages"
+	"github.com/calendar-bot/pkg/i18n"
+	"github.com/calendar-bot/pkg/types"
+	tb "gopkg.in/tucnak/telebot.v2"
+	"strconv"
+	"time"
+)
+
+// values offered in /settings, zero reminder lead time and digest time turn them off
+var (
+	settingsTimezones = []string{
+		"Europe/Kaliningrad", "Europe/Moscow", "Europe/Samara", "Asia/Yekaterinburg", "Asia/Omsk",
+		"Asia/Novosibirsk", "Asia/Krasnoyarsk", "Asia/Irkutsk", "Asia/Yakutsk", "Asia/Vladivostok",
+		"Asia/Magadan", "Asia/Kamchatka", "Europe/London", "Europe/Berlin", "UTC",
+	}
+	settingsEventDurations = []time.Duration{
+		15 * time.Minute, 30 * time.Minute, 45 * time.Minute, time.Hour, 90 * time.Minute, 2 * time.Hour,
+	}
+	settingsReminderLeadTimes = []time.Duration{
+		0, 5 * time.Minute, 10 * time.Minute, 15 * time.Minute, 30 * time.Minute, time.Hour,
+	}
+	settingsDigestTimes = []time.Duration{0, 7 * time.Hour, 8 * time.Hour, 9 * time.Hour, 10 * time.Hour}
+)
+
+// SettingsButtons open the choice of every option, the image mode is switched right away
+func (k *Keyboards) SettingsButtons(lang i18n.Lang, settings types.UserSettings) [][]tb.InlineButton {
+	open := func(option string) tb.InlineButton {
+		return tb.InlineButton{
+			Text:   calendarMessages.GetSettingsOptionButton(lang, option),
+			Unique: telegram.SettingsOpen,
+			Data:   option,
+		}
+	}
+
+	return k.signed([][]tb.InlineButton{
+		{open(telegram.SettingTimezone), open(telegram.SettingLanguage)},
+		{open(telegram.SettingEventDuration), open(telegram.SettingCalendar)},
+		{open(telegram.SettingReminder), open(telegram.SettingDigest)},
+		{{
+			Text:   calendarMessages.GetSettingsOptionButton(lang, telegram.SettingImageMode),
+			Unique: telegram.SettingsSet,
+			Data:   settingsValue(telegram.SettingImageMode, strconv.FormatBool(!settings.ImageMode)),
+		}},
+		{{
+			Text:   lang.T(calendarMessages.SettingsResetButton),
+			Unique: telegram.SettingsReset,
+		}},
+	})
+}
+
+// SettingsOptionButtons are the values of the option two in a row, the current value is selected.
+// calendars are offered for the calendar option only
+func (k *Keyboards) SettingsOptionButtons(lang i18n.Lang, option string, settings types.UserSettings,
+	calendars []types.Calendar, now time.Time) [][]tb.InlineButton {
+
+	var buttons []tb.InlineButton
+	add := func(text string, value string, isSelected bool) {
+		if isSelected {
+			text = calendarMessages.FocusSelectedButton + text
+		}
+		buttons = append(buttons, tb.InlineButton{
+			Text:   text,
+			Unique: telegram.SettingsSet,
+			Data:   settingsValue(option, value),
+		})
+	}
+
+	switch option {
+	case telegram.SettingTimezone:
+		for _, timezone := range settingsTimezones {
+			add(calendarMessages.GetTimezoneText(timezone, now), timezone, timezone == settings.Timezone)
+		}
+	case telegram.SettingLanguage:
+		add(calendarMessages.GetSettingsLanguageText(lang, ""), "", settings.Language == "")
+		for _, language := range i18n.Langs {
+			add(calendarMessages.GetLanguageButton(language), string(language), string(language) == settings.Language)
+		}
+	case telegram.SettingEventDuration:
+		for _, duration := range settingsEventDurations {
+			add(calendarMessages.FormatDuration(lang, duration), minutesValue(duration),
+				duration == settings.EventDuration)
+		}
+	case telegram.SettingCalendar:
+		add(calendarMessages.GetSettingsCalendarText(lang, ""), "", settings.CalendarUID == "")
+		for _, calendar := range calendars {
+			add(calendarMessages.GetBindCalendarButton(calendar), calendar.UID, calendar.UID == settings.CalendarUID)
+		}
+	case telegram.SettingReminder:
+		for _, leadTime := range settingsReminderLeadTimes {
+			add(calendarMessages.GetReminderLeadTimeText(lang, leadTime), minutesValue(leadTime),
+				leadTime == settings.ReminderLeadTime)
+		}
+	case telegram.SettingDigest:
+		for _, digestTime := range settingsDigestTimes {
+			add(calendarMessages.GetDigestTimeText(lang, digestTime), minutesValue(digestTime),
+				digestTime == settings.DigestTime)
+		}
+	}
+
+	keyboard := make([][]tb.InlineButton, 0, len(buttons)/2+2)
+	for i := 0; i < len(buttons); i += 2 {
+		end := i + 2
+		if end > len(buttons) {
+			end = len(buttons)
+		}
+		keyboard = append(keyboard, buttons[i:end])
+	}
+	keyboard = append(keyboard, []tb.InlineButton{{
+		Text:   lang.T(calendarMessages.SettingsBackButton),
+		Unique: telegram.SettingsOpen,
+	}})
+	return k.signed(keyboard)
+}
+
+// settingsValue is the data of the button which sets the option to the value
+func settingsValue(option string, value string) string {
+	return option + "|" + value
+}
+
+func minutesValue(d time.Duration) string {
+	return strconv.Itoa(int(d / time.Minute))
+}
@@ -0,0 +1,37 @@
+package calendarInlineKeyboards
+
+import (
+	"github.com/calendar-bot/pkg/customerrors"
+	"github.com/calendar-bot/pkg/services/callbacks"
+	tb "gopkg.in/tucnak/telebot.v2"
+)
+
+// Keyboards builds the keyboards whose callback data is signed, the signer is shared with the callback handlers
+// which verify the data
+type Keyboards struct {
+	signer callbacks.Signer
+}
+
+func NewKeyboards(signer callbacks.Signer) Keyboards {
+	return Keyboards{signer: signer}
+}
+
+// signed signs the data of the buttons with Unique in place.
+// A button whose payload could not be stored is kept unsigned, its handler answers that the button has expired
+func (k *Keyboards) signed(keyboard [][]tb.InlineButton) [][]tb.InlineButton {
+	for i := range keyboard {
+		for j := range keyboard[i] {
+			button := &keyboard[i][j]
+			if button.Unique == "" {
+				continue
+			}
+			data, err := k.signer.Sign(button.Unique, button.Data)
+			if err != nil {
+				customerrors.HandlerError(err, nil, nil)
+				continue
+			}
+			button.Data = data
+		}
+	}
+	return keyboard
+}
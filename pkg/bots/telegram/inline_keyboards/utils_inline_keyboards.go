@@ -2,6 +2,7 @@ package inline_keyboards
 
 import (
 	"github.com/calendar-bot/pkg/bots/telegram/messages"
+	"github.com/calendar-bot/pkg/i18n"
 	tb "gopkg.in/tucnak/telebot.v2"
 )
 
@@ -9,9 +10,9 @@ const (
 	contactUrl = "https://t.me/alexey_ershkov"
 )
 
-func ReportBugKeyboard() [][]tb.InlineButton {
+func ReportBugKeyboard(lang i18n.Lang) [][]tb.InlineButton {
 	return [][]tb.InlineButton{{{
-		Text: messages.GetMessageReportBug(),
+		Text: messages.GetMessageReportBug(lang),
 		URL:  contactUrl,
 	}}}
 }
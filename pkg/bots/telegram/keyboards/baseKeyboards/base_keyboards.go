@@ -3,7 +3,7 @@ package baseKeyboards
 import tb "gopkg.in/tucnak/telebot.v2"
 
 func getCommands() []string {
-	return []string{"/today", "/next", "/date", "/create", "/about"}
+	return []string{"/today", "/week", "/now", "/next", "/date", "/create", "/holidays", "/about"}
 }
 
 func HelpCommandKeyboard() [][]tb.ReplyButton {
@@ -1,44 +1,37 @@
 package calendarKeyboards
 
 import (
-	"github.com/calendar-bot/pkg/bots/telegram"
 	"github.com/calendar-bot/pkg/bots/telegram/messages/calendarMessages"
+	"github.com/calendar-bot/pkg/bots/telegram/wizard"
+	"github.com/calendar-bot/pkg/i18n"
 	"github.com/calendar-bot/pkg/types"
-	"github.com/goodsign/monday"
 	tb "gopkg.in/tucnak/telebot.v2"
 	"time"
 )
 
-func GetDateFastCommand(cancelText bool) [][]tb.ReplyButton {
-
-	const (
-		formatDate = "2 January"
-		locale     = monday.LocaleRuRU
-	)
-
-	now := time.Now()
-
+// GetDateFastCommand offers the next days, now is in the user's timezone
+func GetDateFastCommand(lang i18n.Lang, cancelText bool, now time.Time) [][]tb.ReplyButton {
 	ret := [][]tb.ReplyButton{
 		{
 			{
-				Text: monday.Format(now, formatDate, locale) + ", Сегодня",
+				Text: calendarMessages.GetDayButtonText(lang, now, 0),
 			},
 			{
-				Text: monday.Format(now.AddDate(0, 0, 1), formatDate, locale) + ", Завтра",
+				Text: calendarMessages.GetDayButtonText(lang, now, 1),
 			},
 			{
-				Text: monday.Format(now.AddDate(0, 0, 2), formatDate, locale),
+				Text: calendarMessages.GetDayButtonText(lang, now, 2),
 			},
 		},
 		{
 			{
-				Text: monday.Format(now.AddDate(0, 0, 3), formatDate, locale),
+				Text: calendarMessages.GetDayButtonText(lang, now, 3),
 			},
 			{
-				Text: monday.Format(now.AddDate(0, 0, 4), formatDate, locale),
+				Text: calendarMessages.GetDayButtonText(lang, now, 4),
 			},
 			{
-				Text: monday.Format(now.AddDate(0, 0, 5), formatDate, locale),
+				Text: calendarMessages.GetDayButtonText(lang, now, 5),
 			},
 		},
 	}
@@ -46,13 +39,13 @@ func GetDateFastCommand(cancelText bool) [][]tb.ReplyButton {
 	if !cancelText {
 		ret = append(ret, []tb.ReplyButton{
 			{
-				Text: calendarMessages.GetCancelDateReplyButton(),
+				Text: calendarMessages.GetCancelDateReplyButton(lang),
 			},
 		})
 	} else {
 		ret = append(ret, []tb.ReplyButton{
 			{
-				Text: calendarMessages.GetCreateCancelText(),
+				Text: calendarMessages.GetCreateCancelText(lang),
 			},
 		})
 	}
@@ -60,172 +53,180 @@ func GetDateFastCommand(cancelText bool) [][]tb.ReplyButton {
 	return ret
 }
 
-func GetCreateFastCommand() [][]tb.ReplyButton {
+func GetCreateFastCommand(lang i18n.Lang) [][]tb.ReplyButton {
 	return [][]tb.ReplyButton{
 		{
 			{
-				Text: "Через полчаса",
+				Text: lang.T(calendarMessages.FastCommandInHalfHour),
 			},
 			{
-				Text: "Через час",
+				Text: lang.T(calendarMessages.FastCommandInHour),
 			},
 			{
-				Text: "Через два часа",
+				Text: lang.T(calendarMessages.FastCommandInTwoHours),
 			},
 			{
-				Text: "Через три часа",
+				Text: lang.T(calendarMessages.FastCommandInThreeHours),
 			},
 		},
 		{
 			{
-				Text: "Сегодня в 9:00",
+				Text: lang.T(calendarMessages.FastCommandTodayAt9),
 			},
 			{
-				Text: "Сегодня в 12:00",
+				Text: lang.T(calendarMessages.FastCommandTodayAt12),
 			},
 			{
-				Text: "Сегодня в 15:00",
+				Text: lang.T(calendarMessages.FastCommandTodayAt15),
 			},
 			{
-				Text: "Сегодня в 18:00",
+				Text: lang.T(calendarMessages.FastCommandTodayAt18),
 			},
 		},
 		{
 			{
-				Text: "Завтра в 9:00",
+				Text: lang.T(calendarMessages.FastCommandTomorrowAt9),
 			},
 			{
-				Text: "Завтра в 12:00",
+				Text: lang.T(calendarMessages.FastCommandTomorrowAt12),
 			},
 			{
-				Text: "Завтра в 15:00",
+				Text: lang.T(calendarMessages.FastCommandTomorrowAt15),
 			},
 			{
-				Text: "Завтра в 18:00",
+				Text: lang.T(calendarMessages.FastCommandTomorrowAt18),
 			},
 		},
 		{
 			{
-				Text: "Через неделю в 12:00",
+				Text: lang.T(calendarMessages.FastCommandInWeekAt12),
 			},
 			{
-				Text: "Через неделю в 18:00",
+				Text: lang.T(calendarMessages.FastCommandInWeekAt18),
 			},
 		},
 		{
 			{
-				Text: calendarMessages.GetCreateCancelText(),
+				Text: calendarMessages.GetCreateCancelText(lang),
 			},
 		},
 	}
 }
 
-func GetCreateDuration() [][]tb.ReplyButton {
+// GetCreateDuration offers the durations of the new event, three in a row, the default duration of the user goes first
+func GetCreateDuration(lang i18n.Lang, defaultDuration time.Duration) [][]tb.ReplyButton {
+	var keyboard [][]tb.ReplyButton
+	for i, duration := range calendarMessages.GetCreateDurations(defaultDuration) {
+		if i%3 == 0 {
+			keyboard = append(keyboard, []tb.ReplyButton{})
+		}
+		row := &keyboard[len(keyboard)-1]
+		*row = append(*row, tb.ReplyButton{Text: calendarMessages.GetCreateDurationText(lang, duration)})
+	}
+	return append(keyboard, []tb.ReplyButton{{Text: calendarMessages.GetCreateFullDay(lang)}})
+}
+
+// GetCreateLocationButtons asks for the user's location, the place can also be attached as a venue
+func GetCreateLocationButtons(lang i18n.Lang) [][]tb.ReplyButton {
 	return [][]tb.ReplyButton{
 		{
 			{
-				Text: calendarMessages.GetCreateEventHalfHour(),
-			},
-			{
-				Text: calendarMessages.GetCreateEventHour(),
-			},
-			{
-				Text: calendarMessages.GetCreateEventHourAndHalf(),
+				Text:     lang.T(calendarMessages.CreateEventShareLocationButton),
+				Location: true,
 			},
 		},
 		{
 			{
-				Text: calendarMessages.GetCreateEventTwoHours(),
-			},
-			{
-				Text: calendarMessages.GetCreateEventFourHours(),
-			},
-			{
-				Text: calendarMessages.GetCreateEventSixHours(),
-			},
-		},
-		{
-			{
-				Text: calendarMessages.GetCreateFullDay(),
+				Text: calendarMessages.GetCreateCancelText(lang),
 			},
 		},
 	}
 }
 
-func GetCreateOptionButtons(session *types.BotRedisSession) [][]tb.ReplyButton {
-	btns := make([][]tb.ReplyButton, 5)
+func GetCreateOptionButtons(lang i18n.Lang, session *types.BotRedisSession) [][]tb.ReplyButton {
+	btns := make([][]tb.ReplyButton, 6)
 	for i := range btns {
 		btns[i] = make([]tb.ReplyButton, 2)
 	}
 	idx := 0
-	if session.Step != telegram.StepCreateFrom {
+	if !wizard.Is(session, wizard.CreateFrom) {
 		btns[idx/2][idx%2] = tb.ReplyButton{
-			Text: calendarMessages.CreateEventChangeStartTimeButton,
+			Text: lang.T(calendarMessages.CreateEventChangeStartTimeButton),
 		}
 		idx++
 	}
 
-	if session.Step != telegram.StepCreateTo {
+	if !wizard.Is(session, wizard.CreateTo) {
 		btns[idx/2][idx%2] = tb.ReplyButton{
-			Text: calendarMessages.CreateEventChangeStopTimeButton,
+			Text: lang.T(calendarMessages.CreateEventChangeStopTimeButton),
 		}
 		idx++
 	}
 
-	if session.Step != telegram.StepCreateTitle {
+	if !wizard.Is(session, wizard.CreateTitle) {
 		if session.Event.Title == "" {
 			btns[idx/2][idx%2] = tb.ReplyButton{
-				Text: calendarMessages.CreateEventAddTitleButton,
+				Text: lang.T(calendarMessages.CreateEventAddTitleButton),
 			}
 		} else {
 			btns[idx/2][idx%2] = tb.ReplyButton{
-				Text: calendarMessages.CreateEventChangeTitleButton,
+				Text: lang.T(calendarMessages.CreateEventChangeTitleButton),
 			}
 		}
 		idx++
 	}
 
-	if session.Step != telegram.StepCreateDesc {
+	if !wizard.Is(session, wizard.CreateDesc) {
 		if session.Event.Description == "" {
 			btns[idx/2][idx%2] = tb.ReplyButton{
-				Text: calendarMessages.CreateEventAddDescButton,
+				Text: lang.T(calendarMessages.CreateEventAddDescButton),
 			}
 		} else {
 			btns[idx/2][idx%2] = tb.ReplyButton{
-				Text: calendarMessages.CreateEventChangeDescButton,
+				Text: lang.T(calendarMessages.CreateEventChangeDescButton),
 			}
 		}
 		idx++
 	}
 
-	if session.Step != telegram.StepCreateLocation {
+	if !wizard.Is(session, wizard.CreateLocation) {
 		if session.Event.Location.Description == "" {
 			btns[idx/2][idx%2] = tb.ReplyButton{
-				Text: calendarMessages.CreateEventAddLocationButton,
+				Text: lang.T(calendarMessages.CreateEventAddLocationButton),
 			}
 		} else {
 			btns[idx/2][idx%2] = tb.ReplyButton{
-				Text: calendarMessages.CreateEventChangeLocationButton,
+				Text: lang.T(calendarMessages.CreateEventChangeLocationButton),
 			}
 		}
 		idx++
 	}
 
-	if session.Step != telegram.StepCreateUser {
+	if !wizard.Is(session, wizard.CreateUser) {
 		btns[idx/2][idx%2] = tb.ReplyButton{
-			Text: calendarMessages.CreateEventAddUser,
+			Text: lang.T(calendarMessages.CreateEventAddUser),
 		}
 		idx++
 	}
 
+	btns[idx/2][idx%2] = tb.ReplyButton{
+		Text: lang.T(calendarMessages.CreateEventRoomButton),
+	}
+	idx++
+
+	btns[idx/2][idx%2] = tb.ReplyButton{
+		Text: calendarMessages.GetCreateCallButton(lang, session.Event.Call),
+	}
+	idx++
+
 	if !session.Event.FullDay {
 		btns[idx/2][idx%2] = tb.ReplyButton{
-			Text: calendarMessages.GetCreateFullDay(),
+			Text: calendarMessages.GetCreateFullDay(lang),
 		}
 	}
 
-	btns[4][0] = tb.ReplyButton{
-		Text: calendarMessages.GetCreateCancelText(),
+	btns[5][0] = tb.ReplyButton{
+		Text: calendarMessages.GetCreateCancelText(lang),
 	}
 
 	return btns
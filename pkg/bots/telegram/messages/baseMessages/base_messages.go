@@ -1,53 +1,53 @@
 package baseMessages
 
 import (
-	"fmt"
+	"github.com/calendar-bot/pkg/i18n"
 	"github.com/calendar-bot/pkg/services/oauth"
 )
 
 const (
-	startNoRegText = "Добрый день, пожалуйста авторизуйтесь для начала работы с календарем. <b>Обратите внимание, что ваши " +
-		"данные будут отправлены во внешний сервис!</b>\n\nПосле того, как " +
-		"вы вернетесь сюда после авторизации - нажмите на кнопку, которая появится вместо поля для ввода сообщения," +
-		" или же воспользуйтесь командой /start"
-	startRegButtonText = "Войти в аккаунт mail.ru"
-	startRegText       = "Здравствуйте, %s! Вы успешно авторизовались в телеграм боте ассистент календаря " +
-		"Mail.ru.Теперь вы можете начать пользоваться ботом. Чтобы узнать какие функции доступны в боте - " +
-		"воспользуйтесь командой /help"
+	startNoRegText     i18n.ID = "base.start_no_reg_text"
+	startRegButtonText i18n.ID = "base.start_reg_button_text"
+	startRegText       i18n.ID = "base.start_reg_text"
 )
 
 const (
-	helpInfoText = "Это бот для работы с календарем mail.ru. Сейчас доступны следующие команды:\n\n" +
-		"/today - просмотр информации в календаре за <b>сегодня</b>\n/next - получение информации о " +
-		"<b>ближайшем событии</b>\n" +
-		"/date - просмотр информации в календаре за <b>выбранную дату</b>\n/create - <b>создание</b> события в " +
-		"календаре в одиночном " +
-		"и групповом чате. Поиск удобного времени для всех участников в групповом чате <b> - для работы каждом участнику" +
-		" необходимо авторизоваться в боте в личном чате с ним</b>\n/about - информация о команде разработке"
+	helpInfoText i18n.ID = "base.help_info_text"
 )
 
 const (
-	aboutInfoText = "Данный бот - это бот ассистент для калнедаря mail.ru. Для просмотра возможностей бота " +
-		"воспользуйтесь командой /help \n\n" +
-		"<b>Проект разработан командой Технопарка \"Трое в лодке не считая дебага\"</b>"
+	aboutInfoText i18n.ID = "base.about_info_text"
 )
 
-func StartNoRegText() string {
-	return startNoRegText
+const (
+	stopNotAuthText i18n.ID = "base.stop_not_auth_text"
+	stopText        i18n.ID = "base.stop_text"
+)
+
+func StartNoRegText(lang i18n.Lang) string {
+	return lang.T(startNoRegText)
+}
+
+func StartRegButtonText(lang i18n.Lang) string {
+	return lang.T(startRegButtonText)
+}
+
+func StartRegText(lang i18n.Lang, info oauth.UserInfoResponse) string {
+	return lang.T(startRegText, info.Name)
 }
 
-func StartRegButtonText() string {
-	return startRegButtonText
+func HelpInfoText(lang i18n.Lang) string {
+	return lang.T(helpInfoText)
 }
 
-func StartRegText(info oauth.UserInfoResponse) string {
-	return fmt.Sprintf(startRegText, info.Name)
+func AboutText(lang i18n.Lang) string {
+	return lang.T(aboutInfoText)
 }
 
-func HelpInfoText() string {
-	return helpInfoText
+func StopNotAuthText(lang i18n.Lang) string {
+	return lang.T(stopNotAuthText)
 }
 
-func AboutText() string {
-	return aboutInfoText
+func StopText(lang i18n.Lang) string {
+	return lang.T(stopText)
 }
@@ -0,0 +1,11 @@
+package baseMessages
+
+import (
+	"github.com/calendar-bot/pkg/i18n"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestCatalogComplete(t *testing.T) {
+	assert.Empty(t, i18n.Missing(i18n.En))
+}
@@ -0,0 +1,45 @@
+package baseMessages
+
+import "github.com/calendar-bot/pkg/i18n"
+
+func init() {
+	i18n.Register(i18n.En, map[i18n.ID]string{
+		startNoRegText: "Hello, please log in to start working with the calendar. <b>Note that your " +
+			"data will be sent to an external service!</b>\n\nWhen " +
+			"you get back here after logging in, tap the button which appears instead of the message field," +
+			" or use the /start command",
+		startRegButtonText: "Log in to mail.ru",
+		startRegText: "Hello, %s! You have logged in to the Mail.ru calendar assistant telegram bot. " +
+			"Now you can start using the bot. To see what the bot can do, " +
+			"use the /help command",
+
+		helpInfoText: "This is a bot for the mail.ru calendar. The following commands are available:\n\n" +
+			"/today - your calendar for <b>today</b>\n/next - your " +
+			"<b>next event</b>\n" +
+			"/date - your calendar for <b>a chosen date</b>\n/create - <b>create</b> an event in " +
+			"the calendar in a private " +
+			"or group chat. Finding a convenient time for all members of a group chat <b> - every member" +
+			" needs to log in to the bot in a private chat with it</b>\n" +
+			"/now - the current event, and in a group chat - <b>who is in a meeting now</b>\n" +
+			"/week - your <b>events for this week</b>\n" +
+			"/images - send the schedule as <b>an image</b> or as text\n" +
+			"/focus - automatically book <b>focus time</b> without meetings\n" +
+			"/vacation - <b>vacation</b>: automatically decline invitations and let colleagues know\n" +
+			"/rules - <b>rules</b> to answer invitations automatically\n" +
+			"/templates - event <b>templates</b> to create events in a couple of taps\n" +
+			"/holidays - upcoming <b>holidays and days off</b> from the holiday calendar\n" +
+			"/availability - <b>members' busy time</b> in a group chat for the week\n" +
+			"/bindcalendar - bind <b>a shared calendar</b> to a group chat\n" +
+			"/members - which <b>group members</b> haven't connected the calendar yet\n" +
+			"/language - the bot <b>language</b>\n" +
+			"/settings - <b>settings</b>: timezone, meeting duration, reminders and the daily schedule\n" +
+			"/about - about the development team",
+
+		aboutInfoText: "This bot is an assistant for the mail.ru calendar. To see what the bot can do, " +
+			"use the /help command \n\n" +
+			"<b>The project is developed by the Technopark team \"Three in a boat, not counting the debug\"</b>",
+
+		stopNotAuthText: "You are not logged in to the bot. To log in, use the /start command in a private chat with the bot",
+		stopText:        "You have logged out",
+	})
+}
@@ -0,0 +1,45 @@
+package baseMessages
+
+import "github.com/calendar-bot/pkg/i18n"
+
+func init() {
+	i18n.Register(i18n.Ru, map[i18n.ID]string{
+		startNoRegText: "Добрый день, пожалуйста авторизуйтесь для начала работы с календарем. <b>Обратите внимание, что ваши " +
+			"данные будут отправлены во внешний сервис!</b>\n\nПосле того, как " +
+			"вы вернетесь сюда после авторизации - нажмите на кнопку, которая появится вместо поля для ввода сообщения," +
+			" или же воспользуйтесь командой /start",
+		startRegButtonText: "Войти в аккаунт mail.ru",
+		startRegText: "Здравствуйте, %s! Вы успешно авторизовались в телеграм боте ассистент календаря " +
+			"Mail.ru.Теперь вы можете начать пользоваться ботом. Чтобы узнать какие функции доступны в боте - " +
+			"воспользуйтесь командой /help",
+
+		helpInfoText: "Это бот для работы с календарем mail.ru. Сейчас доступны следующие команды:\n\n" +
+			"/today - просмотр информации в календаре за <b>сегодня</b>\n/next - получение информации о " +
+			"<b>ближайшем событии</b>\n" +
+			"/date - просмотр информации в календаре за <b>выбранную дату</b>\n/create - <b>создание</b> события в " +
+			"календаре в одиночном " +
+			"и групповом чате. Поиск удобного времени для всех участников в групповом чате <b> - для работы каждом участнику" +
+			" необходимо авторизоваться в боте в личном чате с ним</b>\n" +
+			"/now - текущее событие, а в групповом чате - <b>кто сейчас на встрече</b>\n" +
+			"/week - ваши <b>события на текущую неделю</b>\n" +
+			"/images - присылать расписание <b>картинкой</b> или текстом\n" +
+			"/focus - автоматически бронировать <b>время для фокуса</b> без встреч\n" +
+			"/vacation - <b>отпуск</b>: автоматически отклонять приглашения и предупреждать коллег\n" +
+			"/rules - <b>правила</b> для автоматического ответа на приглашения\n" +
+			"/templates - <b>шаблоны</b> событий для создания в пару нажатий\n" +
+			"/holidays - ближайшие <b>праздники и выходные</b> из календаря праздников\n" +
+			"/availability - <b>занятость участников</b> группового чата на неделю\n" +
+			"/bindcalendar - привязать к групповому чату <b>общий календарь</b>\n" +
+			"/members - кто из <b>участников группы</b> ещё не подключил календарь\n" +
+			"/language - <b>язык</b> бота\n" +
+			"/settings - <b>настройки</b>: часовой пояс, длительность встреч, напоминания и расписание на день\n" +
+			"/about - информация о команде разработке",
+
+		aboutInfoText: "Данный бот - это бот ассистент для калнедаря mail.ru. Для просмотра возможностей бота " +
+			"воспользуйтесь командой /help \n\n" +
+			"<b>Проект разработан командой Технопарка \"Трое в лодке не считая дебага\"</b>",
+
+		stopNotAuthText: "Вы не авторизованны в боте. Для авторизации воспользуйтесь командой /start в личном чате с ботом",
+		stopText:        "Вы успешно разлогинились",
+	})
+}
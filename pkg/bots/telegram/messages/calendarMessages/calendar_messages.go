@@ -3,220 +3,465 @@ package calendarMessages
 import (
 	"fmt"
 	"github.com/calendar-bot/pkg/bots/telegram"
+	eUseCase "github.com/calendar-bot/pkg/events/usecase"
+	"github.com/calendar-bot/pkg/i18n"
 	"github.com/calendar-bot/pkg/types"
 	"github.com/goodsign/monday"
 	"github.com/senseyeio/spaniel"
+	"html"
 	"strings"
 	"time"
 )
 
+const (
+	eventNoTitleText         i18n.ID = "calendar.event_no_title_text"
+	eventTimeText            i18n.ID = "calendar.event_time_text"
+	eventTimeFullDay         i18n.ID = "calendar.event_time_full_day"
+	eventDateStart           i18n.ID = "calendar.event_date_start"
+	eventPlaceText           i18n.ID = "calendar.event_place_text"
+	eventOrganizerText       i18n.ID = "calendar.event_organizer_text"
+	EventCalendarText        i18n.ID = "calendar.event_calendar_text"
+	eventAttendeesHeaderText i18n.ID = "calendar.event_attendees_header_text"
+	eventDescriptionHeader   i18n.ID = "calendar.event_description_header"
+	eventConfroomsHeader     i18n.ID = "calendar.event_confrooms_header"
+
+	eventTodayTitle i18n.ID = "calendar.event_today_title"
+	eventWeekTitle  i18n.ID = "calendar.event_week_title"
+	eventDateTitle  i18n.ID = "calendar.event_date_title"
+	eventNextTitle  i18n.ID = "calendar.event_next_title"
+
+	eventGetDateHeader          i18n.ID = "calendar.event_get_date_header"
+	findTimeStartHeader         i18n.ID = "calendar.find_time_start_header"
+	findTimeStopHeader          i18n.ID = "calendar.find_time_stop_header"
+	findTimeInfoHeader          i18n.ID = "calendar.find_time_info_header"
+	findTimeStartTime           i18n.ID = "calendar.find_time_start_time"
+	findTimeStopTime            i18n.ID = "calendar.find_time_stop_time"
+	findTimeDuration            i18n.ID = "calendar.find_time_duration"
+	findTimeTextFormat          i18n.ID = "calendar.find_time_text_format"
+	FindTimeChooseDayPartHeader i18n.ID = "calendar.find_time_choose_day_part_header"
+	FindTimeChooseLengthHeader  i18n.ID = "calendar.find_time_choose_length_header"
+	eventGetDateMessage         i18n.ID = "calendar.event_get_date_message"
+
+	holidaysTitle    i18n.ID = "calendar.holidays_title"
+	holidaysNotFound i18n.ID = "calendar.holidays_not_found"
+
+	imageModeEnabled       i18n.ID = "calendar.image_mode_enabled"
+	imageModeDisabled      i18n.ID = "calendar.image_mode_disabled"
+	FindTimeHeatmapCaption i18n.ID = "calendar.find_time_heatmap_caption"
+
+	nowCurrentTitle      i18n.ID = "calendar.now_current_title"
+	nowNoCurrentEvent    i18n.ID = "calendar.now_no_current_event"
+	nowRemaining         i18n.ID = "calendar.now_remaining"
+	nowNextTitle         i18n.ID = "calendar.now_next_title"
+	nowNoNextEvent       i18n.ID = "calendar.now_no_next_event"
+	usersStatusHeader    i18n.ID = "calendar.users_status_header"
+	userStatusBusy       i18n.ID = "calendar.user_status_busy"
+	userStatusFree       i18n.ID = "calendar.user_status_free"
+	userStatusFreeFrom   i18n.ID = "calendar.user_status_free_from"
+	userStatusNoFreeTime i18n.ID = "calendar.user_status_no_free_time"
+	userStatusNext       i18n.ID = "calendar.user_status_next"
+	usersStatusNoMembers i18n.ID = "calendar.users_status_no_members"
+
+	focusRuleEnabled   i18n.ID = "calendar.focus_rule_enabled"
+	focusRuleMorning   i18n.ID = "calendar.focus_rule_morning"
+	focusRuleLongest   i18n.ID = "calendar.focus_rule_longest"
+	focusRuleDisabled  i18n.ID = "calendar.focus_rule_disabled"
+	durationHours      i18n.ID = "calendar.duration_hours"
+	durationMinutes    i18n.ID = "calendar.duration_minutes"
+	FocusMorningButton i18n.ID = "calendar.focus_morning_button"
+	FocusAnyTimeButton i18n.ID = "calendar.focus_any_time_button"
+	FocusOffButton     i18n.ID = "calendar.focus_off_button"
+	// FocusEventDescription is the description of the focus blocks created in the calendar
+	FocusEventDescription i18n.ID = "calendar.focus_event_description"
+
+	vacationUsage       i18n.ID = "calendar.vacation_usage"
+	vacationActive      i18n.ID = "calendar.vacation_active"
+	VacationBadDates    i18n.ID = "calendar.vacation_bad_dates"
+	VacationCancelled   i18n.ID = "calendar.vacation_cancelled"
+	VacationNotSet      i18n.ID = "calendar.vacation_not_set"
+	vacationGroupHeader i18n.ID = "calendar.vacation_group_header"
+	vacationGroupLine   i18n.ID = "calendar.vacation_group_line"
+
+	invitationRulesHeader       i18n.ID = "calendar.invitation_rules_header"
+	invitationRulesEmpty        i18n.ID = "calendar.invitation_rules_empty"
+	invitationRulesUsage        i18n.ID = "calendar.invitation_rules_usage"
+	invitationRuleAccept        i18n.ID = "calendar.invitation_rule_accept"
+	invitationRuleDecline       i18n.ID = "calendar.invitation_rule_decline"
+	invitationRuleAny           i18n.ID = "calendar.invitation_rule_any"
+	invitationRuleOrganizer     i18n.ID = "calendar.invitation_rule_organizer"
+	invitationRuleCalendar      i18n.ID = "calendar.invitation_rule_calendar"
+	invitationRuleStartsAfter   i18n.ID = "calendar.invitation_rule_starts_after"
+	invitationRuleEndsBefore    i18n.ID = "calendar.invitation_rule_ends_before"
+	invitationRuleMinDuration   i18n.ID = "calendar.invitation_rule_min_duration"
+	invitationRuleMaxDuration   i18n.ID = "calendar.invitation_rule_max_duration"
+	invitationRuleNoConflict    i18n.ID = "calendar.invitation_rule_no_conflict"
+	invitationRuleDeleteButton  i18n.ID = "calendar.invitation_rule_delete_button"
+	InvitationRuleAdded         i18n.ID = "calendar.invitation_rule_added"
+	InvitationRuleDeleted       i18n.ID = "calendar.invitation_rule_deleted"
+	invitationRuleBadFormat     i18n.ID = "calendar.invitation_rule_bad_format"
+	invitationRulesReportHeader i18n.ID = "calendar.invitation_rules_report_header"
+
+	templatesHeader       i18n.ID = "calendar.templates_header"
+	templatesEmpty        i18n.ID = "calendar.templates_empty"
+	templatesUsage        i18n.ID = "calendar.templates_usage"
+	templateAttendees     i18n.ID = "calendar.template_attendees"
+	templateCall          i18n.ID = "calendar.template_call"
+	templateAppliedHeader i18n.ID = "calendar.template_applied_header"
+	templateSavedText     i18n.ID = "calendar.template_saved_text"
+	TemplateSaveText      i18n.ID = "calendar.template_save_text"
+	TemplateSaveButton    i18n.ID = "calendar.template_save_button"
+	TemplateSaved         i18n.ID = "calendar.template_saved"
+	TemplateDeleted       i18n.ID = "calendar.template_deleted"
+	TemplateApplied       i18n.ID = "calendar.template_applied"
+	TemplateNotFound      i18n.ID = "calendar.template_not_found"
+	TemplateSaveOutdated  i18n.ID = "calendar.template_save_outdated"
+
+	InlineLoginText     i18n.ID = "calendar.inline_login_text"
+	inlineResultFullDay i18n.ID = "calendar.inline_result_full_day"
+
+	ShareEventButton     i18n.ID = "calendar.share_event_button"
+	ShareEventBackButton i18n.ID = "calendar.share_event_back_button"
+	ShareEventNoGroups   i18n.ID = "calendar.share_event_no_groups"
+	ShareEventNotAllowed i18n.ID = "calendar.share_event_not_allowed"
+	ShareEventNoAccess   i18n.ID = "calendar.share_event_no_access"
+	shareEventShared     i18n.ID = "calendar.share_event_shared"
+
+	PostedEventCancelled i18n.ID = "calendar.posted_event_cancelled"
+
+	bindCalendarHeader       i18n.ID = "calendar.bind_calendar_header"
+	bindCalendarCurrent      i18n.ID = "calendar.bind_calendar_current"
+	bindCalendarChoose       i18n.ID = "calendar.bind_calendar_choose"
+	bindCalendarDone         i18n.ID = "calendar.bind_calendar_done"
+	BindCalendarRemoveButton i18n.ID = "calendar.bind_calendar_remove_button"
+	BindCalendarRemoved      i18n.ID = "calendar.bind_calendar_removed"
+	BindCalendarGroupOnly    i18n.ID = "calendar.bind_calendar_group_only"
+	BindCalendarNotAdmin     i18n.ID = "calendar.bind_calendar_not_admin"
+	BindCalendarNoCalendars  i18n.ID = "calendar.bind_calendar_no_calendars"
+	BindCalendarNotFound     i18n.ID = "calendar.bind_calendar_not_found"
+	BindCalendarCreateDenied i18n.ID = "calendar.bind_calendar_create_denied"
+
+	membersHeader           i18n.ID = "calendar.members_header"
+	membersAuthenticated    i18n.ID = "calendar.members_authenticated"
+	membersNotAuthenticated i18n.ID = "calendar.members_not_authenticated"
+	membersEmpty            i18n.ID = "calendar.members_empty"
+	membersAllAuthenticated i18n.ID = "calendar.members_all_authenticated"
+	MembersLoginButton      i18n.ID = "calendar.members_login_button"
+
+	forwardedFrom        i18n.ID = "calendar.forwarded_from"
+	forwardedFromUnknown i18n.ID = "calendar.forwarded_from_unknown"
+
+	availabilityHeader    i18n.ID = "calendar.availability_header"
+	availabilityWeekdays  i18n.ID = "calendar.availability_weekdays"
+	availabilityLegend    i18n.ID = "calendar.availability_legend"
+	availabilityNoMembers i18n.ID = "calendar.availability_no_members"
+
+	AvailabilityJoinButton     i18n.ID = "calendar.availability_join_button"
+	AvailabilityPrevWeekButton i18n.ID = "calendar.availability_prev_week_button"
+	AvailabilityNextWeekButton i18n.ID = "calendar.availability_next_week_button"
+
+	eventNoTodayEventsFound  i18n.ID = "calendar.event_no_today_events_found"
+	eventNoWeekEventsFound   i18n.ID = "calendar.event_no_week_events_found"
+	eventNoDateEventsFound   i18n.ID = "calendar.event_no_date_events_found"
+	eventNoClosestEventFound i18n.ID = "calendar.event_no_closest_event_found"
+
+	findTimePollHeader      i18n.ID = "calendar.find_time_poll_header"
+	FindTimeAdd             i18n.ID = "calendar.find_time_add"
+	FindTimeFind            i18n.ID = "calendar.find_time_find"
+	FindTimeBack            i18n.ID = "calendar.find_time_back"
+	FindTimeExist           i18n.ID = "calendar.find_time_exist"
+	FindTimeNotFound        i18n.ID = "calendar.find_time_not_found"
+	FindTimePeriodIsTooLong i18n.ID = "calendar.find_time_period_is_too_long"
+
+	eventDateNotParsed        i18n.ID = "calendar.event_date_not_parsed"
+	EventDateToIsBeforeFrom   i18n.ID = "calendar.event_date_to_is_before_from"
+	eventSessionNotFound      i18n.ID = "calendar.event_session_not_found"
+	EventNoEventDataFound     i18n.ID = "calendar.event_no_event_data_found"
+	CallbackExpired           i18n.ID = "calendar.callback_expired"
+	eventShowNotFoundError    i18n.ID = "calendar.event_show_not_found_error"
+	eventCallbackResponseText i18n.ID = "calendar.event_callback_response_text"
+
+	eventCancelSearchDate   i18n.ID = "calendar.event_cancel_search_date"
+	eventCanceledSearchDate i18n.ID = "calendar.event_canceled_search_date"
+
+	createEventHeader  i18n.ID = "calendar.create_event_header"
+	createdEventHeader i18n.ID = "calendar.created_event_header"
+
+	createEventInitText     i18n.ID = "calendar.create_event_init_text"
+	createEventToText       i18n.ID = "calendar.create_event_to_text"
+	createEventTitleText    i18n.ID = "calendar.create_event_title_text"
+	CreateEventDescText     i18n.ID = "calendar.create_event_desc_text"
+	CreateEventLocationText i18n.ID = "calendar.create_event_location_text"
+	CreateEventUserText     i18n.ID = "calendar.create_event_user_text"
+
+	createEventCreateText   i18n.ID = "calendar.create_event_create_text"
+	createEventCreatedText  i18n.ID = "calendar.create_event_created_text"
+	createEventCancelText   i18n.ID = "calendar.create_event_cancel_text"
+	createEventCanceledText i18n.ID = "calendar.create_event_canceled_text"
+
+	createEventHalfHour    i18n.ID = "calendar.create_event_half_hour"
+	createEventHour        i18n.ID = "calendar.create_event_hour"
+	createEventHourAndHalf i18n.ID = "calendar.create_event_hour_and_half"
+	createEventTwoHours    i18n.ID = "calendar.create_event_two_hours"
+	createEventFourHours   i18n.ID = "calendar.create_event_four_hours"
+	createEventSixHours    i18n.ID = "calendar.create_event_six_hours"
+	createEventFullDay     i18n.ID = "calendar.create_event_full_day"
+
+	CreateEventChangeStartTimeButton i18n.ID = "calendar.create_event_change_start_time_button"
+	CreateEventChangeStopTimeButton  i18n.ID = "calendar.create_event_change_stop_time_button"
+	CreateEventAddTitleButton        i18n.ID = "calendar.create_event_add_title_button"
+	CreateEventChangeTitleButton     i18n.ID = "calendar.create_event_change_title_button"
+	CreateEventAddDescButton         i18n.ID = "calendar.create_event_add_desc_button"
+	CreateEventChangeDescButton      i18n.ID = "calendar.create_event_change_desc_button"
+	CreateEventAddLocationButton     i18n.ID = "calendar.create_event_add_location_button"
+	CreateEventChangeLocationButton  i18n.ID = "calendar.create_event_change_location_button"
+	CreateEventAddUser               i18n.ID = "calendar.create_event_add_user"
+	CreateEventRoomButton            i18n.ID = "calendar.create_event_room_button"
+	CreateEventAddCallButton         i18n.ID = "calendar.create_event_add_call_button"
+	CreateEventRemoveCallButton      i18n.ID = "calendar.create_event_remove_call_button"
+	CreateEventShareLocationButton   i18n.ID = "calendar.create_event_share_location_button"
+	EventVenueButton                 i18n.ID = "calendar.event_venue_button"
+
+	CreateEventRoomText         i18n.ID = "calendar.create_event_room_text"
+	CreateEventRoomNoRooms      i18n.ID = "calendar.create_event_room_no_rooms"
+	CreateEventRoomNoFreeRooms  i18n.ID = "calendar.create_event_room_no_free_rooms"
+	CreateEventRoomNeedsTime    i18n.ID = "calendar.create_event_room_needs_time"
+	createEventRoomItem         i18n.ID = "calendar.create_event_room_item"
+	CreateEventRoomNoneButton   i18n.ID = "calendar.create_event_room_none_button"
+	CreateEventRoomSelectedText i18n.ID = "calendar.create_event_room_selected_text"
+
+	CreateEventCallAddedText   i18n.ID = "calendar.create_event_call_added_text"
+	CreateEventCallRemovedText i18n.ID = "calendar.create_event_call_removed_text"
+
+	ShowTodayTasks  i18n.ID = "calendar.show_today_tasks"
+	ShowTodayPhrase i18n.ID = "calendar.show_today_phrase"
+	ShowNextTask    i18n.ID = "calendar.show_next_task"
+	ShowNextPhrase  i18n.ID = "calendar.show_next_phrase"
+
+	CreateEventGo    i18n.ID = "calendar.create_event_go"
+	CreateEventNotGo i18n.ID = "calendar.create_event_not_go"
+
+	CreateEventAlreadyOrganize i18n.ID = "calendar.create_event_already_organize"
+	CreateEventAlreadyGo       i18n.ID = "calendar.create_event_already_go"
+	CreateEventAlreadyNotGo    i18n.ID = "calendar.create_event_already_not_go"
+	CreateEventCannotAdd       i18n.ID = "calendar.create_event_cannot_add"
+
+	CreateEventFindTimeMessage   i18n.ID = "calendar.create_event_find_time_message"
+	CreateEventFindTimeYesButton i18n.ID = "calendar.create_event_find_time_yes_button"
+	CreateEventFindTimeNoButton  i18n.ID = "calendar.create_event_find_time_no_button"
+
+	middlewaresUserNotAuthenticated i18n.ID = "calendar.middlewares_user_not_authenticated"
+	middlewaresGroupAlertBase       i18n.ID = "calendar.middlewares_group_alert_base"
+	middlewaresGroupAlertToday      i18n.ID = "calendar.middlewares_group_alert_today"
+	middlewaresGroupAlertNext       i18n.ID = "calendar.middlewares_group_alert_next"
+	middlewaresGroupAlertDate       i18n.ID = "calendar.middlewares_group_alert_date"
+
+	userNotAllow i18n.ID = "calendar.user_not_allow"
+
+	chooseStepText i18n.ID = "calendar.choose_step_text"
+
+	fastDateToday           i18n.ID = "calendar.fast_date_today"
+	fastDateTomorrow        i18n.ID = "calendar.fast_date_tomorrow"
+	FastCommandInHalfHour   i18n.ID = "calendar.fast_command_in_half_hour"
+	FastCommandInHour       i18n.ID = "calendar.fast_command_in_hour"
+	FastCommandInTwoHours   i18n.ID = "calendar.fast_command_in_two_hours"
+	FastCommandInThreeHours i18n.ID = "calendar.fast_command_in_three_hours"
+	FastCommandTodayAt9     i18n.ID = "calendar.fast_command_today_at_9"
+	FastCommandTodayAt12    i18n.ID = "calendar.fast_command_today_at_12"
+	FastCommandTodayAt15    i18n.ID = "calendar.fast_command_today_at_15"
+	FastCommandTodayAt18    i18n.ID = "calendar.fast_command_today_at_18"
+	FastCommandTomorrowAt9  i18n.ID = "calendar.fast_command_tomorrow_at_9"
+	FastCommandTomorrowAt12 i18n.ID = "calendar.fast_command_tomorrow_at_12"
+	FastCommandTomorrowAt15 i18n.ID = "calendar.fast_command_tomorrow_at_15"
+	FastCommandTomorrowAt18 i18n.ID = "calendar.fast_command_tomorrow_at_18"
+	FastCommandInWeekAt12   i18n.ID = "calendar.fast_command_in_week_at_12"
+	FastCommandInWeekAt15   i18n.ID = "calendar.fast_command_in_week_at_15"
+	FastCommandInWeekAt18   i18n.ID = "calendar.fast_command_in_week_at_18"
+
+	AlertYesButton             i18n.ID = "calendar.alert_yes_button"
+	AlertNoButton              i18n.ID = "calendar.alert_no_button"
+	FindTimeMorningButton      i18n.ID = "calendar.find_time_morning_button"
+	FindTimeAfternoonButton    i18n.ID = "calendar.find_time_afternoon_button"
+	FindTimeEveningButton      i18n.ID = "calendar.find_time_evening_button"
+	FindTimeWorkingHoursButton i18n.ID = "calendar.find_time_working_hours_button"
+	FindTimeAnyTimeButton      i18n.ID = "calendar.find_time_any_time_button"
+	FindTimeLength30m          i18n.ID = "calendar.find_time_length_30m"
+	FindTimeLength1h           i18n.ID = "calendar.find_time_length_1h"
+	FindTimeLength1h30m        i18n.ID = "calendar.find_time_length_1h30m"
+	FindTimeLength2h           i18n.ID = "calendar.find_time_length_2h"
+	FindTimeLength2h30m        i18n.ID = "calendar.find_time_length_2h30m"
+	FindTimeLength3h           i18n.ID = "calendar.find_time_length_3h"
+	FindTimeLength4h           i18n.ID = "calendar.find_time_length_4h"
+	FindTimeLength5h           i18n.ID = "calendar.find_time_length_5h"
+
+	languageText     i18n.ID = "calendar.language_text"
+	languageChanged  i18n.ID = "calendar.language_changed"
+	languageButton   i18n.ID = "calendar.language_button"
+	LanguageSelected i18n.ID = "calendar.language_selected"
+
+	settingsHeader              i18n.ID = "calendar.settings_header"
+	settingsTimezone            i18n.ID = "calendar.settings_timezone"
+	settingsLanguage            i18n.ID = "calendar.settings_language"
+	settingsLanguageAuto        i18n.ID = "calendar.settings_language_auto"
+	settingsEventDuration       i18n.ID = "calendar.settings_event_duration"
+	settingsCalendar            i18n.ID = "calendar.settings_calendar"
+	settingsCalendarPersonal    i18n.ID = "calendar.settings_calendar_personal"
+	settingsReminder            i18n.ID = "calendar.settings_reminder"
+	settingsReminderBefore      i18n.ID = "calendar.settings_reminder_before"
+	settingsDigest              i18n.ID = "calendar.settings_digest"
+	settingsDigestAt            i18n.ID = "calendar.settings_digest_at"
+	settingsOff                 i18n.ID = "calendar.settings_off"
+	settingsImageMode           i18n.ID = "calendar.settings_image_mode"
+	settingsImageModeImage      i18n.ID = "calendar.settings_image_mode_image"
+	settingsImageModeText       i18n.ID = "calendar.settings_image_mode_text"
+	settingsFooter              i18n.ID = "calendar.settings_footer"
+	settingsChooseTimezone      i18n.ID = "calendar.settings_choose_timezone"
+	settingsChooseLanguage      i18n.ID = "calendar.settings_choose_language"
+	settingsChooseEventDuration i18n.ID = "calendar.settings_choose_event_duration"
+	settingsChooseCalendar      i18n.ID = "calendar.settings_choose_calendar"
+	settingsChooseReminder      i18n.ID = "calendar.settings_choose_reminder"
+	settingsChooseDigest        i18n.ID = "calendar.settings_choose_digest"
+	settingsTimezoneButton      i18n.ID = "calendar.settings_timezone_button"
+	settingsLanguageButton      i18n.ID = "calendar.settings_language_button"
+	settingsEventDurationButton i18n.ID = "calendar.settings_event_duration_button"
+	settingsCalendarButton      i18n.ID = "calendar.settings_calendar_button"
+	settingsReminderButton      i18n.ID = "calendar.settings_reminder_button"
+	settingsDigestButton        i18n.ID = "calendar.settings_digest_button"
+	settingsImageModeButton     i18n.ID = "calendar.settings_image_mode_button"
+	SettingsResetButton         i18n.ID = "calendar.settings_reset_button"
+	SettingsBackButton          i18n.ID = "calendar.settings_back_button"
+	SettingsSaved               i18n.ID = "calendar.settings_saved"
+	SettingsWasReset            i18n.ID = "calendar.settings_was_reset"
+	SettingsBadValue            i18n.ID = "calendar.settings_bad_value"
+	reminderHeader              i18n.ID = "calendar.reminder_header"
+
+	callLinkButton i18n.ID = "calendar.call_link_button"
+	showMoreButton i18n.ID = "calendar.show_more_button"
+	showLessButton i18n.ID = "calendar.show_less_button"
+)
+
 const (
 	eventNameText                  = "<b>%s</b>\n\n"
-	eventNoTitleText               = "Без названия"
-	eventTimeText                  = "<u>Когда:</u>\n\n⏰ %s, <u>%s</u> - %s<u>%s</u>\n"
-	eventTimeFullDay               = "<u>Когда:</u>\n\n⏰ %s %s, <u>Весь день</u>\n"
-	eventDateStart                 = "<u>Когда:</u>\n\n⏰ <b>Начало:</b> %s %s\n"
-	eventPlaceText                 = "\n<u>Где:</u>\n\n📍 %s\n"
-	eventOrganizerText             = "\nСоздатель - <b>%s</b> (%s)\n"
 	eventSplitLine                 = "---------------\n"
-	EventCalendarText              = "🗓 Календарь <b>%s</b>"
-	eventAttendeesHeaderText       = "<u><i>Участники:</i></u>\n\n"
 	eventAttendeeText              = "%s (%s) "
 	eventAttendeeStatusAccepted    = "✅\n"
 	eventAttendeeStatusNeedsAction = "❓\n"
 	eventAttendeeStatusDeclined    = "❌\n"
-	eventDescriptionHeader         = "<u><i>Описание:</i></u>\n\n"
-	eventConfroomsHeader           = "<u><i>Переговорные комнаты:</i></u>\n\n"
-
-	eventTodayTitle = "<b>Ваши события на сегодня</b>"
-	eventDateTitle  = "<b>Ваши события за %s</b>"
-	eventNextTitle  = "<b>Ваше следующее событие</b>"
-
-	eventGetDateHeader          = "<b>Получение событий за определенную дату: </b>\n\n"
-	findTimeStartHeader         = "<b>Выберите дату начала для поиска времени: </b>\n\n"
-	findTimeStopHeader          = "<b>Выберите дату оконачания для поиска времени: </b>\n\n"
-	findTimeInfoHeader          = "<b>Поиск будет производиться этом временном промежутке </b>\n\n"
-	findTimeStartTime           = "<b>Начало поиска: </b> %s\n"
-	findTimeStopTime            = "<b>Окончание поиска: </b> %s"
-	findTimeDuration            = "<b>Продолжительность события: </b> %s"
-	findTimeTextFormat          = "%s с %s до %s"
-	FindTimeChooseDayPartHeader = "<b>Выберите период дня для события</b>\n\n"
-	FindTimeChooseLengthHeader  = "<b>Выберите продолжительность события</b>\n\n"
-	eventGetDateMessage         = "Для выбора даты воспользуйтесь кнопками или введите дату в формате " +
-		"<pre>&lt;число&gt; &lt;название месяца&gt;</pre> (например: <pre>22 марта</pre>)"
-
-	eventNoTodayEventsFound  = "У вас нет событий сегодня"
-	eventNoDateEventsFound   = "У вас нет событий за выбранную дату"
-	eventNoClosestEventFound = "У вас больше нет событий сегодня"
-
-	findTimePollHeader = "Выберите время. Если хотите, чтобы ваш календарь учитывался - нажмите на кнопку " +
-		"\"Участвую\"\n\nНа данный момент учитываются календари: "
-	FindTimeAdd             = "✅ Участвую"
-	FindTimeFind            = "Найти время"
-	FindTimeBack            = "Изменить параметры"
-	FindTimeExist           = "Вы уже участвуете"
-	FindTimeNotFound        = "К сожалению мы не нашли свободного времени для всех участников с учетом данных параметров"
-	FindTimePeriodIsTooLong = "Выбранный период для поиска слишком большой, сокращаем до максимального возможного"
-
-	eventDateNotParsed      = "Мы не смогли распознать дату, попробуйте еще раз"
-	EventDateToIsBeforeFrom = "<b>Введенная дата раньше начала события, введите корректную дату.</b>\n\n" +
-		"Если вы хотите переставить события на другое время - измените время начала события"
-	eventSessionNotFound = "Мы не смогли найти необходимую информацию для обработки запроса.\nВоспользуйтесь нужной вам " +
-		"командой заново"
-	EventNoEventDataFound = "<b>Мы не смогли распознать данные о событии. Попробуйте сформулировать предложение иначе." +
-		"</b>\n\nНапример: Учеба завтра с 10:00 до 13:00"
-	eventShowNotFoundError = "К сожалению мы не смогли найти информацию о событии.\nВозможно, это старое сообщение." +
-		"\nЗапросите событие с помощью бота заново."
-	eventCallbackResponseText = "Событие: %s"
-
-	eventCancelSearchDate   = "Отмена поиска событий"
-	eventCanceledSearchDate = "Поиск события отменен"
-
-	createEventHeader  = "<u><b>Что получается:</b></u>\n\n"
-	createdEventHeader = "<u><b>Событие создано:</b></u>\n\n"
-
-	createEventInitText = "<b>Введите время начала события</b>\n\nДля выбора даты и времени начала события" +
-		" воспользуйтесь кнопками или введите дату в формате <pre>&lt;число&gt; &lt;название месяца&gt; " +
-		"&lt;ЧЧ:ММ&gt;</pre> (например: 22 марта 15:00)"
-	createEventToText = "<b>Введите время окончания события</b>\n\nДля продолжительности события" +
-		" воспользуйтесь кнопками или введите дату оконочания в формате <pre>&lt;число&gt; &lt;название месяца&gt; " +
-		"&lt;ЧЧ:ММ&gt;</pre> (например: 22 марта 15:00)"
-	createEventTitleText    = "<b>Введите название события</b>"
-	CreateEventDescText     = "<b>Введите описание события</b>"
-	CreateEventLocationText = "<b>Введите место события</b>"
-	CreateEventUserText     = "<b>Введите email пользователя, которого хотите добавить. Можно ввести несколько" +
-		" через запятую</b>"
-
-	createEventCreateText   = "Создать событие"
-	createEventCreatedText  = "Событие успешно создано"
-	createEventCancelText   = "Отмена"
-	createEventCanceledText = "Создание события отменено"
-
-	createEventHalfHour    = "30 минут"
-	createEventHour        = "1 час"
-	createEventHourAndHalf = "1 час 30 минут"
-	createEventTwoHours    = "2 часа"
-	createEventFourHours   = "4 часа"
-	createEventSixHours    = "6 часов"
-	createEventFullDay     = "Весь день"
-
-	CreateEventChangeStartTimeButton = "Изменить время начала"
-	CreateEventChangeStopTimeButton  = "Изменить время окончания"
-	CreateEventAddTitleButton        = "Добавить название"
-	CreateEventChangeTitleButton     = "Изменить название"
-	CreateEventAddDescButton         = "Добавить описание"
-	CreateEventChangeDescButton      = "Изменить описание"
-	CreateEventAddLocationButton     = "Добавить место"
-	CreateEventChangeLocationButton  = "Изменить место"
-	CreateEventAddUser               = "Добавить участников"
-
-	ShowTodayTasks  = "покажи задачи на сегодня"
-	ShowTodayPhrase = "покажи события на сегодня"
-	ShowNextTask    = "покажи следующее событие"
-	ShowNextPhrase  = "покажи ближайшее события"
-
-	CreateEventGo    = "✅ Я иду"
-	CreateEventNotGo = "❌ Я не иду"
-
-	CreateEventAlreadyOrganize = "Вы являетесь создателем события и участуете в нем по умолчанию"
-	CreateEventAlreadyGo       = "Вы уже дали согласие на участие в событии"
-	CreateEventAlreadyNotGo    = "Вы уже дали отказ от участия в событии"
-	CreateEventCannotAdd       = "Мы не смогли изменить ваш статус в событии - подтвердите его с помощью календаря или письма" +
-		" из почты"
-
-	CreateEventFindTimeMessage   = "Как создать событие?"
-	CreateEventFindTimeYesButton = "🔍 Найти удобное время"
-	CreateEventFindTimeNoButton  = "⏰ Создать на конкретное время"
-
-	middlewaresUserNotAuthenticated = "Вы не можете воспользоваться данной функцией пока не авторизуетесь в боте через" +
-		" аккаунт mail.ru. Для авторизации воспользуйтесь командой /start в личном чате с ботом"
-	middlewaresGroupAlertBase  = "Вы уверены, что хотите показать "
-	middlewaresGroupAlertToday = "<b>ВСЕМ</b> свои события на сегодня?"
-	middlewaresGroupAlertNext  = "<b>ВСЕМ</b> своё следующее событие на сегодня?"
-	middlewaresGroupAlertDate  = "<b>ВСЕМ</b> свои события за определенную дату?"
-
-	userNotAllow = "Вы не можете взаимодействовать с данной кнопкой"
+	holidayText                    = "🎉 %s - <b>%s</b>\n"
+	FocusSelectedButton            = "✅ "
+	vacationMessageLine            = "\n\n💬 %s"
+	vacationGroupMessage           = "\n💬 %s"
+	invitationRuleLine             = "%d. %s\n"
+	invitationRuleTimeOfDay        = "%02d:%02d"
+	invitationRulesReportLine      = "%s <b>%s</b>, %s\n"
+	invitationRuleAccepted         = "✅"
+	invitationRuleDeclined         = "❌"
+	templateLine                   = "%d. <b>%s</b>%s\n"
+	templateDetail                 = ", %s"
+	templateApplyButton            = "▶ %s"
+	templateDeleteButton           = "🗑"
+	inlineResultDescription        = "%s, %s - %s"
+	shareEventGroup                = "👥 %s"
+	bindCalendarButton             = "📅 %s"
+	forwardedLink                  = "\n%s"
+	availabilityNameWidth          = 12
 )
 
 const (
 	formatSpan = "2 January"
 	formatDate = "2 January 2006"
 	formatTime = "15:04"
-	locale     = monday.LocaleRuRU
 )
 
-const (
-	callLinkButton = "📲 Ссылка на звонок"
-	showMoreButton = "🔻 Развернуть"
-	showLessButton = "🔺 Свернуть"
-)
+// fastCommands are the reply buttons with the phrases the date parser understands
+var fastCommands = []i18n.ID{
+	FastCommandInHalfHour, FastCommandInHour, FastCommandInTwoHours, FastCommandInThreeHours,
+	FastCommandTodayAt9, FastCommandTodayAt12, FastCommandTodayAt15, FastCommandTodayAt18,
+	FastCommandTomorrowAt9, FastCommandTomorrowAt12, FastCommandTomorrowAt15, FastCommandTomorrowAt18,
+	FastCommandInWeekAt12, FastCommandInWeekAt15, FastCommandInWeekAt18,
+}
+
+// fastDays is the number of days offered on the date keyboards
+const fastDays = 6
+
+// createDurations are the buttons with the duration of the new event
+var createDurations = []struct {
+	duration time.Duration
+	id       i18n.ID
+}{
+	{30 * time.Minute, createEventHalfHour},
+	{time.Hour, createEventHour},
+	{90 * time.Minute, createEventHourAndHalf},
+	{2 * time.Hour, createEventTwoHours},
+	{4 * time.Hour, createEventFourHours},
+	{6 * time.Hour, createEventSixHours},
+}
 
-func parseDate(event *types.Event) []interface{} {
-	fromDate := monday.Format(event.From, formatDate, locale)
+func parseDate(lang i18n.Lang, event *types.Event) []interface{} {
+	fromDate := monday.Format(event.From, formatDate, lang.MondayLocale())
 	toDate := ""
 	if event.From.Year() != event.To.Year() ||
 		event.From.Month() != event.To.Month() ||
 		event.From.Day() != event.To.Day() {
-		toDate = monday.Format(event.To, formatDate, locale)
+		toDate = monday.Format(event.To, formatDate, lang.MondayLocale())
 		toDate += ", "
 	}
 
 	return []interface{}{fromDate, event.From.Format(formatTime), toDate, event.To.Format(formatTime)}
 }
 
-func parseDateFullDay(event *types.Event) []interface{} {
-	fromDate := monday.Format(event.From, formatDate, locale)
+func parseDateFullDay(lang i18n.Lang, event *types.Event) []interface{} {
+	fromDate := monday.Format(event.From, formatDate, lang.MondayLocale())
 	toDate := ""
 	if event.From.Year() != event.To.Year() ||
 		event.From.Month() != event.To.Month() ||
 		event.From.Day()+1 != event.To.Day() {
 		toDate += "- "
-		toDate += monday.Format(event.To, formatDate, locale)
+		toDate += monday.Format(event.To, formatDate, lang.MondayLocale())
 	}
 
 	return []interface{}{fromDate, toDate}
 }
 
-func SingleEventShortText(event *types.Event, isCalendarSend bool) string {
+func SingleEventShortText(lang i18n.Lang, event *types.Event, isCalendarSend bool) string {
 	shortEventText := ""
 	title := event.Title
 	if title == "" {
-		title = eventNoTitleText
+		title = lang.T(eventNoTitleText)
 	}
 	shortEventText += fmt.Sprintf(eventNameText, title)
 	if !event.FullDay {
-		shortEventText += fmt.Sprintf(eventTimeText, parseDate(event)...)
+		shortEventText += lang.T(eventTimeText, parseDate(lang, event)...)
 	} else {
-		shortEventText += fmt.Sprintf(eventTimeFullDay, parseDateFullDay(event)...)
+		shortEventText += lang.T(eventTimeFullDay, parseDateFullDay(lang, event)...)
 	}
 
 	if isCalendarSend {
 		shortEventText += eventSplitLine
-		shortEventText += fmt.Sprintf(EventCalendarText, event.Calendar.Title)
+		shortEventText += lang.T(EventCalendarText, event.Calendar.Title)
 	}
 	return shortEventText
 }
 
-func SingleEventFullText(event *types.Event) string {
+func SingleEventFullText(lang i18n.Lang, event *types.Event) string {
 	fullEventText := ""
 	title := event.Title
 	if title == "" {
-		title = eventNoTitleText
+		title = lang.T(eventNoTitleText)
 	}
 	fullEventText += fmt.Sprintf(eventNameText, title)
 
 	if event.To.IsZero() && !event.From.IsZero() {
-		fullEventText += fmt.Sprintf(eventDateStart, monday.Format(event.From, formatDate, locale),
-			monday.Format(event.From, formatTime, locale))
+		fullEventText += lang.T(eventDateStart, monday.Format(event.From, formatDate, lang.MondayLocale()),
+			monday.Format(event.From, formatTime, lang.MondayLocale()))
 	} else {
 		if !event.FullDay {
-			fullEventText += fmt.Sprintf(eventTimeText, parseDate(event)...)
+			fullEventText += lang.T(eventTimeText, parseDate(lang, event)...)
 		} else {
-			fullEventText += fmt.Sprintf(eventTimeFullDay, parseDateFullDay(event)...)
+			fullEventText += lang.T(eventTimeFullDay, parseDateFullDay(lang, event)...)
 		}
 	}
 	if event.Location.Description != "" {
-		fullEventText += fmt.Sprintf(eventPlaceText, event.Location.Description)
+		fullEventText += lang.T(eventPlaceText, event.Location.Description)
 	}
 
 	organizer := event.Organizer.Name
@@ -228,27 +473,27 @@ func SingleEventFullText(event *types.Event) string {
 		organizer = telegram.MailRuCalendarName
 		email = telegram.MailRuDomain
 	}
-	fullEventText += "\n" + fmt.Sprintf(eventOrganizerText, organizer, email)
+	fullEventText += "\n" + lang.T(eventOrganizerText, organizer, email)
 
 	if len(event.Location.Confrooms) > 0 {
 		fullEventText += eventSplitLine
-		fullEventText += eventConfroomsHeader
+		fullEventText += lang.T(eventConfroomsHeader)
 		for _, confroom := range event.Location.Confrooms {
 			fullEventText += confroom + "\n"
 		}
 	}
 
 	if len(event.Attendees) > 1 {
-		fullEventText += eventAttendeesHeaderText
+		fullEventText += lang.T(eventAttendeesHeaderText)
 		for _, attendee := range event.Attendees {
 			if attendee.Email == event.Organizer.Email {
 				continue
 			}
 			fullEventText += fmt.Sprintf(eventAttendeeText, attendee.Name, attendee.Email)
 			switch attendee.Status {
-			case telegram.StatusAccepted:
+			case types.StatusAccepted:
 				fullEventText += eventAttendeeStatusAccepted
-			case telegram.StatusDeclined:
+			case types.StatusDeclined:
 				fullEventText += eventAttendeeStatusDeclined
 			default:
 				fullEventText += eventAttendeeStatusNeedsAction
@@ -258,190 +503,793 @@ func SingleEventFullText(event *types.Event) string {
 
 	if event.Description != "" {
 		fullEventText += eventSplitLine
-		fullEventText += eventDescriptionHeader
+		fullEventText += lang.T(eventDescriptionHeader)
 		fullEventText += event.Description + "\n"
 	}
 
 	if event.Calendar.Title != "" {
 		fullEventText += eventSplitLine
-		fullEventText += fmt.Sprintf(EventCalendarText, event.Calendar.Title)
+		fullEventText += lang.T(EventCalendarText, event.Calendar.Title)
 	}
 
 	return fullEventText
 }
 
-func RedisNotFoundMessage() string {
-	return eventShowNotFoundError
+func RedisNotFoundMessage(lang i18n.Lang) string {
+	return lang.T(eventShowNotFoundError)
+}
+
+func ShowMoreButton(lang i18n.Lang) string {
+	return lang.T(showMoreButton)
+}
+
+func ShowLessButton(lang i18n.Lang) string {
+	return lang.T(showLessButton)
+}
+
+func CallLinkButton(lang i18n.Lang) string {
+	return lang.T(callLinkButton)
+}
+
+func CallbackResponseHeader(lang i18n.Lang, event *types.Event) string {
+	return lang.T(eventCallbackResponseText, event.Title)
+}
+func NoClosestEvents(lang i18n.Lang) string {
+	return lang.T(eventNoClosestEventFound)
+}
+
+func GetInitDateMessage(lang i18n.Lang) string {
+	return lang.T(eventGetDateHeader) + lang.T(eventGetDateMessage)
+}
+
+func GetTodayTitle(lang i18n.Lang) string {
+	return lang.T(eventTodayTitle)
+}
+
+func GetWeekTitle(lang i18n.Lang, from time.Time, to time.Time) string {
+	return lang.T(eventWeekTitle, monday.Format(from, formatSpan, lang.MondayLocale()),
+		monday.Format(to, formatSpan, lang.MondayLocale()))
+}
+
+func GetWeekNotFound(lang i18n.Lang) string {
+	return lang.T(eventNoWeekEventsFound)
+}
+
+func GetImageModeText(lang i18n.Lang, enabled bool) string {
+	if enabled {
+		return lang.T(imageModeEnabled)
+	}
+	return lang.T(imageModeDisabled)
+}
+
+func GetLanguageText(lang i18n.Lang) string {
+	return lang.T(languageText)
+}
+
+func GetLanguageChangedText(lang i18n.Lang) string {
+	return lang.T(languageChanged)
+}
+
+// GetSettingsText lists the settings of the user, calendarTitle is the title of the calendar for new events
+func GetSettingsText(lang i18n.Lang, settings types.UserSettings, calendarTitle string, now time.Time) string {
+	imageMode := lang.T(settingsImageModeText)
+	if settings.ImageMode {
+		imageMode = lang.T(settingsImageModeImage)
+	}
+
+	return lang.T(settingsHeader) +
+		lang.T(settingsTimezone, html.EscapeString(GetTimezoneText(settings.Timezone, now))) +
+		lang.T(settingsLanguage, GetSettingsLanguageText(lang, settings.Language)) +
+		lang.T(settingsEventDuration, FormatDuration(lang, settings.EventDuration)) +
+		lang.T(settingsCalendar, html.EscapeString(GetSettingsCalendarText(lang, calendarTitle))) +
+		lang.T(settingsReminder, GetReminderLeadTimeText(lang, settings.ReminderLeadTime)) +
+		lang.T(settingsDigest, GetDigestTimeText(lang, settings.DigestTime)) +
+		lang.T(settingsImageMode, imageMode) +
+		lang.T(settingsFooter)
+}
+
+// GetSettingsChooseText asks for the new value of the option
+func GetSettingsChooseText(lang i18n.Lang, option string) string {
+	switch option {
+	case telegram.SettingTimezone:
+		return lang.T(settingsChooseTimezone)
+	case telegram.SettingLanguage:
+		return lang.T(settingsChooseLanguage)
+	case telegram.SettingEventDuration:
+		return lang.T(settingsChooseEventDuration)
+	case telegram.SettingCalendar:
+		return lang.T(settingsChooseCalendar)
+	case telegram.SettingReminder:
+		return lang.T(settingsChooseReminder)
+	default:
+		return lang.T(settingsChooseDigest)
+	}
+}
+
+// GetSettingsOptionButton opens the choice of the option
+func GetSettingsOptionButton(lang i18n.Lang, option string) string {
+	switch option {
+	case telegram.SettingTimezone:
+		return lang.T(settingsTimezoneButton)
+	case telegram.SettingLanguage:
+		return lang.T(settingsLanguageButton)
+	case telegram.SettingEventDuration:
+		return lang.T(settingsEventDurationButton)
+	case telegram.SettingCalendar:
+		return lang.T(settingsCalendarButton)
+	case telegram.SettingReminder:
+		return lang.T(settingsReminderButton)
+	case telegram.SettingDigest:
+		return lang.T(settingsDigestButton)
+	default:
+		return lang.T(settingsImageModeButton)
+	}
+}
+
+// GetTimezoneText is the name of the timezone with its current UTC offset
+func GetTimezoneText(timezone string, now time.Time) string {
+	location, err := time.LoadLocation(timezone)
+	if err != nil {
+		return timezone
+	}
+	_, offset := now.In(location).Zone()
+	if offset == 0 {
+		return timezone + " (UTC)"
+	}
+
+	sign := "+"
+	if offset < 0 {
+		sign = "-"
+		offset = -offset
+	}
+	utcOffset := fmt.Sprintf("UTC%s%d", sign, offset/3600)
+	if minutes := offset % 3600 / 60; minutes != 0 {
+		utcOffset += fmt.Sprintf(":%02d", minutes)
+	}
+	return timezone + " (" + utcOffset + ")"
+}
+
+// GetSettingsLanguageText is the chosen language, the language of the Telegram client if it is not chosen
+func GetSettingsLanguageText(lang i18n.Lang, language string) string {
+	chosen, ok := i18n.Parse(language)
+	if !ok {
+		return lang.T(settingsLanguageAuto)
+	}
+	return GetLanguageButton(chosen)
+}
+
+func GetSettingsCalendarText(lang i18n.Lang, calendarTitle string) string {
+	if calendarTitle == "" {
+		return lang.T(settingsCalendarPersonal)
+	}
+	return calendarTitle
+}
+
+func GetReminderLeadTimeText(lang i18n.Lang, leadTime time.Duration) string {
+	if leadTime == 0 {
+		return lang.T(settingsOff)
+	}
+	return lang.T(settingsReminderBefore, FormatDuration(lang, leadTime))
+}
+
+func GetDigestTimeText(lang i18n.Lang, digestTime time.Duration) string {
+	if digestTime == 0 {
+		return lang.T(settingsOff)
+	}
+	return lang.T(settingsDigestAt, int(digestTime/time.Hour), int(digestTime%time.Hour/time.Minute))
+}
+
+// GetReminderText is sent leadTime before the event starts
+func GetReminderText(lang i18n.Lang, leadTime time.Duration, event *types.Event) string {
+	return lang.T(reminderHeader, FormatDuration(lang, leadTime)) + SingleEventShortText(lang, event, false)
+}
+
+// GetLanguageButton is the name of the language in the language itself
+func GetLanguageButton(lang i18n.Lang) string {
+	return lang.T(languageButton)
+}
+
+func GetDateTitle(lang i18n.Lang, date time.Time) string {
+	return lang.T(eventDateTitle, monday.Format(date, formatDate, lang.MondayLocale()))
+}
+
+func GetHolidaysText(lang i18n.Lang, holidays types.Events) string {
+	text := lang.T(holidaysTitle)
+	for _, holiday := range holidays {
+		date := monday.Format(holiday.From, formatDate, lang.MondayLocale())
+		if holiday.FullDay {
+			if args := parseDateFullDay(lang, &holiday); args[1] != "" {
+				date += " " + args[1].(string)
+			}
+		}
+		text += fmt.Sprintf(holidayText, date, holiday.Title)
+	}
+	return text
+}
+
+func GetHolidaysNotFound(lang i18n.Lang, days int) string {
+	return lang.N(holidaysNotFound, days)
+}
+
+func GetNowCurrentText(lang i18n.Lang, event *types.Event, now time.Time) string {
+	if event == nil {
+		return lang.T(nowNoCurrentEvent)
+	}
+	remaining := int(event.To.Sub(now).Minutes())
+	return lang.T(nowCurrentTitle) + SingleEventShortText(lang, event, false) + lang.T(nowRemaining, remaining)
+}
+
+func GetNowNextText(lang i18n.Lang, event *types.Event) string {
+	if event == nil {
+		return lang.T(nowNoNextEvent)
+	}
+	return lang.T(nowNextTitle) + SingleEventShortText(lang, event, false)
+}
+
+func GetUsersStatusText(lang i18n.Lang, now time.Time, statuses []eUseCase.UserStatus) string {
+	text := lang.T(usersStatusHeader, now.Format(formatTime))
+	if len(statuses) == 0 {
+		return text + lang.T(usersStatusNoMembers)
+	}
+
+	for _, status := range statuses {
+		name := strings.Split(status.User, "@")[0]
+		if !status.InMeeting {
+			text += lang.T(userStatusFree, name)
+		} else {
+			text += lang.T(userStatusBusy, name, status.MeetingEnd.Format(formatTime))
+			if status.FreeFrom.IsZero() {
+				text += lang.T(userStatusNoFreeTime, int(eUseCase.UsersStatusWindowAfter.Hours()))
+			} else if !status.FreeFrom.Equal(status.MeetingEnd) {
+				text += lang.T(userStatusFreeFrom, status.FreeFrom.Format(formatTime))
+			}
+		}
+		if !status.NextMeeting.IsZero() {
+			text += lang.T(userStatusNext, status.NextMeeting.Format(formatTime))
+		}
+		text += "\n"
+	}
+	return text
+}
+
+func GetFocusRuleText(lang i18n.Lang, rule types.FocusRule) string {
+	if !rule.Enabled {
+		return lang.T(focusRuleDisabled)
+	}
+	preference := lang.T(focusRuleLongest)
+	if rule.PreferMorning {
+		preference = lang.T(focusRuleMorning)
+	}
+	return lang.T(focusRuleEnabled, FormatDuration(lang, rule.Duration), preference)
+}
+
+func FormatDuration(lang i18n.Lang, d time.Duration) string {
+	hours := int(d / time.Hour)
+	minutes := int((d % time.Hour) / time.Minute)
+	switch {
+	case minutes == 0:
+		return lang.T(durationHours, hours)
+	case hours == 0:
+		return lang.T(durationMinutes, minutes)
+	default:
+		return lang.T(durationHours, hours) + " " + lang.T(durationMinutes, minutes)
+	}
+}
+
+func GetVacationUsage(lang i18n.Lang, vacation *types.Vacation) string {
+	if vacation == nil {
+		return lang.T(vacationUsage)
+	}
+	return GetVacationText(lang, *vacation) + "\n\n" + lang.T(vacationUsage)
+}
+
+func GetVacationText(lang i18n.Lang, vacation types.Vacation) string {
+	lastDay := vacation.To.AddDate(0, 0, -1)
+	text := lang.T(vacationActive, monday.Format(vacation.From, formatDate, lang.MondayLocale()),
+		monday.Format(lastDay, formatDate, lang.MondayLocale()))
+	if vacation.Message != "" {
+		text += fmt.Sprintf(vacationMessageLine, html.EscapeString(vacation.Message))
+	}
+	return text
+}
+
+func GetVacationsGroupText(lang i18n.Lang, vacations []types.Vacation) string {
+	text := lang.T(vacationGroupHeader)
+	for _, vacation := range vacations {
+		lastDay := vacation.To.AddDate(0, 0, -1)
+		text += lang.T(vacationGroupLine, vacation.Email, monday.Format(lastDay, formatSpan, lang.MondayLocale()))
+		if vacation.Message != "" {
+			text += fmt.Sprintf(vacationGroupMessage, html.EscapeString(vacation.Message))
+		}
+		text += "\n"
+	}
+	return text
+}
+
+func GetRoomButtonText(lang i18n.Lang, room types.Room) string {
+	return lang.N(createEventRoomItem, room.Capacity, room.Email, room.Capacity, room.Floor)
+}
+
+func GetInvitationRulesText(lang i18n.Lang, rules []types.InvitationRule) string {
+	text := lang.T(invitationRulesHeader)
+	if len(rules) == 0 {
+		text += lang.T(invitationRulesEmpty)
+	}
+	for i, rule := range rules {
+		text += fmt.Sprintf(invitationRuleLine, i+1, FormatInvitationRule(lang, rule))
+	}
+	return text + lang.T(invitationRulesUsage)
+}
+
+func GetInvitationRuleBadFormat(lang i18n.Lang) string {
+	return lang.T(invitationRuleBadFormat) + lang.T(invitationRulesUsage)
+}
+
+func GetInvitationRuleDeleteButton(lang i18n.Lang, number int) string {
+	return lang.T(invitationRuleDeleteButton, number)
+}
+
+func FormatInvitationRule(lang i18n.Lang, rule types.InvitationRule) string {
+	text := lang.T(invitationRuleAccept)
+	if rule.Status == types.StatusDeclined {
+		text = lang.T(invitationRuleDecline)
+	}
+
+	conditions := ""
+	if rule.Organizer != "" {
+		conditions += lang.T(invitationRuleOrganizer, html.EscapeString(rule.Organizer))
+	}
+	if rule.Calendar != "" {
+		conditions += lang.T(invitationRuleCalendar, html.EscapeString(rule.Calendar))
+	}
+	if rule.StartsAfter != 0 {
+		conditions += lang.T(invitationRuleStartsAfter, formatTimeOfDay(rule.StartsAfter))
+	}
+	if rule.EndsBefore != 0 {
+		conditions += lang.T(invitationRuleEndsBefore, formatTimeOfDay(rule.EndsBefore))
+	}
+	if rule.MinDuration != 0 {
+		conditions += lang.T(invitationRuleMinDuration, int(rule.MinDuration/time.Minute))
+	}
+	if rule.MaxDuration != 0 {
+		conditions += lang.T(invitationRuleMaxDuration, int(rule.MaxDuration/time.Minute))
+	}
+	if rule.NoConflict {
+		conditions += lang.T(invitationRuleNoConflict)
+	}
+	if conditions == "" {
+		conditions = lang.T(invitationRuleAny)
+	}
+	return text + conditions
+}
+
+func formatTimeOfDay(offset time.Duration) string {
+	return fmt.Sprintf(invitationRuleTimeOfDay, int(offset/time.Hour), int((offset%time.Hour)/time.Minute))
+}
+
+func GetInvitationRulesReport(lang i18n.Lang, results []types.InvitationRuleResult) string {
+	text := lang.T(invitationRulesReportHeader)
+	for _, result := range results {
+		status := invitationRuleAccepted
+		if result.Rule.Status == types.StatusDeclined {
+			status = invitationRuleDeclined
+		}
+		title := result.Event.Title
+		if title == "" {
+			title = lang.T(eventNoTitleText)
+		}
+		when := monday.Format(result.Event.From, formatDate, lang.MondayLocale()) + " " + result.Event.From.Format(formatTime)
+		text += fmt.Sprintf(invitationRulesReportLine, status, html.EscapeString(title), when)
+	}
+	return text
+}
+
+func GetTemplatesText(lang i18n.Lang, templates []types.EventTemplate) string {
+	text := lang.T(templatesHeader)
+	if len(templates) == 0 {
+		return text + lang.T(templatesEmpty)
+	}
+	for i, template := range templates {
+		details := ""
+		if template.Duration > 0 {
+			details += fmt.Sprintf(templateDetail, FormatDuration(lang, template.Duration))
+		}
+		if template.Event.Attendees != nil && len(*template.Event.Attendees) > 0 {
+			details += lang.N(templateAttendees, len(*template.Event.Attendees))
+		}
+		if template.WithCall {
+			details += lang.T(templateCall)
+		}
+		text += fmt.Sprintf(templateLine, i+1, html.EscapeString(GetTemplateName(lang, template)), details)
+	}
+	return text + lang.T(templatesUsage)
+}
+
+func GetTemplateName(lang i18n.Lang, template types.EventTemplate) string {
+	if template.Name == "" {
+		return lang.T(eventNoTitleText)
+	}
+	return template.Name
+}
+
+func GetTemplateApplyButton(lang i18n.Lang, template types.EventTemplate) string {
+	return fmt.Sprintf(templateApplyButton, GetTemplateName(lang, template))
+}
+
+func GetTemplateDeleteButton() string {
+	return templateDeleteButton
+}
+
+func GetTemplateAppliedText(lang i18n.Lang, template types.EventTemplate) string {
+	return lang.T(templateAppliedHeader, html.EscapeString(GetTemplateName(lang, template))) + GetCreateInitText(lang)
+}
+
+func GetTemplateSavedText(lang i18n.Lang, template types.EventTemplate) string {
+	return lang.T(templateSavedText, html.EscapeString(GetTemplateName(lang, template)))
+}
+
+func GetEventNoTitleText(lang i18n.Lang) string {
+	return lang.T(eventNoTitleText)
+}
+
+func GetInlineResultTitle(lang i18n.Lang, event *types.Event) string {
+	if event.Title == "" {
+		return lang.T(eventNoTitleText)
+	}
+	return event.Title
+}
+
+func GetInlineResultDescription(lang i18n.Lang, event *types.Event) string {
+	date := monday.Format(event.From, formatSpan, lang.MondayLocale())
+	if event.FullDay {
+		return lang.T(inlineResultFullDay, date)
+	}
+	return fmt.Sprintf(inlineResultDescription, date, event.From.Format(formatTime), event.To.Format(formatTime))
+}
+
+func GetShareEventGroupButton(group types.UserGroup) string {
+	return fmt.Sprintf(shareEventGroup, group.Title)
+}
+
+func GetShareEventSharedText(lang i18n.Lang, group types.UserGroup) string {
+	return lang.T(shareEventShared, group.Title)
 }
 
-func ShowMoreButton() string {
-	return showMoreButton
+func GetBindCalendarText(lang i18n.Lang, current *types.GroupCalendar) string {
+	text := lang.T(bindCalendarHeader)
+	if current != nil {
+		text += lang.T(bindCalendarCurrent, html.EscapeString(current.CalendarTitle))
+	}
+	return text + lang.T(bindCalendarChoose)
+}
+
+func GetBindCalendarButton(calendar types.Calendar) string {
+	return fmt.Sprintf(bindCalendarButton, calendar.Title)
 }
 
-func ShowLessButton() string {
-	return showLessButton
+func GetBindCalendarDoneText(lang i18n.Lang, groupCalendar types.GroupCalendar) string {
+	return lang.T(bindCalendarDone, html.EscapeString(groupCalendar.CalendarTitle))
+}
+
+func GetMembersText(lang i18n.Lang, authenticated, notAuthenticated []types.ChatMember) string {
+	if len(authenticated) == 0 && len(notAuthenticated) == 0 {
+		return lang.T(membersHeader) + lang.T(membersEmpty)
+	}
+	text := lang.T(membersHeader)
+	if len(authenticated) > 0 {
+		text += lang.T(membersAuthenticated, getMembersList(authenticated))
+	}
+	if len(notAuthenticated) == 0 {
+		return text + lang.T(membersAllAuthenticated)
+	}
+	return text + lang.T(membersNotAuthenticated, getMembersList(notAuthenticated))
 }
 
-func CallLinkButton() string {
-	return callLinkButton
+func getMembersList(members []types.ChatMember) string {
+	names := make([]string, 0, len(members))
+	for _, member := range members {
+		name := html.EscapeString(member.Name)
+		if member.Username != "" {
+			name += " @" + member.Username
+		}
+		names = append(names, name)
+	}
+	return strings.Join(names, ", ")
 }
 
-func CallbackResponseHeader(event *types.Event) string {
-	return fmt.Sprintf(eventCallbackResponseText, event.Title)
+// GetForwardedDescription is the description of an event created from a forwarded message, the sender and the link
+// are escaped because descriptions are shown as HTML
+func GetForwardedDescription(lang i18n.Lang, sender string, link string) string {
+	text := lang.T(forwardedFromUnknown)
+	if sender != "" {
+		text = lang.T(forwardedFrom, html.EscapeString(sender))
+	}
+	if link != "" {
+		text += fmt.Sprintf(forwardedLink, html.EscapeString(link))
+	}
+	return text
 }
-func NoClosestEvents() string {
-	return eventNoClosestEventFound
+
+func GetAvailabilityText(lang i18n.Lang, weekStart time.Time, rows []eUseCase.AvailabilityRow) string {
+	weekEnd := weekStart.AddDate(0, 0, eUseCase.AvailabilityDaysInWeek-1)
+	text := lang.T(availabilityHeader, monday.Format(weekStart, formatSpan, lang.MondayLocale()),
+		monday.Format(weekEnd, formatSpan, lang.MondayLocale()))
+
+	if len(rows) == 0 {
+		return text + lang.T(availabilityNoMembers)
+	}
+
+	var table strings.Builder
+	table.WriteString(strings.Repeat(" ", availabilityNameWidth+1) + lang.T(availabilityWeekdays) + "\n")
+	for _, row := range rows {
+		table.WriteString(padName(strings.Split(row.User, "@")[0], availabilityNameWidth) + " ")
+		for idx, cell := range row.Cells {
+			if idx > 0 && idx%len(eUseCase.AvailabilityHalfDays) == 0 {
+				table.WriteString(" ")
+			}
+			table.WriteString(availabilitySymbol(cell))
+		}
+		table.WriteString("\n")
+	}
+
+	return text + "<pre>" + table.String() + "</pre>" + lang.T(availabilityLegend)
 }
 
-func GetInitDateMessage() string {
-	return eventGetDateHeader + eventGetDateMessage
+func availabilitySymbol(availability eUseCase.Availability) string {
+	switch availability {
+	case eUseCase.Busy:
+		return "█"
+	case eUseCase.PartiallyBusy:
+		return "▒"
+	default:
+		return "·"
+	}
 }
 
-func GetTodayTitle() string {
-	return eventTodayTitle
+func padName(name string, width int) string {
+	runes := []rune(name)
+	if len(runes) > width {
+		return string(runes[:width-1]) + "…"
+	}
+	return name + strings.Repeat(" ", width-len(runes))
 }
 
-func GetDateTitle(date time.Time) string {
-	return fmt.Sprintf(eventDateTitle, monday.Format(date, formatDate, locale))
+func GetNextTitle(lang i18n.Lang) string {
+	return lang.T(eventNextTitle)
 }
 
-func GetNextTitle() string {
-	return eventNextTitle
+func GetTodayNotFound(lang i18n.Lang) string {
+	return lang.T(eventNoTodayEventsFound)
 }
 
-func GetTodayNotFound() string {
-	return eventNoTodayEventsFound
+func GetDateEventsNotFound(lang i18n.Lang) string {
+	return lang.T(eventNoDateEventsFound)
 }
 
-func GetDateEventsNotFound() string {
-	return eventNoDateEventsFound
+func GetDateNotParsed(lang i18n.Lang) string {
+	return lang.T(eventDateNotParsed)
 }
 
-func GetDateNotParsed() string {
-	return eventDateNotParsed
+func RedisSessionNotFound(lang i18n.Lang) string {
+	return lang.T(eventSessionNotFound)
 }
 
-func RedisSessionNotFound() string {
-	return eventSessionNotFound
+func GetCancelDateReplyButton(lang i18n.Lang) string {
+	return lang.T(eventCancelSearchDate)
 }
 
-func GetCancelDateReplyButton() string {
-	return eventCancelSearchDate
+// IsCancelDate reports whether the text is the cancel button of the date search in any language
+func IsCancelDate(text string) bool {
+	return i18n.Is(text, eventCancelSearchDate)
 }
 
-func GetCancelDate() string {
-	return eventCanceledSearchDate
+func GetCancelDate(lang i18n.Lang) string {
+	return lang.T(eventCanceledSearchDate)
 }
 
-func GetUserNotAuth() string {
-	return middlewaresUserNotAuthenticated
+func GetUserNotAuth(lang i18n.Lang) string {
+	return lang.T(middlewaresUserNotAuthenticated)
 }
 
-func GetMessageAlertBase() string {
-	return middlewaresGroupAlertBase
+func GetMessageAlertBase(lang i18n.Lang) string {
+	return lang.T(middlewaresGroupAlertBase)
 }
 
-func GetGroupAlertMessage(data string) string {
-	str := middlewaresGroupAlertBase
+func GetGroupAlertMessage(lang i18n.Lang, data string) string {
+	str := lang.T(middlewaresGroupAlertBase)
 	if strings.Contains(data, telegram.Today) {
-		return str + middlewaresGroupAlertToday
+		return str + lang.T(middlewaresGroupAlertToday)
 	}
 
 	if strings.Contains(data, telegram.Next) {
-		return str + middlewaresGroupAlertNext
+		return str + lang.T(middlewaresGroupAlertNext)
 	}
 
 	if strings.Contains(data, telegram.Date) {
-		return str + middlewaresGroupAlertDate
+		return str + lang.T(middlewaresGroupAlertDate)
 	}
 
 	return ""
 }
 
-func GetCreateInitText() string {
-	return createEventInitText
+func GetCreateInitText(lang i18n.Lang) string {
+	return lang.T(createEventInitText)
 }
 
-func GetCreateCancelText() string {
-	return createEventCancelText
+func GetCreateCancelText(lang i18n.Lang) string {
+	return lang.T(createEventCancelText)
 }
 
-func GetCreateCanceledText() string {
-	return createEventCanceledText
+// IsCreateCancel reports whether the text is the cancel button of the event creation in any language
+func IsCreateCancel(text string) bool {
+	return i18n.Is(text, createEventCancelText)
 }
 
-func GetCreateEventHeader() string {
-	return createEventHeader
+func GetCreateCanceledText(lang i18n.Lang) string {
+	return lang.T(createEventCanceledText)
 }
 
-func GetCreateEventCreateText() string {
-	return createEventCreateText
+func GetCreateEventHeader(lang i18n.Lang) string {
+	return lang.T(createEventHeader)
 }
 
-func GetCreateEventHalfHour() string {
-	return createEventHalfHour
+func GetCreateEventCreateText(lang i18n.Lang) string {
+	return lang.T(createEventCreateText)
 }
-func GetCreateEventHour() string {
-	return createEventHour
+
+// GetCreateDurations are the durations offered for the new event, the default duration of the user goes first
+func GetCreateDurations(defaultDuration time.Duration) []time.Duration {
+	durations := make([]time.Duration, 0, len(createDurations)+1)
+	durations = append(durations, defaultDuration)
+	for _, preset := range createDurations {
+		if preset.duration != defaultDuration {
+			durations = append(durations, preset.duration)
+		}
+	}
+	return durations
 }
-func GetCreateEventHourAndHalf() string {
-	return createEventHourAndHalf
+
+// GetCreateDurationText is the button of the duration, durations without a button of their own are formatted
+func GetCreateDurationText(lang i18n.Lang, d time.Duration) string {
+	for _, preset := range createDurations {
+		if preset.duration == d {
+			return lang.T(preset.id)
+		}
+	}
+	return FormatDuration(lang, d)
 }
-func GetCreateEventTwoHours() string {
-	return createEventTwoHours
+
+// ParseCreateDuration returns the duration of the pressed button, ok is false for other texts
+func ParseCreateDuration(lang i18n.Lang, text string, defaultDuration time.Duration) (d time.Duration, ok bool) {
+	for _, duration := range GetCreateDurations(defaultDuration) {
+		if text == GetCreateDurationText(lang, duration) {
+			return duration, true
+		}
+	}
+	return 0, false
+}
+
+func GetCreateEventToText(lang i18n.Lang) string {
+	return lang.T(createEventToText)
+}
+
+func GetUserNotAllow(lang i18n.Lang) string {
+	return lang.T(userNotAllow)
 }
-func GetCreateEventFourHours() string {
-	return createEventFourHours
+
+func GetEventCreatedText(lang i18n.Lang) string {
+	return lang.T(createEventCreatedText)
 }
-func GetCreateEventSixHours() string {
-	return createEventSixHours
+
+func GetCreatedEventHeader(lang i18n.Lang) string {
+	return lang.T(createdEventHeader)
 }
 
-func GetCreateEventToText() string {
-	return createEventToText
+// GetCreateCallButton toggles the video call of the created event
+func GetCreateCallButton(lang i18n.Lang, call string) string {
+	if call == "" {
+		return lang.T(CreateEventAddCallButton)
+	}
+	return lang.T(CreateEventRemoveCallButton)
 }
 
-func GetUserNotAllow() string {
-	return userNotAllow
+func GetCreateCallText(lang i18n.Lang, call string) string {
+	if call == "" {
+		return lang.T(CreateEventCallRemovedText)
+	}
+	return lang.T(CreateEventCallAddedText)
 }
 
-func GetEventCreatedText() string {
-	return createEventCreatedText
+func GetCreateFullDay(lang i18n.Lang) string {
+	return lang.T(createEventFullDay)
 }
 
-func GetCreatedEventHeader() string {
-	return createdEventHeader
+// IsCreateFullDay reports whether the text is the full day button in any language
+func IsCreateFullDay(text string) bool {
+	return i18n.Is(text, createEventFullDay)
 }
 
-func GetCreateFullDay() string {
-	return createEventFullDay
+// GetCreateFullDayAll is the full day button in every language, to register its handler
+func GetCreateFullDayAll() []string {
+	return i18n.All(createEventFullDay)
 }
 
-func GetCreateEventTitle() string {
-	return createEventTitleText
+func GetCreateEventTitle(lang i18n.Lang) string {
+	return lang.T(createEventTitleText)
+}
+
+func GetChooseStepText(lang i18n.Lang) string {
+	return lang.T(chooseStepText)
+}
+
+// GetDayButtonText is the button of the day offset days after now on the date keyboards
+func GetDayButtonText(lang i18n.Lang, now time.Time, offset int) string {
+	date := monday.Format(now.AddDate(0, 0, offset), formatSpan, lang.MondayLocale())
+	switch offset {
+	case 0:
+		return lang.T(fastDateToday, date)
+	case 1:
+		return lang.T(fastDateTomorrow, date)
+	default:
+		return date
+	}
+}
+
+// ParserText translates the text of the date buttons and the month names back to Russian,
+// the only language of the date parser. The buttons may be pressed by a user of another language in groups
+func ParserText(text string, now time.Time) string {
+	for _, lang := range i18n.Langs {
+		if lang == i18n.Ru {
+			continue
+		}
+		if id, ok := lang.Match(text, fastCommands...); ok {
+			return i18n.Ru.T(id)
+		}
+		for offset := 0; offset < fastDays; offset++ {
+			if text == GetDayButtonText(lang, now, offset) {
+				return GetDayButtonText(i18n.Ru, now, offset)
+			}
+		}
+		for month := time.January; month <= time.December; month++ {
+			date := time.Date(now.Year(), month, 1, 0, 0, 0, 0, time.UTC)
+			text = replaceFold(text, monday.Format(date, "January", lang.MondayLocale()),
+				strings.TrimPrefix(monday.Format(date, "2 January", i18n.Ru.MondayLocale()), "1 "))
+		}
+	}
+	return text
+}
+
+// replaceFold replaces the word ignoring its case, the typed month names are often lowercase
+func replaceFold(text, old, new string) string {
+	idx := strings.Index(strings.ToLower(text), strings.ToLower(old))
+	if idx < 0 || old == "" {
+		return text
+	}
+	return text[:idx] + new + text[idx+len(old):]
 }
 
-func GetFindTimeStartText() string {
-	return findTimeStartHeader + eventGetDateMessage
+func GetFindTimeStartText(lang i18n.Lang) string {
+	return lang.T(findTimeStartHeader) + lang.T(eventGetDateMessage)
 }
 
-func GetFindTimeStopText(time time.Time) string {
-	return findTimeStopHeader + fmt.Sprintf(findTimeStartTime, monday.Format(time, formatDate, locale)) +
-		"\n" + eventGetDateMessage
+func GetFindTimeStopText(lang i18n.Lang, time time.Time) string {
+	return lang.T(findTimeStopHeader) + lang.T(findTimeStartTime, monday.Format(time, formatDate, lang.MondayLocale())) +
+		"\n" + lang.T(eventGetDateMessage)
 }
 
-func GetFindTimeInfoText(timeFrom, timeTo time.Time) string {
-	return findTimeInfoHeader + fmt.Sprintf(findTimeStartTime, monday.Format(timeFrom, formatDate, locale)) +
-		fmt.Sprintf(findTimeStopTime, monday.Format(timeTo, formatDate, locale))
+func GetFindTimeInfoText(lang i18n.Lang, timeFrom, timeTo time.Time) string {
+	return lang.T(findTimeInfoHeader) + lang.T(findTimeStartTime, monday.Format(timeFrom, formatDate, lang.MondayLocale())) +
+		lang.T(findTimeStopTime, monday.Format(timeTo, formatDate, lang.MondayLocale()))
 }
 
-func GetFindTimeInfoTextWithRange(timeFrom, timeTo time.Time, dur string) string {
-	return findTimeInfoHeader + fmt.Sprintf(findTimeStartTime, monday.Format(timeFrom, formatDate, locale)) +
-		fmt.Sprintf(findTimeStopTime, monday.Format(timeTo, formatDate, locale)) + "\n" +
-		fmt.Sprintf(findTimeDuration, dur)
+func GetFindTimeInfoTextWithRange(lang i18n.Lang, timeFrom, timeTo time.Time, dur string) string {
+	return lang.T(findTimeInfoHeader) + lang.T(findTimeStartTime, monday.Format(timeFrom, formatDate, lang.MondayLocale())) +
+		lang.T(findTimeStopTime, monday.Format(timeTo, formatDate, lang.MondayLocale())) + "\n" +
+		lang.T(findTimeDuration, dur)
 
 }
 
+// GenOptionsForPoll is always Russian, the voted option is parsed back by the date parser
 func GenOptionsForPoll(spans spaniel.Spans) []string {
 	str := make([]string, 0)
 	counter := 0
@@ -449,10 +1297,10 @@ func GenOptionsForPoll(spans spaniel.Spans) []string {
 		if counter == 9 {
 			return str
 		}
-		str = append(str, fmt.Sprintf(findTimeTextFormat,
-			monday.Format(span.Start(), formatSpan, locale),
-			monday.Format(span.Start(), formatTime, locale),
-			monday.Format(span.End(), formatTime, locale),
+		str = append(str, i18n.Ru.T(findTimeTextFormat,
+			monday.Format(span.Start(), formatSpan, i18n.Ru.MondayLocale()),
+			monday.Format(span.Start(), formatTime, i18n.Ru.MondayLocale()),
+			monday.Format(span.End(), formatTime, i18n.Ru.MondayLocale()),
 		))
 		counter++
 	}
@@ -460,8 +1308,8 @@ func GenOptionsForPoll(spans spaniel.Spans) []string {
 	return str
 }
 
-func GenFindTimePollHeader(emails []string) string {
-	return findTimePollHeader + strings.Join(emails, ", ")
+func GenFindTimePollHeader(lang i18n.Lang, emails []string) string {
+	return lang.T(findTimePollHeader) + strings.Join(emails, ", ")
 }
 
 func AddNameBold(name string) string {
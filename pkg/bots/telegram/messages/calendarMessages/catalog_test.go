@@ -0,0 +1,57 @@
+package calendarMessages
+
+import (
+	"github.com/calendar-bot/pkg/i18n"
+	"github.com/calendar-bot/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestCatalogComplete(t *testing.T) {
+	assert.Empty(t, i18n.Missing(i18n.En))
+}
+
+func TestParserText(t *testing.T) {
+	now := time.Date(2021, time.March, 22, 10, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, "Завтра в 12:00", ParserText("Tomorrow at 12:00", now))
+	assert.Equal(t, "22 марта, Сегодня", ParserText("22 March, Today", now))
+	assert.Equal(t, "24 марта", ParserText("24 March", now))
+	assert.Equal(t, "5 мая 15:00", ParserText("5 may 15:00", now))
+	assert.Equal(t, "Сегодня в 9:00", ParserText("Сегодня в 9:00", now))
+}
+
+func TestPlurals(t *testing.T) {
+	room := types.Room{Email: "room@mail.ru", Capacity: 3, Floor: 2}
+	assert.Equal(t, "room@mail.ru · 3 места · 2 этаж", GetRoomButtonText(i18n.Ru, room))
+	assert.Equal(t, "room@mail.ru · 3 seats · floor 2", GetRoomButtonText(i18n.En, room))
+
+	room.Capacity = 21
+	assert.Equal(t, "room@mail.ru · 21 место · 2 этаж", GetRoomButtonText(i18n.Ru, room))
+}
+
+func TestCreateDurations(t *testing.T) {
+	assert.Equal(t, []time.Duration{time.Hour, 30 * time.Minute, 90 * time.Minute, 2 * time.Hour, 4 * time.Hour,
+		6 * time.Hour}, GetCreateDurations(time.Hour))
+	assert.Len(t, GetCreateDurations(45*time.Minute), 7)
+
+	assert.Equal(t, "45 min", GetCreateDurationText(i18n.En, 45*time.Minute))
+	d, ok := ParseCreateDuration(i18n.Ru, "1 час 30 минут", time.Hour)
+	assert.True(t, ok)
+	assert.Equal(t, 90*time.Minute, d)
+	d, ok = ParseCreateDuration(i18n.En, "45 min", 45*time.Minute)
+	assert.True(t, ok)
+	assert.Equal(t, 45*time.Minute, d)
+	_, ok = ParseCreateDuration(i18n.En, "45 min", time.Hour)
+	assert.False(t, ok)
+}
+
+func TestTimezoneText(t *testing.T) {
+	now := time.Date(2021, time.March, 22, 10, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, "Europe/Moscow (UTC+3)", GetTimezoneText("Europe/Moscow", now))
+	assert.Equal(t, "Asia/Kolkata (UTC+5:30)", GetTimezoneText("Asia/Kolkata", now))
+	assert.Equal(t, "America/New_York (UTC-4)", GetTimezoneText("America/New_York", now))
+	assert.Equal(t, "UTC (UTC)", GetTimezoneText("UTC", now))
+}
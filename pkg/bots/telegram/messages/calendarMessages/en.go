@@ -0,0 +1,369 @@
+package calendarMessages
+
+import "github.com/calendar-bot/pkg/i18n"
+
+func init() {
+	i18n.Register(i18n.En, map[i18n.ID]string{
+		eventNoTitleText:         "Untitled",
+		eventTimeText:            "<u>When:</u>\n\n⏰ %s, <u>%s</u> - %s<u>%s</u>\n",
+		eventTimeFullDay:         "<u>When:</u>\n\n⏰ %s %s, <u>All day</u>\n",
+		eventDateStart:           "<u>When:</u>\n\n⏰ <b>Starts:</b> %s %s\n",
+		eventPlaceText:           "\n<u>Where:</u>\n\n📍 %s\n",
+		eventOrganizerText:       "\nOrganizer - <b>%s</b> (%s)\n",
+		EventCalendarText:        "🗓 Calendar <b>%s</b>",
+		eventAttendeesHeaderText: "<u><i>Attendees:</i></u>\n\n",
+		eventDescriptionHeader:   "<u><i>Description:</i></u>\n\n",
+		eventConfroomsHeader:     "<u><i>Meeting rooms:</i></u>\n\n",
+
+		eventTodayTitle: "<b>Your events for today</b>",
+		eventWeekTitle:  "<b>Your events from %s to %s</b>",
+		eventDateTitle:  "<b>Your events on %s</b>",
+		eventNextTitle:  "<b>Your next event</b>",
+
+		eventGetDateHeader:          "<b>Events on a date: </b>\n\n",
+		findTimeStartHeader:         "<b>Choose the first day to search for time: </b>\n\n",
+		findTimeStopHeader:          "<b>Choose the last day to search for time: </b>\n\n",
+		findTimeInfoHeader:          "<b>The time will be searched within this period </b>\n\n",
+		findTimeStartTime:           "<b>Search from: </b> %s\n",
+		findTimeStopTime:            "<b>Search to: </b> %s",
+		findTimeDuration:            "<b>Event duration: </b> %s",
+		findTimeTextFormat:          "%s from %s to %s",
+		FindTimeChooseDayPartHeader: "<b>Choose the part of the day for the event</b>\n\n",
+		FindTimeChooseLengthHeader:  "<b>Choose the duration of the event</b>\n\n",
+		eventGetDateMessage: "Use the buttons to choose the date or type it as " +
+			"<pre>&lt;day&gt; &lt;month&gt;</pre> (e.g. <pre>22 March</pre>)",
+
+		holidaysTitle: "<b>Upcoming holidays and days off</b>\n\n",
+
+		imageModeEnabled:       "🖼 The schedule will now be sent as an image",
+		imageModeDisabled:      "📝 The schedule will now be sent as text",
+		FindTimeHeatmapCaption: "Attendees' busy time in the chosen period",
+
+		nowCurrentTitle:      "<b>Happening now</b>\n\n",
+		nowNoCurrentEvent:    "You have no events right now",
+		nowRemaining:         "\n⏳ Ends in: <b>%d min</b>",
+		nowNextTitle:         "<b>Next event</b>\n\n",
+		nowNoNextEvent:       "No more events today",
+		usersStatusHeader:    "<b>Members' status at %s</b>\n\n",
+		userStatusBusy:       "🔴 <b>%s</b> - in a meeting until %s",
+		userStatusFree:       "🟢 <b>%s</b> - free",
+		userStatusFreeFrom:   ", free from %s",
+		userStatusNoFreeTime: ", no free time within %d h",
+		userStatusNext:       ", next meeting at %s",
+		usersStatusNoMembers: "Nobody here yet. The status is shown for those who used /now or /availability in this chat",
+
+		focusRuleEnabled: "<b>Focus time is on</b>\n\nEvery morning the bot books at least " +
+			"<b>%s</b> of working hours (9:00 - 19:00) in your calendar for \"Focus\" events%s.\n" +
+			"If you delete such an event, the bot won't create new ones that day",
+		focusRuleMorning: ", starting in the morning",
+		focusRuleLongest: ", picking the longest free slots",
+		focusRuleDisabled: "<b>Focus time is off</b>\n\nChoose how much time a day the bot should keep " +
+			"in your calendar for work without meetings",
+		durationHours:      "%d h",
+		durationMinutes:    "%d min",
+		FocusMorningButton: "🌅 In the morning",
+		FocusAnyTimeButton: "🕑 Any time",
+		FocusOffButton:     "❌ Turn off",
+		FocusEventDescription: "Time for work without meetings. The event was created by the bot, " +
+			"delete it if it gets in the way",
+
+		vacationUsage: "Specify the dates of your vacation and, optionally, a message for your colleagues:\n" +
+			"<pre>/vacation 01.06 14.06 I'm on vacation</pre>\n" +
+			"While the vacation is active, the bot declines new meeting invitations for these days and mentions it " +
+			"in group chats. Turn off: <pre>/vacation off</pre>",
+		vacationActive:      "<b>🏖 Vacation from %s to %s</b>\n\nNew invitations for these days will be declined automatically",
+		VacationBadDates:    "Couldn't parse the dates. Use the <pre>dd.mm</pre> or <pre>dd.mm.yyyy</pre> format",
+		VacationCancelled:   "Vacation is off, the \"Out of office\" event is deleted",
+		VacationNotSet:      "No vacation is set",
+		vacationGroupHeader: "<b>🏖 On vacation at this time:</b>\n\n",
+		vacationGroupLine:   "%s - until %s",
+
+		invitationRulesHeader: "<b>Invitation reply rules</b>\n\n",
+		invitationRulesEmpty:  "You have no rules yet\n",
+		invitationRulesUsage: "\nAdd a rule:\n<pre>/rules accept from alice@mail.ru noconflict</pre>\n" +
+			"<pre>/rules decline after 19:00</pre>\n" +
+			"Conditions: from &lt;organizer email&gt;, calendar &lt;calendar name&gt;, after HH:MM - starts " +
+			"no earlier, before HH:MM - ends no later, longer &lt;minutes&gt;, shorter &lt;minutes&gt;, " +
+			"noconflict - doesn't overlap your other events.\n" +
+			"The bot checks new invitations every 10 minutes, the first matching rule applies",
+		invitationRuleAccept:        "✅ accept",
+		invitationRuleDecline:       "❌ decline",
+		invitationRuleAny:           " all invitations",
+		invitationRuleOrganizer:     " from %s",
+		invitationRuleCalendar:      " in the calendar \"%s\"",
+		invitationRuleStartsAfter:   " starting no earlier than %s",
+		invitationRuleEndsBefore:    " ending no later than %s",
+		invitationRuleMinDuration:   " longer than %d min",
+		invitationRuleMaxDuration:   " shorter than %d min",
+		invitationRuleNoConflict:    " not overlapping other events",
+		invitationRuleDeleteButton:  "🗑 Delete rule %d",
+		InvitationRuleAdded:         "The rule is added",
+		InvitationRuleDeleted:       "The rule is deleted",
+		invitationRuleBadFormat:     "Couldn't parse the rule\n",
+		invitationRulesReportHeader: "<b>Invitations answered by your rules</b>\n\n",
+
+		templatesHeader:       "<b>Event templates</b>\n\n",
+		templatesEmpty:        "You have no templates yet. An event can be saved as a template right after it is created\n",
+		templatesUsage:        "\nTap a template to create an event from it - you'll only need to set the time",
+		templateCall:          ", video call",
+		templateAppliedHeader: "<b>Event from the template \"%s\"</b>\n\n",
+		templateSavedText:     "The template \"%s\" is saved. All templates - /templates",
+		TemplateSaveText:      "You can save this event as a template to create the same one in a couple of taps next time",
+		TemplateSaveButton:    "💾 Save as template",
+		TemplateSaved:         "The template is saved",
+		TemplateDeleted:       "The template is deleted",
+		TemplateApplied:       "The template is applied",
+		TemplateNotFound:      "The template is not found",
+		TemplateSaveOutdated:  "Only the last created event can be saved",
+
+		InlineLoginText:     "Log in to the calendar to share events",
+		inlineResultFullDay: "%s, all day",
+
+		ShareEventButton:     "📣 Share to a group",
+		ShareEventBackButton: "◀ Back",
+		ShareEventNoGroups:   "The bot hasn't seen you in any group. Send any command to the bot in the group and try again",
+		ShareEventNotAllowed: "Only the organizer can share the event to a group",
+		ShareEventNoAccess:   "The bot or you are no longer in this group",
+		shareEventShared:     "The event is sent to the group «%s»",
+
+		PostedEventCancelled: "❌ <b>The event is cancelled</b>\n\nIt is no longer in the organizer's calendar",
+
+		bindCalendarHeader:  "<b>Group calendar</b>\n\n",
+		bindCalendarCurrent: "The calendar «%s» is bound to the group now\n\n",
+		bindCalendarChoose: "Choose a calendar: its events will be shown by /today, /week and /next, " +
+			"and the events created in the group will be added to it",
+		bindCalendarDone:         "The calendar «%s» is bound to the group. Its events are now available to everyone by /today, /week and /next",
+		BindCalendarRemoveButton: "Unbind the calendar",
+		BindCalendarRemoved:      "The calendar is unbound from the group",
+		BindCalendarGroupOnly:    "A calendar can only be bound in a group chat",
+		BindCalendarNotAdmin:     "Only a group admin can bind a calendar",
+		BindCalendarNoCalendars:  "Couldn't find calendars which can be bound to the group",
+		BindCalendarNotFound:     "The calendar is not among your calendars",
+		BindCalendarCreateDenied: "Only the calendar owner or a group admin can create events in the group calendar",
+
+		membersHeader:           "<b>Group members</b>\n\n",
+		membersAuthenticated:    "✅ Connected the calendar: %s\n",
+		membersNotAuthenticated: "⚠ Haven't connected the calendar yet: %s\n\nTo connect the calendar, open a private chat with the bot",
+		membersEmpty: "The bot hasn't seen the members of this group yet. Members appear here when they write to the group " +
+			"or use the bot",
+		membersAllAuthenticated: "\nAll members have connected the calendar",
+		MembersLoginButton:      "Connect the calendar",
+
+		forwardedFrom:        "From the message of %s",
+		forwardedFromUnknown: "From a forwarded message",
+
+		availabilityHeader:    "<b>Members' busy time from %s to %s</b>\n\n",
+		availabilityWeekdays:  "Mo Tu We Th Fr Sa Su",
+		availabilityLegend:    "\n· free  ▒ partially busy  █ busy\nEach day: morning (9:00 - 13:00) and afternoon (13:00 - 18:00)",
+		availabilityNoMembers: "None of the members has connected the calendar yet. Tap \"Add me\" to have your calendar counted",
+
+		AvailabilityJoinButton:     "✅ Add me",
+		AvailabilityPrevWeekButton: "◀ Prev. week",
+		AvailabilityNextWeekButton: "Next week ▶",
+
+		eventNoTodayEventsFound:  "You have no events today",
+		eventNoWeekEventsFound:   "You have no events this week",
+		eventNoDateEventsFound:   "You have no events on the chosen date",
+		eventNoClosestEventFound: "You have no more events today",
+
+		findTimePollHeader: "Choose the time. If you want your calendar to be counted, tap " +
+			"\"I'm in\"\n\nThe calendars counted so far: ",
+		FindTimeAdd:             "✅ I'm in",
+		FindTimeFind:            "Find time",
+		FindTimeBack:            "Change parameters",
+		FindTimeExist:           "You are already in",
+		FindTimeNotFound:        "Unfortunately we couldn't find free time for all attendees with these parameters",
+		FindTimePeriodIsTooLong: "The chosen search period is too long, shortening it to the maximum possible",
+
+		eventDateNotParsed: "We couldn't recognize the date, please try again",
+		EventDateToIsBeforeFrom: "<b>The date is before the start of the event, please enter a correct date.</b>\n\n" +
+			"If you want to move the event to another time, change its start time",
+		eventSessionNotFound: "We couldn't find the information needed to process the request.\nPlease use " +
+			"the command again",
+		EventNoEventDataFound: "<b>We couldn't recognize the event. Try to phrase it differently." +
+			"</b>\n\nFor example: Учеба завтра с 10:00 до 13:00",
+		CallbackExpired: "This button is outdated. Request the event again, e.g. with /today",
+		eventShowNotFoundError: "Unfortunately we couldn't find the event.\nThis may be an old message." +
+			"\nRequest the event with the bot again.",
+		eventCallbackResponseText: "Event: %s",
+
+		eventCancelSearchDate:   "Cancel event search",
+		eventCanceledSearchDate: "The event search is cancelled",
+
+		createEventHeader:  "<u><b>The event so far:</b></u>\n\n",
+		createdEventHeader: "<u><b>The event is created:</b></u>\n\n",
+
+		createEventInitText: "<b>Enter the start time of the event</b>\n\nUse the buttons to choose the date and time" +
+			" of the start or type it as <pre>&lt;day&gt; &lt;month&gt; " +
+			"&lt;HH:MM&gt;</pre> (e.g. 22 March 15:00)",
+		createEventToText: "<b>Enter the end time of the event</b>\n\nUse the buttons to choose the duration" +
+			" or type the end as <pre>&lt;day&gt; &lt;month&gt; " +
+			"&lt;HH:MM&gt;</pre> (e.g. 22 March 15:00)",
+		createEventTitleText:    "<b>Enter the title of the event</b>",
+		CreateEventDescText:     "<b>Enter the description of the event</b>",
+		CreateEventLocationText: "<b>Enter the place of the event</b>\n\nOr send a location or a venue via 📎",
+		CreateEventUserText: "<b>Enter the email of the user you want to add. Several emails can be" +
+			" separated by commas</b>",
+
+		createEventCreateText:   "Create event",
+		createEventCreatedText:  "The event is created",
+		createEventCancelText:   "Cancel",
+		createEventCanceledText: "Event creation is cancelled",
+
+		createEventHalfHour:    "30 minutes",
+		createEventHour:        "1 hour",
+		createEventHourAndHalf: "1 hour 30 minutes",
+		createEventTwoHours:    "2 hours",
+		createEventFourHours:   "4 hours",
+		createEventSixHours:    "6 hours",
+		createEventFullDay:     "All day",
+
+		CreateEventChangeStartTimeButton: "Change start time",
+		CreateEventChangeStopTimeButton:  "Change end time",
+		CreateEventAddTitleButton:        "Add title",
+		CreateEventChangeTitleButton:     "Change title",
+		CreateEventAddDescButton:         "Add description",
+		CreateEventChangeDescButton:      "Change description",
+		CreateEventAddLocationButton:     "Add place",
+		CreateEventChangeLocationButton:  "Change place",
+		CreateEventAddUser:               "Add attendees",
+		CreateEventRoomButton:            "Choose a meeting room",
+		CreateEventAddCallButton:         "Add video call",
+		CreateEventRemoveCallButton:      "Remove video call",
+		CreateEventShareLocationButton:   "📍 Send location",
+		EventVenueButton:                 "📍 Show on the map",
+
+		CreateEventRoomText:         "<b>Choose a free meeting room</b>\n\nShown are the rooms with enough seats for %d people",
+		CreateEventRoomNoRooms:      "The meeting rooms are not configured",
+		CreateEventRoomNoFreeRooms:  "There are no free meeting rooms for %d people at this time",
+		CreateEventRoomNeedsTime:    "Set the start and the end of the event first",
+		CreateEventRoomNoneButton:   "No meeting room",
+		CreateEventRoomSelectedText: "The meeting room is chosen",
+
+		CreateEventCallAddedText:   "The video call is added, the link will appear in the event",
+		CreateEventCallRemovedText: "The video call is removed",
+
+		ShowTodayTasks:  "show tasks for today",
+		ShowTodayPhrase: "show events for today",
+		ShowNextTask:    "show the next event",
+		ShowNextPhrase:  "show the upcoming event",
+
+		CreateEventGo:    "✅ I'm going",
+		CreateEventNotGo: "❌ I'm not going",
+
+		CreateEventAlreadyOrganize: "You are the organizer of the event and attend it by default",
+		CreateEventAlreadyGo:       "You have already accepted the event",
+		CreateEventAlreadyNotGo:    "You have already declined the event",
+		CreateEventCannotAdd: "We couldn't change your status in the event - confirm it in the calendar or in the email" +
+			" invitation",
+
+		CreateEventFindTimeMessage:   "How to create the event?",
+		CreateEventFindTimeYesButton: "🔍 Find a convenient time",
+		CreateEventFindTimeNoButton:  "⏰ Create at a specific time",
+
+		middlewaresUserNotAuthenticated: "You can't use this feature until you log in to the bot with" +
+			" a mail.ru account. To log in, use the /start command in a private chat with the bot",
+		middlewaresGroupAlertBase:  "Are you sure you want to show ",
+		middlewaresGroupAlertToday: "<b>EVERYONE</b> your events for today?",
+		middlewaresGroupAlertNext:  "<b>EVERYONE</b> your next event for today?",
+		middlewaresGroupAlertDate:  "<b>EVERYONE</b> your events on a date?",
+
+		userNotAllow: "You can't use this button",
+
+		chooseStepText: "Choose a step:",
+
+		fastDateToday:           "%s, Today",
+		fastDateTomorrow:        "%s, Tomorrow",
+		FastCommandInHalfHour:   "In half an hour",
+		FastCommandInHour:       "In an hour",
+		FastCommandInTwoHours:   "In two hours",
+		FastCommandInThreeHours: "In three hours",
+		FastCommandTodayAt9:     "Today at 9:00",
+		FastCommandTodayAt12:    "Today at 12:00",
+		FastCommandTodayAt15:    "Today at 15:00",
+		FastCommandTodayAt18:    "Today at 18:00",
+		FastCommandTomorrowAt9:  "Tomorrow at 9:00",
+		FastCommandTomorrowAt12: "Tomorrow at 12:00",
+		FastCommandTomorrowAt15: "Tomorrow at 15:00",
+		FastCommandTomorrowAt18: "Tomorrow at 18:00",
+		FastCommandInWeekAt12:   "In a week at 12:00",
+		FastCommandInWeekAt15:   "In a week at 15:00",
+		FastCommandInWeekAt18:   "In a week at 18:00",
+
+		AlertYesButton:             "Yes",
+		AlertNoButton:              "No",
+		FindTimeMorningButton:      "Morning (6:00 - 13:00)",
+		FindTimeAfternoonButton:    "Afternoon (12:00 - 19:00)",
+		FindTimeEveningButton:      "Evening (17:00 - 0:00)",
+		FindTimeWorkingHoursButton: "Working hours (9:00 - 18:00)",
+		FindTimeAnyTimeButton:      "Any time",
+		FindTimeLength30m:          "30 min",
+		FindTimeLength1h:           "1 hour",
+		FindTimeLength1h30m:        "1.5 hours",
+		FindTimeLength2h:           "2 hours",
+		FindTimeLength2h30m:        "2.5 hours",
+		FindTimeLength3h:           "3 hours",
+		FindTimeLength4h:           "4 hours",
+		FindTimeLength5h:           "5 hours",
+
+		languageText:     "<b>Bot language</b>\n\nEnglish is selected now. Choose the language of messages and buttons:",
+		languageChanged:  "The bot language is English",
+		languageButton:   "🇬🇧 English",
+		LanguageSelected: "The language is selected",
+
+		settingsHeader:              "<b>⚙ Settings</b>\n\n",
+		settingsTimezone:            "🌍 Timezone: <b>%s</b>\n",
+		settingsLanguage:            "🗣 Language: <b>%s</b>\n",
+		settingsLanguageAuto:        "as in Telegram",
+		settingsEventDuration:       "⏱ Meeting duration: <b>%s</b>\n",
+		settingsCalendar:            "📅 Calendar for new meetings: <b>%s</b>\n",
+		settingsCalendarPersonal:    "main",
+		settingsReminder:            "🔔 Meeting reminder: <b>%s</b>\n",
+		settingsReminderBefore:      "%s before",
+		settingsDigest:              "📰 Daily schedule: <b>%s</b>\n",
+		settingsDigestAt:            "at %02d:%02d",
+		settingsOff:                 "off",
+		settingsImageMode:           "🖼 The schedule is sent <b>%s</b>\n",
+		settingsImageModeImage:      "as an image",
+		settingsImageModeText:       "as text",
+		settingsFooter:              "\nChoose what to change:",
+		settingsChooseTimezone:      "Choose the timezone:",
+		settingsChooseLanguage:      "Choose the language of messages and buttons:",
+		settingsChooseEventDuration: "Choose the duration the bot offers first when a meeting is created:",
+		settingsChooseCalendar:      "Choose the calendar the bot creates meetings in:",
+		settingsChooseReminder:      "How long before a meeting starts should the bot remind you?",
+		settingsChooseDigest:        "When should the bot send the schedule for the day?",
+		settingsTimezoneButton:      "🌍 Timezone",
+		settingsLanguageButton:      "🗣 Language",
+		settingsEventDurationButton: "⏱ Duration",
+		settingsCalendarButton:      "📅 Calendar",
+		settingsReminderButton:      "🔔 Reminder",
+		settingsDigestButton:        "📰 Daily schedule",
+		settingsImageModeButton:     "🖼 Image or text",
+		SettingsResetButton:         "↩ Reset settings",
+		SettingsBackButton:          "◀ Back",
+		SettingsSaved:               "Saved",
+		SettingsWasReset:            "The settings are reset",
+		SettingsBadValue:            "This value can't be saved",
+		reminderHeader:              "🔔 <b>A meeting starts in %s</b>\n\n",
+
+		callLinkButton: "📲 Call link",
+		showMoreButton: "🔻 Show more",
+		showLessButton: "🔺 Show less",
+	})
+
+	i18n.RegisterPlural(i18n.En, map[i18n.ID]i18n.Plural{
+		holidaysNotFound: {
+			One:   "There are no days off in the holiday calendar for the next %d day",
+			Other: "There are no days off in the holiday calendar for the next %d days",
+		},
+		createEventRoomItem: {
+			One:   "%s · %d seat · floor %d",
+			Other: "%s · %d seats · floor %d",
+		},
+		templateAttendees: {
+			One:   ", %d attendee",
+			Other: ", %d attendees",
+		},
+	})
+}
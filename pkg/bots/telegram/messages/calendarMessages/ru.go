@@ -0,0 +1,372 @@
+package calendarMessages
+
+import "github.com/calendar-bot/pkg/i18n"
+
+func init() {
+	i18n.Register(i18n.Ru, map[i18n.ID]string{
+		eventNoTitleText:         "Без названия",
+		eventTimeText:            "<u>Когда:</u>\n\n⏰ %s, <u>%s</u> - %s<u>%s</u>\n",
+		eventTimeFullDay:         "<u>Когда:</u>\n\n⏰ %s %s, <u>Весь день</u>\n",
+		eventDateStart:           "<u>Когда:</u>\n\n⏰ <b>Начало:</b> %s %s\n",
+		eventPlaceText:           "\n<u>Где:</u>\n\n📍 %s\n",
+		eventOrganizerText:       "\nСоздатель - <b>%s</b> (%s)\n",
+		EventCalendarText:        "🗓 Календарь <b>%s</b>",
+		eventAttendeesHeaderText: "<u><i>Участники:</i></u>\n\n",
+		eventDescriptionHeader:   "<u><i>Описание:</i></u>\n\n",
+		eventConfroomsHeader:     "<u><i>Переговорные комнаты:</i></u>\n\n",
+
+		eventTodayTitle: "<b>Ваши события на сегодня</b>",
+		eventWeekTitle:  "<b>Ваши события с %s по %s</b>",
+		eventDateTitle:  "<b>Ваши события за %s</b>",
+		eventNextTitle:  "<b>Ваше следующее событие</b>",
+
+		eventGetDateHeader:          "<b>Получение событий за определенную дату: </b>\n\n",
+		findTimeStartHeader:         "<b>Выберите дату начала для поиска времени: </b>\n\n",
+		findTimeStopHeader:          "<b>Выберите дату оконачания для поиска времени: </b>\n\n",
+		findTimeInfoHeader:          "<b>Поиск будет производиться этом временном промежутке </b>\n\n",
+		findTimeStartTime:           "<b>Начало поиска: </b> %s\n",
+		findTimeStopTime:            "<b>Окончание поиска: </b> %s",
+		findTimeDuration:            "<b>Продолжительность события: </b> %s",
+		findTimeTextFormat:          "%s с %s до %s",
+		FindTimeChooseDayPartHeader: "<b>Выберите период дня для события</b>\n\n",
+		FindTimeChooseLengthHeader:  "<b>Выберите продолжительность события</b>\n\n",
+		eventGetDateMessage: "Для выбора даты воспользуйтесь кнопками или введите дату в формате " +
+			"<pre>&lt;число&gt; &lt;название месяца&gt;</pre> (например: <pre>22 марта</pre>)",
+
+		holidaysTitle: "<b>Ближайшие праздники и выходные</b>\n\n",
+
+		imageModeEnabled:       "🖼 Теперь расписание будет приходить картинкой",
+		imageModeDisabled:      "📝 Теперь расписание будет приходить текстом",
+		FindTimeHeatmapCaption: "Занятость участников в выбранный период",
+
+		nowCurrentTitle:      "<b>Сейчас идёт</b>\n\n",
+		nowNoCurrentEvent:    "Сейчас у вас нет событий",
+		nowRemaining:         "\n⏳ До окончания: <b>%d мин.</b>",
+		nowNextTitle:         "<b>Следующее событие</b>\n\n",
+		nowNoNextEvent:       "Больше событий сегодня нет",
+		usersStatusHeader:    "<b>Статус участников на %s</b>\n\n",
+		userStatusBusy:       "🔴 <b>%s</b> - на встрече до %s",
+		userStatusFree:       "🟢 <b>%s</b> - свободен",
+		userStatusFreeFrom:   ", свободен с %s",
+		userStatusNoFreeTime: ", свободного времени в ближайшие %d ч. нет",
+		userStatusNext:       ", следующая встреча в %s",
+		usersStatusNoMembers: "Пока никого нет. Статус показывается для тех, кто вызывал /now или /availability в этом чате",
+
+		focusRuleEnabled: "<b>Время для фокуса включено</b>\n\nКаждое утро бот бронирует в вашем календаре не меньше " +
+			"<b>%s</b> в рабочее время (9:00 - 19:00) под события \"Focus\"%s.\n" +
+			"Если удалить такое событие, в этот день бот новых создавать не будет",
+		focusRuleMorning: ", начиная с утра",
+		focusRuleLongest: ", выбирая самые длинные свободные промежутки",
+		focusRuleDisabled: "<b>Время для фокуса выключено</b>\n\nВыберите, сколько времени в день бот должен оставлять " +
+			"в календаре для работы без встреч",
+		durationHours:      "%d ч.",
+		durationMinutes:    "%d мин.",
+		FocusMorningButton: "🌅 Утром",
+		FocusAnyTimeButton: "🕑 В любое время",
+		FocusOffButton:     "❌ Выключить",
+		FocusEventDescription: "Время для работы без встреч. Событие создано ботом, удалите его, " +
+			"если оно мешает",
+
+		vacationUsage: "Укажите даты отпуска и, если нужно, сообщение для коллег:\n" +
+			"<pre>/vacation 01.06 14.06 Я в отпуске</pre>\n" +
+			"Пока отпуск активен, бот отклоняет новые приглашения на встречи в эти дни и сообщает о нём в групповых " +
+			"чатах. Отключить: <pre>/vacation off</pre>",
+		vacationActive:      "<b>🏖 Отпуск с %s по %s</b>\n\nНовые приглашения на эти дни будут отклоняться автоматически",
+		VacationBadDates:    "Не удалось разобрать даты. Используйте формат <pre>дд.мм</pre> или <pre>дд.мм.гггг</pre>",
+		VacationCancelled:   "Отпуск отключён, событие \"Out of office\" удалено",
+		VacationNotSet:      "Отпуск не установлен",
+		vacationGroupHeader: "<b>🏖 В это время в отпуске:</b>\n\n",
+		vacationGroupLine:   "%s - до %s",
+
+		invitationRulesHeader: "<b>Правила ответа на приглашения</b>\n\n",
+		invitationRulesEmpty:  "У вас пока нет правил\n",
+		invitationRulesUsage: "\nДобавить правило:\n<pre>/rules accept from alice@mail.ru noconflict</pre>\n" +
+			"<pre>/rules decline after 19:00</pre>\n" +
+			"Условия: from &lt;почта организатора&gt;, calendar &lt;название календаря&gt;, after ЧЧ:ММ - начинается " +
+			"не раньше, before ЧЧ:ММ - заканчивается не позже, longer &lt;минуты&gt;, shorter &lt;минуты&gt;, " +
+			"noconflict - не пересекается с другими вашими событиями.\n" +
+			"Бот проверяет новые приглашения каждые 10 минут, срабатывает первое подходящее правило",
+		invitationRuleAccept:        "✅ принимать",
+		invitationRuleDecline:       "❌ отклонять",
+		invitationRuleAny:           " все приглашения",
+		invitationRuleOrganizer:     " от %s",
+		invitationRuleCalendar:      " из календаря \"%s\"",
+		invitationRuleStartsAfter:   " с началом не раньше %s",
+		invitationRuleEndsBefore:    " с окончанием не позже %s",
+		invitationRuleMinDuration:   " длиннее %d мин.",
+		invitationRuleMaxDuration:   " короче %d мин.",
+		invitationRuleNoConflict:    " без пересечений с другими событиями",
+		invitationRuleDeleteButton:  "🗑 Удалить правило %d",
+		InvitationRuleAdded:         "Правило добавлено",
+		InvitationRuleDeleted:       "Правило удалено",
+		invitationRuleBadFormat:     "Не удалось разобрать правило\n",
+		invitationRulesReportHeader: "<b>Ответы на приглашения по вашим правилам</b>\n\n",
+
+		templatesHeader:       "<b>Шаблоны событий</b>\n\n",
+		templatesEmpty:        "У вас пока нет шаблонов. Сохранить событие как шаблон можно сразу после его создания\n",
+		templatesUsage:        "\nНажмите на шаблон, чтобы создать по нему событие - останется только указать время",
+		templateCall:          ", видеозвонок",
+		templateAppliedHeader: "<b>Событие по шаблону \"%s\"</b>\n\n",
+		templateSavedText:     "Шаблон \"%s\" сохранён. Все шаблоны - /templates",
+		TemplateSaveText:      "Можно сохранить это событие как шаблон и в следующий раз создать такое же в пару нажатий",
+		TemplateSaveButton:    "💾 Сохранить как шаблон",
+		TemplateSaved:         "Шаблон сохранён",
+		TemplateDeleted:       "Шаблон удалён",
+		TemplateApplied:       "Шаблон применён",
+		TemplateNotFound:      "Шаблон не найден",
+		TemplateSaveOutdated:  "Сохранить можно только последнее созданное событие",
+
+		InlineLoginText:     "Войдите в календарь, чтобы делиться событиями",
+		inlineResultFullDay: "%s, весь день",
+
+		ShareEventButton:     "📣 Поделиться в группе",
+		ShareEventBackButton: "◀ Назад",
+		ShareEventNoGroups:   "Бот не видел вас ни в одной группе. Напишите боту любую команду в нужной группе и попробуйте снова",
+		ShareEventNotAllowed: "Поделиться в группе может только организатор события",
+		ShareEventNoAccess:   "Бота или вас больше нет в этой группе",
+		shareEventShared:     "Событие отправлено в группу «%s»",
+
+		PostedEventCancelled: "❌ <b>Событие отменено</b>\n\nЕго больше нет в календаре организатора",
+
+		bindCalendarHeader:  "<b>Календарь группы</b>\n\n",
+		bindCalendarCurrent: "Сейчас к группе привязан календарь «%s»\n\n",
+		bindCalendarChoose: "Выберите календарь: его события будут показываться по /today, /week и /next, " +
+			"а события, созданные в группе, будут добавляться в него",
+		bindCalendarDone:         "Календарь «%s» привязан к группе. Его события теперь доступны всем по /today, /week и /next",
+		BindCalendarRemoveButton: "Отвязать календарь",
+		BindCalendarRemoved:      "Календарь отвязан от группы",
+		BindCalendarGroupOnly:    "Привязать календарь можно только в групповом чате",
+		BindCalendarNotAdmin:     "Привязать календарь может только администратор группы",
+		BindCalendarNoCalendars:  "Не удалось найти календари, которые можно привязать к группе",
+		BindCalendarNotFound:     "Календарь не найден среди ваших календарей",
+		BindCalendarCreateDenied: "Создавать встречи в календаре группы может только его владелец или администратор группы",
+
+		membersHeader:           "<b>Участники группы</b>\n\n",
+		membersAuthenticated:    "✅ Подключили календарь: %s\n",
+		membersNotAuthenticated: "⚠ Ещё не подключили календарь: %s\n\nЧтобы подключить календарь, откройте личный чат с ботом",
+		membersEmpty: "Бот пока не видел участников этой группы. Участники появятся здесь, когда напишут в группе " +
+			"или воспользуются ботом",
+		membersAllAuthenticated: "\nВсе участники подключили календарь",
+		MembersLoginButton:      "Подключить календарь",
+
+		forwardedFrom:        "Из сообщения %s",
+		forwardedFromUnknown: "Из пересланного сообщения",
+
+		availabilityHeader:    "<b>Занятость участников с %s по %s</b>\n\n",
+		availabilityWeekdays:  "Пн Вт Ср Чт Пт Сб Вс",
+		availabilityLegend:    "\n· свободен  ▒ частично занят  █ занят\nВ каждом дне: утро (9:00 - 13:00) и день (13:00 - 18:00)",
+		availabilityNoMembers: "Пока никто из участников не подключил календарь. Нажмите \"Добавить меня\", чтобы ваш календарь учитывался",
+
+		AvailabilityJoinButton:     "✅ Добавить меня",
+		AvailabilityPrevWeekButton: "◀ Пред. неделя",
+		AvailabilityNextWeekButton: "След. неделя ▶",
+
+		eventNoTodayEventsFound:  "У вас нет событий сегодня",
+		eventNoWeekEventsFound:   "У вас нет событий на этой неделе",
+		eventNoDateEventsFound:   "У вас нет событий за выбранную дату",
+		eventNoClosestEventFound: "У вас больше нет событий сегодня",
+
+		findTimePollHeader: "Выберите время. Если хотите, чтобы ваш календарь учитывался - нажмите на кнопку " +
+			"\"Участвую\"\n\nНа данный момент учитываются календари: ",
+		FindTimeAdd:             "✅ Участвую",
+		FindTimeFind:            "Найти время",
+		FindTimeBack:            "Изменить параметры",
+		FindTimeExist:           "Вы уже участвуете",
+		FindTimeNotFound:        "К сожалению мы не нашли свободного времени для всех участников с учетом данных параметров",
+		FindTimePeriodIsTooLong: "Выбранный период для поиска слишком большой, сокращаем до максимального возможного",
+
+		eventDateNotParsed: "Мы не смогли распознать дату, попробуйте еще раз",
+		EventDateToIsBeforeFrom: "<b>Введенная дата раньше начала события, введите корректную дату.</b>\n\n" +
+			"Если вы хотите переставить события на другое время - измените время начала события",
+		eventSessionNotFound: "Мы не смогли найти необходимую информацию для обработки запроса.\nВоспользуйтесь нужной вам " +
+			"командой заново",
+		EventNoEventDataFound: "<b>Мы не смогли распознать данные о событии. Попробуйте сформулировать предложение иначе." +
+			"</b>\n\nНапример: Учеба завтра с 10:00 до 13:00",
+		CallbackExpired: "Эта кнопка устарела. Запросите событие заново, например, командой /today",
+		eventShowNotFoundError: "К сожалению мы не смогли найти информацию о событии.\nВозможно, это старое сообщение." +
+			"\nЗапросите событие с помощью бота заново.",
+		eventCallbackResponseText: "Событие: %s",
+
+		eventCancelSearchDate:   "Отмена поиска событий",
+		eventCanceledSearchDate: "Поиск события отменен",
+
+		createEventHeader:  "<u><b>Что получается:</b></u>\n\n",
+		createdEventHeader: "<u><b>Событие создано:</b></u>\n\n",
+
+		createEventInitText: "<b>Введите время начала события</b>\n\nДля выбора даты и времени начала события" +
+			" воспользуйтесь кнопками или введите дату в формате <pre>&lt;число&gt; &lt;название месяца&gt; " +
+			"&lt;ЧЧ:ММ&gt;</pre> (например: 22 марта 15:00)",
+		createEventToText: "<b>Введите время окончания события</b>\n\nДля продолжительности события" +
+			" воспользуйтесь кнопками или введите дату оконочания в формате <pre>&lt;число&gt; &lt;название месяца&gt; " +
+			"&lt;ЧЧ:ММ&gt;</pre> (например: 22 марта 15:00)",
+		createEventTitleText:    "<b>Введите название события</b>",
+		CreateEventDescText:     "<b>Введите описание события</b>",
+		CreateEventLocationText: "<b>Введите место события</b>\n\nИли отправьте геопозицию или место через 📎",
+		CreateEventUserText: "<b>Введите email пользователя, которого хотите добавить. Можно ввести несколько" +
+			" через запятую</b>",
+
+		createEventCreateText:   "Создать событие",
+		createEventCreatedText:  "Событие успешно создано",
+		createEventCancelText:   "Отмена",
+		createEventCanceledText: "Создание события отменено",
+
+		createEventHalfHour:    "30 минут",
+		createEventHour:        "1 час",
+		createEventHourAndHalf: "1 час 30 минут",
+		createEventTwoHours:    "2 часа",
+		createEventFourHours:   "4 часа",
+		createEventSixHours:    "6 часов",
+		createEventFullDay:     "Весь день",
+
+		CreateEventChangeStartTimeButton: "Изменить время начала",
+		CreateEventChangeStopTimeButton:  "Изменить время окончания",
+		CreateEventAddTitleButton:        "Добавить название",
+		CreateEventChangeTitleButton:     "Изменить название",
+		CreateEventAddDescButton:         "Добавить описание",
+		CreateEventChangeDescButton:      "Изменить описание",
+		CreateEventAddLocationButton:     "Добавить место",
+		CreateEventChangeLocationButton:  "Изменить место",
+		CreateEventAddUser:               "Добавить участников",
+		CreateEventRoomButton:            "Выбрать переговорную",
+		CreateEventAddCallButton:         "Добавить видеозвонок",
+		CreateEventRemoveCallButton:      "Убрать видеозвонок",
+		CreateEventShareLocationButton:   "📍 Отправить геопозицию",
+		EventVenueButton:                 "📍 Показать на карте",
+
+		CreateEventRoomText:         "<b>Выберите свободную переговорную</b>\n\nПоказаны переговорные, в которых хватит мест на %d чел.",
+		CreateEventRoomNoRooms:      "Список переговорных не настроен",
+		CreateEventRoomNoFreeRooms:  "На это время нет свободных переговорных на %d чел.",
+		CreateEventRoomNeedsTime:    "Сначала укажите время начала и окончания события",
+		CreateEventRoomNoneButton:   "Без переговорной",
+		CreateEventRoomSelectedText: "Переговорная выбрана",
+
+		CreateEventCallAddedText:   "Видеозвонок добавлен, ссылка появится в событии",
+		CreateEventCallRemovedText: "Видеозвонок убран",
+
+		ShowTodayTasks:  "покажи задачи на сегодня",
+		ShowTodayPhrase: "покажи события на сегодня",
+		ShowNextTask:    "покажи следующее событие",
+		ShowNextPhrase:  "покажи ближайшее события",
+
+		CreateEventGo:    "✅ Я иду",
+		CreateEventNotGo: "❌ Я не иду",
+
+		CreateEventAlreadyOrganize: "Вы являетесь создателем события и участуете в нем по умолчанию",
+		CreateEventAlreadyGo:       "Вы уже дали согласие на участие в событии",
+		CreateEventAlreadyNotGo:    "Вы уже дали отказ от участия в событии",
+		CreateEventCannotAdd: "Мы не смогли изменить ваш статус в событии - подтвердите его с помощью календаря или письма" +
+			" из почты",
+
+		CreateEventFindTimeMessage:   "Как создать событие?",
+		CreateEventFindTimeYesButton: "🔍 Найти удобное время",
+		CreateEventFindTimeNoButton:  "⏰ Создать на конкретное время",
+
+		middlewaresUserNotAuthenticated: "Вы не можете воспользоваться данной функцией пока не авторизуетесь в боте через" +
+			" аккаунт mail.ru. Для авторизации воспользуйтесь командой /start в личном чате с ботом",
+		middlewaresGroupAlertBase:  "Вы уверены, что хотите показать ",
+		middlewaresGroupAlertToday: "<b>ВСЕМ</b> свои события на сегодня?",
+		middlewaresGroupAlertNext:  "<b>ВСЕМ</b> своё следующее событие на сегодня?",
+		middlewaresGroupAlertDate:  "<b>ВСЕМ</b> свои события за определенную дату?",
+
+		userNotAllow: "Вы не можете взаимодействовать с данной кнопкой",
+
+		chooseStepText: "Выберите шаг:",
+
+		fastDateToday:           "%s, Сегодня",
+		fastDateTomorrow:        "%s, Завтра",
+		FastCommandInHalfHour:   "Через полчаса",
+		FastCommandInHour:       "Через час",
+		FastCommandInTwoHours:   "Через два часа",
+		FastCommandInThreeHours: "Через три часа",
+		FastCommandTodayAt9:     "Сегодня в 9:00",
+		FastCommandTodayAt12:    "Сегодня в 12:00",
+		FastCommandTodayAt15:    "Сегодня в 15:00",
+		FastCommandTodayAt18:    "Сегодня в 18:00",
+		FastCommandTomorrowAt9:  "Завтра в 9:00",
+		FastCommandTomorrowAt12: "Завтра в 12:00",
+		FastCommandTomorrowAt15: "Завтра в 15:00",
+		FastCommandTomorrowAt18: "Завтра в 18:00",
+		FastCommandInWeekAt12:   "Через неделю в 12:00",
+		FastCommandInWeekAt15:   "Через неделю в 15:00",
+		FastCommandInWeekAt18:   "Через неделю в 18:00",
+
+		AlertYesButton:             "Да",
+		AlertNoButton:              "Нет",
+		FindTimeMorningButton:      "Утром (6:00 - 13:00)",
+		FindTimeAfternoonButton:    "Днем (12:00 - 19:00)",
+		FindTimeEveningButton:      "Вечером (17:00 - 0:00)",
+		FindTimeWorkingHoursButton: "В рабочее время (9:00 - 18:00)",
+		FindTimeAnyTimeButton:      "В любое время",
+		FindTimeLength30m:          "30 мин",
+		FindTimeLength1h:           "1 час",
+		FindTimeLength1h30m:        "1,5 часа",
+		FindTimeLength2h:           "2 часа",
+		FindTimeLength2h30m:        "2,5 часа",
+		FindTimeLength3h:           "3 часа",
+		FindTimeLength4h:           "4 часа",
+		FindTimeLength5h:           "5 часов",
+
+		languageText:     "<b>Язык бота</b>\n\nСейчас выбран русский. Выберите язык сообщений и кнопок:",
+		languageChanged:  "Язык бота - русский",
+		languageButton:   "🇷🇺 Русский",
+		LanguageSelected: "Язык выбран",
+
+		settingsHeader:              "<b>⚙ Настройки</b>\n\n",
+		settingsTimezone:            "🌍 Часовой пояс: <b>%s</b>\n",
+		settingsLanguage:            "🗣 Язык: <b>%s</b>\n",
+		settingsLanguageAuto:        "как в Telegram",
+		settingsEventDuration:       "⏱ Длительность встречи: <b>%s</b>\n",
+		settingsCalendar:            "📅 Календарь для новых встреч: <b>%s</b>\n",
+		settingsCalendarPersonal:    "основной",
+		settingsReminder:            "🔔 Напоминание о встрече: <b>%s</b>\n",
+		settingsReminderBefore:      "за %s",
+		settingsDigest:              "📰 Расписание на день: <b>%s</b>\n",
+		settingsDigestAt:            "в %02d:%02d",
+		settingsOff:                 "выключено",
+		settingsImageMode:           "🖼 Расписание присылается <b>%s</b>\n",
+		settingsImageModeImage:      "картинкой",
+		settingsImageModeText:       "текстом",
+		settingsFooter:              "\nВыберите, что изменить:",
+		settingsChooseTimezone:      "Выберите часовой пояс:",
+		settingsChooseLanguage:      "Выберите язык сообщений и кнопок:",
+		settingsChooseEventDuration: "Выберите длительность, которую бот предложит первой при создании встречи:",
+		settingsChooseCalendar:      "Выберите календарь, в котором бот создаёт встречи:",
+		settingsChooseReminder:      "За сколько до начала встречи напоминать о ней?",
+		settingsChooseDigest:        "Во сколько присылать расписание на день?",
+		settingsTimezoneButton:      "🌍 Часовой пояс",
+		settingsLanguageButton:      "🗣 Язык",
+		settingsEventDurationButton: "⏱ Длительность",
+		settingsCalendarButton:      "📅 Календарь",
+		settingsReminderButton:      "🔔 Напоминание",
+		settingsDigestButton:        "📰 Расписание на день",
+		settingsImageModeButton:     "🖼 Картинкой или текстом",
+		SettingsResetButton:         "↩ Сбросить настройки",
+		SettingsBackButton:          "◀ Назад",
+		SettingsSaved:               "Сохранено",
+		SettingsWasReset:            "Настройки сброшены",
+		SettingsBadValue:            "Это значение нельзя сохранить",
+		reminderHeader:              "🔔 <b>Через %s начнётся встреча</b>\n\n",
+
+		callLinkButton: "📲 Ссылка на звонок",
+		showMoreButton: "🔻 Развернуть",
+		showLessButton: "🔺 Свернуть",
+	})
+
+	i18n.RegisterPlural(i18n.Ru, map[i18n.ID]i18n.Plural{
+		holidaysNotFound: {
+			One:  "В календаре праздников нет нерабочих дней на ближайший %d день",
+			Few:  "В календаре праздников нет нерабочих дней на ближайшие %d дня",
+			Many: "В календаре праздников нет нерабочих дней на ближайшие %d дней",
+		},
+		createEventRoomItem: {
+			One:  "%s · %d место · %d этаж",
+			Few:  "%s · %d места · %d этаж",
+			Many: "%s · %d мест · %d этаж",
+		},
+		templateAttendees: {
+			One:  ", %d участник",
+			Few:  ", %d участника",
+			Many: ", %d участников",
+		},
+	})
+}
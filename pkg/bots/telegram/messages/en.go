@@ -0,0 +1,16 @@
+package messages
+
+import "github.com/calendar-bot/pkg/i18n"
+
+func init() {
+	i18n.Register(i18n.En, map[i18n.ID]string{
+		errorTextDev:  "Something went wrong ... \nError: %s",
+		errorTextProd: "Sorry, something went wrong - I'll be fixed soon :)",
+		errorReport:   "Report a bug",
+
+		errorAuthDev:                        "Authorization error: %s",
+		errorAuthProd:                       "It looks like you are not logged in - log in to mail.ru with the /start command",
+		ErrorCommandIsNotAllowedInGroupChat: "This command is not available in a group chat. Go to a private chat with the bot",
+		ErrorCommandIsOnlyForGroupChat:      "This command is only available in a group chat",
+	})
+}
@@ -0,0 +1,16 @@
+package messages
+
+import "github.com/calendar-bot/pkg/i18n"
+
+func init() {
+	i18n.Register(i18n.Ru, map[i18n.ID]string{
+		errorTextDev:  "Что-то пошло не так ... \nОшибка: %s",
+		errorTextProd: "Прошу прощения,но что-то пошло не так - меня обязательно скоро починят :)",
+		errorReport:   "Сообщить об ошибке",
+
+		errorAuthDev:                        "Ошибка авторизации: %s",
+		errorAuthProd:                       "Похоже, что вы не авторизованы - войдите в аккаунт mail.ru с помощью команды /start",
+		ErrorCommandIsNotAllowedInGroupChat: "Эта команда не доступна в групповом чате. Перейдите в личный чат с ботом",
+		ErrorCommandIsOnlyForGroupChat:      "Эта команда доступна только в групповом чате",
+	})
+}
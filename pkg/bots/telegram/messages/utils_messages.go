@@ -1,37 +1,38 @@
 package messages
 
 import (
-	"fmt"
 	"github.com/calendar-bot/pkg/config"
+	"github.com/calendar-bot/pkg/i18n"
 	"os"
 )
 
 const (
-	errorTextDev  = "Что-то пошло не так ... \nОшибка: %s"
-	errorTextProd = "Прошу прощения,но что-то пошло не так - меня обязательно скоро починят :)"
-	errorReport   = "Сообщить об ошибке"
+	errorTextDev  i18n.ID = "utils.error_text_dev"
+	errorTextProd i18n.ID = "utils.error_text_prod"
+	errorReport   i18n.ID = "utils.error_report"
 
-	errorAuthDev                        = "Ошибка авторизации: %s"
-	errorAuthProd                       = "Похоже, что вы не авторизованы - войдите в аккаунт mail.ru с помощью команды /start"
-	ErrorCommandIsNotAllowedInGroupChat = "Эта команда не доступна в групповом чате. Перейдите в личный чат с ботом"
+	errorAuthDev                        i18n.ID = "utils.error_auth_dev"
+	errorAuthProd                       i18n.ID = "utils.error_auth_prod"
+	ErrorCommandIsNotAllowedInGroupChat i18n.ID = "utils.error_command_is_not_allowed_in_group_chat"
+	ErrorCommandIsOnlyForGroupChat      i18n.ID = "utils.error_command_is_only_for_group_chat"
 )
 
-func MessageUnexpectedError(err string) string {
+func MessageUnexpectedError(lang i18n.Lang, err string) string {
 	if os.Getenv(config.EnvAppEnvironment) == config.AppEnvironmentDev {
-		return fmt.Sprintf(errorTextDev, err)
+		return lang.T(errorTextDev, err)
 	} else {
-		return errorTextProd
+		return lang.T(errorTextProd)
 	}
 }
 
-func MessageAuthError(err string) string {
+func MessageAuthError(lang i18n.Lang, err string) string {
 	if os.Getenv(config.EnvAppEnvironment) == config.AppEnvironmentDev {
-		return fmt.Sprintf(errorAuthDev, err)
+		return lang.T(errorAuthDev, err)
 	} else {
-		return errorAuthProd
+		return lang.T(errorAuthProd)
 	}
 }
 
-func GetMessageReportBug() string {
-	return errorReport
+func GetMessageReportBug(lang i18n.Lang) string {
+	return lang.T(errorReport)
 }
@@ -0,0 +1,127 @@
+package wizard
+
+import (
+	"github.com/calendar-bot/pkg/bots/fsm"
+	"github.com/calendar-bot/pkg/types"
+)
+
+// states of the conversation kept in the session
+const (
+	Idle fsm.State = "idle"
+	// waiting for the date to show the events of
+	Date fsm.State = "date"
+	// the group is looking for the time suitable for all members
+	FindTime fsm.State = "find_time"
+
+	// the event was parsed from the text and waits for the missing fields
+	CreateInit     fsm.State = "create_init"
+	CreateFrom     fsm.State = "create_from"
+	CreateTo       fsm.State = "create_to"
+	CreateTitle    fsm.State = "create_title"
+	CreateDesc     fsm.State = "create_desc"
+	CreateUser     fsm.State = "create_user"
+	CreateLocation fsm.State = "create_location"
+)
+
+const (
+	Cancel        fsm.Event = "cancel"
+	AskDate       fsm.Event = "ask_date"
+	DateShown     fsm.Event = "date_shown"
+	StartFindTime fsm.Event = "start_find_time"
+	TimeFound     fsm.Event = "time_found"
+	StartCreate   fsm.Event = "start_create"
+	StartFromText fsm.Event = "start_from_text"
+	EditFrom      fsm.Event = "edit_from"
+	EditTo        fsm.Event = "edit_to"
+	EditTitle     fsm.Event = "edit_title"
+	EditDesc      fsm.Event = "edit_desc"
+	EditUser      fsm.Event = "edit_user"
+	EditLocation  fsm.Event = "edit_location"
+	Created       fsm.Event = "created"
+)
+
+var createStates = []fsm.State{CreateInit, CreateFrom, CreateTo, CreateTitle, CreateDesc, CreateUser, CreateLocation}
+
+// Machine drives the date, create and find-time wizards, the subject of its actions is *types.BotRedisSession
+var Machine = fsm.MustNew(fsm.Definition{
+	Initial: Idle,
+	Cancel:  Cancel,
+	States: []fsm.StateDef{
+		{Name: Idle, OnEnter: clearWizard},
+		{Name: Date},
+		{Name: FindTime},
+		{Name: CreateInit},
+		{Name: CreateFrom},
+		{Name: CreateTo},
+		{Name: CreateTitle},
+		{Name: CreateDesc},
+		{Name: CreateUser},
+		{Name: CreateLocation},
+	},
+	Transitions: []fsm.Transition{
+		{Event: AskDate, From: []fsm.State{Idle}, To: Date},
+		{Event: DateShown, From: []fsm.State{Date}, To: Idle},
+
+		{Event: StartFindTime, From: []fsm.State{Idle}, To: FindTime},
+		{Event: TimeFound, From: []fsm.State{FindTime}, To: CreateTitle},
+
+		{Event: StartCreate, From: []fsm.State{Idle}, To: CreateFrom},
+		{Event: StartFromText, From: []fsm.State{Idle}, To: CreateInit},
+		{Event: EditFrom, From: createStates, To: CreateFrom},
+		{Event: EditTo, From: createStates, To: CreateTo},
+		{Event: EditTitle, From: createStates, To: CreateTitle},
+		{Event: EditDesc, From: createStates, To: CreateDesc},
+		{Event: EditUser, From: createStates, To: CreateUser},
+		{Event: EditLocation, From: createStates, To: CreateLocation},
+		{Event: Created, From: createStates, To: Idle},
+	},
+})
+
+// Fire moves the session to the next state, the session is not changed on invalid transitions
+func Fire(session *types.BotRedisSession, event fsm.Event) error {
+	next, err := Machine.Fire(Current(session), event, session)
+	if err != nil {
+		return err
+	}
+	session.State = string(next)
+	session.LegacyStep, session.LegacyIsDate, session.LegacyIsCreate = 0, false, false
+	return nil
+}
+
+// Current is the state of the session, sessions saved before the state machine are read from their legacy fields.
+// A legacy session looking for the time is idle, find_time_done was not enough to tell it from an idle one
+func Current(session *types.BotRedisSession) fsm.State {
+	switch {
+	case session.State != "":
+		return fsm.State(session.State)
+	case session.LegacyIsDate:
+		return Date
+	case session.LegacyIsCreate && session.LegacyStep >= 0 && session.LegacyStep < len(createStates):
+		// the legacy steps were numbered in the order of createStates
+		return createStates[session.LegacyStep]
+	}
+	return Idle
+}
+
+// Is reports whether the session is in one of the states
+func Is(session *types.BotRedisSession, states ...fsm.State) bool {
+	return Machine.Is(Current(session), states...)
+}
+
+// IsCreating reports whether the fields of the created event are being filled
+func IsCreating(session *types.BotRedisSession) bool {
+	return Is(session, createStates...)
+}
+
+// clearWizard drops the data collected by the finished or cancelled wizard,
+// the last created event is kept to save it as a template
+func clearWizard(subject interface{}) {
+	session := subject.(*types.BotRedisSession)
+	session.Event = types.Event{}
+	session.FreeBusy = types.FreeBusy{}
+	session.FindTimeDayPart = nil
+	session.FindTimeDuration = 0
+	session.Users = nil
+	session.TemplateDuration = 0
+	session.FromTextCreate = false
+}
@@ -0,0 +1,97 @@
+package wizard
+
+import (
+	"encoding/json"
+	"github.com/calendar-bot/pkg/bots/fsm"
+	"github.com/calendar-bot/pkg/types"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func fireAll(t *testing.T, session *types.BotRedisSession, events ...fsm.Event) {
+	for _, event := range events {
+		require.NoError(t, Fire(session, event), event)
+	}
+}
+
+func TestCreateFlow(t *testing.T) {
+	session := &types.BotRedisSession{}
+	assert.True(t, Is(session, Idle))
+
+	fireAll(t, session, StartCreate, EditTo, EditTitle, EditDesc, EditLocation, EditUser, EditFrom)
+	assert.Equal(t, CreateFrom, Current(session))
+	assert.True(t, IsCreating(session))
+
+	session.Event.Title = "title"
+	created := session.Event
+	session.LastCreated = &created
+	fireAll(t, session, Created)
+	assert.True(t, Is(session, Idle))
+	assert.Equal(t, types.Event{}, session.Event)
+	assert.Equal(t, "title", session.LastCreated.Title)
+}
+
+func TestFindTimeFlow(t *testing.T) {
+	session := &types.BotRedisSession{}
+
+	fireAll(t, session, StartFindTime)
+	assert.False(t, IsCreating(session))
+	session.Users = []int64{1, 2}
+	session.FindTimeDuration = time.Hour
+
+	fireAll(t, session, TimeFound)
+	assert.Equal(t, CreateTitle, Current(session))
+
+	fireAll(t, session, Cancel)
+	assert.Equal(t, Idle, Current(session))
+	assert.Nil(t, session.Users)
+	assert.Zero(t, session.FindTimeDuration)
+}
+
+func TestInvalidTransitions(t *testing.T) {
+	session := &types.BotRedisSession{}
+
+	err := Fire(session, EditTitle)
+	assert.Equal(t, fsm.InvalidTransition{State: Idle, Event: EditTitle}, errors.Cause(err))
+	assert.Equal(t, "", session.State)
+
+	fireAll(t, session, AskDate)
+	assert.Error(t, Fire(session, StartCreate))
+	assert.Error(t, Fire(session, Created))
+	fireAll(t, session, DateShown)
+	assert.Error(t, Fire(session, TimeFound))
+}
+
+func TestCancelFromAnyState(t *testing.T) {
+	for _, state := range append([]fsm.State{Idle, Date, FindTime}, createStates...) {
+		session := &types.BotRedisSession{
+			State:            string(state),
+			FromTextCreate:   true,
+			TemplateDuration: time.Hour,
+			Event:            types.Event{Title: "title"},
+		}
+		fireAll(t, session, Cancel)
+		assert.Equal(t, &types.BotRedisSession{State: string(Idle)}, session, state)
+	}
+}
+
+func TestLegacySession(t *testing.T) {
+	legacy := func(data string) *types.BotRedisSession {
+		session := &types.BotRedisSession{}
+		require.NoError(t, json.Unmarshal([]byte(data), session))
+		return session
+	}
+
+	assert.Equal(t, Date, Current(legacy(`{"step":0,"is_date":true,"is_create":false}`)))
+	assert.Equal(t, CreateTitle, Current(legacy(`{"step":3,"is_date":false,"is_create":true}`)))
+	assert.Equal(t, CreateInit, Current(legacy(`{"step":0,"is_create":true,"find_time_done":true}`)))
+	assert.Equal(t, Idle, Current(legacy(`{"step":0,"is_create":false,"find_time_done":false}`)))
+	assert.Equal(t, Idle, Current(legacy(`{"step":42,"is_create":true}`)))
+
+	session := legacy(`{"step":3,"is_create":true}`)
+	fireAll(t, session, EditDesc)
+	assert.Equal(t, &types.BotRedisSession{State: string(CreateDesc)}, session)
+}
@@ -2,11 +2,17 @@ package config
 
 import (
 	"github.com/calendar-bot/pkg/log"
+	"github.com/calendar-bot/pkg/services/callbacks"
 	"github.com/calendar-bot/pkg/services/db"
+	"github.com/calendar-bot/pkg/services/keyring"
 	"github.com/calendar-bot/pkg/services/oauth"
 	"github.com/calendar-bot/pkg/services/redis"
+	"github.com/calendar-bot/pkg/services/sessions"
+	"github.com/calendar-bot/pkg/types"
 	"github.com/pkg/errors"
 	"os"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -14,6 +20,7 @@ const (
 	EnvAppEnvironment = "APP_ENVIRONMENT"
 	EnvAppAddress     = "APP_ADDRESS"
 	EnvParseAddress   = "PARSER_BACKEND_URL"
+	EnvCallAPIURL     = "CALL_API_URL"
 )
 
 const (
@@ -21,6 +28,7 @@ const (
 	EnvBotToken               = "BOT_TOKEN"
 	EnvBotWebhookUrl          = "BOT_WEBHOOK_URL"
 	EnvBotDefaultUserTimezone = "BOT_DEFAULT_USER_TIMEZONE"
+	EnvBotRooms               = "BOT_ROOMS"
 )
 
 const (
@@ -30,6 +38,7 @@ const (
 
 const (
 	defaultBotUserTimezoneValue = "Europe/Moscow"
+	defaultCallAPIURLValue      = "https://corsapi.imgsmail.ru/calls/api/v1"
 )
 
 type AppConfig struct {
@@ -39,11 +48,16 @@ type AppConfig struct {
 	BotToken               string
 	BotWebhookUrl          string
 	BotDefaultUserTimezone string
+	Rooms                  []types.Room
 	DB                     db.Config
 	ParseAddress           string
+	CallAPIURL             string
 	Redis                  redis.Config
 	BotRedis               redis.Config
 	OAuth                  oauth.Config
+	CallbackStore          callbacks.Config
+	Sessions               sessions.Config
+	TokenKeys              keyring.Config
 	Log                    log.Config
 }
 
@@ -51,12 +65,18 @@ func LoadAppConfig() (AppConfig, error) {
 	address := os.Getenv(EnvAppAddress)
 	environment := os.Getenv(EnvAppEnvironment)
 	parseAddress := os.Getenv(EnvParseAddress)
+	callAPIURL := os.Getenv(EnvCallAPIURL)
 
 	botAddress := os.Getenv(EnvBotAddress)
 	botToken := os.Getenv(EnvBotToken)
 	botWebhookUrl := os.Getenv(EnvBotWebhookUrl)
 	botDefaultUserTimezone := os.Getenv(EnvBotDefaultUserTimezone)
 
+	rooms, err := ParseRooms(os.Getenv(EnvBotRooms))
+	if err != nil {
+		return AppConfig{}, errors.WithMessagef(err, "failed to parse %s environment variable", EnvBotRooms)
+	}
+
 	if address == "" {
 		address = ":8080"
 	}
@@ -64,6 +84,9 @@ func LoadAppConfig() (AppConfig, error) {
 	if botAddress == "" {
 		address = ":2000"
 	}
+	if callAPIURL == "" {
+		callAPIURL = defaultCallAPIURLValue
+	}
 	if botDefaultUserTimezone == "" {
 		botDefaultUserTimezone = defaultBotUserTimezoneValue
 	}
@@ -101,6 +124,21 @@ func LoadAppConfig() (AppConfig, error) {
 		return AppConfig{}, errors.WithMessage(err, "failed to load oauth config")
 	}
 
+	callbackStoreConfig, err := callbacks.LoadCallbackStoreConfig()
+	if err != nil {
+		return AppConfig{}, errors.WithMessage(err, "failed to load callback store config")
+	}
+
+	sessionConfig, err := sessions.LoadSessionConfig()
+	if err != nil {
+		return AppConfig{}, errors.WithMessage(err, "failed to load session config")
+	}
+
+	tokenKeysConfig, err := keyring.LoadTokenKeysConfig()
+	if err != nil {
+		return AppConfig{}, errors.WithMessage(err, "failed to load token keys config")
+	}
+
 	// TODO(nickeskov): validate struct
 
 	return AppConfig{
@@ -109,12 +147,17 @@ func LoadAppConfig() (AppConfig, error) {
 		BotToken:               botToken,
 		BotWebhookUrl:          botWebhookUrl,
 		BotDefaultUserTimezone: botDefaultUserTimezone,
+		Rooms:                  rooms,
 		Environment:            environment,
 		DB:                     dbConfig,
 		ParseAddress:           parseAddress,
+		CallAPIURL:             callAPIURL,
 		Redis:                  redisConfig,
 		BotRedis:               botRedisConfig,
 		OAuth:                  oauthConfig,
+		CallbackStore:          callbackStoreConfig,
+		Sessions:               sessionConfig,
+		TokenKeys:              tokenKeysConfig,
 		Log:                    log.LoadLogConfig(),
 	}, nil
 }
@@ -125,6 +168,9 @@ func (app *AppConfig) ToEnv() map[string]string {
 		app.BotRedis.ToEnv(),
 		app.Redis.ToEnv(),
 		app.OAuth.ToEnv(),
+		app.CallbackStore.ToEnv(),
+		app.Sessions.ToEnv(),
+		app.TokenKeys.ToEnv(),
 		app.Log.ToEnv(),
 	}
 
@@ -144,11 +190,46 @@ func (app *AppConfig) ToEnv() map[string]string {
 	ret[EnvAppAddress] = app.Address
 	ret[EnvAppEnvironment] = app.Environment
 	ret[EnvParseAddress] = app.ParseAddress
+	ret[EnvCallAPIURL] = app.CallAPIURL
 
 	ret[EnvBotAddress] = app.BotAddress
 	ret[EnvBotToken] = app.BotToken
 	ret[EnvBotWebhookUrl] = app.BotWebhookUrl
 	ret[EnvBotDefaultUserTimezone] = app.BotDefaultUserTimezone
+	ret[EnvBotRooms] = FormatRooms(app.Rooms)
 
 	return ret
 }
+
+// ParseRooms parses the room directory "email:capacity:floor,email:capacity:floor", empty value is no rooms
+func ParseRooms(value string) ([]types.Room, error) {
+	if strings.TrimSpace(value) == "" {
+		return nil, nil
+	}
+
+	var rooms []types.Room
+	for _, item := range strings.Split(value, ",") {
+		parts := strings.Split(strings.TrimSpace(item), ":")
+		if len(parts) != 3 || parts[0] == "" {
+			return nil, errors.Errorf("bad room %q, expected email:capacity:floor", item)
+		}
+		capacity, err := strconv.Atoi(parts[1])
+		if err != nil {
+			return nil, errors.WithMessagef(err, "bad capacity of room %q", parts[0])
+		}
+		floor, err := strconv.Atoi(parts[2])
+		if err != nil {
+			return nil, errors.WithMessagef(err, "bad floor of room %q", parts[0])
+		}
+		rooms = append(rooms, types.Room{Email: parts[0], Capacity: capacity, Floor: floor})
+	}
+	return rooms, nil
+}
+
+func FormatRooms(rooms []types.Room) string {
+	items := make([]string, 0, len(rooms))
+	for _, room := range rooms {
+		items = append(items, room.Email+":"+strconv.Itoa(room.Capacity)+":"+strconv.Itoa(room.Floor))
+	}
+	return strings.Join(items, ",")
+}
@@ -2,7 +2,9 @@ package config
 
 import (
 	"github.com/bxcodec/faker/v3"
+	"github.com/calendar-bot/pkg/services/keyring"
 	"github.com/calendar-bot/pkg/services/redis"
+	"github.com/calendar-bot/pkg/types"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
@@ -57,6 +59,16 @@ func (s *appConfigTestSuite) generateFakeAppConfig() AppConfig {
 
 	config.BotDefaultUserTimezone = defaultBotUserTimezoneValue
 	config.OAuth.LinkExpireIn = 15 * time.Minute
+	config.CallbackStore.TTL = 72 * time.Hour
+	config.Sessions.TTL = 24 * time.Hour
+	config.TokenKeys = keyring.Config{
+		Keys:        map[string][]byte{"2021-05": []byte("0123456789abcdef0123456789abcdef")},
+		ActiveKeyID: "2021-05",
+	}
+	config.Rooms = []types.Room{
+		{Email: "room-1@corp.mail.ru", Capacity: 6, Floor: 3},
+		{Email: "room-2@corp.mail.ru", Capacity: 12, Floor: 5},
+	}
 
 	config.BotRedis = redis.NewBotConfig(
 		config.Redis.Address,
@@ -97,6 +109,20 @@ func (s *appConfigTestSuite) TestAppConfigEmptyTimezone() {
 	assert.Equal(s.T(), actual.BotDefaultUserTimezone, defaultBotUserTimezoneValue)
 }
 
+func (s *appConfigTestSuite) TestAppConfigEmptyCallAPIURL() {
+	expected := s.generateFakeAppConfig()
+	expected.CallAPIURL = ""
+
+	envs := expected.ToEnv()
+	s.setEnvs(envs)
+	defer s.unsetEnvs(envs)
+
+	actual, err := LoadAppConfig()
+	assert.NoError(s.T(), err)
+
+	assert.Equal(s.T(), defaultCallAPIURLValue, actual.CallAPIURL)
+}
+
 func (s *appConfigTestSuite) TestAppConfigAppEnvironmentValueRandom() {
 	expected := s.generateFakeAppConfig()
 
@@ -134,3 +160,22 @@ func (s *appConfigTestSuite) TestAppConfigAppEnvironmentValueDev() {
 		return &actual
 	})
 }
+
+func (s *appConfigTestSuite) TestParseRooms() {
+	rooms, err := ParseRooms("")
+	assert.NoError(s.T(), err)
+	assert.Empty(s.T(), rooms)
+
+	rooms, err = ParseRooms("big@corp.mail.ru:20:2, small@corp.mail.ru:4:7")
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), []types.Room{
+		{Email: "big@corp.mail.ru", Capacity: 20, Floor: 2},
+		{Email: "small@corp.mail.ru", Capacity: 4, Floor: 7},
+	}, rooms)
+
+	_, err = ParseRooms("big@corp.mail.ru:20")
+	assert.Error(s.T(), err)
+
+	_, err = ParseRooms("big@corp.mail.ru:many:2")
+	assert.Error(s.T(), err)
+}
@@ -50,7 +50,12 @@ func (eh *EventHandlers) getEventsToday(ctx echo.Context) error {
 		return errors.WithStack(err)
 	}
 
-	todayEvent, err := eh.eventUseCase.GetEventsToday(accessToken)
+	location, err := eh.userUseCase.GetUserLocation(telegramID)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	todayEvent, err := eh.eventUseCase.GetEventsToday(accessToken, time.Now().In(location))
 	if err != nil {
 		return errors.Wrapf(err, "failed to get today's events for telegramUserID=%d", telegramID)
 	}
@@ -73,7 +78,12 @@ func (eh *EventHandlers) getClosestEvent(ctx echo.Context) error {
 		return errors.WithStack(err)
 	}
 
-	closesEvent, err := eh.eventUseCase.GetClosestEvent(accessToken)
+	location, err := eh.userUseCase.GetUserLocation(telegramID)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	closesEvent, err := eh.eventUseCase.GetClosestEvent(accessToken, time.Now().In(location))
 	if err != nil {
 		return errors.Wrapf(err, "failed to get the closest event for telegramUserID=%d", telegramID)
 	}
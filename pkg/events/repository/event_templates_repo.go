@@ -0,0 +1,137 @@
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+	"github.com/calendar-bot/pkg/customerrors"
+	"github.com/calendar-bot/pkg/types"
+	"github.com/pkg/errors"
+	"time"
+)
+
+type EventTemplateEntityError struct {
+	error
+}
+
+var (
+	EventTemplateDoesNotExist = EventTemplateEntityError{errors.New("event template does not exist")}
+)
+
+func (er *EventRepository) AddEventTemplate(template types.EventTemplate) (int64, error) {
+	event, err := json.Marshal(template.Event)
+	if err != nil {
+		return 0, errors.Wrapf(err, "cannot marshal event of template=%v", template)
+	}
+
+	var id int64
+	err = er.storage.QueryRow(`
+			INSERT INTO event_templates(
+			                            telegram_user_id,
+			                            name,
+			                            event,
+			                            duration_minutes,
+			                            with_call
+			                            )
+			VALUES ($1, $2, $3, $4, $5)
+			RETURNING id`,
+		template.TelegramUserID,
+		template.Name,
+		event,
+		int64(template.Duration/time.Minute),
+		template.WithCall,
+	).Scan(&id)
+	if err != nil {
+		return 0, errors.Wrapf(err, "cannot add event template=%v", template)
+	}
+	return id, nil
+}
+
+// GetEventTemplates returns templates of the user in the order they were saved
+func (er *EventRepository) GetEventTemplates(telegramID int64) (templates []types.EventTemplate, err error) {
+	rows, err := er.storage.Query(`
+			SELECT id, telegram_user_id, name, event, duration_minutes, with_call
+			FROM event_templates
+			WHERE telegram_user_id = $1
+			ORDER BY id`,
+		telegramID,
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to perform SQL query in GetEventTemplates")
+	}
+	defer func() {
+		err = customerrors.HandleCloser(err, rows)
+	}()
+
+	templates = make([]types.EventTemplate, 0)
+	for rows.Next() {
+		template, err := scanEventTemplate(rows)
+		if err != nil {
+			return nil, err
+		}
+		templates = append(templates, template)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "failed to iterate over event templates")
+	}
+	return templates, nil
+}
+
+// GetEventTemplate returns the template of the user
+// Error types = error, EventTemplateEntityError
+func (er *EventRepository) GetEventTemplate(telegramID int64, templateID int64) (types.EventTemplate, error) {
+	template, err := scanEventTemplate(er.storage.QueryRow(`
+			SELECT id, telegram_user_id, name, event, duration_minutes, with_call
+			FROM event_templates
+			WHERE telegram_user_id = $1 AND id = $2`,
+		telegramID,
+		templateID,
+	))
+
+	switch {
+	case errors.Cause(err) == sql.ErrNoRows:
+		return types.EventTemplate{}, EventTemplateDoesNotExist
+	case err != nil:
+		return types.EventTemplate{}, errors.Wrapf(err, "failed to get event template id=%d of telegramID=%d",
+			templateID, telegramID)
+	}
+	return template, nil
+}
+
+func (er *EventRepository) DeleteEventTemplate(telegramID int64, templateID int64) error {
+	_, err := er.storage.Exec(
+		`DELETE FROM event_templates WHERE telegram_user_id = $1 AND id = $2`,
+		telegramID,
+		templateID,
+	)
+	if err != nil {
+		return errors.Wrapf(err, "cannot delete event template id=%d of telegramID=%d", templateID, telegramID)
+	}
+	return nil
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanEventTemplate(row rowScanner) (types.EventTemplate, error) {
+	var template types.EventTemplate
+	var event []byte
+	var durationMinutes int64
+	err := row.Scan(
+		&template.ID,
+		&template.TelegramUserID,
+		&template.Name,
+		&event,
+		&durationMinutes,
+		&template.WithCall,
+	)
+	if err != nil {
+		return types.EventTemplate{}, errors.WithStack(err)
+	}
+
+	if err := json.Unmarshal(event, &template.Event); err != nil {
+		return types.EventTemplate{}, errors.Wrapf(err, "cannot unmarshal event of template id=%d", template.ID)
+	}
+	template.Duration = time.Duration(durationMinutes) * time.Minute
+	return template, nil
+}
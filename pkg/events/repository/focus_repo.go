@@ -0,0 +1,169 @@
+package repository
+
+import (
+	"database/sql"
+	"github.com/calendar-bot/pkg/customerrors"
+	"github.com/calendar-bot/pkg/types"
+	"github.com/pkg/errors"
+	"time"
+)
+
+type FocusEntityError struct {
+	error
+}
+
+var (
+	FocusRuleDoesNotExist = FocusEntityError{errors.New("focus rule does not exist")}
+)
+
+func (er *EventRepository) UpsertFocusRule(rule types.FocusRule) error {
+	_, err := er.storage.Exec(`
+			INSERT INTO focus_rules(
+			                        telegram_user_id,
+			                        duration_minutes,
+			                        prefer_morning,
+			                        enabled
+			                        )
+			VALUES ($1, $2, $3, $4)
+			ON CONFLICT (telegram_user_id) DO UPDATE
+			SET duration_minutes = EXCLUDED.duration_minutes,
+			    prefer_morning   = EXCLUDED.prefer_morning,
+			    enabled          = EXCLUDED.enabled`,
+		rule.TelegramUserID,
+		int64(rule.Duration/time.Minute),
+		rule.PreferMorning,
+		rule.Enabled,
+	)
+	if err != nil {
+		return errors.Wrapf(err, "cannot upsert focus rule=%v", rule)
+	}
+	return nil
+}
+
+// GetFocusRule returns focus rule of the user
+// Error types = error, FocusEntityError
+func (er *EventRepository) GetFocusRule(telegramID int64) (types.FocusRule, error) {
+	rule := types.FocusRule{TelegramUserID: telegramID}
+	var durationMinutes int64
+	err := er.storage.QueryRow(
+		`SELECT duration_minutes, prefer_morning, enabled FROM focus_rules WHERE telegram_user_id = $1`,
+		telegramID,
+	).Scan(
+		&durationMinutes,
+		&rule.PreferMorning,
+		&rule.Enabled,
+	)
+
+	switch {
+	case err == sql.ErrNoRows:
+		return types.FocusRule{}, FocusRuleDoesNotExist
+	case err != nil:
+		return types.FocusRule{}, errors.Wrapf(err, "failed to get focus rule by telegramID=%d", telegramID)
+	}
+
+	rule.Duration = time.Duration(durationMinutes) * time.Minute
+	return rule, nil
+}
+
+func (er *EventRepository) GetEnabledFocusRules() (rules []types.FocusRule, err error) {
+	rows, err := er.storage.Query(
+		`SELECT telegram_user_id, duration_minutes, prefer_morning, enabled FROM focus_rules WHERE enabled`,
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to perform SQL query in GetEnabledFocusRules")
+	}
+	defer func() {
+		err = customerrors.HandleCloser(err, rows)
+	}()
+
+	for rows.Next() {
+		var rule types.FocusRule
+		var durationMinutes int64
+		if err := rows.Scan(&rule.TelegramUserID, &durationMinutes, &rule.PreferMorning, &rule.Enabled); err != nil {
+			return nil, errors.Wrap(err, "error while scanning focus rules")
+		}
+		rule.Duration = time.Duration(durationMinutes) * time.Minute
+		rules = append(rules, rule)
+	}
+
+	return rules, nil
+}
+
+func (er *EventRepository) AddFocusEvent(event types.FocusEvent) error {
+	_, err := er.storage.Exec(`
+			INSERT INTO focus_events(
+			                         telegram_user_id,
+			                         event_uid,
+			                         calendar_uid,
+			                         event_from,
+			                         event_to,
+			                         deleted
+			                         )
+			VALUES ($1, $2, $3, $4, $5, $6)`,
+		event.TelegramUserID,
+		event.EventUID,
+		event.CalendarUID,
+		event.From,
+		event.To,
+		event.Deleted,
+	)
+	if err != nil {
+		return errors.Wrapf(err, "cannot add focus event=%v", event)
+	}
+	return nil
+}
+
+// GetFocusEvents returns focus blocks of the user intersecting [from, to)
+func (er *EventRepository) GetFocusEvents(telegramID int64, from time.Time, to time.Time) (
+	events []types.FocusEvent, err error) {
+
+	rows, err := er.storage.Query(`
+			SELECT event_uid, calendar_uid, event_from, event_to, deleted
+			FROM focus_events
+			WHERE telegram_user_id = $1 AND event_from < $3 AND event_to > $2
+			ORDER BY event_from`,
+		telegramID,
+		from,
+		to,
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to perform SQL query in GetFocusEvents")
+	}
+	defer func() {
+		err = customerrors.HandleCloser(err, rows)
+	}()
+
+	for rows.Next() {
+		event := types.FocusEvent{TelegramUserID: telegramID}
+		if err := rows.Scan(&event.EventUID, &event.CalendarUID, &event.From, &event.To, &event.Deleted); err != nil {
+			return nil, errors.Wrap(err, "error while scanning focus events")
+		}
+		events = append(events, event)
+	}
+
+	return events, nil
+}
+
+func (er *EventRepository) MarkFocusEventDeleted(telegramID int64, eventUID string) error {
+	_, err := er.storage.Exec(
+		`UPDATE focus_events SET deleted = TRUE WHERE telegram_user_id = $1 AND event_uid = $2`,
+		telegramID,
+		eventUID,
+	)
+	if err != nil {
+		return errors.Wrapf(err, "cannot mark focus event uid=%s of telegramID=%d deleted", eventUID, telegramID)
+	}
+	return nil
+}
+
+func (er *EventRepository) DeleteFocusEvent(telegramID int64, eventUID string) error {
+	_, err := er.storage.Exec(
+		`DELETE FROM focus_events WHERE telegram_user_id = $1 AND event_uid = $2`,
+		telegramID,
+		eventUID,
+	)
+	if err != nil {
+		return errors.Wrapf(err, "cannot delete focus event uid=%s of telegramID=%d", eventUID, telegramID)
+	}
+	return nil
+}
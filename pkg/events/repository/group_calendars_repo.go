@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"database/sql"
+	"github.com/calendar-bot/pkg/types"
+	"github.com/pkg/errors"
+)
+
+type GroupCalendarEntityError struct {
+	error
+}
+
+var (
+	GroupCalendarDoesNotExist = GroupCalendarEntityError{errors.New("group calendar does not exist")}
+)
+
+// SetGroupCalendar binds the calendar to the group chat, the previous binding of the chat is replaced
+func (er *EventRepository) SetGroupCalendar(groupCalendar types.GroupCalendar) error {
+	_, err := er.storage.Exec(`
+			INSERT INTO group_calendars(
+			                            chat_id,
+			                            telegram_user_id,
+			                            calendar_uid,
+			                            calendar_title
+			                            )
+			VALUES ($1, $2, $3, $4)
+			ON CONFLICT (chat_id) DO UPDATE SET telegram_user_id = EXCLUDED.telegram_user_id,
+			                                    calendar_uid = EXCLUDED.calendar_uid,
+			                                    calendar_title = EXCLUDED.calendar_title`,
+		groupCalendar.ChatID,
+		groupCalendar.TelegramUserID,
+		groupCalendar.CalendarUID,
+		groupCalendar.CalendarTitle,
+	)
+	if err != nil {
+		return errors.Wrapf(err, "cannot set group calendar=%v", groupCalendar)
+	}
+	return nil
+}
+
+// GetGroupCalendar returns the calendar bound to the chat
+// Error types = error, GroupCalendarEntityError
+func (er *EventRepository) GetGroupCalendar(chatID int64) (types.GroupCalendar, error) {
+	var groupCalendar types.GroupCalendar
+	err := er.storage.QueryRow(
+		`SELECT chat_id, telegram_user_id, calendar_uid, calendar_title FROM group_calendars WHERE chat_id = $1`,
+		chatID,
+	).Scan(
+		&groupCalendar.ChatID,
+		&groupCalendar.TelegramUserID,
+		&groupCalendar.CalendarUID,
+		&groupCalendar.CalendarTitle,
+	)
+
+	switch {
+	case err == sql.ErrNoRows:
+		return types.GroupCalendar{}, GroupCalendarDoesNotExist
+	case err != nil:
+		return types.GroupCalendar{}, errors.Wrapf(err, "failed to get group calendar by chatID=%d", chatID)
+	}
+
+	return groupCalendar, nil
+}
+
+func (er *EventRepository) DeleteGroupCalendar(chatID int64) error {
+	_, err := er.storage.Exec(`DELETE FROM group_calendars WHERE chat_id = $1`, chatID)
+	if err != nil {
+		return errors.Wrapf(err, "cannot delete group calendar of chatID=%d", chatID)
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+package repository
+
+import (
+	"database/sql"
+	"github.com/calendar-bot/pkg/customerrors"
+	"github.com/calendar-bot/pkg/types"
+	"github.com/pkg/errors"
+	"time"
+)
+
+const invitationRuleColumns = `id, telegram_user_id, status, organizer, calendar, starts_after_minutes,
+			       ends_before_minutes, min_duration_minutes, max_duration_minutes, no_conflict`
+
+func (er *EventRepository) AddInvitationRule(rule types.InvitationRule) (int64, error) {
+	var id int64
+	err := er.storage.QueryRow(`
+			INSERT INTO invitation_rules(
+			                             telegram_user_id,
+			                             status,
+			                             organizer,
+			                             calendar,
+			                             starts_after_minutes,
+			                             ends_before_minutes,
+			                             min_duration_minutes,
+			                             max_duration_minutes,
+			                             no_conflict
+			                             )
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+			RETURNING id`,
+		rule.TelegramUserID,
+		rule.Status,
+		rule.Organizer,
+		rule.Calendar,
+		int64(rule.StartsAfter/time.Minute),
+		int64(rule.EndsBefore/time.Minute),
+		int64(rule.MinDuration/time.Minute),
+		int64(rule.MaxDuration/time.Minute),
+		rule.NoConflict,
+	).Scan(&id)
+	if err != nil {
+		return 0, errors.Wrapf(err, "cannot add invitation rule=%v", rule)
+	}
+	return id, nil
+}
+
+// GetInvitationRules returns rules of the user in the order they were added
+func (er *EventRepository) GetInvitationRules(telegramID int64) (rules []types.InvitationRule, err error) {
+	rows, err := er.storage.Query(
+		`SELECT `+invitationRuleColumns+` FROM invitation_rules WHERE telegram_user_id = $1 ORDER BY id`,
+		telegramID,
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to perform SQL query in GetInvitationRules")
+	}
+	defer func() {
+		err = customerrors.HandleCloser(err, rows)
+	}()
+
+	return scanInvitationRules(rows)
+}
+
+// GetAllInvitationRules returns rules of all users ordered by user and then by the order they were added
+func (er *EventRepository) GetAllInvitationRules() (rules []types.InvitationRule, err error) {
+	rows, err := er.storage.Query(
+		`SELECT ` + invitationRuleColumns + ` FROM invitation_rules ORDER BY telegram_user_id, id`,
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to perform SQL query in GetAllInvitationRules")
+	}
+	defer func() {
+		err = customerrors.HandleCloser(err, rows)
+	}()
+
+	return scanInvitationRules(rows)
+}
+
+func scanInvitationRules(rows *sql.Rows) ([]types.InvitationRule, error) {
+	var rules []types.InvitationRule
+	for rows.Next() {
+		var rule types.InvitationRule
+		var startsAfter, endsBefore, minDuration, maxDuration int64
+		err := rows.Scan(
+			&rule.ID,
+			&rule.TelegramUserID,
+			&rule.Status,
+			&rule.Organizer,
+			&rule.Calendar,
+			&startsAfter,
+			&endsBefore,
+			&minDuration,
+			&maxDuration,
+			&rule.NoConflict,
+		)
+		if err != nil {
+			return nil, errors.Wrap(err, "error while scanning invitation rules")
+		}
+		rule.StartsAfter = time.Duration(startsAfter) * time.Minute
+		rule.EndsBefore = time.Duration(endsBefore) * time.Minute
+		rule.MinDuration = time.Duration(minDuration) * time.Minute
+		rule.MaxDuration = time.Duration(maxDuration) * time.Minute
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+func (er *EventRepository) DeleteInvitationRule(telegramID int64, ruleID int64) error {
+	_, err := er.storage.Exec(
+		`DELETE FROM invitation_rules WHERE telegram_user_id = $1 AND id = $2`,
+		telegramID,
+		ruleID,
+	)
+	if err != nil {
+		return errors.Wrapf(err, "cannot delete invitation rule id=%d of telegramID=%d", ruleID, telegramID)
+	}
+	return nil
+}
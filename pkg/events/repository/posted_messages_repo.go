@@ -0,0 +1,134 @@
+package repository
+
+import (
+	"database/sql"
+	"github.com/calendar-bot/pkg/customerrors"
+	"github.com/calendar-bot/pkg/types"
+	"github.com/pkg/errors"
+	"time"
+)
+
+const postedMessageColumns = `chat_id, message_id, event_uid, calendar_uid, telegram_user_id, type, text_hash, event_to`
+
+// AddPostedMessage tracks the message, a tracked message only gets the new text hash and event end
+func (er *EventRepository) AddPostedMessage(posted types.PostedMessage) error {
+	_, err := er.storage.Exec(`
+			INSERT INTO posted_messages(
+			                            chat_id,
+			                            message_id,
+			                            event_uid,
+			                            calendar_uid,
+			                            telegram_user_id,
+			                            type,
+			                            text_hash,
+			                            event_to
+			                            )
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+			ON CONFLICT (chat_id, message_id) DO UPDATE SET text_hash = EXCLUDED.text_hash,
+			                                                event_to = EXCLUDED.event_to`,
+		posted.ChatID,
+		posted.MessageID,
+		posted.EventUID,
+		posted.CalendarUID,
+		posted.TelegramUserID,
+		posted.Type,
+		posted.TextHash,
+		posted.EventTo,
+	)
+	if err != nil {
+		return errors.Wrapf(err, "cannot add posted message=%v", posted)
+	}
+	return nil
+}
+
+// GetPostedMessages returns tracked messages of the event in the order they were posted
+func (er *EventRepository) GetPostedMessages(eventUID string) (posted []types.PostedMessage, err error) {
+	rows, err := er.storage.Query(
+		`SELECT `+postedMessageColumns+` FROM posted_messages WHERE event_uid = $1 ORDER BY created_at`,
+		eventUID,
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to perform SQL query in GetPostedMessages")
+	}
+	defer func() {
+		err = customerrors.HandleCloser(err, rows)
+	}()
+
+	return scanPostedMessages(rows)
+}
+
+// GetActivePostedMessages returns tracked messages of events ending after since, grouped by event
+func (er *EventRepository) GetActivePostedMessages(since time.Time) (posted []types.PostedMessage, err error) {
+	rows, err := er.storage.Query(
+		`SELECT `+postedMessageColumns+` FROM posted_messages WHERE event_to >= $1 ORDER BY event_uid, created_at`,
+		since,
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to perform SQL query in GetActivePostedMessages")
+	}
+	defer func() {
+		err = customerrors.HandleCloser(err, rows)
+	}()
+
+	return scanPostedMessages(rows)
+}
+
+func scanPostedMessages(rows *sql.Rows) ([]types.PostedMessage, error) {
+	var posted []types.PostedMessage
+	for rows.Next() {
+		var msg types.PostedMessage
+		err := rows.Scan(
+			&msg.ChatID,
+			&msg.MessageID,
+			&msg.EventUID,
+			&msg.CalendarUID,
+			&msg.TelegramUserID,
+			&msg.Type,
+			&msg.TextHash,
+			&msg.EventTo,
+		)
+		if err != nil {
+			return nil, errors.Wrap(err, "error while scanning posted messages")
+		}
+		posted = append(posted, msg)
+	}
+	return posted, nil
+}
+
+func (er *EventRepository) UpdatePostedMessageHash(chatID int64, messageID string, textHash string) error {
+	_, err := er.storage.Exec(
+		`UPDATE posted_messages SET text_hash = $3 WHERE chat_id = $1 AND message_id = $2`,
+		chatID,
+		messageID,
+		textHash,
+	)
+	if err != nil {
+		return errors.Wrapf(err, "cannot update hash of posted message chatID=%d messageID=%s", chatID, messageID)
+	}
+	return nil
+}
+
+func (er *EventRepository) DeletePostedMessage(chatID int64, messageID string) error {
+	_, err := er.storage.Exec(
+		`DELETE FROM posted_messages WHERE chat_id = $1 AND message_id = $2`,
+		chatID,
+		messageID,
+	)
+	if err != nil {
+		return errors.Wrapf(err, "cannot delete posted message chatID=%d messageID=%s", chatID, messageID)
+	}
+	return nil
+}
+
+// DeleteEndedPostedMessages stops tracking messages of events ended before the time
+func (er *EventRepository) DeleteEndedPostedMessages(before time.Time) (int64, error) {
+	res, err := er.storage.Exec(`DELETE FROM posted_messages WHERE event_to < $1`, before)
+	if err != nil {
+		return 0, errors.Wrapf(err, "cannot delete posted messages of events ended before %s", before)
+	}
+	deleted, err := res.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "cannot get count of deleted posted messages")
+	}
+	return deleted, nil
+}
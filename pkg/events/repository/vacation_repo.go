@@ -0,0 +1,149 @@
+package repository
+
+import (
+	"database/sql"
+	"github.com/calendar-bot/pkg/customerrors"
+	"github.com/calendar-bot/pkg/types"
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+	"time"
+)
+
+type VacationEntityError struct {
+	error
+}
+
+var (
+	VacationDoesNotExist = VacationEntityError{errors.New("vacation does not exist")}
+)
+
+func (er *EventRepository) UpsertVacation(vacation types.Vacation) error {
+	_, err := er.storage.Exec(`
+			INSERT INTO vacations(
+			                      telegram_user_id,
+			                      date_from,
+			                      date_to,
+			                      message,
+			                      event_uid,
+			                      calendar_uid
+			                      )
+			VALUES ($1, $2, $3, $4, $5, $6)
+			ON CONFLICT (telegram_user_id) DO UPDATE
+			SET date_from    = EXCLUDED.date_from,
+			    date_to      = EXCLUDED.date_to,
+			    message      = EXCLUDED.message,
+			    event_uid    = EXCLUDED.event_uid,
+			    calendar_uid = EXCLUDED.calendar_uid`,
+		vacation.TelegramUserID,
+		vacation.From,
+		vacation.To,
+		vacation.Message,
+		vacation.EventUID,
+		vacation.CalendarUID,
+	)
+	if err != nil {
+		return errors.Wrapf(err, "cannot upsert vacation=%v", vacation)
+	}
+	return nil
+}
+
+// GetVacation returns the vacation of the user
+// Error types = error, VacationEntityError
+func (er *EventRepository) GetVacation(telegramID int64) (types.Vacation, error) {
+	vacation := types.Vacation{TelegramUserID: telegramID}
+	err := er.storage.QueryRow(`
+			SELECT date_from, date_to, message, event_uid, calendar_uid
+			FROM vacations
+			WHERE telegram_user_id = $1`,
+		telegramID,
+	).Scan(
+		&vacation.From,
+		&vacation.To,
+		&vacation.Message,
+		&vacation.EventUID,
+		&vacation.CalendarUID,
+	)
+
+	switch {
+	case err == sql.ErrNoRows:
+		return types.Vacation{}, VacationDoesNotExist
+	case err != nil:
+		return types.Vacation{}, errors.Wrapf(err, "failed to get vacation by telegramID=%d", telegramID)
+	}
+
+	return vacation, nil
+}
+
+// GetActiveVacations returns vacations which have not ended by now together with emails of their owners
+func (er *EventRepository) GetActiveVacations(now time.Time) (vacations []types.Vacation, err error) {
+	rows, err := er.storage.Query(`
+			SELECT v.telegram_user_id, u.mail_user_email, v.date_from, v.date_to, v.message, v.event_uid, v.calendar_uid
+			FROM vacations AS v
+			JOIN users AS u ON u.telegram_user_id = v.telegram_user_id
+			WHERE v.date_to > $1`,
+		now,
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to perform SQL query in GetActiveVacations")
+	}
+	defer func() {
+		err = customerrors.HandleCloser(err, rows)
+	}()
+
+	return scanVacations(rows)
+}
+
+// GetVacationsOverlapping returns vacations intersecting [from, to) of users given by telegram ids or emails
+func (er *EventRepository) GetVacationsOverlapping(telegramIDs []int64, emails []string, from time.Time,
+	to time.Time) (vacations []types.Vacation, err error) {
+
+	rows, err := er.storage.Query(`
+			SELECT v.telegram_user_id, u.mail_user_email, v.date_from, v.date_to, v.message, v.event_uid, v.calendar_uid
+			FROM vacations AS v
+			JOIN users AS u ON u.telegram_user_id = v.telegram_user_id
+			WHERE (v.telegram_user_id = ANY($1) OR u.mail_user_email = ANY($2))
+			  AND v.date_from < $4 AND v.date_to > $3
+			ORDER BY v.date_from`,
+		pq.Array(telegramIDs),
+		pq.Array(emails),
+		from,
+		to,
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to perform SQL query in GetVacationsOverlapping")
+	}
+	defer func() {
+		err = customerrors.HandleCloser(err, rows)
+	}()
+
+	return scanVacations(rows)
+}
+
+func scanVacations(rows *sql.Rows) ([]types.Vacation, error) {
+	var vacations []types.Vacation
+	for rows.Next() {
+		var vacation types.Vacation
+		err := rows.Scan(
+			&vacation.TelegramUserID,
+			&vacation.Email,
+			&vacation.From,
+			&vacation.To,
+			&vacation.Message,
+			&vacation.EventUID,
+			&vacation.CalendarUID,
+		)
+		if err != nil {
+			return nil, errors.Wrap(err, "error while scanning vacations")
+		}
+		vacations = append(vacations, vacation)
+	}
+	return vacations, nil
+}
+
+func (er *EventRepository) DeleteVacation(telegramID int64) error {
+	_, err := er.storage.Exec(`DELETE FROM vacations WHERE telegram_user_id = $1`, telegramID)
+	if err != nil {
+		return errors.Wrapf(err, "cannot delete vacation of telegramID=%d", telegramID)
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+package usecase
+
+import (
+	"github.com/calendar-bot/pkg/types"
+	"github.com/pkg/errors"
+	"github.com/senseyeio/spaniel"
+	"time"
+)
+
+type Availability int
+
+const (
+	Free Availability = iota
+	PartiallyBusy
+	Busy
+)
+
+const AvailabilityDaysInWeek = 7
+
+// AvailabilityHalfDays are the parts of the day shown in the availability matrix
+var AvailabilityHalfDays = []types.DayPart{
+	{Start: time.Date(0, 0, 0, 9, 0, 0, 0, time.UTC), Duration: 4 * time.Hour},
+	{Start: time.Date(0, 0, 0, 13, 0, 0, 0, time.UTC), Duration: 5 * time.Hour},
+}
+
+type AvailabilityRow struct {
+	User string
+	// Cells are ordered by day, then by half day
+	Cells []Availability
+}
+
+func (uc *EventUseCase) GetUsersAvailability(accessToken string, emails []string,
+	weekStart time.Time) ([]AvailabilityRow, error) {
+
+	if len(emails) == 0 {
+		return nil, nil
+	}
+
+	response, err := uc.GetUsersBusyIntervals(accessToken, types.FreeBusy{
+		Users: emails,
+		From:  weekStart,
+		To:    weekStart.AddDate(0, 0, AvailabilityDaysInWeek),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "GetUsersAvailability")
+	}
+
+	return AvailabilityMatrix(response.Data, emails, weekStart, AvailabilityDaysInWeek, AvailabilityHalfDays), nil
+}
+
+// AvailabilityMatrix builds one row per email, users without busy intervals are free all the time
+func AvailabilityMatrix(freeBusy types.FreeBusyUser, emails []string, from time.Time, days int,
+	halfDays []types.DayPart) []AvailabilityRow {
+
+	busyByUser := make(map[string]spaniel.Spans, len(freeBusy.FreeBusy))
+	for _, intervals := range freeBusy.FreeBusy {
+		spans := busyByUser[intervals.User]
+		for _, interval := range intervals.FreeBusy {
+			spans = append(spans, interval)
+		}
+		busyByUser[intervals.User] = spans
+	}
+
+	rows := make([]AvailabilityRow, 0, len(emails))
+	for _, email := range emails {
+		busy := busyByUser[email].Union()
+		cells := make([]Availability, 0, days*len(halfDays))
+		for day := 0; day < days; day++ {
+			date := from.AddDate(0, 0, day)
+			for _, part := range halfDays {
+				hour, minute, second := part.Start.Clock()
+				start := time.Date(date.Year(), date.Month(), date.Day(), hour, minute, second, 0, date.Location())
+				cells = append(cells, SpanAvailability(spaniel.New(start, start.Add(part.Duration)), busy))
+			}
+		}
+		rows = append(rows, AvailabilityRow{User: email, Cells: cells})
+	}
+
+	return rows
+}
+
+// SpanAvailability tells how much of the slot is covered by merged busy spans
+func SpanAvailability(slot spaniel.Span, busy spaniel.Spans) Availability {
+	var busyDuration time.Duration
+	for _, span := range busy {
+		truncated := TruncateSpanBy(slot)(span)
+		if d := truncated.End().Sub(truncated.Start()); d > 0 {
+			busyDuration += d
+		}
+	}
+
+	switch {
+	case busyDuration <= 0:
+		return Free
+	case busyDuration >= slot.End().Sub(slot.Start()):
+		return Busy
+	default:
+		return PartiallyBusy
+	}
+}
@@ -0,0 +1,51 @@
+package usecase
+
+import (
+	"github.com/calendar-bot/pkg/types"
+	"github.com/senseyeio/spaniel"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestSpanAvailability(t *testing.T) {
+	slot := spaniel.New(
+		time.Date(2021, 5, 3, 9, 0, 0, 0, time.UTC),
+		time.Date(2021, 5, 3, 13, 0, 0, 0, time.UTC),
+	)
+
+	assert.Equal(t, Free, SpanAvailability(slot, nil))
+	assert.Equal(t, Free, SpanAvailability(slot, spaniel.Spans{
+		spaniel.New(time.Date(2021, 5, 3, 14, 0, 0, 0, time.UTC), time.Date(2021, 5, 3, 15, 0, 0, 0, time.UTC)),
+	}))
+	assert.Equal(t, PartiallyBusy, SpanAvailability(slot, spaniel.Spans{
+		spaniel.New(time.Date(2021, 5, 3, 10, 0, 0, 0, time.UTC), time.Date(2021, 5, 3, 11, 0, 0, 0, time.UTC)),
+	}))
+	assert.Equal(t, Busy, SpanAvailability(slot, spaniel.Spans{
+		spaniel.New(time.Date(2021, 5, 3, 8, 0, 0, 0, time.UTC), time.Date(2021, 5, 3, 14, 0, 0, 0, time.UTC)),
+	}))
+}
+
+func TestAvailabilityMatrix(t *testing.T) {
+	from := time.Date(2021, 5, 3, 0, 0, 0, 0, time.UTC)
+	freeBusy := types.FreeBusyUser{
+		FreeBusy: []types.FreeBusyIntervals{
+			{
+				User: "busy@mail.ru",
+				FreeBusy: []types.FromTo{
+					{From: time.Date(2021, 5, 3, 9, 0, 0, 0, time.UTC), To: time.Date(2021, 5, 3, 11, 0, 0, 0, time.UTC)},
+					{From: time.Date(2021, 5, 3, 11, 0, 0, 0, time.UTC), To: time.Date(2021, 5, 3, 13, 0, 0, 0, time.UTC)},
+					{From: time.Date(2021, 5, 4, 15, 0, 0, 0, time.UTC), To: time.Date(2021, 5, 4, 16, 0, 0, 0, time.UTC)},
+				},
+			},
+		},
+	}
+
+	rows := AvailabilityMatrix(freeBusy, []string{"busy@mail.ru", "free@mail.ru"}, from, 2, AvailabilityHalfDays)
+
+	assert.Len(t, rows, 2)
+	assert.Equal(t, "busy@mail.ru", rows[0].User)
+	assert.Equal(t, []Availability{Busy, Free, Free, PartiallyBusy}, rows[0].Cells)
+	assert.Equal(t, "free@mail.ru", rows[1].User)
+	assert.Equal(t, []Availability{Free, Free, Free, Free}, rows[1].Cells)
+}
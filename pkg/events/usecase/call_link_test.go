@@ -0,0 +1,39 @@
+package usecase
+
+import (
+	"github.com/calendar-bot/pkg/events/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestMailCallLink(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/room", r.URL.Path)
+
+		switch r.Header.Get("Authorization") {
+		case "Bearer good":
+			_, _ = w.Write([]byte(`{"id":"42","url":"https://calls.mail.ru/room/42"}`))
+		case "Bearer empty":
+			_, _ = w.Write([]byte(`{"id":"42"}`))
+		default:
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	}))
+	defer server.Close()
+
+	uc := NewEventUseCase(repository.EventRepository{}, server.URL+"/")
+
+	link, err := uc.MailCallLink("good")
+	require.NoError(t, err)
+	assert.Equal(t, "https://calls.mail.ru/room/42", link)
+
+	_, err = uc.MailCallLink("empty")
+	assert.Error(t, err)
+
+	_, err = uc.MailCallLink("bad")
+	assert.Error(t, err)
+}
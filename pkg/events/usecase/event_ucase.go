@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"github.com/calendar-bot/pkg/customerrors"
 	"github.com/calendar-bot/pkg/events/repository"
 	"github.com/calendar-bot/pkg/types"
 	"github.com/fatih/structs"
@@ -21,21 +22,25 @@ import (
 
 type EventUseCase struct {
 	eventStorage repository.EventRepository
+	callAPIURL   string
 }
 
-func NewEventUseCase(eventStor repository.EventRepository) EventUseCase {
+// NewEventUseCase takes the base URL of the Mail.ru calls API used by MailCallLink
+func NewEventUseCase(eventStor repository.EventRepository, callAPIURL string) EventUseCase {
 	return EventUseCase{
 		eventStorage: eventStor,
+		callAPIURL:   strings.TrimRight(callAPIURL, "/"),
 	}
 }
 
+// getStartDay and getEndDay bound the day of t in the location of t, pass t in the user's timezone
 func getStartDay(t time.Time) time.Time {
 	year, month, day := t.Date()
-	return time.Date(year, month, day, 0, 0, 0, 0, time.Now().Location())
+	return time.Date(year, month, day, 0, 0, 0, 0, t.Location())
 }
 func getEndDay(t time.Time) time.Time {
 	year, month, day := t.Date()
-	return time.Date(year, month, day, 23, 59, 59, 0, time.Now().Location())
+	return time.Date(year, month, day, 23, 59, 59, 0, t.Location())
 }
 
 func closestEvent(events []types.Event) *types.Event {
@@ -71,8 +76,12 @@ func getEventsBySpecificDay(t time.Time, accessToken string) (events *types.Even
 		timer.ObserveDuration()
 	}()
 
-	startDay := getStartDay(t).Format(time.RFC3339)
-	endDay := getEndDay(t).Format(time.RFC3339)
+	return getEventsByRange(getStartDay(t), getEndDay(t), accessToken)
+}
+
+func getEventsByRange(from, to time.Time, accessToken string) (events *types.EventsResponse, err error) {
+	startDay := from.Format(time.RFC3339)
+	endDay := to.Format(time.RFC3339)
 
 	graphqlRequest := fmt.Sprintf(`
 	{
@@ -164,18 +173,19 @@ func getEventsBySpecificDay(t time.Time, accessToken string) (events *types.Even
 	return &eventsResponse, nil
 }
 
-func (uc *EventUseCase) GetEventsToday(accessToken string) (*types.EventsResponse, error) {
-	return getEventsBySpecificDay(time.Now(), accessToken)
+// GetEventsToday returns the events of the day of now, now must be in the user's timezone
+func (uc *EventUseCase) GetEventsToday(accessToken string, now time.Time) (*types.EventsResponse, error) {
+	return getEventsBySpecificDay(now, accessToken)
 }
 
-func (uc *EventUseCase) GetClosestEvent(accessToken string) (event *types.Event, err error) {
+func (uc *EventUseCase) GetClosestEvent(accessToken string, now time.Time) (event *types.Event, err error) {
 	timer := prometheus.NewTimer(metricGetClosestEventDuration)
 	defer func() {
 		metricGetClosestEventTotalCount.WithLabelValues(metricStatusFromErr(err))
 		timer.ObserveDuration()
 	}()
 
-	eventsResponse, err := uc.GetEventsToday(accessToken)
+	eventsResponse, err := uc.GetEventsToday(accessToken, now)
 	if err != nil {
 		return nil, err
 	}
@@ -192,6 +202,29 @@ func (uc *EventUseCase) GetEventsByDate(accessToken string, date time.Time) (*ty
 	return getEventsBySpecificDay(date, accessToken)
 }
 
+func (uc *EventUseCase) GetEventsByRange(accessToken string, from, to time.Time) (events *types.EventsResponse, err error) {
+	timer := prometheus.NewTimer(metricGetEventsByRangeDuration)
+	defer func() {
+		metricGetEventsByRangeTotalCount.WithLabelValues(metricStatusFromErr(err)).Inc()
+		timer.ObserveDuration()
+	}()
+
+	return getEventsByRange(from, to, accessToken)
+}
+
+// GetHolidays returns events from the user's holiday calendars in [from, to), sorted by start time
+func (uc *EventUseCase) GetHolidays(accessToken string, from, to time.Time) (types.Events, error) {
+	eventsResponse, err := uc.GetEventsByRange(accessToken, from, to)
+	if err != nil {
+		return nil, errors.Wrap(err, "GetHolidays")
+	}
+	if eventsResponse == nil {
+		return nil, nil
+	}
+
+	return FilterHolidays(eventsResponse.Data.Events), nil
+}
+
 func (uc *EventUseCase) GetEventByEventID(accessToken, calendarID, eventID string) (events *types.EventResponse, err error) {
 	timer := prometheus.NewTimer(metricGetEventByEventIDDuration)
 	defer func() {
@@ -356,6 +389,14 @@ func (uc *EventUseCase) GetUsersFreeIntervals(accessToken string, freeBusy types
 		return nil, errors.Wrap(err, "GetUsersFreeIntervals")
 	}
 
+	if conf.HolidaysAsBusy {
+		holidays, err := uc.GetHolidays(accessToken, freeBusy.From, freeBusy.To)
+		if err != nil {
+			return nil, errors.Wrap(err, "GetUsersFreeIntervals")
+		}
+		response.Data.FreeBusy = append(response.Data.FreeBusy, HolidaysBusyIntervals(holidays))
+	}
+
 	freeBusyBorders := spaniel.New(freeBusy.From, freeBusy.To)
 	busyFlatTimeSpan := MergeBusyIntervals(response.Data, conf.StretchBusyIntervalsBy)
 
@@ -385,11 +426,6 @@ func (uc *EventUseCase) GetUsersFreeIntervals(accessToken string, freeBusy types
 	return filteredFreeTimeSpans, nil
 }
 
-func getNewTime(t time.Time) time.Time {
-	year, month, day := t.Date()
-	return time.Date(year, month, day, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.Now().Location())
-}
-
 func RemoveLastChar(str string) string {
 	for len(str) > 0 {
 		_, size := utf8.DecodeLastRuneInString(str)
@@ -545,19 +581,13 @@ func (uc *EventUseCase) CreateEvent(accessToken string, eventInput types.EventIn
 		timer.ObserveDuration()
 	}()
 
-	fromTime, err := time.Parse(time.RFC3339, *eventInput.From)
-	if err != nil {
+	// the times keep the offset they were given in, it is the user's timezone
+	if _, err := time.Parse(time.RFC3339, *eventInput.From); err != nil {
 		return nil, errors.Errorf("failed to parse `from` time, %v", err)
 	}
-	from := getNewTime(fromTime).Format(time.RFC3339)
-	eventInput.From = &from
-
-	toTime, err := time.Parse(time.RFC3339, *eventInput.To)
-	if err != nil {
+	if _, err := time.Parse(time.RFC3339, *eventInput.To); err != nil {
 		return nil, errors.Errorf("failed to parse `to` time, %v", err)
 	}
-	to := getNewTime(toTime).Format(time.RFC3339)
-	eventInput.To = &to
 
 	m := structs.Map(eventInput)
 
@@ -695,15 +725,75 @@ func (uc *EventUseCase) ChangeStatus(accessToken string, reactEvent types.Change
 	return res, nil
 }
 
+func (uc *EventUseCase) DeleteEvent(accessToken string, calendarID string, eventID string) (err error) {
+	timer := prometheus.NewTimer(metricDeleteEventDuration)
+	defer func() {
+		metricDeleteEventTotalCount.WithLabelValues(metricStatusFromErr(err)).Inc()
+		timer.ObserveDuration()
+	}()
+
+	mutationReq := fmt.Sprintf(
+		`mutation{deleteEvent(uri: {uid: \"%s\", calendar: \"%s\"})}`,
+		eventID,
+		calendarID,
+	)
+	deleteEventRequest := fmt.Sprintf(`{"query":"%s"}`, mutationReq)
+
+	request, err := http.NewRequest("POST", "https://calendar.mail.ru/graphql", bytes.NewBuffer([]byte(deleteEventRequest)))
+	if err != nil {
+		return errors.Errorf("failed to create a request: , %v", err)
+	}
+
+	var bearerToken = "Bearer " + accessToken
+	request.Header.Add(
+		"Authorization",
+		bearerToken,
+	)
+	request.Header.Set("Content-Type", "application/json")
+
+	client := &http.Client{Timeout: time.Second * 10}
+	response, err := client.Do(request)
+	if err != nil {
+		return errors.Errorf("The HTTP request failed with error %v", err)
+	}
+	defer func() {
+		if closeErr := response.Body.Close(); closeErr != nil {
+			zap.S().Errorf("failed to close body of response of func DeleteEvent, %v", closeErr)
+		}
+	}()
+
+	res, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return errors.Errorf("failed to read body %v", err)
+	}
+
+	errorsResp := types.GraphQLErrorsResp{}
+	if err := json.Unmarshal(res, &errorsResp); err != nil {
+		return errors.Wrap(err, "failed to unmarshal delete event response")
+	}
+	if len(errorsResp.Errors) > 0 {
+		return errors.Errorf("failed to delete event uid=%s: %s", eventID, errorsResp.Errors[0].Message)
+	}
+
+	return nil
+}
+
 type CallLink struct {
 	Id  string `json:"id,omitempty"`
 	Url string `json:"url,omitempty"`
 }
 
-func (uc *EventUseCase) MailCallLink(accessToken string) (string, error) {
-	request, err := http.NewRequest("POST", "https://corsapi.imgsmail.ru/calls/api/v1/room", nil)
+// MailCallLink creates a Mail.ru call room and returns the link to join it
+func (uc *EventUseCase) MailCallLink(accessToken string) (link string, err error) {
+	timer := prometheus.NewTimer(metricMailCallLinkDuration)
+	defer func() {
+		metricMailCallLinkTotalCount.WithLabelValues(metricStatusFromErr(err)).Inc()
+		timer.ObserveDuration()
+	}()
+
+	request, err := http.NewRequest("POST", uc.callAPIURL+"/room", nil)
 	if err != nil {
-		return "", errors.Errorf("failed to create a request: , %v", err)
+		return "", errors.Wrap(err, "failed to create call room request")
 	}
 
 	var bearerToken = "Bearer " + accessToken
@@ -716,25 +806,27 @@ func (uc *EventUseCase) MailCallLink(accessToken string) (string, error) {
 	client := &http.Client{Timeout: time.Second * 10}
 	response, err := client.Do(request)
 	if err != nil {
-		return "", errors.Errorf("The HTTP request failed with error %v", err)
+		return "", errors.Wrap(err, "call room request failed")
 	}
 	defer func() {
-		err = response.Body.Close()
-		if err != nil {
-			fmt.Printf("failed to close body of response of func getEvents, %v", err)
-		}
+		err = customerrors.HandleCloser(err, response.Body)
 	}()
 
 	res, err := ioutil.ReadAll(response.Body)
 	if err != nil {
-		return "", errors.Errorf("failed to read body %v", err)
+		return "", errors.Wrap(err, "failed to read call room response body")
+	}
+	if response.StatusCode != http.StatusOK && response.StatusCode != http.StatusCreated {
+		return "", errors.Errorf("call room was not created, status=%d, response: %s", response.StatusCode, res)
 	}
 
 	callLink := CallLink{}
-
 	err = json.Unmarshal(res, &callLink)
 	if err != nil {
 		return "", errors.Wrap(err, "failed to unmarshal call link")
 	}
+	if callLink.Url == "" {
+		return "", errors.Errorf("call room response has no url: %s", res)
+	}
 	return callLink.Url, nil
 }
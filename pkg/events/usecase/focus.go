@@ -0,0 +1,233 @@
+package usecase
+
+import (
+	"encoding/json"
+	"github.com/calendar-bot/pkg/events/repository"
+	"github.com/calendar-bot/pkg/types"
+	"github.com/pkg/errors"
+	"github.com/senseyeio/spaniel"
+	"sort"
+	"time"
+)
+
+const (
+	FocusEventTitle = "Focus"
+
+	// focus blocks are placed only inside working hours
+	FocusWorkDayStart     = 9 * time.Hour
+	FocusWorkDayEnd       = 19 * time.Hour
+	FocusMinBlockDuration = 30 * time.Minute
+
+	DefaultFocusDuration = 2 * time.Hour
+	// focusPlanningHorizon bounds the search of future focus blocks when the rule is turned off
+	focusPlanningHorizon = 30 * 24 * time.Hour
+)
+
+// GetFocusRule returns the user's rule, a disabled default one if the user has not set it up yet
+func (uc *EventUseCase) GetFocusRule(telegramUserID int64) (types.FocusRule, error) {
+	rule, err := uc.eventStorage.GetFocusRule(telegramUserID)
+	switch {
+	case err == repository.FocusRuleDoesNotExist:
+		return types.FocusRule{
+			TelegramUserID: telegramUserID,
+			Duration:       DefaultFocusDuration,
+			PreferMorning:  true,
+		}, nil
+	case err != nil:
+		return types.FocusRule{}, errors.WithStack(err)
+	}
+	return rule, nil
+}
+
+func (uc *EventUseCase) SetFocusRule(rule types.FocusRule) error {
+	return errors.WithStack(uc.eventStorage.UpsertFocusRule(rule))
+}
+
+func (uc *EventUseCase) GetEnabledFocusRules() ([]types.FocusRule, error) {
+	rules, err := uc.eventStorage.GetEnabledFocusRules()
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return rules, nil
+}
+
+// DisableFocusRule turns the rule off and removes focus blocks which have not ended yet
+func (uc *EventUseCase) DisableFocusRule(accessToken string, telegramUserID int64) error {
+	rule, err := uc.GetFocusRule(telegramUserID)
+	if err != nil {
+		return err
+	}
+	rule.Enabled = false
+	if err := uc.SetFocusRule(rule); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	blocks, err := uc.eventStorage.GetFocusEvents(telegramUserID, now, now.Add(focusPlanningHorizon))
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	for _, block := range blocks {
+		if !block.Deleted {
+			if err := uc.DeleteEvent(accessToken, block.CalendarUID, block.EventUID); err != nil {
+				return errors.Wrap(err, "DisableFocusRule")
+			}
+		}
+		if err := uc.eventStorage.DeleteFocusEvent(telegramUserID, block.EventUID); err != nil {
+			return errors.WithStack(err)
+		}
+	}
+	return nil
+}
+
+// PlanFocusTime creates focus blocks for the rest of the working day of now until the rule's goal is met.
+// now must be in the timezone of the user, the working hours are counted in it.
+// The blocks get the description in the language of the user.
+// If the user has deleted one of the blocks of the day, no new blocks are created that day
+func (uc *EventUseCase) PlanFocusTime(accessToken string, email string, rule types.FocusRule, description string,
+	now time.Time) (created int, err error) {
+
+	if !rule.Enabled || now.Weekday() == time.Saturday || now.Weekday() == time.Sunday {
+		return 0, nil
+	}
+
+	dayStart := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	from := dayStart.Add(FocusWorkDayStart)
+	to := dayStart.Add(FocusWorkDayEnd)
+	if now.After(from) {
+		from = now
+	}
+	if to.Sub(from) < FocusMinBlockDuration {
+		return 0, nil
+	}
+
+	blocks, err := uc.eventStorage.GetFocusEvents(rule.TelegramUserID, dayStart, dayStart.AddDate(0, 0, 1))
+	if err != nil {
+		return 0, errors.WithStack(err)
+	}
+
+	var planned time.Duration
+	for _, block := range blocks {
+		if block.Deleted {
+			return 0, nil
+		}
+
+		exists, err := uc.focusEventExists(accessToken, block)
+		if err != nil {
+			return 0, err
+		}
+		if !exists {
+			if err := uc.eventStorage.MarkFocusEventDeleted(block.TelegramUserID, block.EventUID); err != nil {
+				return 0, errors.WithStack(err)
+			}
+			return 0, nil
+		}
+		planned += block.To.Sub(block.From)
+	}
+
+	if planned >= rule.Duration {
+		return 0, nil
+	}
+
+	free, err := uc.GetUsersFreeIntervals(accessToken, types.FreeBusy{
+		Users: []string{email},
+		From:  from,
+		To:    to,
+	}, FreeBusyConfig{HolidaysAsBusy: true})
+	if err != nil {
+		return 0, errors.Wrap(err, "PlanFocusTime")
+	}
+
+	for _, span := range PlanFocusBlocks(free, rule.Duration-planned, rule.PreferMorning) {
+		if err := uc.createFocusEvent(accessToken, rule.TelegramUserID, description, span); err != nil {
+			return created, err
+		}
+		created++
+	}
+	return created, nil
+}
+
+// PlanFocusBlocks picks blocks of at least FocusMinBlockDuration from free spans to cover need.
+// With preferMorning the earliest spans are used first, otherwise the longest ones
+func PlanFocusBlocks(free spaniel.Spans, need time.Duration, preferMorning bool) spaniel.Spans {
+	candidates := make(spaniel.Spans, 0, len(free))
+	for _, span := range free {
+		if span.End().Sub(span.Start()) >= FocusMinBlockDuration {
+			candidates = append(candidates, span)
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if preferMorning {
+			return candidates[i].Start().Before(candidates[j].Start())
+		}
+		return candidates[i].End().Sub(candidates[i].Start()) > candidates[j].End().Sub(candidates[j].Start())
+	})
+
+	blocks := make(spaniel.Spans, 0)
+	for _, span := range candidates {
+		if need <= 0 {
+			break
+		}
+		duration := span.End().Sub(span.Start())
+		if duration > need {
+			duration = need
+		}
+		if duration < FocusMinBlockDuration {
+			duration = FocusMinBlockDuration
+		}
+		blocks = append(blocks, spaniel.New(span.Start(), span.Start().Add(duration)))
+		need -= duration
+	}
+
+	sort.SliceStable(blocks, func(i, j int) bool {
+		return blocks[i].Start().Before(blocks[j].Start())
+	})
+	return blocks
+}
+
+func (uc *EventUseCase) focusEventExists(accessToken string, block types.FocusEvent) (bool, error) {
+	response, err := uc.GetEventByEventID(accessToken, block.CalendarUID, block.EventUID)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to check focus event")
+	}
+	return response != nil && response.Data.Event.Uid != "", nil
+}
+
+func (uc *EventUseCase) createFocusEvent(accessToken string, telegramUserID int64, description string,
+	span spaniel.Span) error {
+
+	title := FocusEventTitle
+	from := span.Start().Format(time.RFC3339)
+	to := span.End().Format(time.RFC3339)
+	private, busy := true, true
+
+	resp, err := uc.CreateEvent(accessToken, types.EventInput{
+		Title:       &title,
+		Description: &description,
+		From:        &from,
+		To:          &to,
+		Private:     &private,
+		Busy:        &busy,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to create focus event")
+	}
+
+	created := types.CreateEventResp{}
+	if err := json.Unmarshal(resp, &created); err != nil {
+		return errors.Wrap(err, "failed to unmarshal created focus event")
+	}
+	if created.Data.CreateEvent.Uid == "" {
+		return errors.Errorf("focus event was not created, response: %s", resp)
+	}
+
+	return errors.WithStack(uc.eventStorage.AddFocusEvent(types.FocusEvent{
+		TelegramUserID: telegramUserID,
+		EventUID:       created.Data.CreateEvent.Uid,
+		CalendarUID:    created.Data.CreateEvent.Calendar.UID,
+		From:           span.Start(),
+		To:             span.End(),
+	}))
+}
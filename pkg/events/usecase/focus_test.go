@@ -0,0 +1,37 @@
+package usecase
+
+import (
+	"github.com/senseyeio/spaniel"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestPlanFocusBlocks(t *testing.T) {
+	at := func(hour, minute int) time.Time {
+		return time.Date(2021, 5, 4, hour, minute, 0, 0, time.UTC)
+	}
+	free := spaniel.Spans{
+		spaniel.New(at(9, 0), at(10, 0)),
+		spaniel.New(at(11, 0), at(11, 20)),
+		spaniel.New(at(14, 0), at(17, 0)),
+	}
+
+	morning := PlanFocusBlocks(free, 2*time.Hour, true)
+	assert.Equal(t, spaniel.Spans{
+		spaniel.New(at(9, 0), at(10, 0)),
+		spaniel.New(at(14, 0), at(15, 0)),
+	}, morning)
+
+	longest := PlanFocusBlocks(free, 2*time.Hour, false)
+	assert.Equal(t, spaniel.Spans{
+		spaniel.New(at(14, 0), at(16, 0)),
+	}, longest)
+
+	short := PlanFocusBlocks(free, 10*time.Minute, true)
+	assert.Equal(t, spaniel.Spans{
+		spaniel.New(at(9, 0), at(9, 30)),
+	}, short)
+
+	assert.Empty(t, PlanFocusBlocks(free, 0, true))
+}
@@ -49,6 +49,8 @@ type FreeBusyConfig struct {
 	SplitFreeIntervalsBy    *time.Duration
 	MinFreeIntervalDuration *time.Duration
 	MaxFreeIntervalDuration *time.Duration
+	// HolidaysAsBusy marks full-day events from the token owner's holiday calendars as busy
+	HolidaysAsBusy bool
 }
 
 func MergeSpanFilters(filters ...SpanFilterFunc) SpanFilterFunc {
@@ -0,0 +1,32 @@
+package usecase
+
+import (
+	"github.com/calendar-bot/pkg/types"
+	"strconv"
+)
+
+// geoPrecision keeps about 10 cm, more than enough for a venue
+const geoPrecision = 6
+
+func GeoFromCoordinates(latitude float32, longitude float32) types.Geo {
+	return types.Geo{
+		Latitude:  strconv.FormatFloat(float64(latitude), 'f', geoPrecision, 32),
+		Longitude: strconv.FormatFloat(float64(longitude), 'f', geoPrecision, 32),
+	}
+}
+
+// GeoCoordinates parses coordinates of the event location, ok is false if the event has no valid coordinates
+func GeoCoordinates(geo types.Geo) (latitude float32, longitude float32, ok bool) {
+	if geo.Latitude == "" || geo.Longitude == "" {
+		return 0, 0, false
+	}
+	lat, err := strconv.ParseFloat(geo.Latitude, 32)
+	if err != nil || lat < -90 || lat > 90 {
+		return 0, 0, false
+	}
+	lng, err := strconv.ParseFloat(geo.Longitude, 32)
+	if err != nil || lng < -180 || lng > 180 {
+		return 0, 0, false
+	}
+	return float32(lat), float32(lng), true
+}
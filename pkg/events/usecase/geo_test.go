@@ -0,0 +1,24 @@
+package usecase
+
+import (
+	"github.com/calendar-bot/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestGeoCoordinates(t *testing.T) {
+	geo := GeoFromCoordinates(55.796932, 37.537849)
+	assert.Equal(t, types.Geo{Latitude: "55.796932", Longitude: "37.537849"}, geo)
+
+	lat, lng, ok := GeoCoordinates(geo)
+	assert.True(t, ok)
+	assert.InDelta(t, 55.796932, lat, 1e-5)
+	assert.InDelta(t, 37.537849, lng, 1e-5)
+
+	_, _, ok = GeoCoordinates(types.Geo{})
+	assert.False(t, ok)
+	_, _, ok = GeoCoordinates(types.Geo{Latitude: "north", Longitude: "37.5"})
+	assert.False(t, ok)
+	_, _, ok = GeoCoordinates(types.Geo{Latitude: "95", Longitude: "37.5"})
+	assert.False(t, ok)
+}
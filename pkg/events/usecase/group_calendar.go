@@ -0,0 +1,117 @@
+package usecase
+
+import (
+	"encoding/json"
+	"github.com/calendar-bot/pkg/customerrors"
+	"github.com/calendar-bot/pkg/events/repository"
+	"github.com/calendar-bot/pkg/types"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"io/ioutil"
+	"net/http"
+	"time"
+)
+
+// GetCalendars returns calendars of the token owner
+func (uc *EventUseCase) GetCalendars(accessToken string) (calendars []types.Calendar, err error) {
+	timer := prometheus.NewTimer(metricGetCalendarsDuration)
+	defer func() {
+		metricGetCalendarsTotalCount.WithLabelValues(metricStatusFromErr(err)).Inc()
+		timer.ObserveDuration()
+	}()
+
+	request, err := http.NewRequest("GET", "https://calendar.mail.ru/graphql", nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create calendars request")
+	}
+	q := request.URL.Query()
+	q.Add("query", `{calendars {uid, title, type}}`)
+	request.URL.RawQuery = q.Encode()
+	request.Header.Add("Authorization", "Bearer "+accessToken)
+
+	client := &http.Client{Timeout: time.Second * 10}
+	response, err := client.Do(request)
+	if err != nil {
+		return nil, errors.Errorf("The HTTP request failed with error %v", err)
+	}
+	defer func() {
+		err = customerrors.HandleCloser(err, response.Body)
+	}()
+
+	res, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read calendars response")
+	}
+
+	calendarsResponse := types.CalendarsResponse{}
+	if err := json.Unmarshal(res, &calendarsResponse); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal calendars response %q", res)
+	}
+	return calendarsResponse.Data.Calendars, nil
+}
+
+// BindableCalendars are calendars which can be bound to a group, holiday calendars are read only
+func BindableCalendars(calendars []types.Calendar) []types.Calendar {
+	var ret []types.Calendar
+	for _, calendar := range calendars {
+		if calendar.Type != types.CalendarTypeHoliday {
+			ret = append(ret, calendar)
+		}
+	}
+	return ret
+}
+
+// FindCalendar returns nil if there is no calendar with the uid
+func FindCalendar(calendars []types.Calendar, uid string) *types.Calendar {
+	for i := range calendars {
+		if calendars[i].UID == uid {
+			return &calendars[i]
+		}
+	}
+	return nil
+}
+
+// FilterCalendarEvents keeps only events of the calendar, the order is kept
+func FilterCalendarEvents(events types.Events, calendarUID string) types.Events {
+	var ret types.Events
+	for _, event := range events {
+		if event.Calendar.UID == calendarUID {
+			ret = append(ret, event)
+		}
+	}
+	return ret
+}
+
+// GetClosestCalendarEvent is GetClosestEvent among events of the calendar only
+func (uc *EventUseCase) GetClosestCalendarEvent(accessToken string, calendarUID string,
+	now time.Time) (*types.Event, error) {
+
+	eventsResponse, err := uc.GetEventsToday(accessToken, now)
+	if err != nil {
+		return nil, errors.Wrap(err, "GetClosestCalendarEvent")
+	}
+	if eventsResponse == nil {
+		return nil, nil
+	}
+	return closestEvent(FilterCalendarEvents(eventsResponse.Data.Events, calendarUID)), nil
+}
+
+func (uc *EventUseCase) SetGroupCalendar(groupCalendar types.GroupCalendar) error {
+	return errors.WithStack(uc.eventStorage.SetGroupCalendar(groupCalendar))
+}
+
+// GetGroupCalendar returns nil if no calendar is bound to the chat
+func (uc *EventUseCase) GetGroupCalendar(chatID int64) (*types.GroupCalendar, error) {
+	groupCalendar, err := uc.eventStorage.GetGroupCalendar(chatID)
+	switch {
+	case err == repository.GroupCalendarDoesNotExist:
+		return nil, nil
+	case err != nil:
+		return nil, errors.WithStack(err)
+	}
+	return &groupCalendar, nil
+}
+
+func (uc *EventUseCase) DeleteGroupCalendar(chatID int64) error {
+	return errors.WithStack(uc.eventStorage.DeleteGroupCalendar(chatID))
+}
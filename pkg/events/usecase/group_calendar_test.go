@@ -0,0 +1,48 @@
+package usecase
+
+import (
+	"github.com/calendar-bot/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestBindableCalendars(t *testing.T) {
+	calendars := []types.Calendar{
+		{UID: "personal", Type: types.CalendarTypePersonal},
+		{UID: "holidays", Type: types.CalendarTypeHoliday},
+		{UID: "team", Type: "SHARED"},
+	}
+
+	bindable := BindableCalendars(calendars)
+	require.Len(t, bindable, 2)
+	assert.Equal(t, "personal", bindable[0].UID)
+	assert.Equal(t, "team", bindable[1].UID)
+
+	assert.Nil(t, BindableCalendars(nil))
+}
+
+func TestFindCalendar(t *testing.T) {
+	calendars := []types.Calendar{{UID: "personal", Title: "Мой"}, {UID: "team", Title: "Команда"}}
+
+	calendar := FindCalendar(calendars, "team")
+	require.NotNil(t, calendar)
+	assert.Equal(t, "Команда", calendar.Title)
+
+	assert.Nil(t, FindCalendar(calendars, "other"))
+}
+
+func TestFilterCalendarEvents(t *testing.T) {
+	events := types.Events{
+		{Uid: "1", Calendar: types.Calendar{UID: "team"}},
+		{Uid: "2", Calendar: types.Calendar{UID: "personal"}},
+		{Uid: "3", Calendar: types.Calendar{UID: "team"}},
+	}
+
+	filtered := FilterCalendarEvents(events, "team")
+	require.Len(t, filtered, 2)
+	assert.Equal(t, "1", filtered[0].Uid)
+	assert.Equal(t, "3", filtered[1].Uid)
+
+	assert.Empty(t, FilterCalendarEvents(events, "other"))
+}
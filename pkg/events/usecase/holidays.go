@@ -0,0 +1,62 @@
+package usecase
+
+import (
+	"github.com/calendar-bot/pkg/types"
+	"sort"
+	"time"
+)
+
+const holidaysFreeBusyUser = "holidays"
+
+func IsHoliday(event types.Event) bool {
+	return event.Calendar.Type == types.CalendarTypeHoliday
+}
+
+// FilterHolidays returns holiday events sorted by start time, each holiday only once
+func FilterHolidays(events types.Events) types.Events {
+	holidays := make(types.Events, 0)
+	seen := make(map[string]struct{})
+	for _, event := range events {
+		if !IsHoliday(event) {
+			continue
+		}
+		// virtual events of the same holiday share uid, but differ by date
+		key := event.Uid + event.From.Format(time.RFC3339)
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		holidays = append(holidays, event)
+	}
+
+	sort.SliceStable(holidays, func(i, j int) bool {
+		return holidays[i].From.Before(holidays[j].From)
+	})
+
+	return holidays
+}
+
+// HolidayDaySpan returns whole days covered by a full-day event
+func HolidayDaySpan(event types.Event) types.FromTo {
+	from := time.Date(event.From.Year(), event.From.Month(), event.From.Day(), 0, 0, 0, 0, event.From.Location())
+	to := time.Date(event.To.Year(), event.To.Month(), event.To.Day(), 0, 0, 0, 0, event.To.Location())
+	if !to.After(from) {
+		to = from.AddDate(0, 0, 1)
+	}
+	return types.FromTo{From: from, To: to}
+}
+
+// HolidaysBusyIntervals converts full-day holidays into busy intervals for the free/busy pipeline
+func HolidaysBusyIntervals(holidays types.Events) types.FreeBusyIntervals {
+	intervals := types.FreeBusyIntervals{
+		User:     holidaysFreeBusyUser,
+		FreeBusy: make([]types.FromTo, 0, len(holidays)),
+	}
+	for _, holiday := range holidays {
+		if !holiday.FullDay || !IsHoliday(holiday) {
+			continue
+		}
+		intervals.FreeBusy = append(intervals.FreeBusy, HolidayDaySpan(holiday))
+	}
+	return intervals
+}
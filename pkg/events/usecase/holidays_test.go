@@ -0,0 +1,59 @@
+package usecase
+
+import (
+	"github.com/calendar-bot/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestFilterHolidays(t *testing.T) {
+	holidayCalendar := types.Calendar{UID: "holidays", Type: types.CalendarTypeHoliday}
+	personalCalendar := types.Calendar{UID: "personal", Type: types.CalendarTypePersonal}
+
+	events := types.Events{
+		{Uid: "2", Title: "Victory day", From: time.Date(2021, 5, 9, 0, 0, 0, 0, time.UTC), Calendar: holidayCalendar},
+		{Uid: "meeting", From: time.Date(2021, 5, 3, 12, 0, 0, 0, time.UTC), Calendar: personalCalendar},
+		{Uid: "1", Title: "Labour day", From: time.Date(2021, 5, 1, 0, 0, 0, 0, time.UTC), Calendar: holidayCalendar},
+		{Uid: "1", Title: "Labour day", From: time.Date(2021, 5, 1, 0, 0, 0, 0, time.UTC), Calendar: holidayCalendar},
+	}
+
+	holidays := FilterHolidays(events)
+
+	assert.Len(t, holidays, 2)
+	assert.Equal(t, "1", holidays[0].Uid)
+	assert.Equal(t, "2", holidays[1].Uid)
+}
+
+func TestHolidaysBusyIntervals(t *testing.T) {
+	holidayCalendar := types.Calendar{UID: "holidays", Type: types.CalendarTypeHoliday}
+
+	holidays := types.Events{
+		{
+			FullDay:  true,
+			From:     time.Date(2021, 5, 1, 0, 0, 0, 0, time.UTC),
+			To:       time.Date(2021, 5, 3, 0, 0, 0, 0, time.UTC),
+			Calendar: holidayCalendar,
+		},
+		{
+			FullDay:  true,
+			From:     time.Date(2021, 5, 9, 0, 0, 0, 0, time.UTC),
+			To:       time.Date(2021, 5, 9, 0, 0, 0, 0, time.UTC),
+			Calendar: holidayCalendar,
+		},
+		{
+			FullDay:  false,
+			From:     time.Date(2021, 5, 10, 10, 0, 0, 0, time.UTC),
+			To:       time.Date(2021, 5, 10, 11, 0, 0, 0, time.UTC),
+			Calendar: holidayCalendar,
+		},
+	}
+
+	intervals := HolidaysBusyIntervals(holidays)
+
+	assert.Equal(t, holidaysFreeBusyUser, intervals.User)
+	assert.Equal(t, []types.FromTo{
+		{From: time.Date(2021, 5, 1, 0, 0, 0, 0, time.UTC), To: time.Date(2021, 5, 3, 0, 0, 0, 0, time.UTC)},
+		{From: time.Date(2021, 5, 9, 0, 0, 0, 0, time.UTC), To: time.Date(2021, 5, 10, 0, 0, 0, 0, time.UTC)},
+	}, intervals.FreeBusy)
+}
@@ -0,0 +1,72 @@
+package usecase
+
+import (
+	"github.com/calendar-bot/pkg/types"
+	"sort"
+	"strings"
+	"time"
+)
+
+// InlineQueryDays is the range searched when the inline query has no date
+const InlineQueryDays = 7
+
+// InlineQuery is the range and the title search parsed from the text of an inline query
+type InlineQuery struct {
+	From   time.Time
+	To     time.Time
+	Search string
+}
+
+// ParseInlineQuery takes today, tomorrow, week and dd.mm dates in English or Russian,
+// the rest of the words is searched in the event titles
+func ParseInlineQuery(text string, now time.Time) InlineQuery {
+	dayStart := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	query := InlineQuery{
+		From: dayStart,
+		To:   dayStart.AddDate(0, 0, InlineQueryDays),
+	}
+
+	search := make([]string, 0)
+	for _, word := range strings.Fields(text) {
+		switch strings.ToLower(word) {
+		case "today", "сегодня":
+			query.From, query.To = dayStart, dayStart.AddDate(0, 0, 1)
+		case "tomorrow", "завтра":
+			query.From, query.To = dayStart.AddDate(0, 0, 1), dayStart.AddDate(0, 0, 2)
+		case "week", "неделя":
+			query.From, query.To = dayStart, dayStart.AddDate(0, 0, InlineQueryDays)
+		default:
+			if date, err := time.ParseInLocation("02.01", word, now.Location()); err == nil {
+				day := time.Date(now.Year(), date.Month(), date.Day(), 0, 0, 0, 0, now.Location())
+				if day.Before(dayStart) {
+					day = day.AddDate(1, 0, 0)
+				}
+				query.From, query.To = day, day.AddDate(0, 0, 1)
+				continue
+			}
+			search = append(search, word)
+		}
+	}
+	query.Search = strings.Join(search, " ")
+	return query
+}
+
+// FilterInlineEvents returns events which have not ended by now and contain the search in the title,
+// earliest first and without holidays
+func FilterInlineEvents(events types.Events, search string, now time.Time) types.Events {
+	search = strings.ToLower(search)
+	filtered := make(types.Events, 0, len(events))
+	for _, event := range events {
+		if IsHoliday(event) || !event.To.After(now) {
+			continue
+		}
+		if search != "" && !strings.Contains(strings.ToLower(event.Title), search) {
+			continue
+		}
+		filtered = append(filtered, event)
+	}
+	sort.SliceStable(filtered, func(i, j int) bool {
+		return filtered[i].From.Before(filtered[j].From)
+	})
+	return filtered
+}
@@ -0,0 +1,56 @@
+package usecase
+
+import (
+	"github.com/calendar-bot/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestParseInlineQuery(t *testing.T) {
+	moscow, err := time.LoadLocation("Europe/Moscow")
+	if err != nil {
+		t.Skip("no timezone database")
+	}
+	now := time.Date(2021, 12, 30, 15, 20, 0, 0, moscow)
+	day := func(month time.Month, d int, year int) time.Time {
+		return time.Date(year, month, d, 0, 0, 0, 0, moscow)
+	}
+
+	query := ParseInlineQuery("", now)
+	assert.Equal(t, InlineQuery{From: day(12, 30, 2021), To: day(1, 6, 2022)}, query)
+
+	query = ParseInlineQuery("Tomorrow standup", now)
+	assert.Equal(t, InlineQuery{From: day(12, 31, 2021), To: day(1, 1, 2022), Search: "standup"}, query)
+
+	query = ParseInlineQuery("сегодня", now)
+	assert.Equal(t, InlineQuery{From: day(12, 30, 2021), To: day(12, 31, 2021)}, query)
+
+	query = ParseInlineQuery("02.01 retro  board", now)
+	assert.Equal(t, InlineQuery{From: day(1, 2, 2022), To: day(1, 3, 2022), Search: "retro board"}, query)
+}
+
+func TestFilterInlineEvents(t *testing.T) {
+	at := func(hour int) time.Time {
+		return time.Date(2021, 5, 4, hour, 0, 0, 0, time.UTC)
+	}
+	events := types.Events{
+		{Uid: "late", Title: "Daily Standup", From: at(15), To: at(16)},
+		{Uid: "past", Title: "Standup", From: at(8), To: at(9)},
+		{Uid: "early", Title: "standup sync", From: at(11), To: at(12)},
+		{Uid: "other", Title: "Retro", From: at(12), To: at(13)},
+		{Uid: "holiday", Title: "Standup day", From: at(0), To: at(23),
+			Calendar: types.Calendar{Type: types.CalendarTypeHoliday}},
+	}
+
+	uids := func(events types.Events) []string {
+		ret := make([]string, 0, len(events))
+		for _, event := range events {
+			ret = append(ret, event.Uid)
+		}
+		return ret
+	}
+
+	assert.Equal(t, []string{"early", "late"}, uids(FilterInlineEvents(events, "STANDUP", at(10))))
+	assert.Equal(t, []string{"early", "other", "late"}, uids(FilterInlineEvents(events, "", at(10))))
+}
@@ -0,0 +1,152 @@
+package usecase
+
+import (
+	"github.com/calendar-bot/pkg/types"
+	"github.com/pkg/errors"
+	"strings"
+	"time"
+)
+
+// invitations further than the horizon are answered by later syncs
+const invitationRulesHorizon = 14 * 24 * time.Hour
+
+func (uc *EventUseCase) AddInvitationRule(rule types.InvitationRule) (int64, error) {
+	id, err := uc.eventStorage.AddInvitationRule(rule)
+	if err != nil {
+		return 0, errors.WithStack(err)
+	}
+	return id, nil
+}
+
+func (uc *EventUseCase) GetInvitationRules(telegramUserID int64) ([]types.InvitationRule, error) {
+	rules, err := uc.eventStorage.GetInvitationRules(telegramUserID)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return rules, nil
+}
+
+func (uc *EventUseCase) GetAllInvitationRules() ([]types.InvitationRule, error) {
+	rules, err := uc.eventStorage.GetAllInvitationRules()
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return rules, nil
+}
+
+func (uc *EventUseCase) DeleteInvitationRule(telegramUserID int64, ruleID int64) error {
+	return errors.WithStack(uc.eventStorage.DeleteInvitationRule(telegramUserID, ruleID))
+}
+
+// ApplyInvitationRules answers pending invitations of the next two weeks with the first matching rule,
+// times of day in the rules are taken in the user's location
+func (uc *EventUseCase) ApplyInvitationRules(accessToken string, email string, rules []types.InvitationRule,
+	location *time.Location, now time.Time) ([]types.InvitationRuleResult, error) {
+
+	results := make([]types.InvitationRuleResult, 0)
+	if len(rules) == 0 {
+		return results, nil
+	}
+
+	response, err := uc.GetEventsByRange(accessToken, now, now.Add(invitationRulesHorizon))
+	if err != nil {
+		return nil, errors.Wrap(err, "ApplyInvitationRules")
+	}
+	if response == nil {
+		return results, nil
+	}
+
+	events := response.Data.Events
+	for _, event := range PendingInvitations(events, email) {
+		rule := MatchInvitationRule(rules, event, location, HasConflict(event, events, email))
+		if rule == nil {
+			continue
+		}
+
+		_, err := uc.ChangeStatus(accessToken, types.ChangeStatus{
+			EventID:    event.Uid,
+			CalendarID: event.Calendar.UID,
+			Status:     rule.Status,
+		})
+		if err != nil {
+			return results, errors.Wrapf(err, "failed to apply invitation rule id=%d to event uid=%s", rule.ID, event.Uid)
+		}
+		// attendees are shared with events, so an accepted invitation is a conflict for the next ones
+		setAttendeeStatus(event, email, rule.Status)
+		results = append(results, types.InvitationRuleResult{Event: event, Rule: *rule})
+	}
+	return results, nil
+}
+
+// MatchInvitationRule returns the first rule matching the event, nil if there is none
+func MatchInvitationRule(rules []types.InvitationRule, event types.Event, location *time.Location,
+	conflict bool) *types.InvitationRule {
+
+	for i := range rules {
+		if InvitationRuleMatches(rules[i], event, location, conflict) {
+			return &rules[i]
+		}
+	}
+	return nil
+}
+
+// InvitationRuleMatches checks StartsAfter and EndsBefore against the time of day in location
+func InvitationRuleMatches(rule types.InvitationRule, event types.Event, location *time.Location, conflict bool) bool {
+	if rule.Organizer != "" && !strings.EqualFold(rule.Organizer, event.Organizer.Email) {
+		return false
+	}
+	if rule.Calendar != "" && !strings.EqualFold(rule.Calendar, event.Calendar.Title) {
+		return false
+	}
+
+	from := event.From.In(location)
+	dayStart := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, location)
+	if rule.StartsAfter != 0 && from.Sub(dayStart) < rule.StartsAfter {
+		return false
+	}
+	if rule.EndsBefore != 0 && event.To.Sub(dayStart) > rule.EndsBefore {
+		return false
+	}
+
+	duration := event.To.Sub(event.From)
+	if rule.MinDuration != 0 && duration < rule.MinDuration {
+		return false
+	}
+	if rule.MaxDuration != 0 && duration > rule.MaxDuration {
+		return false
+	}
+
+	return !rule.NoConflict || !conflict
+}
+
+// HasConflict reports whether the event overlaps another event of the user which is not declined or unanswered
+func HasConflict(event types.Event, events types.Events, email string) bool {
+	for _, other := range events {
+		if other.Uid == event.Uid && other.Calendar.UID == event.Calendar.UID {
+			continue
+		}
+		if IsHoliday(other) || !other.From.Before(event.To) || !other.To.After(event.From) {
+			continue
+		}
+
+		status := types.StatusAccepted
+		for _, attendee := range other.Attendees {
+			if attendee.Email == email {
+				status = attendee.Status
+				break
+			}
+		}
+		if status != types.StatusDeclined && status != types.StatusNeedsAction {
+			return true
+		}
+	}
+	return false
+}
+
+func setAttendeeStatus(event types.Event, email string, status string) {
+	for i := range event.Attendees {
+		if event.Attendees[i].Email == email {
+			event.Attendees[i].Status = status
+		}
+	}
+}
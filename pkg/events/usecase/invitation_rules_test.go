@@ -0,0 +1,75 @@
+package usecase
+
+import (
+	"github.com/calendar-bot/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestInvitationRuleMatches(t *testing.T) {
+	at := func(hour, minute int) time.Time {
+		return time.Date(2021, 5, 4, hour, minute, 0, 0, time.UTC)
+	}
+	event := types.Event{
+		Uid:       "1",
+		From:      at(19, 30),
+		To:        at(20, 0),
+		Calendar:  types.Calendar{UID: "work", Title: "Работа"},
+		Organizer: types.AttendeeEvent{Email: "alice@mail.ru"},
+	}
+
+	assert.True(t, InvitationRuleMatches(types.InvitationRule{Organizer: "Alice@mail.ru"}, event, time.UTC, false))
+	assert.False(t, InvitationRuleMatches(types.InvitationRule{Organizer: "bob@mail.ru"}, event, time.UTC, false))
+	assert.True(t, InvitationRuleMatches(types.InvitationRule{Calendar: "работа"}, event, time.UTC, false))
+	assert.True(t, InvitationRuleMatches(types.InvitationRule{StartsAfter: 19 * time.Hour}, event, time.UTC, false))
+	assert.False(t, InvitationRuleMatches(types.InvitationRule{StartsAfter: 20 * time.Hour}, event, time.UTC, false))
+	assert.False(t, InvitationRuleMatches(types.InvitationRule{EndsBefore: 19 * time.Hour}, event, time.UTC, false))
+	assert.True(t, InvitationRuleMatches(types.InvitationRule{MaxDuration: 30 * time.Minute}, event, time.UTC, false))
+	assert.False(t, InvitationRuleMatches(types.InvitationRule{MinDuration: time.Hour}, event, time.UTC, false))
+	assert.False(t, InvitationRuleMatches(types.InvitationRule{NoConflict: true}, event, time.UTC, true))
+	assert.True(t, InvitationRuleMatches(types.InvitationRule{}, event, time.UTC, true))
+
+	rules := []types.InvitationRule{
+		{ID: 1, Organizer: "bob@mail.ru", Status: types.StatusAccepted},
+		{ID: 2, StartsAfter: 19 * time.Hour, Status: types.StatusDeclined},
+		{ID: 3, Status: types.StatusAccepted},
+	}
+	if rule := MatchInvitationRule(rules, event, time.UTC, false); assert.NotNil(t, rule) {
+		assert.Equal(t, int64(2), rule.ID)
+	}
+	assert.Nil(t, MatchInvitationRule(rules[:1], event, time.UTC, false))
+
+	// 19:30 UTC is 22:30 in Moscow
+	moscow := time.FixedZone("MSK", 3*60*60)
+	assert.True(t, InvitationRuleMatches(types.InvitationRule{StartsAfter: 22 * time.Hour}, event, moscow, false))
+	assert.False(t, InvitationRuleMatches(types.InvitationRule{StartsAfter: 23 * time.Hour}, event, moscow, false))
+	assert.True(t, InvitationRuleMatches(types.InvitationRule{EndsBefore: 23 * time.Hour}, event, moscow, false))
+}
+
+func TestHasConflict(t *testing.T) {
+	const me = "me@mail.ru"
+	at := func(hour int) time.Time {
+		return time.Date(2021, 5, 4, hour, 0, 0, 0, time.UTC)
+	}
+	withStatus := func(uid string, from, to int, status string) types.Event {
+		return types.Event{
+			Uid:       uid,
+			From:      at(from),
+			To:        at(to),
+			Attendees: types.AttendeesEvent{{Email: me, Status: status}},
+		}
+	}
+
+	invitation := withStatus("new", 10, 11, types.StatusNeedsAction)
+	events := types.Events{
+		invitation,
+		withStatus("declined", 10, 11, types.StatusDeclined),
+		withStatus("pending", 10, 11, types.StatusNeedsAction),
+		withStatus("before", 9, 10, types.StatusAccepted),
+	}
+	assert.False(t, HasConflict(invitation, events, me))
+
+	own := types.Event{Uid: "own", From: at(10), To: at(12)}
+	assert.True(t, HasConflict(invitation, append(events, own), me))
+}
@@ -18,6 +18,14 @@ var (
 		},
 		[]string{statusMetricLabel},
 	)
+	metricGetEventsByRangeTotalCount = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: eventsMetricsNamespace,
+			Name:      "get_events_by_range_count",
+			Help:      "Total count of 'get events by range' requests",
+		},
+		[]string{statusMetricLabel},
+	)
 	metricGetClosestEventTotalCount = prometheus.NewCounterVec(
 		prometheus.CounterOpts{
 			Namespace: eventsMetricsNamespace,
@@ -26,6 +34,14 @@ var (
 		},
 		[]string{statusMetricLabel},
 	)
+	metricGetCurrentEventTotalCount = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: eventsMetricsNamespace,
+			Name:      "get_current_event_count",
+			Help:      "Total count of 'get current event' requests",
+		},
+		[]string{statusMetricLabel},
+	)
 	metricGetEventByEventIDTotalCount = prometheus.NewCounterVec(
 		prometheus.CounterOpts{
 			Namespace: eventsMetricsNamespace,
@@ -74,6 +90,30 @@ var (
 		},
 		[]string{statusMetricLabel},
 	)
+	metricDeleteEventTotalCount = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: eventsMetricsNamespace,
+			Name:      "delete_event_count",
+			Help:      "Total count of 'delete event' requests",
+		},
+		[]string{statusMetricLabel},
+	)
+	metricMailCallLinkTotalCount = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: eventsMetricsNamespace,
+			Name:      "mail_call_link_count",
+			Help:      "Total count of 'mail call link' requests",
+		},
+		[]string{statusMetricLabel},
+	)
+	metricGetCalendarsTotalCount = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: eventsMetricsNamespace,
+			Name:      "get_calendars_count",
+			Help:      "Total count of 'get calendars' requests",
+		},
+		[]string{statusMetricLabel},
+	)
 )
 
 // nickeskov: histograms
@@ -86,6 +126,13 @@ var (
 			Help:      "'get events by specific day' request duration",
 		},
 	)
+	metricGetEventsByRangeDuration = prometheus.NewHistogram(
+		prometheus.HistogramOpts{
+			Namespace: eventsMetricsNamespace,
+			Name:      "get_events_by_range_duration",
+			Help:      "'get events by range' request duration",
+		},
+	)
 	metricGetClosestEventDuration = prometheus.NewHistogram(
 		prometheus.HistogramOpts{
 			Namespace: eventsMetricsNamespace,
@@ -93,6 +140,13 @@ var (
 			Help:      "'get closes event' request duration",
 		},
 	)
+	metricGetCurrentEventDuration = prometheus.NewHistogram(
+		prometheus.HistogramOpts{
+			Namespace: eventsMetricsNamespace,
+			Name:      "get_current_event_duration",
+			Help:      "'get current event' request duration",
+		},
+	)
 	metricGetEventByEventIDDuration = prometheus.NewHistogram(
 		prometheus.HistogramOpts{
 			Namespace: eventsMetricsNamespace,
@@ -128,6 +182,27 @@ var (
 			Help:      "'add attendee' request duration",
 		},
 	)
+	metricDeleteEventDuration = prometheus.NewHistogram(
+		prometheus.HistogramOpts{
+			Namespace: eventsMetricsNamespace,
+			Name:      "delete_event_duration",
+			Help:      "'delete event' request duration",
+		},
+	)
+	metricMailCallLinkDuration = prometheus.NewHistogram(
+		prometheus.HistogramOpts{
+			Namespace: eventsMetricsNamespace,
+			Name:      "mail_call_link_duration",
+			Help:      "'mail call link' request duration",
+		},
+	)
+	metricGetCalendarsDuration = prometheus.NewHistogram(
+		prometheus.HistogramOpts{
+			Namespace: eventsMetricsNamespace,
+			Name:      "get_calendars_duration",
+			Help:      "'get calendars' request duration",
+		},
+	)
 	metricChangeStatusDuration = prometheus.NewHistogram(
 		prometheus.HistogramOpts{
 			Namespace: eventsMetricsNamespace,
@@ -141,24 +216,34 @@ func init() {
 	// nickeskov: counters
 	prometheus.MustRegister(
 		metricGetEventsBySpecificDayTotalCount,
+		metricGetEventsByRangeTotalCount,
 		metricGetClosestEventTotalCount,
+		metricGetCurrentEventTotalCount,
 		metricGetEventByEventIDTotalCount,
 		metricGetUsersBusyIntervalsTotalCount,
 		metricGetUsersFreeIntervalsTotalCount,
 		metricCreateEventTotalCount,
 		metricAddAttendeeTotalCount,
 		metricChangeStatusTotalCount,
+		metricDeleteEventTotalCount,
+		metricMailCallLinkTotalCount,
+		metricGetCalendarsTotalCount,
 	)
 	// nickeskov: histograms
 	prometheus.MustRegister(
 		metricGetEventsBySpecificDayDuration,
+		metricGetEventsByRangeDuration,
 		metricGetClosestEventDuration,
+		metricGetCurrentEventDuration,
 		metricGetEventByEventIDDuration,
 		metricGetUsersBusyIntervalsDuration,
 		metricGetUsersFreeIntervalsDuration,
 		metricCreateEventDuration,
 		metricAddAttendeeDuration,
 		metricChangeStatusDuration,
+		metricDeleteEventDuration,
+		metricMailCallLinkDuration,
+		metricGetCalendarsDuration,
 	)
 }
 
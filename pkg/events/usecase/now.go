@@ -0,0 +1,131 @@
+package usecase
+
+import (
+	"github.com/calendar-bot/pkg/types"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/senseyeio/spaniel"
+	"time"
+)
+
+// window around the current moment used to find out who is busy right now
+const (
+	UsersStatusWindowBefore = 6 * time.Hour
+	UsersStatusWindowAfter  = 12 * time.Hour
+)
+
+type UserStatus struct {
+	User      string
+	InMeeting bool
+	// MeetingEnd is the end of the current meeting, zero if the user is free
+	MeetingEnd time.Time
+	// FreeFrom is the moment the user is free again, zero if it is outside of the window
+	FreeFrom time.Time
+	// NextMeeting is the start of the next meeting inside the window, zero if there is none
+	NextMeeting time.Time
+}
+
+func (uc *EventUseCase) GetUsersStatus(accessToken string, emails []string, now time.Time) ([]UserStatus, error) {
+	if len(emails) == 0 {
+		return nil, nil
+	}
+
+	windowEnd := now.Add(UsersStatusWindowAfter)
+	response, err := uc.GetUsersBusyIntervals(accessToken, types.FreeBusy{
+		Users: emails,
+		From:  now.Add(-UsersStatusWindowBefore),
+		To:    windowEnd,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "GetUsersStatus")
+	}
+
+	busyByUser := make(map[string][]types.FromTo, len(response.Data.FreeBusy))
+	for _, intervals := range response.Data.FreeBusy {
+		busyByUser[intervals.User] = append(busyByUser[intervals.User], intervals.FreeBusy...)
+	}
+
+	statuses := make([]UserStatus, 0, len(emails))
+	for _, email := range emails {
+		statuses = append(statuses, UserStatusAt(email, busyByUser[email], now, windowEnd))
+	}
+	return statuses, nil
+}
+
+// UserStatusAt tells whether the user is busy at the moment now judging by busy intervals,
+// which are known up to windowEnd
+func UserStatusAt(user string, busy []types.FromTo, now time.Time, windowEnd time.Time) UserStatus {
+	status := UserStatus{User: user, FreeFrom: now}
+
+	spans := make(spaniel.Spans, 0, len(busy))
+	for _, interval := range busy {
+		if !interval.To.After(interval.From) {
+			continue
+		}
+		spans = append(spans, interval)
+		if !interval.From.After(now) && interval.To.After(now) {
+			status.InMeeting = true
+			if status.MeetingEnd.IsZero() || interval.To.Before(status.MeetingEnd) {
+				status.MeetingEnd = interval.To
+			}
+		}
+	}
+
+	for _, span := range spans.Union() {
+		switch {
+		case !span.Start().After(now) && span.End().After(now):
+			status.FreeFrom = span.End()
+			if !status.FreeFrom.Before(windowEnd) {
+				status.FreeFrom = time.Time{}
+			}
+		case span.Start().After(now) && (status.NextMeeting.IsZero() || span.Start().Before(status.NextMeeting)):
+			if status.InMeeting && !span.Start().After(status.FreeFrom) {
+				continue
+			}
+			status.NextMeeting = span.Start()
+		}
+	}
+
+	return status
+}
+
+// GetCurrentEvent returns the event that is going on right now and the one after it, now is in the user's timezone
+func (uc *EventUseCase) GetCurrentEvent(accessToken string, now time.Time) (current *types.Event, next *types.Event,
+	err error) {
+
+	timer := prometheus.NewTimer(metricGetCurrentEventDuration)
+	defer func() {
+		metricGetCurrentEventTotalCount.WithLabelValues(metricStatusFromErr(err)).Inc()
+		timer.ObserveDuration()
+	}()
+
+	eventsResponse, err := uc.GetEventsToday(accessToken, now)
+	if err != nil {
+		return nil, nil, err
+	}
+	if eventsResponse == nil {
+		return nil, nil, nil
+	}
+
+	current, next = currentAndNextEvent(eventsResponse.Data.Events, now)
+	return current, next, nil
+}
+
+func currentAndNextEvent(events []types.Event, now time.Time) (current *types.Event, next *types.Event) {
+	for i := range events {
+		event := &events[i]
+		if event.FullDay {
+			continue
+		}
+		if !event.From.After(now) && event.To.After(now) {
+			if current == nil || event.From.Before(current.From) {
+				current = event
+			}
+			continue
+		}
+		if event.From.After(now) && (next == nil || event.From.Before(next.From)) {
+			next = event
+		}
+	}
+	return current, next
+}
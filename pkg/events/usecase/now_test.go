@@ -0,0 +1,67 @@
+package usecase
+
+import (
+	"github.com/calendar-bot/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestUserStatusAt(t *testing.T) {
+	at := func(hour, minute int) time.Time {
+		return time.Date(2021, 5, 3, hour, minute, 0, 0, time.UTC)
+	}
+	now := at(12, 0)
+	windowEnd := at(20, 0)
+
+	busy := []types.FromTo{
+		{From: at(11, 30), To: at(12, 30)},
+		{From: at(12, 15), To: at(13, 0)},
+		{From: at(15, 0), To: at(16, 0)},
+	}
+	status := UserStatusAt("busy@mail.ru", busy, now, windowEnd)
+	assert.True(t, status.InMeeting)
+	assert.Equal(t, at(12, 30), status.MeetingEnd)
+	assert.Equal(t, at(13, 0), status.FreeFrom)
+	assert.Equal(t, at(15, 0), status.NextMeeting)
+
+	status = UserStatusAt("free@mail.ru", busy[2:], now, windowEnd)
+	assert.False(t, status.InMeeting)
+	assert.Equal(t, now, status.FreeFrom)
+	assert.Equal(t, at(15, 0), status.NextMeeting)
+
+	status = UserStatusAt("long@mail.ru", []types.FromTo{{From: at(9, 0), To: at(21, 0)}}, now, windowEnd)
+	assert.True(t, status.InMeeting)
+	assert.True(t, status.FreeFrom.IsZero())
+	assert.True(t, status.NextMeeting.IsZero())
+}
+
+func TestCurrentAndNextEvent(t *testing.T) {
+	at := func(hour int) time.Time {
+		return time.Date(2021, 5, 3, hour, 0, 0, 0, time.UTC)
+	}
+	events := []types.Event{
+		{Uid: "full", FullDay: true, From: at(0), To: at(0).AddDate(0, 0, 1)},
+		{Uid: "later", From: at(16), To: at(17)},
+		{Uid: "now", From: at(11), To: at(13)},
+		{Uid: "next", From: at(14), To: at(15)},
+		{Uid: "past", From: at(9), To: at(10)},
+	}
+
+	current, next := currentAndNextEvent(events, at(12))
+	assert.Equal(t, "now", current.Uid)
+	assert.Equal(t, "next", next.Uid)
+
+	current, next = currentAndNextEvent(events, at(18))
+	assert.Nil(t, current)
+	assert.Nil(t, next)
+}
+
+func TestDayBoundsInUserLocation(t *testing.T) {
+	moscow := time.FixedZone("MSK", 3*60*60)
+	// 22:30 UTC is already the next day in Moscow
+	now := time.Date(2021, 5, 4, 22, 30, 0, 0, time.UTC).In(moscow)
+
+	assert.Equal(t, time.Date(2021, 5, 5, 0, 0, 0, 0, moscow), getStartDay(now))
+	assert.Equal(t, time.Date(2021, 5, 5, 23, 59, 59, 0, moscow), getEndDay(now))
+}
@@ -0,0 +1,75 @@
+package usecase
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"github.com/calendar-bot/pkg/types"
+	"github.com/pkg/errors"
+	"time"
+)
+
+func (uc *EventUseCase) AddPostedMessage(posted types.PostedMessage) error {
+	return errors.WithStack(uc.eventStorage.AddPostedMessage(posted))
+}
+
+func (uc *EventUseCase) GetPostedMessages(eventUID string) ([]types.PostedMessage, error) {
+	posted, err := uc.eventStorage.GetPostedMessages(eventUID)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return posted, nil
+}
+
+func (uc *EventUseCase) GetActivePostedMessages(since time.Time) ([]types.PostedMessage, error) {
+	posted, err := uc.eventStorage.GetActivePostedMessages(since)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return posted, nil
+}
+
+func (uc *EventUseCase) UpdatePostedMessageHash(chatID int64, messageID string, textHash string) error {
+	return errors.WithStack(uc.eventStorage.UpdatePostedMessageHash(chatID, messageID, textHash))
+}
+
+func (uc *EventUseCase) DeletePostedMessage(chatID int64, messageID string) error {
+	return errors.WithStack(uc.eventStorage.DeletePostedMessage(chatID, messageID))
+}
+
+func (uc *EventUseCase) DeleteEndedPostedMessages(before time.Time) (int64, error) {
+	deleted, err := uc.eventStorage.DeleteEndedPostedMessages(before)
+	if err != nil {
+		return 0, errors.WithStack(err)
+	}
+	return deleted, nil
+}
+
+// PostedMessageTextHash identifies the rendered text of a posted message, so that unchanged messages are not edited
+func PostedMessageTextHash(text string) string {
+	sum := sha1.Sum([]byte(text))
+	return hex.EncodeToString(sum[:])
+}
+
+// GroupPostedMessagesByEvent splits messages into groups of copies of the same event read by the same user,
+// groups are in the order of their first message
+func GroupPostedMessagesByEvent(posted []types.PostedMessage) [][]types.PostedMessage {
+	type key struct {
+		eventUID       string
+		calendarUID    string
+		telegramUserID int64
+	}
+
+	var groups [][]types.PostedMessage
+	index := make(map[key]int)
+	for _, msg := range posted {
+		k := key{msg.EventUID, msg.CalendarUID, msg.TelegramUserID}
+		i, ok := index[k]
+		if !ok {
+			i = len(groups)
+			index[k] = i
+			groups = append(groups, nil)
+		}
+		groups[i] = append(groups[i], msg)
+	}
+	return groups
+}
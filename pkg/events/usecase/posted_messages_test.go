@@ -0,0 +1,31 @@
+package usecase
+
+import (
+	"github.com/calendar-bot/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestPostedMessageTextHash(t *testing.T) {
+	assert.Equal(t, PostedMessageTextHash("event"), PostedMessageTextHash("event"))
+	assert.NotEqual(t, PostedMessageTextHash("event"), PostedMessageTextHash("event changed"))
+	assert.Len(t, PostedMessageTextHash(""), 40)
+}
+
+func TestGroupPostedMessagesByEvent(t *testing.T) {
+	posted := []types.PostedMessage{
+		{ChatID: -1, MessageID: "1", EventUID: "a", CalendarUID: "cal", TelegramUserID: 10},
+		{ChatID: -2, MessageID: "2", EventUID: "b", CalendarUID: "cal", TelegramUserID: 10},
+		{ChatID: -3, MessageID: "3", EventUID: "a", CalendarUID: "cal", TelegramUserID: 10},
+		{ChatID: 0, MessageID: "inline", EventUID: "a", CalendarUID: "other", TelegramUserID: 20},
+	}
+
+	groups := GroupPostedMessagesByEvent(posted)
+	require.Len(t, groups, 3)
+	assert.Equal(t, []types.PostedMessage{posted[0], posted[2]}, groups[0])
+	assert.Equal(t, []types.PostedMessage{posted[1]}, groups[1])
+	assert.Equal(t, []types.PostedMessage{posted[3]}, groups[2])
+
+	assert.Empty(t, GroupPostedMessagesByEvent(nil))
+}
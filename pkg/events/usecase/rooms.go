@@ -0,0 +1,83 @@
+package usecase
+
+import (
+	"github.com/calendar-bot/pkg/types"
+	"github.com/pkg/errors"
+	"sort"
+	"strings"
+	"time"
+)
+
+// GetFreeRooms returns rooms from the directory which fit the attendees and are free in [from, to)
+func (uc *EventUseCase) GetFreeRooms(accessToken string, rooms []types.Room, attendees int, from time.Time,
+	to time.Time) ([]types.Room, error) {
+
+	fitting := RoomsForAttendees(rooms, attendees)
+	if len(fitting) == 0 {
+		return fitting, nil
+	}
+
+	emails := make([]string, 0, len(fitting))
+	for _, room := range fitting {
+		emails = append(emails, room.Email)
+	}
+
+	response, err := uc.GetUsersBusyIntervals(accessToken, types.FreeBusy{
+		Users: emails,
+		From:  from,
+		To:    to,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "GetFreeRooms")
+	}
+	if response == nil {
+		return nil, errors.New("GetFreeRooms: empty busy intervals response")
+	}
+
+	return FreeRooms(fitting, response.Data, from, to), nil
+}
+
+// Headcount is the number of people the event needs seats for: the attendees and the organizer,
+// who is counted even if the event has no organizer set yet or does not list them among the attendees
+func Headcount(event types.Event) int {
+	people := map[string]struct{}{strings.ToLower(event.Organizer.Email): {}}
+	for _, attendee := range event.Attendees {
+		people[strings.ToLower(attendee.Email)] = struct{}{}
+	}
+	return len(people)
+}
+
+// RoomsForAttendees returns rooms with enough seats, the smallest ones first
+func RoomsForAttendees(rooms []types.Room, attendees int) []types.Room {
+	fitting := make([]types.Room, 0, len(rooms))
+	for _, room := range rooms {
+		if room.Capacity >= attendees {
+			fitting = append(fitting, room)
+		}
+	}
+	sort.SliceStable(fitting, func(i, j int) bool {
+		return fitting[i].Capacity < fitting[j].Capacity
+	})
+	return fitting
+}
+
+// FreeRooms keeps the order of rooms and drops the ones having busy intervals intersecting [from, to)
+func FreeRooms(rooms []types.Room, busy types.FreeBusyUser, from time.Time, to time.Time) []types.Room {
+	busyRooms := make(map[string]struct{})
+	for _, intervals := range busy.FreeBusy {
+		for _, interval := range intervals.FreeBusy {
+			if interval.From.Before(to) && interval.To.After(from) {
+				busyRooms[intervals.User] = struct{}{}
+				break
+			}
+		}
+	}
+
+	free := make([]types.Room, 0, len(rooms))
+	for _, room := range rooms {
+		if _, ok := busyRooms[room.Email]; !ok {
+			free = append(free, room)
+		}
+	}
+	return free
+}
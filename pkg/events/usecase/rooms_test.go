@@ -0,0 +1,53 @@
+package usecase
+
+import (
+	"github.com/calendar-bot/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestHeadcount(t *testing.T) {
+	organizer := types.AttendeeEvent{Email: "me@mail.ru"}
+	others := types.AttendeesEvent{{Email: "alice@mail.ru"}, {Email: "bob@mail.ru"}}
+
+	assert.Equal(t, 1, Headcount(types.Event{}))
+	assert.Equal(t, 1, Headcount(types.Event{Organizer: organizer}))
+	assert.Equal(t, 3, Headcount(types.Event{Organizer: organizer, Attendees: others}))
+	assert.Equal(t, 3, Headcount(types.Event{Attendees: others}))
+	assert.Equal(t, 3, Headcount(types.Event{
+		Organizer: organizer,
+		Attendees: append(types.AttendeesEvent{{Email: "Me@mail.ru"}}, others...),
+	}))
+}
+
+func TestRoomsForAttendees(t *testing.T) {
+	rooms := []types.Room{
+		{Email: "big@corp.mail.ru", Capacity: 20},
+		{Email: "small@corp.mail.ru", Capacity: 4},
+		{Email: "medium@corp.mail.ru", Capacity: 8},
+	}
+
+	assert.Equal(t, []types.Room{rooms[2], rooms[0]}, RoomsForAttendees(rooms, 5))
+	assert.Equal(t, []types.Room{rooms[1], rooms[2], rooms[0]}, RoomsForAttendees(rooms, 1))
+	assert.Empty(t, RoomsForAttendees(rooms, 21))
+}
+
+func TestFreeRooms(t *testing.T) {
+	at := func(hour int) time.Time {
+		return time.Date(2021, 5, 4, hour, 0, 0, 0, time.UTC)
+	}
+	rooms := []types.Room{
+		{Email: "a@corp.mail.ru"},
+		{Email: "b@corp.mail.ru"},
+		{Email: "c@corp.mail.ru"},
+	}
+	busy := types.FreeBusyUser{FreeBusy: []types.FreeBusyIntervals{
+		{User: "a@corp.mail.ru", FreeBusy: []types.FromTo{{From: at(9), To: at(10)}}},
+		{User: "b@corp.mail.ru", FreeBusy: []types.FromTo{{From: at(10), To: at(12)}}},
+		{User: "c@corp.mail.ru"},
+	}}
+
+	assert.Equal(t, []types.Room{rooms[0], rooms[2]}, FreeRooms(rooms, busy, at(10), at(11)))
+	assert.Equal(t, rooms, FreeRooms(rooms, busy, at(12), at(13)))
+}
@@ -0,0 +1,128 @@
+package usecase
+
+import (
+	"github.com/calendar-bot/pkg/events/repository"
+	"github.com/calendar-bot/pkg/types"
+	"github.com/pkg/errors"
+)
+
+func (uc *EventUseCase) AddEventTemplate(template types.EventTemplate) (int64, error) {
+	id, err := uc.eventStorage.AddEventTemplate(template)
+	if err != nil {
+		return 0, errors.WithStack(err)
+	}
+	return id, nil
+}
+
+func (uc *EventUseCase) GetEventTemplates(telegramUserID int64) ([]types.EventTemplate, error) {
+	templates, err := uc.eventStorage.GetEventTemplates(telegramUserID)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return templates, nil
+}
+
+// GetEventTemplate returns nil if the user has no such template
+func (uc *EventUseCase) GetEventTemplate(telegramUserID int64, templateID int64) (*types.EventTemplate, error) {
+	template, err := uc.eventStorage.GetEventTemplate(telegramUserID, templateID)
+	switch {
+	case err == repository.EventTemplateDoesNotExist:
+		return nil, nil
+	case err != nil:
+		return nil, errors.WithStack(err)
+	}
+	return &template, nil
+}
+
+func (uc *EventUseCase) DeleteEventTemplate(telegramUserID int64, templateID int64) error {
+	return errors.WithStack(uc.eventStorage.DeleteEventTemplate(telegramUserID, templateID))
+}
+
+// TemplateFromEvent keeps everything of the event except its time and the organizer.
+// A call in the template means that a new call room is created for every event
+func TemplateFromEvent(event types.Event) types.EventTemplate {
+	template := types.EventTemplate{
+		Name:     event.Title,
+		Duration: event.To.Sub(event.From),
+		WithCall: event.Call != "",
+	}
+
+	if event.Title != "" {
+		title := event.Title
+		template.Event.Title = &title
+	}
+	if event.Description != "" {
+		description := event.Description
+		template.Event.Description = &description
+	}
+	if event.FullDay {
+		fullDay := true
+		template.Event.FullDay = &fullDay
+	}
+
+	if event.Location.Description != "" || len(event.Location.Confrooms) > 0 || event.Location.Geo.Latitude != "" {
+		location := &types.Location{
+			Description: event.Location.Description,
+			Confrooms:   event.Location.Confrooms,
+		}
+		if event.Location.Geo.Latitude != "" {
+			geo := event.Location.Geo
+			location.Geo = &geo
+		}
+		template.Event.Location = location
+	}
+
+	attendees := types.Attendees{}
+	for _, attendee := range event.Attendees {
+		if attendee.Email == event.Organizer.Email {
+			continue
+		}
+		attendees = append(attendees, types.Attendee{
+			Email: attendee.Email,
+			Role:  attendee.Role,
+		})
+	}
+	if len(attendees) > 0 {
+		template.Event.Attendees = &attendees
+	}
+
+	return template
+}
+
+// EventFromTemplate fills a new event with the template, the organizer, time and call are left to the caller
+func EventFromTemplate(template types.EventTemplate) types.Event {
+	input := template.Event
+	event := types.Event{}
+
+	if input.Title != nil {
+		event.Title = *input.Title
+	}
+	if input.Description != nil {
+		event.Description = *input.Description
+	}
+	if input.FullDay != nil {
+		event.FullDay = *input.FullDay
+	}
+	if input.Location != nil {
+		event.Location.Description = input.Location.Description
+		event.Location.Confrooms = input.Location.Confrooms
+		if input.Location.Geo != nil {
+			event.Location.Geo = *input.Location.Geo
+		}
+	}
+	if input.Attendees != nil {
+		for _, attendee := range *input.Attendees {
+			role := attendee.Role
+			if role == "" {
+				role = types.RoleRequired
+			}
+			event.Attendees = append(event.Attendees, types.AttendeeEvent{
+				Email:  attendee.Email,
+				Role:   role,
+				Status: types.StatusNeedsAction,
+			})
+		}
+	}
+
+	return event
+}
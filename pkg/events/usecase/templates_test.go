@@ -0,0 +1,66 @@
+package usecase
+
+import (
+	"github.com/calendar-bot/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestTemplateFromEvent(t *testing.T) {
+	from := time.Date(2021, 5, 4, 10, 0, 0, 0, time.UTC)
+	organizer := types.AttendeeEvent{Email: "me@mail.ru", Role: types.RoleRequired}
+	event := types.Event{
+		Title:     "1:1",
+		From:      from,
+		To:        from.Add(30 * time.Minute),
+		Call:      "https://calls.mail.ru/room/42",
+		Location:  types.LocationEvent{Confrooms: []string{"room@corp.mail.ru"}},
+		Organizer: organizer,
+		Attendees: types.AttendeesEvent{
+			organizer,
+			{Email: "alice@mail.ru", Role: types.RoleRequired, Status: types.StatusAccepted},
+		},
+	}
+
+	template := TemplateFromEvent(event)
+	assert.Equal(t, "1:1", template.Name)
+	assert.Equal(t, 30*time.Minute, template.Duration)
+	require.NotNil(t, template.Event.Title)
+	assert.Equal(t, "1:1", *template.Event.Title)
+	assert.Nil(t, template.Event.Description)
+	assert.Nil(t, template.Event.From)
+	assert.Nil(t, template.Event.To)
+	assert.True(t, template.WithCall)
+	assert.Nil(t, template.Event.Call)
+	require.NotNil(t, template.Event.Location)
+	assert.Equal(t, []string{"room@corp.mail.ru"}, template.Event.Location.Confrooms)
+	assert.Nil(t, template.Event.Location.Geo)
+	require.NotNil(t, template.Event.Attendees)
+	assert.Equal(t, types.Attendees{{Email: "alice@mail.ru", Role: types.RoleRequired}}, *template.Event.Attendees)
+}
+
+func TestEventFromTemplate(t *testing.T) {
+	title := "Retro"
+	fullDay := true
+	template := types.EventTemplate{
+		Event: types.EventInput{
+			Title:     &title,
+			FullDay:   &fullDay,
+			Location:  &types.Location{Description: "Office", Geo: &types.Geo{Latitude: "55.7", Longitude: "37.6"}},
+			Attendees: &types.Attendees{{Email: "bob@mail.ru"}},
+		},
+	}
+
+	event := EventFromTemplate(template)
+	assert.Equal(t, "Retro", event.Title)
+	assert.True(t, event.FullDay)
+	assert.True(t, event.From.IsZero())
+	assert.Equal(t, "", event.Call)
+	assert.Equal(t, "Office", event.Location.Description)
+	assert.Equal(t, types.Geo{Latitude: "55.7", Longitude: "37.6"}, event.Location.Geo)
+	assert.Equal(t, types.AttendeesEvent{
+		{Email: "bob@mail.ru", Role: types.RoleRequired, Status: types.StatusNeedsAction},
+	}, event.Attendees)
+}
@@ -0,0 +1,151 @@
+package usecase
+
+import (
+	"encoding/json"
+	"github.com/calendar-bot/pkg/events/repository"
+	"github.com/calendar-bot/pkg/types"
+	"github.com/pkg/errors"
+	"time"
+)
+
+const VacationEventTitle = "Out of office"
+
+// GetVacation returns nil if the user has no vacation set
+func (uc *EventUseCase) GetVacation(telegramUserID int64) (*types.Vacation, error) {
+	vacation, err := uc.eventStorage.GetVacation(telegramUserID)
+	switch {
+	case err == repository.VacationDoesNotExist:
+		return nil, nil
+	case err != nil:
+		return nil, errors.WithStack(err)
+	}
+	return &vacation, nil
+}
+
+// SetVacation replaces the user's vacation and creates a full-day out of office event for it
+func (uc *EventUseCase) SetVacation(accessToken string, vacation types.Vacation) error {
+	if err := uc.deleteVacationEvent(accessToken, vacation.TelegramUserID); err != nil {
+		return err
+	}
+
+	title := VacationEventTitle
+	description := vacation.Message
+	fullDay := true
+	from := vacation.From.Format(time.RFC3339)
+	to := vacation.To.Format(time.RFC3339)
+
+	resp, err := uc.CreateEvent(accessToken, types.EventInput{
+		Title:       &title,
+		Description: &description,
+		FullDay:     &fullDay,
+		From:        &from,
+		To:          &to,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to create out of office event")
+	}
+
+	created := types.CreateEventResp{}
+	if err := json.Unmarshal(resp, &created); err != nil {
+		return errors.Wrap(err, "failed to unmarshal created out of office event")
+	}
+	if created.Data.CreateEvent.Uid == "" {
+		return errors.Errorf("out of office event was not created, response: %s", resp)
+	}
+
+	vacation.EventUID = created.Data.CreateEvent.Uid
+	vacation.CalendarUID = created.Data.CreateEvent.Calendar.UID
+	return errors.WithStack(uc.eventStorage.UpsertVacation(vacation))
+}
+
+// CancelVacation removes the vacation and its out of office event
+func (uc *EventUseCase) CancelVacation(accessToken string, telegramUserID int64) error {
+	if err := uc.deleteVacationEvent(accessToken, telegramUserID); err != nil {
+		return err
+	}
+	return errors.WithStack(uc.eventStorage.DeleteVacation(telegramUserID))
+}
+
+func (uc *EventUseCase) deleteVacationEvent(accessToken string, telegramUserID int64) error {
+	previous, err := uc.GetVacation(telegramUserID)
+	if err != nil || previous == nil || previous.EventUID == "" {
+		return err
+	}
+	return errors.Wrap(uc.DeleteEvent(accessToken, previous.CalendarUID, previous.EventUID), "deleteVacationEvent")
+}
+
+func (uc *EventUseCase) GetActiveVacations(now time.Time) ([]types.Vacation, error) {
+	vacations, err := uc.eventStorage.GetActiveVacations(now)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return vacations, nil
+}
+
+func (uc *EventUseCase) GetVacationsOverlapping(telegramUserIDs []int64, emails []string, from time.Time,
+	to time.Time) ([]types.Vacation, error) {
+
+	vacations, err := uc.eventStorage.GetVacationsOverlapping(telegramUserIDs, emails, from, to)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return vacations, nil
+}
+
+// DeclineVacationInvitations declines invitations of the vacation owner which fall into the rest of the vacation
+func (uc *EventUseCase) DeclineVacationInvitations(accessToken string, vacation types.Vacation,
+	now time.Time) (declined int, err error) {
+
+	from := vacation.From
+	if now.After(from) {
+		from = now
+	}
+	if !from.Before(vacation.To) {
+		return 0, nil
+	}
+
+	response, err := uc.GetEventsByRange(accessToken, from, vacation.To)
+	if err != nil {
+		return 0, errors.Wrap(err, "DeclineVacationInvitations")
+	}
+	if response == nil {
+		return 0, nil
+	}
+
+	for _, event := range PendingInvitations(response.Data.Events, vacation.Email) {
+		_, err := uc.ChangeStatus(accessToken, types.ChangeStatus{
+			EventID:    event.Uid,
+			CalendarID: event.Calendar.UID,
+			Status:     types.StatusDeclined,
+		})
+		if err != nil {
+			return declined, errors.Wrapf(err, "failed to decline event uid=%s", event.Uid)
+		}
+		declined++
+	}
+	return declined, nil
+}
+
+// PendingInvitations returns events organized by others where the user has not answered yet,
+// each event only once
+func PendingInvitations(events types.Events, email string) types.Events {
+	invitations := make(types.Events, 0)
+	seen := make(map[string]struct{})
+	for _, event := range events {
+		if IsHoliday(event) || event.Organizer.Email == email {
+			continue
+		}
+		key := event.Calendar.UID + event.Uid
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		for _, attendee := range event.Attendees {
+			if attendee.Email == email && attendee.Status == types.StatusNeedsAction {
+				seen[key] = struct{}{}
+				invitations = append(invitations, event)
+				break
+			}
+		}
+	}
+	return invitations
+}
@@ -0,0 +1,42 @@
+package usecase
+
+import (
+	"github.com/calendar-bot/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestPendingInvitations(t *testing.T) {
+	const me = "me@mail.ru"
+	invited := func(uid string, status string) types.Event {
+		return types.Event{
+			Uid:       uid,
+			Calendar:  types.Calendar{UID: "work"},
+			Organizer: types.AttendeeEvent{Email: "boss@mail.ru"},
+			Attendees: types.AttendeesEvent{
+				{Email: "boss@mail.ru", Status: types.StatusAccepted},
+				{Email: me, Status: status},
+			},
+		}
+	}
+
+	own := invited("own", types.StatusNeedsAction)
+	own.Organizer.Email = me
+	holiday := invited("holiday", types.StatusNeedsAction)
+	holiday.Calendar.Type = types.CalendarTypeHoliday
+
+	events := types.Events{
+		invited("new", types.StatusNeedsAction),
+		invited("new", types.StatusNeedsAction),
+		invited("accepted", types.StatusAccepted),
+		own,
+		holiday,
+		{Uid: "other", Organizer: types.AttendeeEvent{Email: "boss@mail.ru"}},
+	}
+
+	declined := PendingInvitations(events, me)
+	if assert.Len(t, declined, 1) {
+		assert.Equal(t, "new", declined[0].Uid)
+	}
+	assert.Empty(t, PendingInvitations(nil, me))
+}
@@ -0,0 +1,170 @@
+package i18n
+
+import (
+	"fmt"
+	"sync"
+)
+
+// ID identifies the message in the catalogs of all languages
+type ID string
+
+// Plural holds the forms of the message for the plural categories of the language.
+// Russian uses One, Few and Many, English uses One and Other. Missing forms fall back to Other
+type Plural struct {
+	One   string
+	Few   string
+	Many  string
+	Other string
+}
+
+var catalogs = struct {
+	sync.RWMutex
+	texts   map[Lang]map[ID]string
+	plurals map[Lang]map[ID]Plural
+}{
+	texts:   make(map[Lang]map[ID]string),
+	plurals: make(map[Lang]map[ID]Plural),
+}
+
+// Register adds the messages of the language, message packages call it from init
+func Register(lang Lang, texts map[ID]string) {
+	catalogs.Lock()
+	defer catalogs.Unlock()
+	if catalogs.texts[lang] == nil {
+		catalogs.texts[lang] = make(map[ID]string)
+	}
+	for id, text := range texts {
+		catalogs.texts[lang][id] = text
+	}
+}
+
+// RegisterPlural adds the messages of the language which depend on a number
+func RegisterPlural(lang Lang, plurals map[ID]Plural) {
+	catalogs.Lock()
+	defer catalogs.Unlock()
+	if catalogs.plurals[lang] == nil {
+		catalogs.plurals[lang] = make(map[ID]Plural)
+	}
+	for id, plural := range plurals {
+		catalogs.plurals[lang][id] = plural
+	}
+}
+
+// T returns the message formatted with the args. Messages missing in the language are taken
+// from the default one, unknown messages are returned as their ID
+func (l Lang) T(id ID, args ...interface{}) string {
+	text, ok := l.lookup(id)
+	if !ok {
+		text = string(id)
+	}
+	if len(args) == 0 {
+		return text
+	}
+	return fmt.Sprintf(text, args...)
+}
+
+// N returns the form of the message for the number n formatted with the args,
+// the message gets n as the only argument when the args are omitted
+func (l Lang) N(id ID, n int, args ...interface{}) string {
+	plural, ok := l.lookupPlural(id)
+	if !ok {
+		return l.T(id, n)
+	}
+	text := plural.Other
+	switch form := l.pluralForm(n); {
+	case form == formOne && plural.One != "":
+		text = plural.One
+	case form == formFew && plural.Few != "":
+		text = plural.Few
+	case form == formMany && plural.Many != "":
+		text = plural.Many
+	}
+	if len(args) == 0 {
+		args = []interface{}{n}
+	}
+	return fmt.Sprintf(text, args...)
+}
+
+// Match returns the ID of the message which reads as the text in the language,
+// it maps the text of the pressed reply button back to the message
+func (l Lang) Match(text string, ids ...ID) (ID, bool) {
+	for _, id := range ids {
+		if l.T(id) == text {
+			return id, true
+		}
+	}
+	return "", false
+}
+
+// Find is Match over all the languages, the user may have switched the language
+// after the keyboard was sent
+func Find(text string, ids ...ID) (ID, bool) {
+	for _, lang := range Langs {
+		if id, ok := lang.Match(text, ids...); ok {
+			return id, true
+		}
+	}
+	return "", false
+}
+
+// Is reports whether the text is the message in any of the languages
+func Is(text string, id ID) bool {
+	for _, lang := range Langs {
+		if lang.T(id) == text {
+			return true
+		}
+	}
+	return false
+}
+
+// All returns the message in every language, e.g. to register the handler of a reply button
+func All(id ID) []string {
+	texts := make([]string, 0, len(Langs))
+	seen := make(map[string]bool, len(Langs))
+	for _, lang := range Langs {
+		text := lang.T(id)
+		if !seen[text] {
+			seen[text] = true
+			texts = append(texts, text)
+		}
+	}
+	return texts
+}
+
+func (l Lang) lookup(id ID) (string, bool) {
+	catalogs.RLock()
+	defer catalogs.RUnlock()
+	if text, ok := catalogs.texts[l][id]; ok {
+		return text, true
+	}
+	text, ok := catalogs.texts[Default][id]
+	return text, ok
+}
+
+func (l Lang) lookupPlural(id ID) (Plural, bool) {
+	catalogs.RLock()
+	defer catalogs.RUnlock()
+	if plural, ok := catalogs.plurals[l][id]; ok {
+		return plural, true
+	}
+	plural, ok := catalogs.plurals[Default][id]
+	return plural, ok
+}
+
+// Missing lists the messages of the default language which are not translated to the language
+func Missing(lang Lang) []ID {
+	catalogs.RLock()
+	defer catalogs.RUnlock()
+	var missing []ID
+	for id := range catalogs.texts[Default] {
+		if _, ok := catalogs.texts[lang][id]; !ok {
+			missing = append(missing, id)
+		}
+	}
+	for id := range catalogs.plurals[Default] {
+		if _, ok := catalogs.plurals[lang][id]; !ok {
+			missing = append(missing, id)
+		}
+	}
+	return missing
+}
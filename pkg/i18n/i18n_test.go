@@ -0,0 +1,99 @@
+package i18n
+
+import (
+	"github.com/goodsign/monday"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+const (
+	testGreeting ID = "test.greeting"
+	testOnlyRu   ID = "test.only_ru"
+	testMinutes  ID = "test.minutes"
+)
+
+func init() {
+	Register(Ru, map[ID]string{
+		testGreeting: "Привет, %s",
+		testOnlyRu:   "Только по-русски",
+	})
+	Register(En, map[ID]string{
+		testGreeting: "Hello, %s",
+	})
+	RegisterPlural(Ru, map[ID]Plural{
+		testMinutes: {One: "%d минута", Few: "%d минуты", Many: "%d минут"},
+	})
+	RegisterPlural(En, map[ID]Plural{
+		testMinutes: {One: "%d minute", Other: "%d minutes"},
+	})
+}
+
+func TestFromLanguageCode(t *testing.T) {
+	assert.Equal(t, Ru, FromLanguageCode(""))
+	assert.Equal(t, Ru, FromLanguageCode("ru"))
+	assert.Equal(t, Ru, FromLanguageCode("uk-UA"))
+	assert.Equal(t, En, FromLanguageCode("en"))
+	assert.Equal(t, En, FromLanguageCode("en-GB"))
+	assert.Equal(t, En, FromLanguageCode("de"))
+}
+
+func TestParse(t *testing.T) {
+	lang, ok := Parse("en")
+	assert.True(t, ok)
+	assert.Equal(t, En, lang)
+
+	_, ok = Parse("de")
+	assert.False(t, ok)
+}
+
+func TestT(t *testing.T) {
+	assert.Equal(t, "Привет, Вася", Ru.T(testGreeting, "Вася"))
+	assert.Equal(t, "Hello, John", En.T(testGreeting, "John"))
+	assert.Equal(t, "Только по-русски", En.T(testOnlyRu))
+	assert.Equal(t, "test.unknown", En.T("test.unknown"))
+}
+
+func TestN(t *testing.T) {
+	cases := map[int]string{
+		1:   "1 минута",
+		2:   "2 минуты",
+		5:   "5 минут",
+		11:  "11 минут",
+		12:  "12 минут",
+		21:  "21 минута",
+		22:  "22 минуты",
+		111: "111 минут",
+		0:   "0 минут",
+	}
+	for n, expected := range cases {
+		assert.Equal(t, expected, Ru.N(testMinutes, n))
+	}
+
+	assert.Equal(t, "1 minute", En.N(testMinutes, 1))
+	assert.Equal(t, "21 minutes", En.N(testMinutes, 21))
+}
+
+func TestMatch(t *testing.T) {
+	id, ok := En.Match("Hello, %s", testOnlyRu, testGreeting)
+	assert.True(t, ok)
+	assert.Equal(t, testGreeting, id)
+
+	_, ok = En.Match("Привет, %s", testGreeting)
+	assert.False(t, ok)
+
+	id, ok = Find("Привет, %s", testOnlyRu, testGreeting)
+	assert.True(t, ok)
+	assert.Equal(t, testGreeting, id)
+
+	assert.True(t, Is("Привет, %s", testGreeting))
+	assert.True(t, Is("Hello, %s", testGreeting))
+	assert.False(t, Is("Hi", testGreeting))
+
+	assert.Equal(t, []string{"Привет, %s", "Hello, %s"}, All(testGreeting))
+	assert.Equal(t, []string{"Только по-русски"}, All(testOnlyRu))
+}
+
+func TestMondayLocale(t *testing.T) {
+	assert.Equal(t, monday.Locale(monday.LocaleRuRU), Ru.MondayLocale())
+	assert.Equal(t, monday.Locale(monday.LocaleEnUS), En.MondayLocale())
+}
@@ -0,0 +1,54 @@
+package i18n
+
+import (
+	"github.com/goodsign/monday"
+	"strings"
+)
+
+// Lang is the language of the bot messages
+type Lang string
+
+const (
+	Ru Lang = "ru"
+	En Lang = "en"
+
+	// Default is used for unknown users and for group chats
+	Default = Ru
+)
+
+// Langs are the supported languages in the order they are offered to the user
+var Langs = []Lang{Ru, En}
+
+// russianSpeaking are the languages of the Telegram clients whose users are expected to read Russian
+var russianSpeaking = map[string]bool{"ru": true, "be": true, "uk": true, "kk": true}
+
+// FromLanguageCode picks the language for the IETF language tag of the Telegram client,
+// the clients without the tag get the default one
+func FromLanguageCode(code string) Lang {
+	code = strings.ToLower(code)
+	if idx := strings.IndexAny(code, "-_"); idx >= 0 {
+		code = code[:idx]
+	}
+	if code == "" || russianSpeaking[code] {
+		return Default
+	}
+	return En
+}
+
+// Parse returns the supported language by its code
+func Parse(code string) (Lang, bool) {
+	for _, lang := range Langs {
+		if string(lang) == code {
+			return lang, true
+		}
+	}
+	return "", false
+}
+
+// MondayLocale is the locale to format the names of months and weekdays
+func (l Lang) MondayLocale() monday.Locale {
+	if l == En {
+		return monday.LocaleEnUS
+	}
+	return monday.LocaleRuRU
+}
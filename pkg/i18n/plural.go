@@ -0,0 +1,33 @@
+package i18n
+
+type pluralForm int
+
+const (
+	formOne pluralForm = iota
+	formFew
+	formMany
+	formOther
+)
+
+// pluralForm follows the CLDR plural rules for integers
+func (l Lang) pluralForm(n int) pluralForm {
+	if n < 0 {
+		n = -n
+	}
+	if l != Ru {
+		if n == 1 {
+			return formOne
+		}
+		return formOther
+	}
+
+	mod10, mod100 := n%10, n%100
+	switch {
+	case mod10 == 1 && mod100 != 11:
+		return formOne
+	case mod10 >= 2 && mod10 <= 4 && (mod100 < 12 || mod100 > 14):
+		return formFew
+	default:
+		return formMany
+	}
+}
@@ -0,0 +1,89 @@
+package jobs
+
+import (
+	"context"
+	"go.uber.org/zap"
+	"time"
+)
+
+// instances which do not hold the lock check this often whether they can take the job over
+const exclusiveCheckInterval = time.Minute
+
+// Lock is held by at most one instance of the bot at a time
+type Lock interface {
+	Hold(ctx context.Context) (bool, error)
+	Release() error
+}
+
+type exclusiveJob struct {
+	name          string
+	job           Job
+	lock          Lock
+	checkInterval time.Duration
+}
+
+// Exclusive runs the job only on the instance holding the lock, so replicas do not repeat the work of each other.
+// The other instances take the job over when the holder dies
+func Exclusive(name string, job Job, lock Lock) Job {
+	return &exclusiveJob{
+		name:          name,
+		job:           job,
+		lock:          lock,
+		checkInterval: exclusiveCheckInterval,
+	}
+}
+
+func (j *exclusiveJob) Run(ctx context.Context) {
+	defer func() {
+		if err := j.lock.Release(); err != nil {
+			zap.S().Errorf("%s: %v", j.name, err)
+		}
+	}()
+
+	var stop func()
+	defer func() {
+		if stop != nil {
+			stop()
+		}
+	}()
+
+	ticker := time.NewTicker(j.checkInterval)
+	defer ticker.Stop()
+	for {
+		held, err := j.lock.Hold(ctx)
+		if err != nil {
+			zap.S().Errorf("%s: %v", j.name, err)
+		}
+
+		switch {
+		case held && stop == nil:
+			stop = j.start(ctx)
+			zap.S().Infof("%s: started on this instance", j.name)
+		case !held && stop != nil:
+			stop()
+			stop = nil
+			zap.S().Infof("%s: lock is lost, stopped on this instance", j.name)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// start runs the job until the returned stop is called, stop waits for the job to return
+func (j *exclusiveJob) start(ctx context.Context) (stop func()) {
+	jobCtx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		j.job.Run(jobCtx)
+	}()
+
+	return func() {
+		cancel()
+		<-done
+	}
+}
@@ -0,0 +1,65 @@
+package jobs
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type fakeLock struct {
+	free     int32
+	released int32
+}
+
+func (l *fakeLock) Hold(context.Context) (bool, error) {
+	return atomic.LoadInt32(&l.free) == 1, nil
+}
+
+func (l *fakeLock) Release() error {
+	atomic.StoreInt32(&l.released, 1)
+	return nil
+}
+
+type blockingJob struct {
+	running int32
+}
+
+func (j *blockingJob) Run(ctx context.Context) {
+	atomic.StoreInt32(&j.running, 1)
+	<-ctx.Done()
+	atomic.StoreInt32(&j.running, 0)
+}
+
+func TestExclusiveJob(t *testing.T) {
+	lock := &fakeLock{}
+	job := &blockingJob{}
+	exclusive := &exclusiveJob{name: "test job", job: job, lock: lock, checkInterval: time.Millisecond}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		exclusive.Run(ctx)
+		close(done)
+	}()
+
+	isRunning := func() bool { return atomic.LoadInt32(&job.running) == 1 }
+
+	time.Sleep(20 * time.Millisecond)
+	assert.False(t, isRunning(), "the job must not run while another instance holds the lock")
+
+	atomic.StoreInt32(&lock.free, 1)
+	assert.Eventually(t, isRunning, time.Second, time.Millisecond)
+
+	atomic.StoreInt32(&lock.free, 0)
+	assert.Eventually(t, func() bool { return !isRunning() }, time.Second, time.Millisecond)
+
+	atomic.StoreInt32(&lock.free, 1)
+	assert.Eventually(t, isRunning, time.Second, time.Millisecond)
+
+	cancel()
+	<-done
+	assert.False(t, isRunning())
+	assert.Equal(t, int32(1), atomic.LoadInt32(&lock.released))
+}
@@ -0,0 +1,86 @@
+package jobs
+
+import (
+	"context"
+	"github.com/calendar-bot/pkg/bots/telegram/messages/calendarMessages"
+	eUseCase "github.com/calendar-bot/pkg/events/usecase"
+	"github.com/calendar-bot/pkg/types"
+	uUseCase "github.com/calendar-bot/pkg/users/usecase"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"time"
+)
+
+// focus blocks are planned before the working day starts, at this hour in the timezone of every user
+const focusTimeJobHour = 7
+
+// focusTimeJobInterval is an hour, so the job runs once within focusTimeJobHour of every timezone
+const focusTimeJobInterval = time.Hour
+
+type FocusTimeJob struct {
+	eventUseCase eUseCase.EventUseCase
+	userUseCase  uUseCase.UserUseCase
+	languages    UserLanguages
+}
+
+func NewFocusTimeJob(eventUseCase eUseCase.EventUseCase, userUseCase uUseCase.UserUseCase,
+	languages UserLanguages) *FocusTimeJob {
+
+	return &FocusTimeJob{
+		eventUseCase: eventUseCase,
+		userUseCase:  userUseCase,
+		languages:    languages,
+	}
+}
+
+func (j *FocusTimeJob) Run(ctx context.Context) {
+	runEvery(ctx, focusTimeJobInterval, j.RunOnce)
+}
+
+// RunOnce plans focus time for every user with an enabled rule whose local time is focusTimeJobHour,
+// errors of one user do not stop the others
+func (j *FocusTimeJob) RunOnce(now time.Time) {
+	rules, err := j.eventUseCase.GetEnabledFocusRules()
+	if err != nil {
+		zap.S().Errorf("focus time job: failed to get rules: %v", err)
+		return
+	}
+
+	for _, rule := range rules {
+		created, err := j.planForUser(rule, now)
+		if err != nil {
+			zap.S().Errorf("focus time job: telegramUserID=%d: %v", rule.TelegramUserID, err)
+			continue
+		}
+		if created > 0 {
+			zap.S().Infof("focus time job: created %d focus blocks for telegramUserID=%d", created, rule.TelegramUserID)
+		}
+	}
+}
+
+func (j *FocusTimeJob) planForUser(rule types.FocusRule, now time.Time) (int, error) {
+	location, err := j.userUseCase.GetUserLocation(rule.TelegramUserID)
+	if err != nil {
+		return 0, errors.WithStack(err)
+	}
+	now = now.In(location)
+	if !isFocusPlanningTime(now) {
+		return 0, nil
+	}
+
+	token, err := j.userUseCase.GetOrRefreshOAuthAccessTokenByTelegramUserID(rule.TelegramUserID)
+	if err != nil {
+		return 0, errors.WithStack(err)
+	}
+	email, err := j.userUseCase.GetUserEmailByTelegramUserID(rule.TelegramUserID)
+	if err != nil {
+		return 0, errors.WithStack(err)
+	}
+	description := j.languages.UserLang(rule.TelegramUserID).T(calendarMessages.FocusEventDescription)
+	return j.eventUseCase.PlanFocusTime(token, email, rule, description, now)
+}
+
+// isFocusPlanningTime reports whether the focus blocks of the day are planned at the local time of the user
+func isFocusPlanningTime(localNow time.Time) bool {
+	return localNow.Hour() == focusTimeJobHour
+}
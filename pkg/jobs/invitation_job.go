@@ -0,0 +1,135 @@
+package jobs
+
+import (
+	"context"
+	"github.com/calendar-bot/pkg/bots/telegram/messages/calendarMessages"
+	eUseCase "github.com/calendar-bot/pkg/events/usecase"
+	"github.com/calendar-bot/pkg/i18n"
+	"github.com/calendar-bot/pkg/types"
+	uUseCase "github.com/calendar-bot/pkg/users/usecase"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	tb "gopkg.in/tucnak/telebot.v2"
+	"time"
+)
+
+// new invitations are answered with at most this delay
+const invitationSyncInterval = 10 * time.Minute
+
+// UserLanguages tells the language the user has chosen for the bot messages
+type UserLanguages interface {
+	UserLang(telegramUserID int64) i18n.Lang
+}
+
+// InvitationSyncJob declines invitations of users on vacation and answers invitations by the users' rules
+type InvitationSyncJob struct {
+	eventUseCase eUseCase.EventUseCase
+	userUseCase  uUseCase.UserUseCase
+	bot          *tb.Bot
+	languages    UserLanguages
+}
+
+func NewInvitationSyncJob(eventUseCase eUseCase.EventUseCase, userUseCase uUseCase.UserUseCase,
+	bot *tb.Bot, languages UserLanguages) *InvitationSyncJob {
+
+	return &InvitationSyncJob{
+		eventUseCase: eventUseCase,
+		userUseCase:  userUseCase,
+		bot:          bot,
+		languages:    languages,
+	}
+}
+
+func (j *InvitationSyncJob) Run(ctx context.Context) {
+	runEvery(ctx, invitationSyncInterval, j.RunOnce)
+}
+
+// RunOnce syncs invitations of every user, errors of one user do not stop the others.
+// Vacations go first so that rules do not accept invitations for the vacation days
+func (j *InvitationSyncJob) RunOnce(now time.Time) {
+	j.syncVacations(now)
+	j.syncRules(now)
+}
+
+func (j *InvitationSyncJob) syncVacations(now time.Time) {
+	vacations, err := j.eventUseCase.GetActiveVacations(now)
+	if err != nil {
+		zap.S().Errorf("invitation sync job: failed to get vacations: %v", err)
+		return
+	}
+
+	for _, vacation := range vacations {
+		declined, err := j.declineForUser(vacation, now)
+		if err != nil {
+			zap.S().Errorf("invitation sync job: vacation of telegramUserID=%d: %v", vacation.TelegramUserID, err)
+			continue
+		}
+		if declined > 0 {
+			zap.S().Infof("invitation sync job: declined %d invitations for telegramUserID=%d",
+				declined, vacation.TelegramUserID)
+		}
+	}
+}
+
+func (j *InvitationSyncJob) declineForUser(vacation types.Vacation, now time.Time) (int, error) {
+	token, err := j.userUseCase.GetOrRefreshOAuthAccessTokenByTelegramUserID(vacation.TelegramUserID)
+	if err != nil {
+		return 0, errors.WithStack(err)
+	}
+	return j.eventUseCase.DeclineVacationInvitations(token, vacation, now)
+}
+
+func (j *InvitationSyncJob) syncRules(now time.Time) {
+	rules, err := j.eventUseCase.GetAllInvitationRules()
+	if err != nil {
+		zap.S().Errorf("invitation sync job: failed to get rules: %v", err)
+		return
+	}
+
+	for telegramUserID, userRules := range groupRulesByUser(rules) {
+		results, err := j.applyRulesForUser(telegramUserID, userRules, now)
+		if len(results) > 0 {
+			j.report(telegramUserID, results)
+		}
+		if err != nil {
+			zap.S().Errorf("invitation sync job: rules of telegramUserID=%d: %v", telegramUserID, err)
+		}
+	}
+}
+
+func (j *InvitationSyncJob) applyRulesForUser(telegramUserID int64, rules []types.InvitationRule,
+	now time.Time) ([]types.InvitationRuleResult, error) {
+
+	token, err := j.userUseCase.GetOrRefreshOAuthAccessTokenByTelegramUserID(telegramUserID)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	email, err := j.userUseCase.GetUserEmailByTelegramUserID(telegramUserID)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	location, err := j.userUseCase.GetUserLocation(telegramUserID)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return j.eventUseCase.ApplyInvitationRules(token, email, rules, location, now)
+}
+
+// report sends the answered invitations to the private chat with the user
+func (j *InvitationSyncJob) report(telegramUserID int64, results []types.InvitationRuleResult) {
+	lang := j.languages.UserLang(telegramUserID)
+	_, err := j.bot.Send(&tb.User{ID: int(telegramUserID)}, calendarMessages.GetInvitationRulesReport(lang, results),
+		&tb.SendOptions{ParseMode: tb.ModeHTML})
+	if err != nil {
+		zap.S().Errorf("invitation sync job: failed to report to telegramUserID=%d: %v", telegramUserID, err)
+	}
+}
+
+// groupRulesByUser keeps the order of rules of every user
+func groupRulesByUser(rules []types.InvitationRule) map[int64][]types.InvitationRule {
+	grouped := make(map[int64][]types.InvitationRule)
+	for _, rule := range rules {
+		grouped[rule.TelegramUserID] = append(grouped[rule.TelegramUserID], rule)
+	}
+	return grouped
+}
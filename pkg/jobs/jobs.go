@@ -0,0 +1,27 @@
+package jobs
+
+import (
+	"context"
+	"time"
+)
+
+// Job is a background task started together with the bot
+type Job interface {
+	Run(ctx context.Context)
+}
+
+// runEvery calls f right away and then every interval until ctx is done
+func runEvery(ctx context.Context, interval time.Duration, f func(now time.Time)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	f(time.Now())
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			f(now)
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package jobs
+
+import (
+	"github.com/calendar-bot/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestIsFocusPlanningTime(t *testing.T) {
+	moscow, err := time.LoadLocation("Europe/Moscow")
+	require.NoError(t, err)
+
+	now := time.Date(2021, 5, 4, 4, 30, 0, 0, time.UTC)
+	assert.False(t, isFocusPlanningTime(now))
+	assert.True(t, isFocusPlanningTime(now.In(moscow)))
+	assert.False(t, isFocusPlanningTime(now.Add(time.Hour).In(moscow)))
+}
+
+func TestGroupRulesByUser(t *testing.T) {
+	rules := []types.InvitationRule{
+		{ID: 1, TelegramUserID: 10},
+		{ID: 2, TelegramUserID: 20},
+		{ID: 3, TelegramUserID: 10},
+	}
+	assert.Equal(t, map[int64][]types.InvitationRule{
+		10: {rules[0], rules[2]},
+		20: {rules[1]},
+	}, groupRulesByUser(rules))
+}
+
+func TestIsReminderDue(t *testing.T) {
+	from := time.Date(2021, 5, 4, 9, 44, 0, 0, time.UTC)
+	to := from.Add(time.Minute)
+	event := types.Event{From: time.Date(2021, 5, 4, 10, 0, 0, 0, time.UTC)}
+
+	assert.True(t, isReminderDue(event, 15*time.Minute, from, to))
+	assert.False(t, isReminderDue(event, 16*time.Minute, from, to))
+	assert.False(t, isReminderDue(event, 14*time.Minute, from, to))
+
+	event.FullDay = true
+	assert.False(t, isReminderDue(event, 15*time.Minute, from, to))
+}
+
+func TestDueDigestDay(t *testing.T) {
+	location, err := time.LoadLocation("Asia/Yekaterinburg")
+	assert.NoError(t, err)
+	day := time.Date(2021, 5, 4, 0, 0, 0, 0, location)
+
+	from := time.Date(2021, 5, 4, 8, 59, 30, 0, location)
+	got, ok := dueDigestDay(9*time.Hour, from, from.Add(time.Minute))
+	assert.True(t, ok)
+	assert.Equal(t, day, got)
+
+	_, ok = dueDigestDay(9*time.Hour, from.Add(time.Minute), from.Add(2*time.Minute))
+	assert.False(t, ok)
+	_, ok = dueDigestDay(0, from, from.Add(time.Minute))
+	assert.False(t, ok)
+
+	// the run after midnight sends the digest of the previous day
+	lateFrom := time.Date(2021, 5, 4, 23, 58, 30, 0, location)
+	got, ok = dueDigestDay(23*time.Hour+59*time.Minute, lateFrom, lateFrom.Add(2*time.Minute))
+	assert.True(t, ok)
+	assert.Equal(t, day, got)
+}
@@ -0,0 +1,129 @@
+package jobs
+
+import (
+	"context"
+	eUseCase "github.com/calendar-bot/pkg/events/usecase"
+	"github.com/calendar-bot/pkg/types"
+	uUseCase "github.com/calendar-bot/pkg/users/usecase"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"time"
+)
+
+// reminders and digests are sent with at most this delay
+const notificationInterval = time.Minute
+
+// ScheduleNotifier sends the reminders and the daily digests chosen in the user settings
+type ScheduleNotifier interface {
+	SendReminder(settings types.UserSettings, event types.Event)
+	SendDigest(settings types.UserSettings, day time.Time, events types.Events)
+}
+
+// NotificationJob reminds about the upcoming events and sends the schedule of the day
+type NotificationJob struct {
+	eventUseCase eUseCase.EventUseCase
+	userUseCase  uUseCase.UserUseCase
+	notifier     ScheduleNotifier
+	lastRun      time.Time
+}
+
+func NewNotificationJob(eventUseCase eUseCase.EventUseCase, userUseCase uUseCase.UserUseCase,
+	notifier ScheduleNotifier) *NotificationJob {
+
+	return &NotificationJob{
+		eventUseCase: eventUseCase,
+		userUseCase:  userUseCase,
+		notifier:     notifier,
+	}
+}
+
+func (j *NotificationJob) Run(ctx context.Context) {
+	runEvery(ctx, notificationInterval, j.RunOnce)
+}
+
+// RunOnce sends the notifications due since the previous run, errors of one user do not stop the others
+func (j *NotificationJob) RunOnce(now time.Time) {
+	from := j.lastRun
+	if from.IsZero() {
+		from = now.Add(-notificationInterval)
+	}
+	j.lastRun = now
+
+	settings, err := j.userUseCase.GetNotifiedUserSettings()
+	if err != nil {
+		zap.S().Errorf("notification job: failed to get settings: %v", err)
+		return
+	}
+
+	for _, userSettings := range settings {
+		if err := j.notifyUser(userSettings, from, now); err != nil {
+			zap.S().Errorf("notification job: telegramUserID=%d: %v", userSettings.TelegramUserID, err)
+		}
+	}
+}
+
+func (j *NotificationJob) notifyUser(settings types.UserSettings, from time.Time, to time.Time) error {
+	location, err := time.LoadLocation(settings.Timezone)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	digestDay, digestIsDue := dueDigestDay(settings.DigestTime, from.In(location), to.In(location))
+	if settings.ReminderLeadTime == 0 && !digestIsDue {
+		return nil
+	}
+
+	token, err := j.userUseCase.GetOrRefreshOAuthAccessTokenByTelegramUserID(settings.TelegramUserID)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	if settings.ReminderLeadTime > 0 {
+		events, err := j.eventUseCase.GetEventsByRange(token, from.Add(settings.ReminderLeadTime),
+			to.Add(settings.ReminderLeadTime))
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		if events != nil {
+			for _, event := range events.Data.Events {
+				if isReminderDue(event, settings.ReminderLeadTime, from, to) {
+					j.notifier.SendReminder(settings, event)
+				}
+			}
+		}
+	}
+
+	if digestIsDue {
+		events, err := j.eventUseCase.GetEventsByRange(token, digestDay, digestDay.AddDate(0, 0, 1))
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		if events != nil && len(events.Data.Events) > 0 {
+			j.notifier.SendDigest(settings, digestDay, events.Data.Events)
+		}
+	}
+	return nil
+}
+
+// isReminderDue reports whether the reminder about the event falls in (from, to], all day events are not reminded
+func isReminderDue(event types.Event, leadTime time.Duration, from time.Time, to time.Time) bool {
+	remindAt := event.From.Add(-leadTime)
+	return !event.FullDay && remindAt.After(from) && !remindAt.After(to)
+}
+
+// dueDigestDay returns the start of the day whose digest time falls in (from, to].
+// The times are in the timezone of the user, zero digestTime means the digest is off
+func dueDigestDay(digestTime time.Duration, from time.Time, to time.Time) (time.Time, bool) {
+	if digestTime == 0 {
+		return time.Time{}, false
+	}
+
+	first := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, from.Location())
+	last := time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, to.Location())
+	for day := last; !day.Before(first); day = day.AddDate(0, 0, -1) {
+		digestAt := day.Add(digestTime)
+		if digestAt.After(from) && !digestAt.After(to) {
+			return day, true
+		}
+	}
+	return time.Time{}, false
+}
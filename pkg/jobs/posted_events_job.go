@@ -0,0 +1,89 @@
+package jobs
+
+import (
+	"context"
+	eUseCase "github.com/calendar-bot/pkg/events/usecase"
+	"github.com/calendar-bot/pkg/types"
+	uUseCase "github.com/calendar-bot/pkg/users/usecase"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"time"
+)
+
+const (
+	// changes made in the calendar appear in the posted messages with at most this delay
+	postedEventsSyncInterval = 5 * time.Minute
+	// messages of ended events are kept in sync for a while for the late answers
+	postedEventsKeepAfterEnd = 24 * time.Hour
+)
+
+// PostedMessagesEditor re-renders messages with event cards posted by the bot
+type PostedMessagesEditor interface {
+	EditPostedMessages(event *types.Event, posted []types.PostedMessage)
+	CancelPostedMessages(posted []types.PostedMessage)
+}
+
+// PostedEventsSyncJob keeps the posted event cards in sync with the changes made in the calendar
+type PostedEventsSyncJob struct {
+	eventUseCase eUseCase.EventUseCase
+	userUseCase  uUseCase.UserUseCase
+	editor       PostedMessagesEditor
+}
+
+func NewPostedEventsSyncJob(eventUseCase eUseCase.EventUseCase, userUseCase uUseCase.UserUseCase,
+	editor PostedMessagesEditor) *PostedEventsSyncJob {
+
+	return &PostedEventsSyncJob{
+		eventUseCase: eventUseCase,
+		userUseCase:  userUseCase,
+		editor:       editor,
+	}
+}
+
+func (j *PostedEventsSyncJob) Run(ctx context.Context) {
+	runEvery(ctx, postedEventsSyncInterval, j.RunOnce)
+}
+
+// RunOnce re-reads every event with posted messages, errors of one event do not stop the others
+func (j *PostedEventsSyncJob) RunOnce(now time.Time) {
+	since := now.Add(-postedEventsKeepAfterEnd)
+	deleted, err := j.eventUseCase.DeleteEndedPostedMessages(since)
+	if err != nil {
+		zap.S().Errorf("posted events sync job: failed to delete ended messages: %v", err)
+	} else if deleted > 0 {
+		zap.S().Infof("posted events sync job: stopped tracking %d messages of ended events", deleted)
+	}
+
+	posted, err := j.eventUseCase.GetActivePostedMessages(since)
+	if err != nil {
+		zap.S().Errorf("posted events sync job: failed to get messages: %v", err)
+		return
+	}
+
+	for _, group := range eUseCase.GroupPostedMessagesByEvent(posted) {
+		if err := j.syncEvent(group); err != nil {
+			zap.S().Errorf("posted events sync job: event=%s telegramUserID=%d: %v",
+				group[0].EventUID, group[0].TelegramUserID, err)
+		}
+	}
+}
+
+// syncEvent edits the copies of one event, the copies of a deleted event are marked cancelled
+func (j *PostedEventsSyncJob) syncEvent(posted []types.PostedMessage) error {
+	first := posted[0]
+	token, err := j.userUseCase.GetOrRefreshOAuthAccessTokenByTelegramUserID(first.TelegramUserID)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	resp, err := j.eventUseCase.GetEventByEventID(token, first.CalendarUID, first.EventUID)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	if resp == nil || resp.Data.Event.Uid == "" {
+		j.editor.CancelPostedMessages(posted)
+		return nil
+	}
+	j.editor.EditPostedMessages(&resp.Data.Event, posted)
+	return nil
+}
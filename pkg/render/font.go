@@ -0,0 +1,108 @@
+package render
+
+import (
+	_ "embed"
+	"encoding/hex"
+	"github.com/pkg/errors"
+	"image"
+	"image/color"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	glyphWidth  = 8
+	glyphHeight = 16
+
+	fallbackGlyph = '?'
+	ellipsis      = "…"
+)
+
+//go:embed fonts/dejavu_sans_mono_8x16.hex
+var defaultFontHex string
+
+var defaultFont = mustParseHexFont(defaultFontHex)
+
+// bitmapFont is a monospace 8x16 font, each glyph row is a byte with the leftmost pixel in the high bit
+type bitmapFont struct {
+	glyphs map[rune][glyphHeight]byte
+}
+
+func mustParseHexFont(data string) *bitmapFont {
+	font, err := parseHexFont(data)
+	if err != nil {
+		panic(err)
+	}
+	return font
+}
+
+// parseHexFont reads fonts in the GNU Unifont .hex format, lines starting with # are comments
+func parseHexFont(data string) (*bitmapFont, error) {
+	font := &bitmapFont{glyphs: make(map[rune][glyphHeight]byte)}
+	for i, line := range strings.Split(data, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		parts := strings.SplitN(line, ":", 2)
+		if len(parts) != 2 || len(parts[1]) != 2*glyphHeight {
+			return nil, errors.Errorf("bad glyph definition at line %d", i+1)
+		}
+
+		codePoint, err := strconv.ParseUint(parts[0], 16, 32)
+		if err != nil {
+			return nil, errors.Wrapf(err, "bad code point at line %d", i+1)
+		}
+		rows, err := hex.DecodeString(parts[1])
+		if err != nil {
+			return nil, errors.Wrapf(err, "bad glyph bitmap at line %d", i+1)
+		}
+
+		var glyph [glyphHeight]byte
+		copy(glyph[:], rows)
+		font.glyphs[rune(codePoint)] = glyph
+	}
+	return font, nil
+}
+
+func (f *bitmapFont) glyph(r rune) [glyphHeight]byte {
+	if glyph, ok := f.glyphs[r]; ok {
+		return glyph
+	}
+	return f.glyphs[fallbackGlyph]
+}
+
+// drawText draws s with the top left corner at (x, y), every font pixel becomes a scale x scale square
+func drawText(dst *image.RGBA, x, y int, s string, c color.Color, scale int) {
+	for _, r := range s {
+		glyph := defaultFont.glyph(r)
+		for row := 0; row < glyphHeight; row++ {
+			for col := 0; col < glyphWidth; col++ {
+				if glyph[row]&(0x80>>uint(col)) == 0 {
+					continue
+				}
+				fillRect(dst, image.Rect(x+col*scale, y+row*scale, x+(col+1)*scale, y+(row+1)*scale), c)
+			}
+		}
+		x += glyphWidth * scale
+	}
+}
+
+func textWidth(s string, scale int) int {
+	return utf8.RuneCountInString(s) * glyphWidth * scale
+}
+
+// fitText cuts s with an ellipsis so that it fits into maxWidth pixels
+func fitText(s string, maxWidth int, scale int) string {
+	if textWidth(s, scale) <= maxWidth {
+		return s
+	}
+	maxRunes := maxWidth / (glyphWidth * scale)
+	if maxRunes < 1 {
+		return ""
+	}
+	runes := []rune(s)
+	return string(runes[:maxRunes-1]) + ellipsis
+}
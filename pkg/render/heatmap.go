@@ -0,0 +1,144 @@
+package render
+
+import (
+	"fmt"
+	eUseCase "github.com/calendar-bot/pkg/events/usecase"
+	"github.com/calendar-bot/pkg/types"
+	"github.com/goodsign/monday"
+	"github.com/senseyeio/spaniel"
+	"image"
+	"image/color"
+	"strings"
+	"time"
+)
+
+const (
+	heatmapNameWidth = 14 * glyphWidth
+	heatmapCellWidth = 20
+	heatmapRowHeight = glyphHeight + 4
+	heatmapSlot      = 30 * time.Minute
+	heatmapPadding   = 4
+
+	heatmapDayFormat = "Monday, 2 January"
+)
+
+var (
+	heatmapFreeColor    = color.RGBA{R: 0xd9, G: 0xea, B: 0xd3, A: 0xff}
+	heatmapBusyColor    = color.RGBA{R: 0xe0, G: 0x66, B: 0x66, A: 0xff}
+	heatmapOutsideColor = color.RGBA{R: 0xf3, G: 0xf3, B: 0xf3, A: 0xff}
+)
+
+// heatmapDefaultDayPart is used when the search is not limited by a part of the day
+var heatmapDefaultDayPart = types.DayPart{
+	Start:    time.Date(0, 0, 0, 8, 0, 0, 0, time.UTC),
+	Duration: 12 * time.Hour,
+}
+
+// FreeBusyHeatmap draws a block per day with a row per user, the busier the user is during
+// a half hour the redder the cell. Only dayPart of every day is drawn, the dates are written in locale
+func FreeBusyHeatmap(freeBusy types.FreeBusyUser, users []string, from time.Time, to time.Time,
+	dayPart *types.DayPart, locale monday.Locale) *image.RGBA {
+
+	if dayPart == nil {
+		dayPart = &heatmapDefaultDayPart
+	}
+
+	busyByUser := make(map[string]spaniel.Spans, len(freeBusy.FreeBusy))
+	for _, intervals := range freeBusy.FreeBusy {
+		spans := busyByUser[intervals.User]
+		for _, interval := range intervals.FreeBusy {
+			spans = append(spans, interval)
+		}
+		busyByUser[intervals.User] = spans
+	}
+	for user, spans := range busyByUser {
+		busyByUser[user] = spans.Union()
+	}
+
+	hour, minute, second := dayPart.Start.Clock()
+	partStart := time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute + time.Duration(second)*time.Second
+	partDuration := dayPart.Duration
+	if partStart+partDuration > 24*time.Hour {
+		partDuration = 24*time.Hour - partStart
+	}
+	slots := int(partDuration / heatmapSlot)
+	if slots < 1 {
+		slots = 1
+	}
+
+	var days []time.Time
+	for day := dayStart(from); day.Before(to); day = day.AddDate(0, 0, 1) {
+		days = append(days, day)
+	}
+
+	width := heatmapNameWidth + slots*heatmapCellWidth + 1
+	height := heatmapRowHeight + len(days)*(len(users)+1)*heatmapRowHeight + 1
+	img := newCanvas(width, height)
+
+	for slot := 0; slot < slots; slot++ {
+		slotStart := partStart + time.Duration(slot)*heatmapSlot
+		if slotStart%time.Hour != 0 {
+			continue
+		}
+		x := heatmapNameWidth + slot*heatmapCellWidth
+		drawText(img, x+1, (heatmapRowHeight-glyphHeight)/2, fmt.Sprintf("%02d", int(slotStart.Hours())), mutedTextColor, 1)
+	}
+
+	y := heatmapRowHeight
+	for _, day := range days {
+		horizontalLine(img, 0, width, y, gridColor)
+		label := fitText(monday.Format(day, heatmapDayFormat, locale), width-2*heatmapPadding, 1)
+		drawText(img, heatmapPadding, y+(heatmapRowHeight-glyphHeight)/2, label, textColor, 1)
+		y += heatmapRowHeight
+
+		for _, user := range users {
+			name := fitText(strings.Split(user, "@")[0], heatmapNameWidth-2*heatmapPadding, 1)
+			drawText(img, heatmapPadding, y+(heatmapRowHeight-glyphHeight)/2, name, textColor, 1)
+
+			for slot := 0; slot < slots; slot++ {
+				start := day.Add(partStart + time.Duration(slot)*heatmapSlot)
+				end := start.Add(heatmapSlot)
+
+				cellColor := heatmapOutsideColor
+				if start.Before(to) && end.After(from) {
+					cellColor = blend(heatmapFreeColor, heatmapBusyColor, BusyFraction(spaniel.New(start, end), busyByUser[user]))
+				}
+
+				x := heatmapNameWidth + slot*heatmapCellWidth
+				fillRect(img, image.Rect(x+1, y+1, x+heatmapCellWidth, y+heatmapRowHeight), cellColor)
+			}
+			y += heatmapRowHeight
+		}
+	}
+
+	return img
+}
+
+// BusyFraction returns the part of the slot covered by merged busy spans, from 0 to 1
+func BusyFraction(slot spaniel.Span, busy spaniel.Spans) float64 {
+	slotDuration := slot.End().Sub(slot.Start())
+	if slotDuration <= 0 {
+		return 0
+	}
+
+	var busyDuration time.Duration
+	for _, span := range busy {
+		truncated := eUseCase.TruncateSpanBy(slot)(span)
+		if d := truncated.End().Sub(truncated.Start()); d > 0 {
+			busyDuration += d
+		}
+	}
+
+	fraction := float64(busyDuration) / float64(slotDuration)
+	if fraction > 1 {
+		return 1
+	}
+	return fraction
+}
+
+func blend(from color.RGBA, to color.RGBA, t float64) color.RGBA {
+	mix := func(a, b uint8) uint8 {
+		return uint8(float64(a) + (float64(b)-float64(a))*t)
+	}
+	return color.RGBA{R: mix(from.R, to.R), G: mix(from.G, to.G), B: mix(from.B, to.B), A: 0xff}
+}
@@ -0,0 +1,65 @@
+package render
+
+import (
+	"bytes"
+	"github.com/calendar-bot/pkg/types"
+	"github.com/pkg/errors"
+	"hash/fnv"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+)
+
+var (
+	backgroundColor = color.RGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff}
+	gridColor       = color.RGBA{R: 0xe0, G: 0xe0, B: 0xe0, A: 0xff}
+	textColor       = color.RGBA{R: 0x33, G: 0x33, B: 0x33, A: 0xff}
+	mutedTextColor  = color.RGBA{R: 0x88, G: 0x88, B: 0x88, A: 0xff}
+)
+
+// calendarPalette holds the fill and the border colours of events, picked by calendar uid
+var calendarPalette = [][2]color.RGBA{
+	{{R: 0xcf, G: 0xe2, B: 0xf3, A: 0xff}, {R: 0x3d, G: 0x85, B: 0xc6, A: 0xff}},
+	{{R: 0xd9, G: 0xea, B: 0xd3, A: 0xff}, {R: 0x6a, G: 0xa8, B: 0x4f, A: 0xff}},
+	{{R: 0xfc, G: 0xe5, B: 0xcd, A: 0xff}, {R: 0xe6, G: 0x91, B: 0x38, A: 0xff}},
+	{{R: 0xea, G: 0xd1, B: 0xdc, A: 0xff}, {R: 0xa6, G: 0x4d, B: 0x79, A: 0xff}},
+	{{R: 0xd9, G: 0xd2, B: 0xe9, A: 0xff}, {R: 0x67, G: 0x4e, B: 0xa7, A: 0xff}},
+	{{R: 0xd0, G: 0xe0, B: 0xe3, A: 0xff}, {R: 0x45, G: 0x81, B: 0x8e, A: 0xff}},
+	{{R: 0xff, G: 0xf2, B: 0xcc, A: 0xff}, {R: 0xbf, G: 0x90, B: 0x00, A: 0xff}},
+	{{R: 0xf4, G: 0xcc, B: 0xcc, A: 0xff}, {R: 0xcc, G: 0x41, B: 0x25, A: 0xff}},
+}
+
+func calendarColors(calendar types.Calendar) (fill color.RGBA, border color.RGBA) {
+	hash := fnv.New32a()
+	_, _ = hash.Write([]byte(calendar.UID))
+	colors := calendarPalette[hash.Sum32()%uint32(len(calendarPalette))]
+	return colors[0], colors[1]
+}
+
+func newCanvas(width, height int) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(img, img.Bounds(), &image.Uniform{C: backgroundColor}, image.Point{}, draw.Src)
+	return img
+}
+
+func fillRect(dst *image.RGBA, rect image.Rectangle, c color.Color) {
+	draw.Draw(dst, rect.Intersect(dst.Bounds()), &image.Uniform{C: c}, image.Point{}, draw.Src)
+}
+
+func horizontalLine(dst *image.RGBA, x0, x1, y int, c color.Color) {
+	fillRect(dst, image.Rect(x0, y, x1, y+1), c)
+}
+
+func verticalLine(dst *image.RGBA, x, y0, y1 int, c color.Color) {
+	fillRect(dst, image.Rect(x, y0, x+1, y1), c)
+}
+
+// EncodePNG encodes a rendered image for sending it as a telegram photo
+func EncodePNG(img image.Image) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, errors.Wrap(err, "failed to encode png")
+	}
+	return buf.Bytes(), nil
+}
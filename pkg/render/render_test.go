@@ -0,0 +1,94 @@
+package render
+
+import (
+	"bytes"
+	"github.com/calendar-bot/pkg/types"
+	"github.com/goodsign/monday"
+	"github.com/senseyeio/spaniel"
+	"github.com/stretchr/testify/assert"
+	"image/png"
+	"testing"
+	"time"
+)
+
+func TestDefaultFontHasCyrillic(t *testing.T) {
+	for _, r := range "AzАяЁё0…" {
+		_, ok := defaultFont.glyphs[r]
+		assert.True(t, ok, "no glyph for %q", r)
+	}
+}
+
+func TestParseHexFontBadLine(t *testing.T) {
+	_, err := parseHexFont("0041:0000")
+	assert.Error(t, err)
+}
+
+func TestFitText(t *testing.T) {
+	assert.Equal(t, "Встреча", fitText("Встреча", 7*glyphWidth, 1))
+	assert.Equal(t, "Вст…", fitText("Встреча", 4*glyphWidth, 1))
+	assert.Equal(t, "", fitText("Встреча", glyphWidth-1, 1))
+}
+
+func TestLayoutLanes(t *testing.T) {
+	at := func(hour, minute int) time.Time {
+		return time.Date(2021, 5, 3, hour, minute, 0, 0, time.UTC)
+	}
+	items := []timelineItem{
+		{event: types.Event{Uid: "c"}, from: at(10, 30), to: at(11, 30)},
+		{event: types.Event{Uid: "a"}, from: at(10, 0), to: at(11, 0)},
+		{event: types.Event{Uid: "b"}, from: at(10, 0), to: at(10, 30)},
+		{event: types.Event{Uid: "d"}, from: at(12, 0), to: at(13, 0)},
+	}
+
+	layoutLanes(items)
+
+	lanes := make(map[string][2]int)
+	for _, item := range items {
+		lanes[item.event.Uid] = [2]int{item.lane, item.lanes}
+	}
+	assert.Equal(t, [2]int{0, 2}, lanes["a"])
+	assert.Equal(t, [2]int{1, 2}, lanes["b"])
+	assert.Equal(t, [2]int{1, 2}, lanes["c"])
+	assert.Equal(t, [2]int{0, 1}, lanes["d"])
+}
+
+func TestTimelineHoursWidenedByEvents(t *testing.T) {
+	from := time.Date(2021, 5, 3, 0, 0, 0, 0, time.UTC)
+	timed := [][]timelineItem{
+		{{from: from.Add(7*time.Hour + 30*time.Minute), to: from.Add(9 * time.Hour)}},
+		{{from: from.AddDate(0, 0, 1).Add(20 * time.Hour), to: from.AddDate(0, 0, 1).Add(21*time.Hour + time.Minute)}},
+	}
+
+	startHour, endHour := timelineHours(timed, from)
+
+	assert.Equal(t, 7, startHour)
+	assert.Equal(t, 22, endHour)
+}
+
+func TestWeekTimelineEncodes(t *testing.T) {
+	weekStart := time.Date(2021, 5, 3, 0, 0, 0, 0, time.UTC)
+	events := types.Events{
+		{Title: "Планёрка", From: weekStart.Add(10 * time.Hour), To: weekStart.Add(11 * time.Hour)},
+		{Title: "Праздник", FullDay: true, From: weekStart, To: weekStart.AddDate(0, 0, 1)},
+	}
+
+	img := WeekTimeline(events, weekStart, monday.LocaleRuRU)
+	data, err := EncodePNG(img)
+	assert.NoError(t, err)
+
+	decoded, err := png.Decode(bytes.NewReader(data))
+	assert.NoError(t, err)
+	assert.Equal(t, timelineHourLabelWidth+7*weekColumnWidth+1, decoded.Bounds().Dx())
+}
+
+func TestBusyFraction(t *testing.T) {
+	slot := spaniel.New(time.Date(2021, 5, 3, 10, 0, 0, 0, time.UTC), time.Date(2021, 5, 3, 10, 30, 0, 0, time.UTC))
+
+	assert.Equal(t, 0.0, BusyFraction(slot, nil))
+	assert.Equal(t, 0.5, BusyFraction(slot, spaniel.Spans{
+		spaniel.New(time.Date(2021, 5, 3, 9, 0, 0, 0, time.UTC), time.Date(2021, 5, 3, 10, 15, 0, 0, time.UTC)),
+	}))
+	assert.Equal(t, 1.0, BusyFraction(slot, spaniel.Spans{
+		spaniel.New(time.Date(2021, 5, 3, 9, 0, 0, 0, time.UTC), time.Date(2021, 5, 3, 11, 0, 0, 0, time.UTC)),
+	}))
+}
@@ -0,0 +1,251 @@
+package render
+
+import (
+	"fmt"
+	eUseCase "github.com/calendar-bot/pkg/events/usecase"
+	"github.com/calendar-bot/pkg/types"
+	"github.com/goodsign/monday"
+	"image"
+	"sort"
+	"time"
+)
+
+const (
+	timelineHourLabelWidth   = 6 * glyphWidth
+	timelineHeaderHeight     = glyphHeight + 8
+	timelineAllDayRowHeight  = glyphHeight + 4
+	timelinePadding          = 4
+	timelineBorderWidth      = 3
+	timelineMinEventDuration = 15 * time.Minute
+
+	timelineDefaultStartHour = 8
+	timelineDefaultEndHour   = 20
+
+	dayColumnWidth  = 560
+	dayHourHeight   = 48
+	weekColumnWidth = 128
+	weekHourHeight  = 36
+)
+
+const timelineDayFormat = "Mon 02.01"
+
+// timelineItem is a timed event clipped to a single day column
+type timelineItem struct {
+	event types.Event
+	from  time.Time
+	to    time.Time
+	// lane is the index of the column the item takes inside its group of overlapping items
+	lane  int
+	lanes int
+}
+
+// DayTimeline draws events of a single day on an hourly grid, the date is written in locale
+func DayTimeline(events types.Events, day time.Time, locale monday.Locale) *image.RGBA {
+	return drawTimeline(events, dayStart(day), 1, dayColumnWidth, dayHourHeight, locale)
+}
+
+// WeekTimeline draws events of seven days starting from weekStart, one column per day
+func WeekTimeline(events types.Events, weekStart time.Time, locale monday.Locale) *image.RGBA {
+	return drawTimeline(events, dayStart(weekStart), 7, weekColumnWidth, weekHourHeight, locale)
+}
+
+func dayStart(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+func drawTimeline(events types.Events, from time.Time, days int, columnWidth int, hourHeight int,
+	locale monday.Locale) *image.RGBA {
+
+	timed := make([][]timelineItem, days)
+	allDay := make([]types.Events, days)
+	allDayRows := 0
+	for day := 0; day < days; day++ {
+		start := from.AddDate(0, 0, day)
+		end := start.AddDate(0, 0, 1)
+		for _, event := range events {
+			if event.FullDay {
+				span := eUseCase.HolidayDaySpan(event)
+				if span.From.Before(end) && span.To.After(start) {
+					allDay[day] = append(allDay[day], event)
+				}
+				continue
+			}
+			if item, ok := clipToDay(event, start, end); ok {
+				timed[day] = append(timed[day], item)
+			}
+		}
+		layoutLanes(timed[day])
+		if len(allDay[day]) > allDayRows {
+			allDayRows = len(allDay[day])
+		}
+	}
+
+	startHour, endHour := timelineHours(timed, from)
+
+	top := timelineHeaderHeight + allDayRows*timelineAllDayRowHeight
+	width := timelineHourLabelWidth + days*columnWidth + 1
+	height := top + (endHour-startHour)*hourHeight + 1
+	img := newCanvas(width, height)
+
+	for hour := startHour; hour <= endHour; hour++ {
+		y := top + (hour-startHour)*hourHeight
+		horizontalLine(img, timelineHourLabelWidth, width, y, gridColor)
+		if hour < endHour {
+			drawText(img, timelinePadding, y+2, fmt.Sprintf("%02d:00", hour), mutedTextColor, 1)
+		}
+	}
+
+	for day := 0; day < days; day++ {
+		x := timelineHourLabelWidth + day*columnWidth
+		date := from.AddDate(0, 0, day)
+		verticalLine(img, x, 0, height, gridColor)
+
+		label := fitText(monday.Format(date, timelineDayFormat, locale), columnWidth-2*timelinePadding, 1)
+		drawText(img, x+timelinePadding, (timelineHeaderHeight-glyphHeight)/2, label, textColor, 1)
+
+		for row, event := range allDay[day] {
+			y := timelineHeaderHeight + row*timelineAllDayRowHeight
+			drawEventBlock(img, image.Rect(x+1, y+1, x+columnWidth-1, y+timelineAllDayRowHeight-1), event, "")
+		}
+
+		gridStart := date.Add(time.Duration(startHour) * time.Hour)
+		for _, item := range timed[day] {
+			laneWidth := (columnWidth - 1) / item.lanes
+			x0 := x + 1 + item.lane*laneWidth
+			y0 := top + minutesToPixels(item.from.Sub(gridStart), hourHeight)
+			y1 := top + minutesToPixels(item.to.Sub(gridStart), hourHeight)
+			timeRange := item.event.From.Format("15:04") + "-" + item.event.To.Format("15:04")
+			drawEventBlock(img, image.Rect(x0, y0+1, x0+laneWidth-1, y1), item.event, timeRange)
+		}
+	}
+	verticalLine(img, width-1, 0, height, gridColor)
+
+	return img
+}
+
+func minutesToPixels(d time.Duration, hourHeight int) int {
+	return int(d.Minutes() * float64(hourHeight) / 60)
+}
+
+// clipToDay cuts a timed event by the borders of the day, too short events are stretched to stay visible
+func clipToDay(event types.Event, start time.Time, end time.Time) (timelineItem, bool) {
+	if !event.From.Before(end) {
+		return timelineItem{}, false
+	}
+	if event.To.After(event.From) && !event.To.After(start) {
+		return timelineItem{}, false
+	}
+	if !event.To.After(event.From) && event.From.Before(start) {
+		return timelineItem{}, false
+	}
+
+	item := timelineItem{event: event, from: event.From, to: event.To}
+	if item.from.Before(start) {
+		item.from = start
+	}
+	if item.to.After(end) {
+		item.to = end
+	}
+	if item.to.Sub(item.from) < timelineMinEventDuration {
+		item.to = item.from.Add(timelineMinEventDuration)
+		if item.to.After(end) {
+			item.from, item.to = end.Add(-timelineMinEventDuration), end
+		}
+	}
+	return item, true
+}
+
+// layoutLanes places overlapping items side by side: every item takes the first free lane
+// and all items of a group of overlapping events share the lane count of the group
+func layoutLanes(items []timelineItem) {
+	sort.SliceStable(items, func(i, j int) bool {
+		if !items[i].from.Equal(items[j].from) {
+			return items[i].from.Before(items[j].from)
+		}
+		return items[i].to.After(items[j].to)
+	})
+
+	groupStart := 0
+	var groupEnd time.Time
+	var laneEnds []time.Time
+
+	closeGroup := func(end int) {
+		for i := groupStart; i < end; i++ {
+			items[i].lanes = len(laneEnds)
+		}
+	}
+
+	for i := range items {
+		if i > 0 && !items[i].from.Before(groupEnd) {
+			closeGroup(i)
+			groupStart = i
+			laneEnds = laneEnds[:0]
+		}
+
+		lane := 0
+		for lane < len(laneEnds) && laneEnds[lane].After(items[i].from) {
+			lane++
+		}
+		if lane == len(laneEnds) {
+			laneEnds = append(laneEnds, items[i].to)
+		} else {
+			laneEnds[lane] = items[i].to
+		}
+		items[i].lane = lane
+
+		if items[i].to.After(groupEnd) {
+			groupEnd = items[i].to
+		}
+	}
+	closeGroup(len(items))
+}
+
+// timelineHours returns the hour range of the grid, it is widened to fit all timed events
+func timelineHours(timed [][]timelineItem, from time.Time) (startHour int, endHour int) {
+	startHour, endHour = timelineDefaultStartHour, timelineDefaultEndHour
+	for day, items := range timed {
+		date := from.AddDate(0, 0, day)
+		for _, item := range items {
+			if hour := int(item.from.Sub(date).Hours()); hour < startHour {
+				startHour = hour
+			}
+			endHours := item.to.Sub(date).Hours()
+			if hour := int(endHours); float64(hour) < endHours {
+				endHours = float64(hour + 1)
+			}
+			if int(endHours) > endHour {
+				endHour = int(endHours)
+			}
+		}
+	}
+	if startHour < 0 {
+		startHour = 0
+	}
+	if endHour > 24 {
+		endHour = 24
+	}
+	return startHour, endHour
+}
+
+func drawEventBlock(img *image.RGBA, rect image.Rectangle, event types.Event, subtitle string) {
+	fill, border := calendarColors(event.Calendar)
+	fillRect(img, rect, fill)
+	fillRect(img, image.Rect(rect.Min.X, rect.Min.Y, rect.Min.X+timelineBorderWidth, rect.Max.Y), border)
+
+	textX := rect.Min.X + timelineBorderWidth + timelinePadding
+	maxWidth := rect.Max.X - textX - 1
+
+	lines := []string{event.Title}
+	if subtitle != "" {
+		lines = append(lines, subtitle)
+	}
+
+	y := rect.Min.Y + (timelineAllDayRowHeight-glyphHeight)/2 - 1
+	for _, line := range lines {
+		if y+glyphHeight > rect.Max.Y+2 {
+			break
+		}
+		drawText(img, textX, y, fitText(line, maxWidth, 1), textColor, 1)
+		y += glyphHeight
+	}
+}
@@ -0,0 +1,55 @@
+package callbacks
+
+import (
+	"github.com/pkg/errors"
+	"os"
+	"time"
+)
+
+const (
+	EnvCallbackStoreTTL   = "CALLBACK_STORE_TTL"
+	EnvCallbackSignSecret = "CALLBACK_SIGN_SECRET"
+)
+
+// buttons of posted events are refreshed on every re-render, so the TTL only has to outlive the forgotten ones
+const callbackStoreTTLDefault = 30 * 24 * time.Hour
+
+type Config struct {
+	TTL        time.Duration `valid:"-"`
+	SignSecret string        `valid:"-"`
+}
+
+func LoadCallbackStoreConfig() (Config, error) {
+	ttl := callbackStoreTTLDefault
+	if ttlStr := os.Getenv(EnvCallbackStoreTTL); ttlStr != "" {
+		parsed, err := time.ParseDuration(ttlStr)
+		if err != nil {
+			return Config{}, errors.Wrapf(
+				err,
+				"failed to parse %s environment variable as time.Duration",
+				EnvCallbackStoreTTL,
+			)
+		}
+		if parsed <= 0 {
+			return Config{}, errors.Errorf("%s duration must be greater than zero", EnvCallbackStoreTTL)
+		}
+		ttl = parsed
+	}
+
+	signSecret := os.Getenv(EnvCallbackSignSecret)
+	if signSecret == "" {
+		return Config{}, errors.Errorf("%s environment variable must be set", EnvCallbackSignSecret)
+	}
+
+	return Config{
+		TTL:        ttl,
+		SignSecret: signSecret,
+	}, nil
+}
+
+func (c *Config) ToEnv() map[string]string {
+	return map[string]string{
+		EnvCallbackStoreTTL:   c.TTL.String(),
+		EnvCallbackSignSecret: c.SignSecret,
+	}
+}
@@ -0,0 +1,36 @@
+package callbacks
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestLoadCallbackStoreConfig(t *testing.T) {
+	defer os.Unsetenv(EnvCallbackStoreTTL)
+	defer os.Unsetenv(EnvCallbackSignSecret)
+
+	require.NoError(t, os.Unsetenv(EnvCallbackStoreTTL))
+	require.NoError(t, os.Unsetenv(EnvCallbackSignSecret))
+	_, err := LoadCallbackStoreConfig()
+	assert.Error(t, err)
+
+	require.NoError(t, os.Setenv(EnvCallbackSignSecret, "secret"))
+	config, err := LoadCallbackStoreConfig()
+	require.NoError(t, err)
+	assert.Equal(t, Config{TTL: callbackStoreTTLDefault, SignSecret: "secret"}, config)
+
+	expected := Config{TTL: 36 * time.Hour, SignSecret: "another secret"}
+	for key, value := range expected.ToEnv() {
+		require.NoError(t, os.Setenv(key, value))
+	}
+	config, err = LoadCallbackStoreConfig()
+	require.NoError(t, err)
+	assert.Equal(t, expected, config)
+
+	require.NoError(t, os.Setenv(EnvCallbackStoreTTL, "-1h"))
+	_, err = LoadCallbackStoreConfig()
+	assert.Error(t, err)
+}
@@ -0,0 +1,14 @@
+package callbacks
+
+import "github.com/pkg/errors"
+
+type Error struct {
+	error
+}
+
+var (
+	// KeyDoesNotExist is returned when the button data has expired or has never been stored
+	KeyDoesNotExist = Error{errors.New("callback data does not exist in redis")}
+	// InvalidSignature is returned for callback data the bot has not signed
+	InvalidSignature = Error{errors.New("callback data signature is invalid")}
+)
@@ -0,0 +1,70 @@
+package callbacks
+
+import (
+	"github.com/go-redis/redis/v8"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const callbackStoreMetricsNamespace = "callback_store"
+
+const (
+	kindMetricLabel   = "kind"
+	statusMetricLabel = "status"
+)
+
+var (
+	metricSetTotalCount = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: callbackStoreMetricsNamespace,
+			Name:      "set_count",
+			Help:      "Total callback data sets count",
+		},
+		[]string{kindMetricLabel, statusMetricLabel},
+	)
+	metricGetTotalCount = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: callbackStoreMetricsNamespace,
+			Name:      "get_count",
+			Help:      "Total callback data gets count, expired buttons are counted as key_does_not_exist",
+		},
+		[]string{kindMetricLabel, statusMetricLabel},
+	)
+	metricVerifyTotalCount = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: callbackStoreMetricsNamespace,
+			Name:      "verify_count",
+			Help:      "Total callback data verifications count",
+		},
+		[]string{statusMetricLabel},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(
+		metricSetTotalCount,
+		metricGetTotalCount,
+		metricVerifyTotalCount,
+	)
+}
+
+func metricStatusFromErr(err error) string {
+	if err == nil {
+		return "ok"
+	}
+	switch err := errors.Cause(err).(type) {
+	case redis.Error:
+		return "redis_err"
+	case Error:
+		switch err {
+		case KeyDoesNotExist:
+			return "key_does_not_exist"
+		case InvalidSignature:
+			return "invalid_signature"
+		default:
+			return "unknown_callback_store_err"
+		}
+	default:
+		return "unknown_err"
+	}
+}
@@ -0,0 +1,28 @@
+package callbacks
+
+import (
+	"fmt"
+	"github.com/go-redis/redis/v8"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestMetricStatusFromErr(t *testing.T) {
+	dummyErr := fmt.Errorf("dummy error")
+	data := []struct {
+		err      error
+		expected string
+	}{
+		{nil, "ok"},
+		{KeyDoesNotExist, "key_does_not_exist"},
+		{InvalidSignature, "invalid_signature"},
+		{Error{dummyErr}, "unknown_callback_store_err"},
+		{redis.TxFailedErr, "redis_err"},
+		{dummyErr, "unknown_err"},
+	}
+
+	for _, testCase := range data {
+		actual := metricStatusFromErr(testCase.err)
+		assert.Equal(t, testCase.expected, actual)
+	}
+}
@@ -0,0 +1,88 @@
+package callbacks
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"strings"
+)
+
+const (
+	// Telegram drops buttons with callback data longer than this
+	maxCallbackDataLength = 64
+	// 8 bytes of HMAC-SHA256 in unpadded base64
+	signatureLength = 11
+	// payloads which do not fit are replaced with "~<opaque id>"
+	opaqueIDMark = "~"
+	// telebot sends the data of buttons with Unique as "\f<unique>|<data>"
+	uniqueOverhead = len("\f") + len("|")
+)
+
+// PayloadKind maps the opaque ID of a long payload to the payload itself
+const PayloadKind Kind = "payload"
+
+// Signer signs the data of inline buttons with the server secret,
+// so that handlers only receive data the bot has sent itself
+type Signer struct {
+	secret []byte
+	store  *Store
+}
+
+func NewSigner(config *Config, store *Store) Signer {
+	return Signer{
+		secret: []byte(config.SignSecret),
+		store:  store,
+	}
+}
+
+// Sign returns "<payload>|<signature>", the payload is the data itself or the opaque ID of the stored data
+// if the signed data does not fit into the callback data limit
+func (s *Signer) Sign(unique, data string) (string, error) {
+	payload := data
+	if !fitsCallbackData(unique, data) || strings.HasPrefix(data, opaqueIDMark) {
+		id := opaqueID(unique, data)
+		if err := s.store.Set(PayloadKind, data, id); err != nil {
+			return "", err
+		}
+		payload = opaqueIDMark + id
+	}
+	return payload + "|" + s.signature(unique, payload), nil
+}
+
+// Verify checks the signature of the data received with the button unique and returns the data passed to Sign.
+// It returns InvalidSignature for forged data and KeyDoesNotExist if the stored payload has expired
+func (s *Signer) Verify(unique, signed string) (data string, err error) {
+	defer func() {
+		metricVerifyTotalCount.WithLabelValues(metricStatusFromErr(err)).Inc()
+	}()
+
+	sep := strings.LastIndex(signed, "|")
+	if sep < 0 {
+		return "", InvalidSignature
+	}
+	payload, signature := signed[:sep], signed[sep+1:]
+	if !hmac.Equal([]byte(signature), []byte(s.signature(unique, payload))) {
+		return "", InvalidSignature
+	}
+
+	if !strings.HasPrefix(payload, opaqueIDMark) {
+		return payload, nil
+	}
+	return s.store.Get(PayloadKind, strings.TrimPrefix(payload, opaqueIDMark))
+}
+
+func (s *Signer) signature(unique, payload string) string {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(unique + "|" + payload))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil)[:8])
+}
+
+func fitsCallbackData(unique, data string) bool {
+	return uniqueOverhead+len(unique)+len(data)+len("|")+signatureLength <= maxCallbackDataLength
+}
+
+// opaqueID is derived from the payload, so re-rendered keyboards reuse the stored payload and prolong its TTL
+func opaqueID(unique, data string) string {
+	sum := sha256.Sum256([]byte(unique + "|" + data))
+	return base64.RawURLEncoding.EncodeToString(sum[:8])
+}
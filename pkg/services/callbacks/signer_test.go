@@ -0,0 +1,55 @@
+package callbacks
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+)
+
+func TestSignerShortData(t *testing.T) {
+	signer := NewSigner(&Config{SignSecret: "secret"}, nil)
+
+	signed, err := signer.Sign("GRG", "uid-1|42")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(signed, "uid-1|42|"))
+	assert.Len(t, signed, len("uid-1|42|")+signatureLength)
+
+	data, err := signer.Verify("GRG", signed)
+	require.NoError(t, err)
+	assert.Equal(t, "uid-1|42", data)
+
+	empty, err := signer.Sign("CRE", "")
+	require.NoError(t, err)
+	data, err = signer.Verify("CRE", empty)
+	require.NoError(t, err)
+	assert.Equal(t, "", data)
+}
+
+func TestSignerRejectsForgedData(t *testing.T) {
+	signer := NewSigner(&Config{SignSecret: "secret"}, nil)
+	signed, err := signer.Sign("GRG", "uid-1|42")
+	require.NoError(t, err)
+	signature := signed[strings.LastIndex(signed, "|"):]
+
+	_, err = signer.Verify("GRG", "uid-1|43"+signature)
+	assert.Equal(t, InvalidSignature, err)
+
+	_, err = signer.Verify("GRN", signed)
+	assert.Equal(t, InvalidSignature, err)
+
+	_, err = signer.Verify("GRG", "uid-1")
+	assert.Equal(t, InvalidSignature, err)
+
+	other := NewSigner(&Config{SignSecret: "other"}, nil)
+	_, err = other.Verify("GRG", signed)
+	assert.Equal(t, InvalidSignature, err)
+}
+
+func TestFitsCallbackData(t *testing.T) {
+	assert.True(t, fitsCallbackData("GRG", strings.Repeat("a", 47)))
+	assert.False(t, fitsCallbackData("GRG", strings.Repeat("a", 48)))
+	assert.Equal(t, opaqueID("GRG", "data"), opaqueID("GRG", "data"))
+	assert.NotEqual(t, opaqueID("GRG", "data"), opaqueID("GRN", "data"))
+	assert.Len(t, opaqueID("GRG", "data"), signatureLength)
+}
@@ -0,0 +1,85 @@
+package callbacks
+
+import (
+	"context"
+	"github.com/go-redis/redis/v8"
+	"github.com/pkg/errors"
+	"strings"
+	"time"
+)
+
+const keyPrefix = "cb"
+
+// Kind is the namespace of the callback data, keys of different kinds never collide
+type Kind string
+
+const (
+	// EventCalendarKind maps event UID to the calendar UID of the event buttons
+	EventCalendarKind Kind = "event_calendar"
+	// AttendeeCalendarKind maps attendee email and event UID to the calendar UID of the attendee's copy
+	AttendeeCalendarKind Kind = "attendee_calendar"
+)
+
+// Store keeps the data behind inline buttons which does not fit into the 64 bytes of callback data
+type Store struct {
+	redisDB *redis.Client
+	ttl     time.Duration
+}
+
+func NewStore(config *Config, redisDB *redis.Client) Store {
+	return Store{
+		redisDB: redisDB,
+		ttl:     config.TTL,
+	}
+}
+
+// Key builds the key "cb:<kind>:<id parts joined by :>"
+func Key(kind Kind, id ...string) string {
+	return keyPrefix + ":" + string(kind) + ":" + strings.Join(id, ":")
+}
+
+// Set stores the value and restarts its TTL
+func (s *Store) Set(kind Kind, value string, id ...string) (err error) {
+	defer func() {
+		metricSetTotalCount.WithLabelValues(string(kind), metricStatusFromErr(err)).Inc()
+	}()
+
+	key := Key(kind, id...)
+	if err := s.redisDB.Set(context.TODO(), key, value, s.ttl).Err(); err != nil {
+		return errors.Wrapf(err, "failed to set callback data by key='%s'", key)
+	}
+	return nil
+}
+
+// Get returns KeyDoesNotExist if the value has expired
+func (s *Store) Get(kind Kind, id ...string) (value string, err error) {
+	defer func() {
+		metricGetTotalCount.WithLabelValues(string(kind), metricStatusFromErr(err)).Inc()
+	}()
+
+	key := Key(kind, id...)
+	value, err = s.redisDB.Get(context.TODO(), key).Result()
+	switch {
+	case err == redis.Nil:
+		return "", KeyDoesNotExist
+	case err != nil:
+		return "", errors.Wrapf(err, "failed to get callback data by key='%s'", key)
+	}
+	return value, nil
+}
+
+func (s *Store) SetEventCalendar(eventUID, calendarUID string) error {
+	return s.Set(EventCalendarKind, calendarUID, eventUID)
+}
+
+func (s *Store) GetEventCalendar(eventUID string) (string, error) {
+	return s.Get(EventCalendarKind, eventUID)
+}
+
+func (s *Store) SetAttendeeCalendar(email, eventUID, calendarUID string) error {
+	return s.Set(AttendeeCalendarKind, calendarUID, email, eventUID)
+}
+
+func (s *Store) GetAttendeeCalendar(email, eventUID string) (string, error) {
+	return s.Get(AttendeeCalendarKind, email, eventUID)
+}
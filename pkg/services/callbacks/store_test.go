@@ -0,0 +1,12 @@
+package callbacks
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestKey(t *testing.T) {
+	assert.Equal(t, "cb:event_calendar:uid-1", Key(EventCalendarKind, "uid-1"))
+	assert.Equal(t, "cb:attendee_calendar:user@mail.ru:uid-1", Key(AttendeeCalendarKind, "user@mail.ru", "uid-1"))
+	assert.NotEqual(t, Key(EventCalendarKind, "uid-1"), Key(AttendeeCalendarKind, "uid-1"))
+}
@@ -0,0 +1,79 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"github.com/pkg/errors"
+	"hash/fnv"
+	"sync"
+)
+
+// AdvisoryLock is a Postgres session advisory lock named by a string. The lock is held on its own
+// connection, so Postgres releases it as soon as the instance holding it dies
+type AdvisoryLock struct {
+	storage *sql.DB
+	name    string
+	key     int64
+
+	mu   sync.Mutex
+	conn *sql.Conn
+}
+
+func NewAdvisoryLock(db *sql.DB, name string) *AdvisoryLock {
+	hash := fnv.New64a()
+	_, _ = hash.Write([]byte(name))
+
+	return &AdvisoryLock{
+		storage: db,
+		name:    name,
+		key:     int64(hash.Sum64()),
+	}
+}
+
+// Hold reports whether this instance holds the lock, the lock is taken if it is free.
+// A taken lock is kept until Release, or until its connection is lost
+func (l *AdvisoryLock) Hold(ctx context.Context) (bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.conn != nil {
+		if err := l.conn.PingContext(ctx); err == nil {
+			return true, nil
+		}
+		// the session is gone and the lock is gone with it
+		_ = l.conn.Close()
+		l.conn = nil
+	}
+
+	conn, err := l.storage.Conn(ctx)
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to get db connection for lock %q", l.name)
+	}
+	var locked bool
+	err = conn.QueryRowContext(ctx, `SELECT pg_try_advisory_lock($1)`, l.key).Scan(&locked)
+	if err != nil || !locked {
+		if closeErr := conn.Close(); err == nil {
+			err = closeErr
+		}
+		return false, errors.Wrapf(err, "failed to take lock %q", l.name)
+	}
+
+	l.conn = conn
+	return true, nil
+}
+
+// Release gives the lock away if it is held
+func (l *AdvisoryLock) Release() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.conn == nil {
+		return nil
+	}
+	_, err := l.conn.ExecContext(context.Background(), `SELECT pg_advisory_unlock($1)`, l.key)
+	if closeErr := l.conn.Close(); err == nil {
+		err = closeErr
+	}
+	l.conn = nil
+	return errors.Wrapf(err, "failed to release lock %q", l.name)
+}
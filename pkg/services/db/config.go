@@ -12,6 +12,7 @@ const (
 	EnvDBUsername           = "DB_USERNAME"
 	EnvDBPassword           = "DB_PASSWORD"
 	EnvDBMaxOpenConnections = "DB_MAX_OPEN_CONNECTIONS"
+	EnvDBAutoMigrate        = "DB_AUTO_MIGRATE"
 )
 
 type Config struct {
@@ -19,6 +20,8 @@ type Config struct {
 	Username           string `valid:"-"`
 	Password           string `valid:"-"`
 	MaxOpenConnections int    `valid:"-"`
+	// AutoMigrate applies the pending migrations on startup
+	AutoMigrate bool `valid:"-"`
 }
 
 func LoadDBConfig() (Config, error) {
@@ -36,11 +39,22 @@ func LoadDBConfig() (Config, error) {
 		maxOpenConnections = value
 	}
 
+	autoMigrate := false
+	if autoMigrateStr := os.Getenv(EnvDBAutoMigrate); autoMigrateStr != "" {
+		value, err := strconv.ParseBool(autoMigrateStr)
+		if err != nil {
+			return Config{},
+				errors.WithMessagef(err, "failed to parse %s environment variable as bool", EnvDBAutoMigrate)
+		}
+		autoMigrate = value
+	}
+
 	conf := Config{
 		Name:               name,
 		Username:           username,
 		Password:           password,
 		MaxOpenConnections: maxOpenConnections,
+		AutoMigrate:        autoMigrate,
 	}
 
 	if _, err := govalidator.ValidateStruct(&conf); err != nil {
@@ -56,5 +70,6 @@ func (db *Config) ToEnv() map[string]string {
 		EnvDBUsername:           db.Username,
 		EnvDBPassword:           db.Password,
 		EnvDBMaxOpenConnections: strconv.Itoa(db.MaxOpenConnections),
+		EnvDBAutoMigrate:        strconv.FormatBool(db.AutoMigrate),
 	}
 }
@@ -94,3 +94,19 @@ func (s *dbConfigTestSuite) TestDBConfigInvalidMaxOpenConnections() {
 
 	assert.Error(s.T(), convertErr)
 }
+
+func (s *dbConfigTestSuite) TestDBConfigInvalidAutoMigrate() {
+	expected := Config{}
+	require.NoError(s.T(), faker.FakeData(&expected))
+
+	envs := expected.ToEnv()
+	envs[EnvDBAutoMigrate] = "invalid"
+
+	s.setEnvs(envs)
+	defer s.unsetEnvs(envs)
+
+	_, err := LoadDBConfig()
+	convertErr := errors.Cause(err).(*strconv.NumError)
+
+	assert.Error(s.T(), convertErr)
+}
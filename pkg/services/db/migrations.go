@@ -0,0 +1,315 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"github.com/calendar-bot/pkg/customerrors"
+	"github.com/pkg/errors"
+	"io/fs"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+// baselineVersion is the migration creating the tables which existed before the migrations, it is never reverted
+const baselineVersion int64 = 1
+
+// migrationsLockKey is the key of the advisory lock held while migrating, so parallel instances migrate one by one
+const migrationsLockKey int64 = 7031985604
+
+const (
+	migrationUpSuffix   = ".up.sql"
+	migrationDownSuffix = ".down.sql"
+)
+
+// Migration is a schema change read from "<version>_<name>.up.sql" and "<version>_<name>.down.sql"
+type Migration struct {
+	Version int64
+	Name    string
+	Up      string
+	Down    string
+}
+
+// MigrationStatus is the migration with the time it was applied at, nil AppliedAt means it is pending
+type MigrationStatus struct {
+	Migration
+	AppliedAt *time.Time
+}
+
+// Migrations returns the migrations embedded in the binary ordered by version
+func Migrations() ([]Migration, error) {
+	files, err := fs.Sub(migrationFiles, "migrations")
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return LoadMigrations(files)
+}
+
+// LoadMigrations reads the migrations from the root of fsys, every version must have both up and down files
+func LoadMigrations(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read migrations")
+	}
+
+	byVersion := make(map[int64]*Migration)
+	for _, entry := range entries {
+		fileName := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(fileName, ".sql") {
+			continue
+		}
+
+		var base string
+		var isUp bool
+		switch {
+		case strings.HasSuffix(fileName, migrationUpSuffix):
+			base, isUp = strings.TrimSuffix(fileName, migrationUpSuffix), true
+		case strings.HasSuffix(fileName, migrationDownSuffix):
+			base = strings.TrimSuffix(fileName, migrationDownSuffix)
+		default:
+			return nil, errors.Errorf("migration %q is neither up nor down", fileName)
+		}
+
+		parts := strings.SplitN(base, "_", 2)
+		if len(parts) != 2 || parts[1] == "" {
+			return nil, errors.Errorf("bad migration name %q, expected <version>_<name>", fileName)
+		}
+		version, err := strconv.ParseInt(parts[0], 10, 64)
+		if err != nil || version <= 0 {
+			return nil, errors.Errorf("bad version of migration %q", fileName)
+		}
+
+		content, err := fs.ReadFile(fsys, fileName)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read migration %q", fileName)
+		}
+
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: parts[1]}
+			byVersion[version] = migration
+		} else if migration.Name != parts[1] {
+			return nil, errors.Errorf("migrations %q and %q have the same version %d",
+				migration.Name, parts[1], version)
+		}
+		if isUp {
+			migration.Up = string(content)
+		} else {
+			migration.Down = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.Up == "" || migration.Down == "" {
+			return nil, errors.Errorf("migration %d_%s must have both up and down files",
+				migration.Version, migration.Name)
+		}
+		migrations = append(migrations, *migration)
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+	return migrations, nil
+}
+
+// Migrator applies and reverts the migrations, the applied ones are tracked in the schema_migrations table
+type Migrator struct {
+	storage    *sql.DB
+	migrations []Migration
+}
+
+func NewMigrator(db *sql.DB, migrations []Migration) Migrator {
+	return Migrator{
+		storage:    db,
+		migrations: migrations,
+	}
+}
+
+// Up applies the pending migrations in the order of versions, every migration runs in its own transaction
+func (m *Migrator) Up(ctx context.Context) (applied []Migration, err error) {
+	err = m.withLock(ctx, func(conn *sql.Conn) error {
+		appliedAt, err := appliedMigrations(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for _, migration := range m.migrations {
+			if _, ok := appliedAt[migration.Version]; ok {
+				continue
+			}
+			err := inTx(ctx, conn, func(tx *sql.Tx) error {
+				if _, err := tx.ExecContext(ctx, migration.Up); err != nil {
+					return errors.Wrapf(err, "failed to apply migration %d_%s", migration.Version, migration.Name)
+				}
+				_, err := tx.ExecContext(ctx,
+					`INSERT INTO schema_migrations(version, name, applied_at) VALUES ($1, $2, now())`,
+					migration.Version,
+					migration.Name,
+				)
+				return errors.Wrapf(err, "failed to save migration %d_%s", migration.Version, migration.Name)
+			})
+			if err != nil {
+				return err
+			}
+			applied = append(applied, migration)
+		}
+		return nil
+	})
+	return applied, err
+}
+
+// Down reverts the last applied migration, nil is returned if there is nothing to revert.
+// The baseline is not reverted, it would drop the users
+func (m *Migrator) Down(ctx context.Context) (reverted *Migration, err error) {
+	err = m.withLock(ctx, func(conn *sql.Conn) error {
+		appliedAt, err := appliedMigrations(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		var last int64
+		for version := range appliedAt {
+			if version > last {
+				last = version
+			}
+		}
+		if last == 0 {
+			return nil
+		}
+		if last <= baselineVersion {
+			return errors.Errorf("migration %d is the baseline and can not be reverted", last)
+		}
+
+		migration := m.find(last)
+		if migration == nil {
+			return errors.Errorf("applied migration %d is unknown to this build", last)
+		}
+		err = inTx(ctx, conn, func(tx *sql.Tx) error {
+			if _, err := tx.ExecContext(ctx, migration.Down); err != nil {
+				return errors.Wrapf(err, "failed to revert migration %d_%s", migration.Version, migration.Name)
+			}
+			_, err := tx.ExecContext(ctx, `DELETE FROM schema_migrations WHERE version = $1`, migration.Version)
+			return errors.Wrapf(err, "failed to forget migration %d_%s", migration.Version, migration.Name)
+		})
+		if err != nil {
+			return err
+		}
+		reverted = migration
+		return nil
+	})
+	return reverted, err
+}
+
+// Status returns the known migrations ordered by version, applied migrations unknown to this build are included
+func (m *Migrator) Status(ctx context.Context) (statuses []MigrationStatus, err error) {
+	err = m.withLock(ctx, func(conn *sql.Conn) error {
+		appliedAt, err := appliedMigrations(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for _, migration := range m.migrations {
+			status := MigrationStatus{Migration: migration}
+			if at, ok := appliedAt[migration.Version]; ok {
+				at := at
+				status.AppliedAt = &at
+			}
+			statuses = append(statuses, status)
+		}
+		for version, at := range appliedAt {
+			if m.find(version) == nil {
+				at := at
+				statuses = append(statuses, MigrationStatus{Migration: Migration{Version: version}, AppliedAt: &at})
+			}
+		}
+		return nil
+	})
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Version < statuses[j].Version
+	})
+	return statuses, err
+}
+
+func (m *Migrator) find(version int64) *Migration {
+	for i := range m.migrations {
+		if m.migrations[i].Version == version {
+			return &m.migrations[i]
+		}
+	}
+	return nil
+}
+
+// withLock runs f holding the advisory lock, the lock belongs to the session so f must use the given connection
+func (m *Migrator) withLock(ctx context.Context, f func(conn *sql.Conn) error) (err error) {
+	conn, err := m.storage.Conn(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to get db connection for migrations")
+	}
+	defer func() {
+		err = customerrors.HandleCloser(err, conn)
+	}()
+
+	if _, err := conn.ExecContext(ctx, `SELECT pg_advisory_lock($1)`, migrationsLockKey); err != nil {
+		return errors.Wrap(err, "failed to take migrations lock")
+	}
+	defer func() {
+		_, unlockErr := conn.ExecContext(context.Background(), `SELECT pg_advisory_unlock($1)`, migrationsLockKey)
+		if err == nil && unlockErr != nil {
+			err = errors.Wrap(unlockErr, "failed to release migrations lock")
+		}
+	}()
+
+	_, err = conn.ExecContext(ctx, `
+			CREATE TABLE IF NOT EXISTS schema_migrations
+			(
+			    version    BIGINT PRIMARY KEY,
+			    name       TEXT        NOT NULL,
+			    applied_at TIMESTAMPTZ NOT NULL DEFAULT now()
+			)`)
+	if err != nil {
+		return errors.Wrap(err, "failed to create schema_migrations table")
+	}
+	return f(conn)
+}
+
+func appliedMigrations(ctx context.Context, conn *sql.Conn) (appliedAt map[int64]time.Time, err error) {
+	rows, err := conn.QueryContext(ctx, `SELECT version, applied_at FROM schema_migrations`)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to perform SQL query in appliedMigrations")
+	}
+	defer func() {
+		err = customerrors.HandleCloser(err, rows)
+	}()
+
+	appliedAt = make(map[int64]time.Time)
+	for rows.Next() {
+		var version int64
+		var at time.Time
+		if err := rows.Scan(&version, &at); err != nil {
+			return nil, errors.Wrap(err, "error while scanning schema migrations")
+		}
+		appliedAt[version] = at
+	}
+	return appliedAt, errors.WithStack(rows.Err())
+}
+
+// inTx commits the transaction if f succeeds and rolls it back otherwise
+func inTx(ctx context.Context, conn *sql.Conn, f func(tx *sql.Tx) error) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to begin transaction")
+	}
+	if err := f(tx); err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			return errors.Wrapf(err, "rollback failed: %v", rollbackErr)
+		}
+		return err
+	}
+	return errors.Wrap(tx.Commit(), "failed to commit transaction")
+}
@@ -0,0 +1,2 @@
+-- the baseline is never reverted, Migrator.Down refuses it to keep the users
+SELECT 1;
@@ -0,0 +1,13 @@
+-- deployments made before the migrations already have the users table
+CREATE TABLE IF NOT EXISTS users
+(
+    id                     BIGSERIAL PRIMARY KEY,
+    mail_user_id           TEXT        NOT NULL,
+    mail_user_email        TEXT        NOT NULL,
+    mail_refresh_token     TEXT        NOT NULL,
+    telegram_user_id       BIGINT      NOT NULL UNIQUE,
+    telegram_user_timezone TEXT,
+    created_at             TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS users_mail_user_email_idx ON users (mail_user_email);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS focus_events;
+DROP TABLE IF EXISTS focus_rules;
@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS focus_rules
+(
+    telegram_user_id BIGINT PRIMARY KEY,
+    duration_minutes INTEGER NOT NULL,
+    prefer_morning   BOOLEAN NOT NULL DEFAULT FALSE,
+    enabled          BOOLEAN NOT NULL DEFAULT TRUE
+);
+
+CREATE TABLE IF NOT EXISTS focus_events
+(
+    id               BIGSERIAL PRIMARY KEY,
+    telegram_user_id BIGINT      NOT NULL,
+    event_uid        TEXT        NOT NULL,
+    calendar_uid     TEXT        NOT NULL DEFAULT '',
+    event_from       TIMESTAMPTZ NOT NULL,
+    event_to         TIMESTAMPTZ NOT NULL,
+    deleted          BOOLEAN     NOT NULL DEFAULT FALSE
+);
+
+CREATE INDEX IF NOT EXISTS focus_events_telegram_user_id_event_from_idx ON focus_events (telegram_user_id, event_from);
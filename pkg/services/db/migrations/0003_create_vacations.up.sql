@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS vacations
+(
+    telegram_user_id BIGINT PRIMARY KEY,
+    date_from        TIMESTAMPTZ NOT NULL,
+    date_to          TIMESTAMPTZ NOT NULL,
+    message          TEXT        NOT NULL DEFAULT '',
+    event_uid        TEXT        NOT NULL DEFAULT '',
+    calendar_uid     TEXT        NOT NULL DEFAULT ''
+);
@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS invitation_rules
+(
+    id                   BIGSERIAL PRIMARY KEY,
+    telegram_user_id     BIGINT  NOT NULL,
+    status               TEXT    NOT NULL,
+    organizer            TEXT    NOT NULL DEFAULT '',
+    calendar             TEXT    NOT NULL DEFAULT '',
+    starts_after_minutes INTEGER NOT NULL DEFAULT 0,
+    ends_before_minutes  INTEGER NOT NULL DEFAULT 0,
+    min_duration_minutes INTEGER NOT NULL DEFAULT 0,
+    max_duration_minutes INTEGER NOT NULL DEFAULT 0,
+    no_conflict          BOOLEAN NOT NULL DEFAULT FALSE
+);
+
+CREATE INDEX IF NOT EXISTS invitation_rules_telegram_user_id_idx ON invitation_rules (telegram_user_id);
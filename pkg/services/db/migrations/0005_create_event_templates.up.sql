@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS event_templates
+(
+    id               BIGSERIAL PRIMARY KEY,
+    telegram_user_id BIGINT  NOT NULL,
+    name             TEXT    NOT NULL,
+    event            JSONB   NOT NULL,
+    duration_minutes INTEGER NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS event_templates_telegram_user_id_idx ON event_templates (telegram_user_id);
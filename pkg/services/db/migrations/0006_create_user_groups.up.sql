@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS user_groups
+(
+    telegram_user_id BIGINT NOT NULL,
+    chat_id          BIGINT NOT NULL,
+    title            TEXT   NOT NULL DEFAULT '',
+    PRIMARY KEY (telegram_user_id, chat_id)
+);
+
+CREATE INDEX IF NOT EXISTS user_groups_chat_id_idx ON user_groups (chat_id);
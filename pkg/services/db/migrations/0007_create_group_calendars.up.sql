@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS group_calendars
+(
+    chat_id          BIGINT PRIMARY KEY,
+    telegram_user_id BIGINT NOT NULL,
+    calendar_uid     TEXT   NOT NULL,
+    calendar_title   TEXT   NOT NULL DEFAULT ''
+);
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS chat_members
+(
+    chat_id          BIGINT      NOT NULL,
+    telegram_user_id BIGINT      NOT NULL,
+    name             TEXT        NOT NULL DEFAULT '',
+    username         TEXT        NOT NULL DEFAULT '',
+    joined           BOOLEAN     NOT NULL DEFAULT TRUE,
+    authenticated    BOOLEAN     NOT NULL DEFAULT FALSE,
+    updated_at       TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (chat_id, telegram_user_id)
+);
+
+CREATE INDEX IF NOT EXISTS chat_members_telegram_user_id_idx ON chat_members (telegram_user_id);
@@ -0,0 +1,17 @@
+-- chat_id is 0 for inline messages, message_id is the inline message id then
+CREATE TABLE IF NOT EXISTS posted_messages
+(
+    chat_id          BIGINT      NOT NULL,
+    message_id       TEXT        NOT NULL,
+    event_uid        TEXT        NOT NULL,
+    calendar_uid     TEXT        NOT NULL DEFAULT '',
+    telegram_user_id BIGINT      NOT NULL,
+    type             TEXT        NOT NULL,
+    text_hash        TEXT        NOT NULL DEFAULT '',
+    event_to         TIMESTAMPTZ NOT NULL,
+    created_at       TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (chat_id, message_id)
+);
+
+CREATE INDEX IF NOT EXISTS posted_messages_event_uid_idx ON posted_messages (event_uid);
+CREATE INDEX IF NOT EXISTS posted_messages_event_to_idx ON posted_messages (event_to);
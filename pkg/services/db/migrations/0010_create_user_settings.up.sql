@@ -0,0 +1,16 @@
+-- durations are in minutes, zero reminder_minutes and digest_minutes turn the notifications off
+CREATE TABLE IF NOT EXISTS user_settings
+(
+    telegram_user_id       BIGINT PRIMARY KEY,
+    timezone               TEXT        NOT NULL,
+    language               TEXT        NOT NULL DEFAULT '',
+    event_duration_minutes INTEGER     NOT NULL,
+    calendar_uid           TEXT        NOT NULL DEFAULT '',
+    reminder_minutes       INTEGER     NOT NULL DEFAULT 0,
+    digest_minutes         INTEGER     NOT NULL DEFAULT 0,
+    image_mode             BOOLEAN     NOT NULL DEFAULT FALSE,
+    updated_at             TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS user_settings_notified_idx ON user_settings (telegram_user_id)
+    WHERE reminder_minutes > 0 OR digest_minutes > 0;
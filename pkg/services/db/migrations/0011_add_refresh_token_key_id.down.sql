@@ -0,0 +1,3 @@
+-- encrypted tokens are not decrypted back, the users have to log in again after the revert
+ALTER TABLE users
+    DROP COLUMN IF EXISTS mail_refresh_token_key_id;
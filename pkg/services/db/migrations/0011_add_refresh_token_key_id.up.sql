@@ -0,0 +1,3 @@
+-- empty key id is a plaintext token saved before the encryption, it is encrypted by the reencrypt-tokens command
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS mail_refresh_token_key_id TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,3 @@
+-- the templates with a call are kept without it, the links of their old call rooms are not restored
+ALTER TABLE event_templates
+    DROP COLUMN IF EXISTS with_call;
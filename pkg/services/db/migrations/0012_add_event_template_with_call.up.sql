@@ -0,0 +1,8 @@
+-- the call link saved in the templates is replaced by the flag, a new call room is created for every event
+ALTER TABLE event_templates
+    ADD COLUMN IF NOT EXISTS with_call BOOLEAN NOT NULL DEFAULT FALSE;
+
+UPDATE event_templates
+SET with_call = TRUE,
+    event     = event - 'call'
+WHERE event ? 'call';
@@ -0,0 +1,59 @@
+package db
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"testing/fstest"
+)
+
+func TestLoadMigrations(t *testing.T) {
+	files := fstest.MapFS{
+		"0002_add_title.up.sql":      {Data: []byte("ALTER TABLE notes ADD COLUMN title TEXT;")},
+		"0002_add_title.down.sql":    {Data: []byte("ALTER TABLE notes DROP COLUMN title;")},
+		"0001_create_notes.up.sql":   {Data: []byte("CREATE TABLE notes(id BIGINT);")},
+		"0001_create_notes.down.sql": {Data: []byte("DROP TABLE notes;")},
+		"README.md":                  {Data: []byte("not a migration")},
+	}
+
+	migrations, err := LoadMigrations(files)
+	require.NoError(t, err)
+	assert.Equal(t, []Migration{
+		{Version: 1, Name: "create_notes", Up: "CREATE TABLE notes(id BIGINT);", Down: "DROP TABLE notes;"},
+		{
+			Version: 2,
+			Name:    "add_title",
+			Up:      "ALTER TABLE notes ADD COLUMN title TEXT;",
+			Down:    "ALTER TABLE notes DROP COLUMN title;",
+		},
+	}, migrations)
+}
+
+func TestLoadMigrationsErrors(t *testing.T) {
+	badFiles := []fstest.MapFS{
+		{"0001_create_notes.up.sql": {Data: []byte("CREATE TABLE notes(id BIGINT);")}},
+		{"0001_create_notes.sql": {Data: []byte("CREATE TABLE notes(id BIGINT);")}},
+		{"create_notes.up.sql": {Data: []byte("CREATE TABLE notes(id BIGINT);")}},
+		{"0001.up.sql": {Data: []byte("CREATE TABLE notes(id BIGINT);")}},
+		{
+			"0001_create_notes.up.sql":  {Data: []byte("CREATE TABLE notes(id BIGINT);")},
+			"0001_create_tags.down.sql": {Data: []byte("DROP TABLE tags;")},
+		},
+	}
+
+	for _, files := range badFiles {
+		_, err := LoadMigrations(files)
+		assert.Error(t, err)
+	}
+}
+
+func TestEmbeddedMigrations(t *testing.T) {
+	migrations, err := Migrations()
+	require.NoError(t, err)
+	require.NotEmpty(t, migrations)
+	assert.Equal(t, baselineVersion, migrations[0].Version)
+
+	for i, migration := range migrations {
+		assert.Equal(t, int64(i+1), migration.Version, "migration versions must have no gaps")
+	}
+}
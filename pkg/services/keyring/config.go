@@ -0,0 +1,99 @@
+package keyring
+
+import (
+	"encoding/base64"
+	"github.com/pkg/errors"
+	"os"
+	"sort"
+	"strings"
+)
+
+const (
+	// EnvTokenKeys is the key ring "<id>:<base64 key>,<id>:<base64 key>", keys are 32 bytes long
+	EnvTokenKeys = "TOKEN_ENCRYPTION_KEYS"
+	// EnvTokenActiveKey is the ID of the key new tokens are encrypted with, may be omitted for a single key
+	EnvTokenActiveKey = "TOKEN_ENCRYPTION_ACTIVE_KEY"
+)
+
+// keyLength selects AES-256
+const keyLength = 32
+
+type Config struct {
+	Keys        map[string][]byte `valid:"-"`
+	ActiveKeyID string            `valid:"-"`
+}
+
+func LoadTokenKeysConfig() (Config, error) {
+	keys, err := ParseKeys(os.Getenv(EnvTokenKeys))
+	if err != nil {
+		return Config{}, errors.WithMessagef(err, "failed to parse %s environment variable", EnvTokenKeys)
+	}
+	if len(keys) == 0 {
+		return Config{}, errors.Errorf("%s environment variable must be set", EnvTokenKeys)
+	}
+
+	activeKeyID := os.Getenv(EnvTokenActiveKey)
+	if activeKeyID == "" && len(keys) == 1 {
+		for id := range keys {
+			activeKeyID = id
+		}
+	}
+	if _, ok := keys[activeKeyID]; !ok {
+		return Config{}, errors.Errorf("%s environment variable must be one of the keys of %s",
+			EnvTokenActiveKey, EnvTokenKeys)
+	}
+
+	return Config{
+		Keys:        keys,
+		ActiveKeyID: activeKeyID,
+	}, nil
+}
+
+func (c *Config) ToEnv() map[string]string {
+	return map[string]string{
+		EnvTokenKeys:      FormatKeys(c.Keys),
+		EnvTokenActiveKey: c.ActiveKeyID,
+	}
+}
+
+// ParseKeys parses the key ring "<id>:<base64 key>,<id>:<base64 key>", empty value is no keys
+func ParseKeys(value string) (map[string][]byte, error) {
+	if strings.TrimSpace(value) == "" {
+		return nil, nil
+	}
+
+	keys := make(map[string][]byte)
+	for i, item := range strings.Split(value, ",") {
+		parts := strings.Split(strings.TrimSpace(item), ":")
+		if len(parts) != 2 || parts[0] == "" {
+			// nothing of the item is printed, it may be the key itself
+			return nil, errors.Errorf("bad key #%d, expected id:base64 key", i+1)
+		}
+		if _, ok := keys[parts[0]]; ok {
+			return nil, errors.Errorf("duplicate key %q", parts[0])
+		}
+		key, err := base64.StdEncoding.DecodeString(parts[1])
+		if err != nil {
+			return nil, errors.WithMessagef(err, "bad base64 of key %q", parts[0])
+		}
+		if len(key) != keyLength {
+			return nil, errors.Errorf("key %q must be %d bytes long, got %d", parts[0], keyLength, len(key))
+		}
+		keys[parts[0]] = key
+	}
+	return keys, nil
+}
+
+func FormatKeys(keys map[string][]byte) string {
+	ids := make([]string, 0, len(keys))
+	for id := range keys {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	items := make([]string, 0, len(keys))
+	for _, id := range ids {
+		items = append(items, id+":"+base64.StdEncoding.EncodeToString(keys[id]))
+	}
+	return strings.Join(items, ",")
+}
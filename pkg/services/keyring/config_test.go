@@ -0,0 +1,67 @@
+package keyring
+
+import (
+	"bytes"
+	"encoding/base64"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"os"
+	"testing"
+)
+
+func testKey(b byte) []byte {
+	return bytes.Repeat([]byte{b}, keyLength)
+}
+
+func TestLoadTokenKeysConfig(t *testing.T) {
+	defer os.Unsetenv(EnvTokenKeys)
+	defer os.Unsetenv(EnvTokenActiveKey)
+
+	require.NoError(t, os.Unsetenv(EnvTokenKeys))
+	require.NoError(t, os.Unsetenv(EnvTokenActiveKey))
+	_, err := LoadTokenKeysConfig()
+	assert.Error(t, err)
+
+	require.NoError(t, os.Setenv(EnvTokenKeys, "2021:"+base64.StdEncoding.EncodeToString(testKey(1))))
+	config, err := LoadTokenKeysConfig()
+	require.NoError(t, err)
+	assert.Equal(t, Config{Keys: map[string][]byte{"2021": testKey(1)}, ActiveKeyID: "2021"}, config)
+
+	expected := Config{Keys: map[string][]byte{"2021": testKey(1), "2022": testKey(2)}, ActiveKeyID: "2022"}
+	for key, value := range expected.ToEnv() {
+		require.NoError(t, os.Setenv(key, value))
+	}
+	config, err = LoadTokenKeysConfig()
+	require.NoError(t, err)
+	assert.Equal(t, expected, config)
+
+	require.NoError(t, os.Unsetenv(EnvTokenActiveKey))
+	_, err = LoadTokenKeysConfig()
+	assert.Error(t, err, "the active key must be chosen among several keys")
+
+	require.NoError(t, os.Setenv(EnvTokenActiveKey, "2023"))
+	_, err = LoadTokenKeysConfig()
+	assert.Error(t, err)
+}
+
+func TestParseKeys(t *testing.T) {
+	keys, err := ParseKeys("")
+	assert.NoError(t, err)
+	assert.Empty(t, keys)
+
+	encoded := base64.StdEncoding.EncodeToString(testKey(1))
+	keys, err = ParseKeys("old:" + encoded + ", new:" + base64.StdEncoding.EncodeToString(testKey(2)))
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]byte{"old": testKey(1), "new": testKey(2)}, keys)
+
+	for _, value := range []string{
+		encoded,
+		":" + encoded,
+		"old:" + encoded + ",old:" + encoded,
+		"old:not base64",
+		"old:" + base64.StdEncoding.EncodeToString([]byte("short")),
+	} {
+		_, err := ParseKeys(value)
+		assert.Error(t, err, value)
+	}
+}
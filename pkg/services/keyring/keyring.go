@@ -0,0 +1,158 @@
+package keyring
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"github.com/pkg/errors"
+	"io"
+	"strings"
+)
+
+type Error struct {
+	error
+}
+
+var (
+	// UnknownKey is returned for data encrypted with a key which is not in the ring anymore
+	UnknownKey = Error{errors.New("encryption key is not in the key ring")}
+	// BadCiphertext is returned for data which is corrupted or encrypted with another key
+	BadCiphertext = Error{errors.New("ciphertext is corrupted")}
+)
+
+// KeyRing encrypts secrets with envelope encryption: every secret is sealed with its own random data key
+// and the data key is sealed with the active key of the ring. Old keys are kept to decrypt secrets until
+// they are re-encrypted with the active key
+type KeyRing struct {
+	keys        map[string]cipher.AEAD
+	activeKeyID string
+}
+
+func NewKeyRing(config *Config) (KeyRing, error) {
+	keys := make(map[string]cipher.AEAD, len(config.Keys))
+	for id, key := range config.Keys {
+		aead, err := newAEAD(key)
+		if err != nil {
+			return KeyRing{}, errors.WithMessagef(err, "bad key %q", id)
+		}
+		keys[id] = aead
+	}
+	if _, ok := keys[config.ActiveKeyID]; !ok {
+		return KeyRing{}, errors.Errorf("active key %q is not in the key ring", config.ActiveKeyID)
+	}
+
+	return KeyRing{
+		keys:        keys,
+		activeKeyID: config.ActiveKeyID,
+	}, nil
+}
+
+// ActiveKeyID is the ID of the key Encrypt uses
+func (r *KeyRing) ActiveKeyID() string {
+	return r.activeKeyID
+}
+
+// Encrypt returns the ID of the active key and "<sealed data key>.<sealed plaintext>" in unpadded base64
+func (r *KeyRing) Encrypt(plaintext string) (keyID string, ciphertext string, err error) {
+	dataKey := make([]byte, keyLength)
+	if _, err := io.ReadFull(rand.Reader, dataKey); err != nil {
+		return "", "", errors.Wrap(err, "failed to generate data key")
+	}
+	data, err := newAEAD(dataKey)
+	if err != nil {
+		return "", "", err
+	}
+
+	// the key ID is authenticated, so the sealed data key can not be passed off as sealed with another key
+	sealedKey, err := seal(r.keys[r.activeKeyID], dataKey, []byte(r.activeKeyID))
+	if err != nil {
+		return "", "", err
+	}
+	sealedText, err := seal(data, []byte(plaintext), nil)
+	if err != nil {
+		return "", "", err
+	}
+
+	return r.activeKeyID, encode(sealedKey) + "." + encode(sealedText), nil
+}
+
+// Decrypt opens the ciphertext returned by Encrypt together with keyID
+func (r *KeyRing) Decrypt(keyID string, ciphertext string) (string, error) {
+	key, ok := r.keys[keyID]
+	if !ok {
+		return "", errors.WithMessagef(UnknownKey, "key %q", keyID)
+	}
+
+	parts := strings.Split(ciphertext, ".")
+	if len(parts) != 2 {
+		return "", BadCiphertext
+	}
+	sealedKey, err := decode(parts[0])
+	if err != nil {
+		return "", err
+	}
+	sealedText, err := decode(parts[1])
+	if err != nil {
+		return "", err
+	}
+
+	dataKey, err := open(key, sealedKey, []byte(keyID))
+	if err != nil {
+		return "", err
+	}
+	data, err := newAEAD(dataKey)
+	if err != nil {
+		return "", BadCiphertext
+	}
+	plaintext, err := open(data, sealedText, nil)
+	if err != nil {
+		return "", err
+	}
+	return string(plaintext), nil
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return aead, nil
+}
+
+// seal returns the random nonce followed by the sealed plaintext
+func seal(aead cipher.AEAD, plaintext []byte, additionalData []byte) ([]byte, error) {
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(plaintext)+aead.Overhead())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, errors.Wrap(err, "failed to generate nonce")
+	}
+	return aead.Seal(nonce, nonce, plaintext, additionalData), nil
+}
+
+func open(aead cipher.AEAD, sealed []byte, additionalData []byte) ([]byte, error) {
+	if len(sealed) < aead.NonceSize() {
+		return nil, BadCiphertext
+	}
+	nonce, sealed := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+	plaintext, err := aead.Open(nil, nonce, sealed, additionalData)
+	if err != nil {
+		return nil, BadCiphertext
+	}
+	return plaintext, nil
+}
+
+func encode(data []byte) string {
+	return base64.RawStdEncoding.EncodeToString(data)
+}
+
+func decode(value string) ([]byte, error) {
+	data, err := base64.RawStdEncoding.DecodeString(value)
+	if err != nil {
+		return nil, BadCiphertext
+	}
+	return data, nil
+}
@@ -0,0 +1,81 @@
+package keyring
+
+import (
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+)
+
+func newTestKeyRing(t *testing.T, activeKeyID string) KeyRing {
+	ring, err := NewKeyRing(&Config{
+		Keys:        map[string][]byte{"old": testKey(1), "new": testKey(2)},
+		ActiveKeyID: activeKeyID,
+	})
+	require.NoError(t, err)
+	return ring
+}
+
+func TestEncryptDecrypt(t *testing.T) {
+	ring := newTestKeyRing(t, "new")
+
+	keyID, ciphertext, err := ring.Encrypt("refresh-token")
+	require.NoError(t, err)
+	assert.Equal(t, "new", keyID)
+	assert.NotContains(t, ciphertext, "refresh-token")
+
+	plaintext, err := ring.Decrypt(keyID, ciphertext)
+	require.NoError(t, err)
+	assert.Equal(t, "refresh-token", plaintext)
+
+	_, again, err := ring.Encrypt("refresh-token")
+	require.NoError(t, err)
+	assert.NotEqual(t, ciphertext, again, "every encryption must use a new data key and nonce")
+}
+
+func TestDecryptWithRotatedKeys(t *testing.T) {
+	oldRing := newTestKeyRing(t, "old")
+	keyID, ciphertext, err := oldRing.Encrypt("refresh-token")
+	require.NoError(t, err)
+
+	ring := newTestKeyRing(t, "new")
+	plaintext, err := ring.Decrypt(keyID, ciphertext)
+	require.NoError(t, err)
+	assert.Equal(t, "refresh-token", plaintext)
+
+	_, err = ring.Decrypt("new", ciphertext)
+	assert.Equal(t, BadCiphertext, errors.Cause(err))
+
+	_, err = ring.Decrypt("removed", ciphertext)
+	assert.Equal(t, UnknownKey, errors.Cause(err))
+}
+
+func TestDecryptCorrupted(t *testing.T) {
+	ring := newTestKeyRing(t, "new")
+	keyID, ciphertext, err := ring.Encrypt("refresh-token")
+	require.NoError(t, err)
+
+	parts := strings.Split(ciphertext, ".")
+	flipped := []byte(parts[1])
+	if middle := len(flipped) / 2; flipped[middle] == 'A' {
+		flipped[middle] = 'B'
+	} else {
+		flipped[middle] = 'A'
+	}
+	for _, corrupted := range []string{
+		"",
+		parts[0],
+		parts[1] + "." + parts[0],
+		parts[0] + "." + string(flipped),
+		parts[0] + ".!" + parts[1],
+	} {
+		_, err := ring.Decrypt(keyID, corrupted)
+		assert.Equal(t, BadCiphertext, errors.Cause(err), corrupted)
+	}
+}
+
+func TestNewKeyRingUnknownActiveKey(t *testing.T) {
+	_, err := NewKeyRing(&Config{Keys: map[string][]byte{"old": testKey(1)}, ActiveKeyID: "new"})
+	assert.Error(t, err)
+}
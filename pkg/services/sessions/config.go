@@ -0,0 +1,44 @@
+package sessions
+
+import (
+	"github.com/pkg/errors"
+	"os"
+	"time"
+)
+
+const EnvSessionTTL = "SESSION_TTL"
+
+// an abandoned dialog is forgotten after a day without updates
+const sessionTTLDefault = 24 * time.Hour
+
+type Config struct {
+	TTL time.Duration `valid:"-"`
+}
+
+func LoadSessionConfig() (Config, error) {
+	ttl := sessionTTLDefault
+	if ttlStr := os.Getenv(EnvSessionTTL); ttlStr != "" {
+		parsed, err := time.ParseDuration(ttlStr)
+		if err != nil {
+			return Config{}, errors.Wrapf(
+				err,
+				"failed to parse %s environment variable as time.Duration",
+				EnvSessionTTL,
+			)
+		}
+		if parsed <= 0 {
+			return Config{}, errors.Errorf("%s duration must be greater than zero", EnvSessionTTL)
+		}
+		ttl = parsed
+	}
+
+	return Config{
+		TTL: ttl,
+	}, nil
+}
+
+func (c *Config) ToEnv() map[string]string {
+	return map[string]string{
+		EnvSessionTTL: c.TTL.String(),
+	}
+}
@@ -0,0 +1,30 @@
+package sessions
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestLoadSessionConfig(t *testing.T) {
+	defer os.Unsetenv(EnvSessionTTL)
+
+	require.NoError(t, os.Unsetenv(EnvSessionTTL))
+	config, err := LoadSessionConfig()
+	require.NoError(t, err)
+	assert.Equal(t, sessionTTLDefault, config.TTL)
+
+	expected := Config{TTL: 2 * time.Hour}
+	for key, value := range expected.ToEnv() {
+		require.NoError(t, os.Setenv(key, value))
+	}
+	config, err = LoadSessionConfig()
+	require.NoError(t, err)
+	assert.Equal(t, expected, config)
+
+	require.NoError(t, os.Setenv(EnvSessionTTL, "0s"))
+	_, err = LoadSessionConfig()
+	assert.Error(t, err)
+}
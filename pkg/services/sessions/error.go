@@ -0,0 +1,10 @@
+package sessions
+
+import "github.com/pkg/errors"
+
+type Error struct {
+	error
+}
+
+// VersionConflict is returned when the session was changed by another update since it was read
+var VersionConflict = Error{errors.New("session was changed concurrently")}
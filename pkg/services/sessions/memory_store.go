@@ -0,0 +1,89 @@
+package sessions
+
+import (
+	"encoding/json"
+	"github.com/calendar-bot/pkg/types"
+	"github.com/pkg/errors"
+	"sync"
+	"time"
+)
+
+// MemorySessionStore is the SessionStore for tests, it keeps sessions in the process memory
+type MemorySessionStore struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	now     func() time.Time
+	entries map[Key]memoryEntry
+}
+
+type memoryEntry struct {
+	data      []byte
+	expiresAt time.Time
+}
+
+func NewMemorySessionStore(ttl time.Duration, now func() time.Time) *MemorySessionStore {
+	return &MemorySessionStore{
+		ttl:     ttl,
+		now:     now,
+		entries: make(map[Key]memoryEntry),
+	}
+}
+
+func (s *MemorySessionStore) Get(key Key) (*types.BotRedisSession, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, ok := s.entry(key)
+	if !ok {
+		return &types.BotRedisSession{}, nil
+	}
+	entry.expiresAt = s.now().Add(s.ttl)
+	s.entries[key] = entry
+	return unmarshalSession(entry.data)
+}
+
+func (s *MemorySessionStore) Set(key Key, session *types.BotRedisSession) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var version int64
+	if entry, ok := s.entry(key); ok {
+		stored, err := unmarshalSession(entry.data)
+		if err != nil {
+			return err
+		}
+		version = stored.Version
+	}
+	if version != session.Version {
+		return VersionConflict
+	}
+
+	b, err := json.Marshal(envelope{Version: session.Version + 1, Session: session})
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	s.entries[key] = memoryEntry{data: b, expiresAt: s.now().Add(s.ttl)}
+	session.Version++
+	return nil
+}
+
+func (s *MemorySessionStore) Delete(key Key) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.entries, key)
+	return nil
+}
+
+// entry returns the not expired entry, the caller holds the lock
+func (s *MemorySessionStore) entry(key Key) (memoryEntry, bool) {
+	entry, ok := s.entries[key]
+	if !ok {
+		return memoryEntry{}, false
+	}
+	if !s.now().Before(entry.expiresAt) {
+		delete(s.entries, key)
+		return memoryEntry{}, false
+	}
+	return entry, true
+}
@@ -0,0 +1,81 @@
+package sessions
+
+import (
+	"github.com/calendar-bot/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestKeyString(t *testing.T) {
+	assert.Equal(t, "session:12:345", NewKey(12, 345).String())
+	assert.NotEqual(t, NewKey(12, 345).String(), NewKey(123, 45).String())
+	assert.Equal(t, "session:-100123:45", NewKey(-100123, 45).String())
+}
+
+func TestMemorySessionStoreCompareAndSet(t *testing.T) {
+	now := time.Date(2021, 5, 4, 12, 0, 0, 0, time.UTC)
+	store := NewMemorySessionStore(time.Hour, func() time.Time { return now })
+	key := NewKey(1, 2)
+
+	session, err := store.Get(key)
+	require.NoError(t, err)
+	assert.Equal(t, &types.BotRedisSession{}, session)
+
+	session.FromTextCreate = true
+	require.NoError(t, store.Set(key, session))
+	assert.Equal(t, int64(1), session.Version)
+
+	first, err := store.Get(key)
+	require.NoError(t, err)
+	second, err := store.Get(key)
+	require.NoError(t, err)
+	assert.True(t, first.FromTextCreate)
+	assert.Equal(t, int64(1), first.Version)
+
+	first.State = "create_title"
+	require.NoError(t, store.Set(key, first))
+	second.State = "create_desc"
+	assert.Equal(t, VersionConflict, store.Set(key, second))
+
+	stored, err := store.Get(key)
+	require.NoError(t, err)
+	assert.Equal(t, "create_title", stored.State)
+	assert.Equal(t, int64(2), stored.Version)
+
+	reset := Reset(stored)
+	require.NoError(t, store.Set(key, reset))
+	stored, err = store.Get(key)
+	require.NoError(t, err)
+	assert.Equal(t, &types.BotRedisSession{Version: 3}, stored)
+
+	require.NoError(t, store.Delete(key))
+	stored, err = store.Get(key)
+	require.NoError(t, err)
+	assert.Equal(t, &types.BotRedisSession{}, stored)
+}
+
+func TestMemorySessionStoreTTL(t *testing.T) {
+	now := time.Date(2021, 5, 4, 12, 0, 0, 0, time.UTC)
+	store := NewMemorySessionStore(time.Hour, func() time.Time { return now })
+	key := NewKey(1, 2)
+
+	require.NoError(t, store.Set(key, &types.BotRedisSession{FromTextCreate: true}))
+
+	now = now.Add(50 * time.Minute)
+	session, err := store.Get(key)
+	require.NoError(t, err)
+	assert.True(t, session.FromTextCreate)
+
+	// the read has prolonged the session
+	now = now.Add(50 * time.Minute)
+	session, err = store.Get(key)
+	require.NoError(t, err)
+	assert.True(t, session.FromTextCreate)
+
+	now = now.Add(time.Hour)
+	session, err = store.Get(key)
+	require.NoError(t, err)
+	assert.Equal(t, &types.BotRedisSession{}, session)
+}
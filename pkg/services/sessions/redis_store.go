@@ -0,0 +1,95 @@
+package sessions
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/calendar-bot/pkg/types"
+	"github.com/go-redis/redis/v8"
+	"github.com/pkg/errors"
+	"time"
+)
+
+type RedisSessionStore struct {
+	redisDB *redis.Client
+	ttl     time.Duration
+}
+
+func NewRedisSessionStore(config *Config, redisDB *redis.Client) *RedisSessionStore {
+	return &RedisSessionStore{
+		redisDB: redisDB,
+		ttl:     config.TTL,
+	}
+}
+
+func (s *RedisSessionStore) Get(key Key) (*types.BotRedisSession, error) {
+	var get *redis.StringCmd
+	_, err := s.redisDB.TxPipelined(context.TODO(), func(pipe redis.Pipeliner) error {
+		get = pipe.Get(context.TODO(), key.String())
+		pipe.Expire(context.TODO(), key.String(), s.ttl)
+		return nil
+	})
+	if err != nil && err != redis.Nil {
+		return nil, errors.Wrapf(err, "failed to get session by key='%s'", key)
+	}
+	return decodeSession(get)
+}
+
+func (s *RedisSessionStore) Set(key Key, session *types.BotRedisSession) error {
+	err := s.redisDB.Watch(context.TODO(), func(tx *redis.Tx) error {
+		stored, err := decodeSession(tx.Get(context.TODO(), key.String()))
+		if err != nil {
+			return err
+		}
+		if stored.Version != session.Version {
+			return VersionConflict
+		}
+
+		b, err := json.Marshal(envelope{Version: session.Version + 1, Session: session})
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		_, err = tx.TxPipelined(context.TODO(), func(pipe redis.Pipeliner) error {
+			pipe.Set(context.TODO(), key.String(), b, s.ttl)
+			return nil
+		})
+		return err
+	}, key.String())
+
+	switch {
+	case err == redis.TxFailedErr:
+		return VersionConflict
+	case err == VersionConflict:
+		return err
+	case err != nil:
+		return errors.Wrapf(err, "failed to set session by key='%s'", key)
+	}
+	session.Version++
+	return nil
+}
+
+func (s *RedisSessionStore) Delete(key Key) error {
+	if err := s.redisDB.Del(context.TODO(), key.String()).Err(); err != nil {
+		return errors.Wrapf(err, "failed to delete session by key='%s'", key)
+	}
+	return nil
+}
+
+func decodeSession(get *redis.StringCmd) (*types.BotRedisSession, error) {
+	b, err := get.Bytes()
+	switch {
+	case err == redis.Nil:
+		return &types.BotRedisSession{}, nil
+	case err != nil:
+		return nil, errors.WithStack(err)
+	}
+	return unmarshalSession(b)
+}
+
+func unmarshalSession(b []byte) (*types.BotRedisSession, error) {
+	stored := envelope{Session: &types.BotRedisSession{}}
+	if err := json.Unmarshal(b, &stored); err != nil {
+		return nil, errors.Wrap(err, "cannot decode stored session")
+	}
+	stored.Session.Version = stored.Version
+	return stored.Session, nil
+}
@@ -0,0 +1,46 @@
+package sessions
+
+import (
+	"github.com/calendar-bot/pkg/types"
+	"strconv"
+)
+
+// Key identifies the session of the user in the chat
+type Key struct {
+	ChatID         int64
+	TelegramUserID int64
+}
+
+func NewKey(chatID int64, telegramUserID int64) Key {
+	return Key{
+		ChatID:         chatID,
+		TelegramUserID: telegramUserID,
+	}
+}
+
+// String is the unambiguous storage key "session:<chat id>:<telegram user id>"
+func (k Key) String() string {
+	return "session:" + strconv.FormatInt(k.ChatID, 10) + ":" + strconv.FormatInt(k.TelegramUserID, 10)
+}
+
+// SessionStore keeps the state of the multi-step dialogs with the bot
+type SessionStore interface {
+	// Get returns the stored session with its Version set and prolongs its TTL,
+	// an empty session with zero Version is returned if there is no session
+	Get(key Key) (*types.BotRedisSession, error)
+	// Set stores the session if it has not been changed since it was read, VersionConflict is returned otherwise.
+	// The Version of the session is increased on success, so it can be set again
+	Set(key Key, session *types.BotRedisSession) error
+	Delete(key Key) error
+}
+
+// Reset returns an empty session which replaces the session in the store
+func Reset(session *types.BotRedisSession) *types.BotRedisSession {
+	return &types.BotRedisSession{Version: session.Version}
+}
+
+// envelope is the stored form of the session, the version is not a part of the session JSON
+type envelope struct {
+	Version int64                  `json:"version"`
+	Session *types.BotRedisSession `json:"session"`
+}
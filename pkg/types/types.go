@@ -6,6 +6,19 @@ import (
 	"time"
 )
 
+const (
+	CalendarTypePersonal = "PERSONAL"
+	CalendarTypeHoliday  = "HOLIDAYS"
+)
+
+// role and statuses of attendees in the calendar API
+const (
+	RoleRequired      = "REQUIRED"
+	StatusNeedsAction = "NEEDS_ACTION"
+	StatusAccepted    = "ACCEPTED"
+	StatusDeclined    = "DECLINED"
+)
+
 type Calendar struct {
 	UID   string `json:"uid,omitempty"`
 	Title string `json:"title,omitempty"`
@@ -49,6 +62,14 @@ type Event struct {
 	Payload     string         `json:"payload,omitempty"`
 }
 
+type DataCalendars struct {
+	Calendars []Calendar `json:"calendars,omitempty"`
+}
+
+type CalendarsResponse struct {
+	Data DataCalendars `json:"data,omitempty"`
+}
+
 type DataEvents struct {
 	Events Events `json:"events,omitempty"`
 }
@@ -101,6 +122,10 @@ type EventInput struct {
 	Call        *string    `json:"call,omitempty"`
 	Chat        *string    `json:"chat,omitempty"`
 	Payload     *string    `json:"payload,omitempty"`
+	// Private hides the details of the event from those who see the calendar
+	Private *bool `json:"private,omitempty"`
+	// Busy makes the event time busy in free/busy search
+	Busy *bool `json:"busy,omitempty"`
 }
 
 type AddAttendee struct {
@@ -162,11 +187,14 @@ type DayPart struct {
 }
 
 type BotRedisSession struct {
-	Step             int                  `json:"step"`
+	// State is the wizard state, see the wizard package
+	State string `json:"state"`
+	// LegacyStep, LegacyIsDate and LegacyIsCreate are read from sessions saved before State,
+	// the wizard package turns them into State
+	LegacyStep       int                  `json:"step,omitempty"`
+	LegacyIsDate     bool                 `json:"is_date,omitempty"`
+	LegacyIsCreate   bool                 `json:"is_create,omitempty"`
 	FromTextCreate   bool                 `json:"from_text_create"`
-	IsDate           bool                 `json:"is_date"`
-	IsCreate         bool                 `json:"is_create"`
-	FindTimeDone     bool                 `json:"find_time_done"`
 	Event            Event                `json:"event"`
 	FreeBusy         FreeBusy             `json:"free_busy"`
 	FindTimeDayPart  *DayPart             `json:"day_part"`
@@ -176,6 +204,9 @@ type BotRedisSession struct {
 	PollMsg          utils.CustomEditable `json:"poll_msg"`
 	InlineMsg        utils.CustomEditable `json:"inline_msg"`
 	FindTimeInfoMsg  utils.CustomEditable `json:"find_time_info_msg"`
+	TemplateDuration time.Duration        `json:"template_duration"`
+	LastCreated      *Event               `json:"last_created,omitempty"`
+	Version          int64                `json:"-"`
 }
 
 type ParseDateReq struct {
@@ -205,3 +236,134 @@ type RespData struct {
 type CreateEventResp struct {
 	Data RespData `json:"data,omitempty"`
 }
+
+type GraphQLError struct {
+	Message string `json:"message,omitempty"`
+}
+
+type GraphQLErrorsResp struct {
+	Errors []GraphQLError `json:"errors,omitempty"`
+}
+
+type FocusRule struct {
+	TelegramUserID int64
+	Duration       time.Duration
+	PreferMorning  bool
+	Enabled        bool
+}
+
+// FocusEvent is a focus block created by the bot in the user's calendar
+type FocusEvent struct {
+	TelegramUserID int64
+	EventUID       string
+	CalendarUID    string
+	From           time.Time
+	To             time.Time
+	// Deleted is set when the user has removed the block from the calendar
+	Deleted bool
+}
+
+// Vacation is an out of office period of the user, To is exclusive
+type Vacation struct {
+	TelegramUserID int64
+	// Email is filled when vacations are searched together with users
+	Email       string
+	From        time.Time
+	To          time.Time
+	Message     string
+	EventUID    string
+	CalendarUID string
+}
+
+// InvitationRule answers invitations matching all of its set conditions with Status.
+// Time of day conditions are offsets from the event day start, zero values mean the condition is not set
+type InvitationRule struct {
+	ID             int64
+	TelegramUserID int64
+	Status         string
+	Organizer      string
+	Calendar       string
+	StartsAfter    time.Duration
+	EndsBefore     time.Duration
+	MinDuration    time.Duration
+	MaxDuration    time.Duration
+	// NoConflict matches only invitations which do not overlap other events of the user
+	NoConflict bool
+}
+
+// InvitationRuleResult is the status set to the invitation by the rule
+type InvitationRuleResult struct {
+	Event Event
+	Rule  InvitationRule
+}
+
+// Room is a meeting room from the bot's room directory
+type Room struct {
+	Email    string
+	Capacity int
+	Floor    int
+}
+
+// EventTemplate is a partial event saved by the user, time of the event is picked when the template is applied
+type EventTemplate struct {
+	ID             int64
+	TelegramUserID int64
+	Name           string
+	Event          EventInput
+	Duration       time.Duration
+	// WithCall means that a new call room is created for every event of the template
+	WithCall bool
+}
+
+// UserGroup is a group chat where the bot has seen the authenticated user
+type UserGroup struct {
+	TelegramUserID int64
+	ChatID         int64
+	Title          string
+}
+
+// GroupCalendar is the calendar of the owner shown and filled in the group chat
+type GroupCalendar struct {
+	ChatID         int64
+	TelegramUserID int64
+	CalendarUID    string
+	CalendarTitle  string
+}
+
+// ChatMember is a group member seen by the bot, Joined is false after the member has left the group
+type ChatMember struct {
+	ChatID         int64
+	TelegramUserID int64
+	Name           string
+	Username       string
+	Joined         bool
+	Authenticated  bool
+}
+
+// PostedMessage is a message with the event card posted by the bot, ChatID is 0 for inline messages.
+// TelegramUserID is the user whose token reads the event and whose calendar answers go to
+type PostedMessage struct {
+	ChatID         int64
+	MessageID      string
+	EventUID       string
+	CalendarUID    string
+	TelegramUserID int64
+	Type           string
+	TextHash       string
+	EventTo        time.Time
+}
+
+// UserSettings are the preferences of the user chosen with /settings.
+// Empty Language means the language of the Telegram client, empty CalendarUID means the personal calendar.
+// Zero ReminderLeadTime and zero DigestTime turn the reminders and the daily digest off
+type UserSettings struct {
+	TelegramUserID   int64
+	Timezone         string
+	Language         string
+	EventDuration    time.Duration
+	CalendarUID      string
+	ReminderLeadTime time.Duration
+	// DigestTime is the offset from the day start in the user's timezone
+	DigestTime time.Duration
+	ImageMode  bool
+}
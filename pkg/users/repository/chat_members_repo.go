@@ -0,0 +1,104 @@
+package repository
+
+import (
+	"github.com/calendar-bot/pkg/customerrors"
+	"github.com/calendar-bot/pkg/types"
+	"github.com/pkg/errors"
+)
+
+// TouchChatMember marks the member as present in the group and updates the name.
+// A new member is authenticated if the user has already logged in to the bot
+func (us *UserRepository) TouchChatMember(member types.ChatMember) error {
+	_, err := us.storage.Exec(`
+			INSERT INTO chat_members(
+			                         chat_id,
+			                         telegram_user_id,
+			                         name,
+			                         username,
+			                         joined,
+			                         authenticated,
+			                         updated_at
+			                         )
+			VALUES ($1, $2, $3, $4, TRUE, EXISTS(SELECT 1 FROM users WHERE telegram_user_id = $2), now())
+			ON CONFLICT (chat_id, telegram_user_id) DO UPDATE SET name = EXCLUDED.name,
+			                                                      username = EXCLUDED.username,
+			                                                      joined = TRUE,
+			                                                      updated_at = now()`,
+		member.ChatID,
+		member.TelegramUserID,
+		member.Name,
+		member.Username,
+	)
+	if err != nil {
+		return errors.Wrapf(err, "cannot touch chat member=%v", member)
+	}
+	return nil
+}
+
+func (us *UserRepository) LeaveChatMember(chatID int64, telegramID int64) error {
+	_, err := us.storage.Exec(
+		`UPDATE chat_members SET joined = FALSE, updated_at = now() WHERE chat_id = $1 AND telegram_user_id = $2`,
+		chatID,
+		telegramID,
+	)
+	if err != nil {
+		return errors.Wrapf(err, "cannot leave chat member telegramID=%d in chatID=%d", telegramID, chatID)
+	}
+	return nil
+}
+
+// DeleteChatMembers forgets all members of the group, used when the bot has left the group
+func (us *UserRepository) DeleteChatMembers(chatID int64) error {
+	_, err := us.storage.Exec(`DELETE FROM chat_members WHERE chat_id = $1`, chatID)
+	if err != nil {
+		return errors.Wrapf(err, "cannot delete chat members of chatID=%d", chatID)
+	}
+	return nil
+}
+
+// GetChatMembers returns members who are still in the group ordered by name
+func (us *UserRepository) GetChatMembers(chatID int64) (members []types.ChatMember, err error) {
+	rows, err := us.storage.Query(`
+			SELECT chat_id, telegram_user_id, name, username, joined, authenticated
+			FROM chat_members
+			WHERE chat_id = $1 AND joined
+			ORDER BY name`,
+		chatID,
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to perform SQL query in GetChatMembers")
+	}
+	defer func() {
+		err = customerrors.HandleCloser(err, rows)
+	}()
+
+	for rows.Next() {
+		var member types.ChatMember
+		err := rows.Scan(
+			&member.ChatID,
+			&member.TelegramUserID,
+			&member.Name,
+			&member.Username,
+			&member.Joined,
+			&member.Authenticated,
+		)
+		if err != nil {
+			return nil, errors.Wrap(err, "error while scanning chat members")
+		}
+		members = append(members, member)
+	}
+	return members, nil
+}
+
+// SetChatMemberAuthenticated updates the authenticated flag of the user in all groups
+func (us *UserRepository) SetChatMemberAuthenticated(telegramID int64, authenticated bool) error {
+	_, err := us.storage.Exec(
+		`UPDATE chat_members SET authenticated = $2 WHERE telegram_user_id = $1 AND authenticated <> $2`,
+		telegramID,
+		authenticated,
+	)
+	if err != nil {
+		return errors.Wrapf(err, "cannot set authenticated=%t for chat member telegramID=%d", authenticated, telegramID)
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+package repository
+
+import (
+	"database/sql"
+	"github.com/calendar-bot/pkg/customerrors"
+	"github.com/calendar-bot/pkg/types"
+	"github.com/pkg/errors"
+	"time"
+)
+
+type SettingsEntityError struct {
+	error
+}
+
+var (
+	UserSettingsDoNotExist = SettingsEntityError{errors.New("user settings do not exist")}
+)
+
+const userSettingsColumns = `telegram_user_id, timezone, language, event_duration_minutes, calendar_uid,
+			       reminder_minutes, digest_minutes, image_mode`
+
+func (us *UserRepository) UpsertUserSettings(settings types.UserSettings) error {
+	_, err := us.storage.Exec(`
+			INSERT INTO user_settings(
+			                          telegram_user_id,
+			                          timezone,
+			                          language,
+			                          event_duration_minutes,
+			                          calendar_uid,
+			                          reminder_minutes,
+			                          digest_minutes,
+			                          image_mode,
+			                          updated_at
+			                          )
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, now())
+			ON CONFLICT (telegram_user_id) DO UPDATE
+			SET timezone               = EXCLUDED.timezone,
+			    language               = EXCLUDED.language,
+			    event_duration_minutes = EXCLUDED.event_duration_minutes,
+			    calendar_uid           = EXCLUDED.calendar_uid,
+			    reminder_minutes       = EXCLUDED.reminder_minutes,
+			    digest_minutes         = EXCLUDED.digest_minutes,
+			    image_mode             = EXCLUDED.image_mode,
+			    updated_at             = now()`,
+		settings.TelegramUserID,
+		settings.Timezone,
+		settings.Language,
+		int64(settings.EventDuration/time.Minute),
+		settings.CalendarUID,
+		int64(settings.ReminderLeadTime/time.Minute),
+		int64(settings.DigestTime/time.Minute),
+		settings.ImageMode,
+	)
+	if err != nil {
+		return errors.Wrapf(err, "cannot upsert user settings=%v", settings)
+	}
+	return nil
+}
+
+// GetUserSettings returns settings saved by the user
+// Error types = error, SettingsEntityError
+func (us *UserRepository) GetUserSettings(telegramID int64) (types.UserSettings, error) {
+	settings, err := scanUserSettings(us.storage.QueryRow(
+		`SELECT `+userSettingsColumns+` FROM user_settings WHERE telegram_user_id = $1`,
+		telegramID,
+	))
+
+	switch {
+	case err == sql.ErrNoRows:
+		return types.UserSettings{}, UserSettingsDoNotExist
+	case err != nil:
+		return types.UserSettings{}, errors.Wrapf(err, "failed to get user settings by telegramID=%d", telegramID)
+	}
+	return settings, nil
+}
+
+// GetNotifiedUserSettings returns settings of the users who want reminders or the daily digest
+func (us *UserRepository) GetNotifiedUserSettings() (settings []types.UserSettings, err error) {
+	rows, err := us.storage.Query(
+		`SELECT ` + userSettingsColumns + ` FROM user_settings WHERE reminder_minutes > 0 OR digest_minutes > 0`,
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to perform SQL query in GetNotifiedUserSettings")
+	}
+	defer func() {
+		err = customerrors.HandleCloser(err, rows)
+	}()
+
+	for rows.Next() {
+		userSettings, err := scanUserSettings(rows)
+		if err != nil {
+			return nil, errors.Wrap(err, "error while scanning user settings")
+		}
+		settings = append(settings, userSettings)
+	}
+	return settings, nil
+}
+
+func (us *UserRepository) DeleteUserSettings(telegramID int64) error {
+	_, err := us.storage.Exec(`DELETE FROM user_settings WHERE telegram_user_id = $1`, telegramID)
+	if err != nil {
+		return errors.Wrapf(err, "cannot delete user settings of telegramID=%d", telegramID)
+	}
+	return nil
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanUserSettings(row rowScanner) (types.UserSettings, error) {
+	var settings types.UserSettings
+	var durationMinutes, reminderMinutes, digestMinutes int64
+	err := row.Scan(
+		&settings.TelegramUserID,
+		&settings.Timezone,
+		&settings.Language,
+		&durationMinutes,
+		&settings.CalendarUID,
+		&reminderMinutes,
+		&digestMinutes,
+		&settings.ImageMode,
+	)
+	if err != nil {
+		return types.UserSettings{}, err
+	}
+
+	settings.EventDuration = time.Duration(durationMinutes) * time.Minute
+	settings.ReminderLeadTime = time.Duration(reminderMinutes) * time.Minute
+	settings.DigestTime = time.Duration(digestMinutes) * time.Minute
+	return settings, nil
+}
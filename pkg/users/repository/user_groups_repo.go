@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"github.com/calendar-bot/pkg/customerrors"
+	"github.com/calendar-bot/pkg/types"
+	"github.com/pkg/errors"
+)
+
+// AddUserGroup remembers the group of the user, the title of a known group is updated
+func (us *UserRepository) AddUserGroup(group types.UserGroup) error {
+	_, err := us.storage.Exec(`
+			INSERT INTO user_groups(
+			                        telegram_user_id,
+			                        chat_id,
+			                        title
+			                        )
+			VALUES ($1, $2, $3)
+			ON CONFLICT (telegram_user_id, chat_id) DO UPDATE SET title = EXCLUDED.title`,
+		group.TelegramUserID,
+		group.ChatID,
+		group.Title,
+	)
+	if err != nil {
+		return errors.Wrapf(err, "cannot add user group=%v", group)
+	}
+	return nil
+}
+
+// GetUserGroups returns groups of the user ordered by title
+func (us *UserRepository) GetUserGroups(telegramID int64) (groups []types.UserGroup, err error) {
+	rows, err := us.storage.Query(
+		`SELECT telegram_user_id, chat_id, title FROM user_groups WHERE telegram_user_id = $1 ORDER BY title`,
+		telegramID,
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to perform SQL query in GetUserGroups")
+	}
+	defer func() {
+		err = customerrors.HandleCloser(err, rows)
+	}()
+
+	for rows.Next() {
+		var group types.UserGroup
+		if err := rows.Scan(&group.TelegramUserID, &group.ChatID, &group.Title); err != nil {
+			return nil, errors.Wrap(err, "error while scanning user groups")
+		}
+		groups = append(groups, group)
+	}
+	return groups, nil
+}
+
+func (us *UserRepository) DeleteUserGroup(telegramID int64, chatID int64) error {
+	_, err := us.storage.Exec(
+		`DELETE FROM user_groups WHERE telegram_user_id = $1 AND chat_id = $2`,
+		telegramID,
+		chatID,
+	)
+	if err != nil {
+		return errors.Wrapf(err, "cannot delete group chatID=%d of telegramID=%d", chatID, telegramID)
+	}
+	return nil
+}
+
+// DeleteGroup forgets the group for all users, used when the bot has left the group
+func (us *UserRepository) DeleteGroup(chatID int64) error {
+	_, err := us.storage.Exec(`DELETE FROM user_groups WHERE chat_id = $1`, chatID)
+	if err != nil {
+		return errors.Wrapf(err, "cannot delete group chatID=%d", chatID)
+	}
+	return nil
+}
@@ -4,6 +4,7 @@ import (
 	"database/sql"
 	"fmt"
 	"github.com/calendar-bot/pkg/customerrors"
+	"github.com/calendar-bot/pkg/services/keyring"
 	"github.com/calendar-bot/pkg/types"
 	"github.com/pkg/errors"
 	"strings"
@@ -17,25 +18,29 @@ var (
 	UserDoesNotExist = UserEntityError{errors.New("user does not exist")}
 )
 
+// UserRepository encrypts refresh tokens with the key ring, they are never stored in plaintext
 type UserRepository struct {
 	storage *sql.DB
+	keyRing *keyring.KeyRing
 }
 
-func NewUserRepository(db *sql.DB) UserRepository {
+func NewUserRepository(db *sql.DB, keyRing *keyring.KeyRing) UserRepository {
 	return UserRepository{
 		storage: db,
+		keyRing: keyRing,
 	}
 }
 
 // GetOAuthRefreshTokenByTelegramUserID returns OAuthAccessToken
 // Error types = error, UserEntityError
 func (us *UserRepository) GetOAuthRefreshTokenByTelegramUserID(telegramID int64) (string, error) {
-	var refreshToken string
+	var refreshToken, keyID string
 	err := us.storage.QueryRow(
-		`SELECT u.mail_refresh_token FROM users AS u WHERE u.telegram_user_id = $1`,
+		`SELECT u.mail_refresh_token, u.mail_refresh_token_key_id FROM users AS u WHERE u.telegram_user_id = $1`,
 		telegramID,
 	).Scan(
 		&refreshToken,
+		&keyID,
 	)
 
 	switch {
@@ -45,9 +50,95 @@ func (us *UserRepository) GetOAuthRefreshTokenByTelegramUserID(telegramID int64)
 		return "", errors.Wrapf(err, "failed to get mail refresh token by telegramID=%d", telegramID)
 	}
 
+	refreshToken, err = us.decryptRefreshToken(keyID, refreshToken)
+	if err != nil {
+		return "", errors.WithMessagef(err, "failed to decrypt mail refresh token of telegramID=%d", telegramID)
+	}
 	return refreshToken, nil
 }
 
+// storedRefreshToken is the refresh token as it is saved in the users table
+type storedRefreshToken struct {
+	telegramID   int64
+	refreshToken string
+	keyID        string
+}
+
+// ReencryptRefreshTokens encrypts the tokens which are not encrypted with the active key with it.
+// It is run after the active key is rotated, the old key may be removed from the ring afterwards
+func (us *UserRepository) ReencryptRefreshTokens() (reencrypted int64, err error) {
+	tokens, err := us.getNotActiveKeyRefreshTokens()
+	if err != nil {
+		return 0, err
+	}
+
+	for _, token := range tokens {
+		refreshToken, err := us.decryptRefreshToken(token.keyID, token.refreshToken)
+		if err != nil {
+			return reencrypted, errors.WithMessagef(err,
+				"failed to decrypt mail refresh token of telegramID=%d", token.telegramID)
+		}
+		keyID, encrypted, err := us.keyRing.Encrypt(refreshToken)
+		if err != nil {
+			return reencrypted, errors.WithMessagef(err,
+				"failed to encrypt mail refresh token of telegramID=%d", token.telegramID)
+		}
+
+		// the token is skipped if the user has logged in again meanwhile
+		res, err := us.storage.Exec(`
+				UPDATE users SET mail_refresh_token = $2, mail_refresh_token_key_id = $3
+				WHERE telegram_user_id = $1 AND mail_refresh_token = $4 AND mail_refresh_token_key_id = $5`,
+			token.telegramID,
+			encrypted,
+			keyID,
+			token.refreshToken,
+			token.keyID,
+		)
+		if err != nil {
+			return reencrypted, errors.Wrapf(err,
+				"cannot update mail refresh token of telegramID=%d", token.telegramID)
+		}
+		updated, err := res.RowsAffected()
+		if err != nil {
+			return reencrypted, errors.WithStack(err)
+		}
+		reencrypted += updated
+	}
+	return reencrypted, nil
+}
+
+func (us *UserRepository) getNotActiveKeyRefreshTokens() (tokens []storedRefreshToken, err error) {
+	rows, err := us.storage.Query(
+		`SELECT telegram_user_id, mail_refresh_token, mail_refresh_token_key_id
+			FROM users
+			WHERE mail_refresh_token_key_id <> $1`,
+		us.keyRing.ActiveKeyID(),
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to perform SQL query in getNotActiveKeyRefreshTokens")
+	}
+	defer func() {
+		err = customerrors.HandleCloser(err, rows)
+	}()
+
+	for rows.Next() {
+		var token storedRefreshToken
+		if err := rows.Scan(&token.telegramID, &token.refreshToken, &token.keyID); err != nil {
+			return nil, errors.Wrap(err, "error while scanning refresh tokens")
+		}
+		tokens = append(tokens, token)
+	}
+	return tokens, errors.WithStack(rows.Err())
+}
+
+// decryptRefreshToken returns tokens with empty keyID as is, they were saved before the encryption
+func (us *UserRepository) decryptRefreshToken(keyID string, refreshToken string) (string, error) {
+	if keyID == "" {
+		return refreshToken, nil
+	}
+	return us.keyRing.Decrypt(keyID, refreshToken)
+}
+
 func (us *UserRepository) GetUserEmailByTelegramUserID(telegramID int64) (string, error) {
 	var email string
 	err := us.storage.QueryRow(
@@ -106,24 +197,31 @@ func (us *UserRepository) TryGetUsersEmailsByTelegramUserIDs(telegramIDs []int64
 }
 
 func (us *UserRepository) CreateUser(user types.TelegramDBUser) error {
-	_, err := us.storage.Exec(`
+	keyID, refreshToken, err := us.keyRing.Encrypt(user.MailRefreshToken)
+	if err != nil {
+		return errors.WithMessagef(err, "cannot encrypt mail refresh token of telegramID=%d", user.TelegramUserId)
+	}
+
+	_, err = us.storage.Exec(`
 			INSERT INTO users(
 							  mail_user_id,
 							  mail_user_email,
 			                  mail_refresh_token,
+			                  mail_refresh_token_key_id,
 							  telegram_user_id,
 			                  telegram_user_timezone
 							  )
-			VALUES ($1, $2, $3, $4, $5)`,
+			VALUES ($1, $2, $3, $4, $5, $6)`,
 		user.MailUserID,
 		user.MailUserEmail,
-		user.MailRefreshToken,
+		refreshToken,
+		keyID,
 		user.TelegramUserId,
 		user.TelegramUserTimezone,
 	)
 
 	if err != nil {
-		return errors.Wrapf(err, "cannot create user=%v", user)
+		return errors.Wrapf(err, "cannot create user with telegramUserID=%d", user.TelegramUserId)
 	}
 
 	return nil
@@ -132,10 +230,10 @@ func (us *UserRepository) CreateUser(user types.TelegramDBUser) error {
 func (us *UserRepository) GetTelegramUserTimezoneByTelegramUserID(telegramID int64) (*string, error) {
 	var tz sql.NullString
 	err := us.storage.QueryRow(
-		`UPDATE users SET telegram_user_timezone = $2 WHERE telegram_user_id = $1 RETURNING telegram_user_id`,
+		`SELECT telegram_user_timezone FROM users WHERE telegram_user_id = $1`,
 		telegramID,
 	).Scan(
-		&telegramID,
+		&tz,
 	)
 
 	switch {
@@ -0,0 +1,58 @@
+package usecase
+
+import (
+	"github.com/calendar-bot/pkg/types"
+	"github.com/pkg/errors"
+)
+
+func (uuc *UserUseCase) TouchChatMember(member types.ChatMember) error {
+	if err := uuc.userRepository.TouchChatMember(member); err != nil {
+		return errors.Wrap(err, "TouchChatMember")
+	}
+	return nil
+}
+
+func (uuc *UserUseCase) LeaveChatMember(chatID int64, telegramID int64) error {
+	if err := uuc.userRepository.LeaveChatMember(chatID, telegramID); err != nil {
+		return errors.Wrap(err, "LeaveChatMember")
+	}
+	return nil
+}
+
+func (uuc *UserUseCase) DeleteChatMembers(chatID int64) error {
+	if err := uuc.userRepository.DeleteChatMembers(chatID); err != nil {
+		return errors.Wrap(err, "DeleteChatMembers")
+	}
+	return nil
+}
+
+func (uuc *UserUseCase) GetChatMembers(chatID int64) ([]types.ChatMember, error) {
+	members, err := uuc.userRepository.GetChatMembers(chatID)
+	if err != nil {
+		return nil, errors.Wrap(err, "GetChatMembers")
+	}
+	return members, nil
+}
+
+// AuthenticatedMemberIDs returns telegram IDs of members who have logged in to the bot
+func AuthenticatedMemberIDs(members []types.ChatMember) []int64 {
+	ids := make([]int64, 0, len(members))
+	for _, member := range members {
+		if member.Authenticated {
+			ids = append(ids, member.TelegramUserID)
+		}
+	}
+	return ids
+}
+
+// SplitMembersByAuthentication returns members who have logged in to the bot and who still need to
+func SplitMembersByAuthentication(members []types.ChatMember) (authenticated, notAuthenticated []types.ChatMember) {
+	for _, member := range members {
+		if member.Authenticated {
+			authenticated = append(authenticated, member)
+		} else {
+			notAuthenticated = append(notAuthenticated, member)
+		}
+	}
+	return authenticated, notAuthenticated
+}
@@ -0,0 +1,25 @@
+package usecase
+
+import (
+	"github.com/calendar-bot/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestSplitMembersByAuthentication(t *testing.T) {
+	members := []types.ChatMember{
+		{TelegramUserID: 1, Name: "Alice", Authenticated: true},
+		{TelegramUserID: 2, Name: "Bob"},
+		{TelegramUserID: 3, Name: "Carol", Authenticated: true},
+	}
+
+	authenticated, notAuthenticated := SplitMembersByAuthentication(members)
+	assert.Equal(t, []types.ChatMember{members[0], members[2]}, authenticated)
+	assert.Equal(t, []types.ChatMember{members[1]}, notAuthenticated)
+	assert.Equal(t, []int64{1, 3}, AuthenticatedMemberIDs(members))
+
+	authenticated, notAuthenticated = SplitMembersByAuthentication(nil)
+	assert.Empty(t, authenticated)
+	assert.Empty(t, notAuthenticated)
+	assert.Empty(t, AuthenticatedMemberIDs(nil))
+}
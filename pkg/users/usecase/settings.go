@@ -0,0 +1,129 @@
+package usecase
+
+import (
+	"github.com/calendar-bot/pkg/i18n"
+	"github.com/calendar-bot/pkg/types"
+	"github.com/calendar-bot/pkg/users/repository"
+	"github.com/pkg/errors"
+	"time"
+)
+
+const (
+	DefaultEventDuration = time.Hour
+	MaxEventDuration     = 24 * time.Hour
+	MaxReminderLeadTime  = 24 * time.Hour
+)
+
+type SettingsError struct {
+	error
+}
+
+var (
+	BadTimezone         = SettingsError{errors.New("unknown timezone")}
+	BadLanguage         = SettingsError{errors.New("unknown language")}
+	BadEventDuration    = SettingsError{errors.New("event duration must be a whole number of minutes up to a day")}
+	BadReminderLeadTime = SettingsError{errors.New("reminder lead time must be a whole number of minutes up to a day")}
+	BadDigestTime       = SettingsError{errors.New("digest time must be a whole number of minutes within a day")}
+)
+
+// ValidateUserSettings checks the values which can not be checked without the calendar,
+// the default calendar is checked when the user picks it from the list
+func ValidateUserSettings(settings types.UserSettings) error {
+	if settings.Timezone == "" || settings.Timezone == "Local" {
+		return BadTimezone
+	}
+	if _, err := time.LoadLocation(settings.Timezone); err != nil {
+		return BadTimezone
+	}
+	if _, ok := i18n.Parse(settings.Language); !ok && settings.Language != "" {
+		return BadLanguage
+	}
+	if !wholeMinutes(settings.EventDuration) || settings.EventDuration <= 0 ||
+		settings.EventDuration > MaxEventDuration {
+		return BadEventDuration
+	}
+	if !wholeMinutes(settings.ReminderLeadTime) || settings.ReminderLeadTime < 0 ||
+		settings.ReminderLeadTime > MaxReminderLeadTime {
+		return BadReminderLeadTime
+	}
+	if !wholeMinutes(settings.DigestTime) || settings.DigestTime < 0 || settings.DigestTime >= 24*time.Hour {
+		return BadDigestTime
+	}
+	return nil
+}
+
+func wholeMinutes(d time.Duration) bool {
+	return d%time.Minute == 0
+}
+
+// DefaultUserSettings are used until the user changes anything in /settings
+func (uuc *UserUseCase) DefaultUserSettings(telegramID int64) types.UserSettings {
+	return types.UserSettings{
+		TelegramUserID: telegramID,
+		Timezone:       uuc.defaultTimezone,
+		EventDuration:  DefaultEventDuration,
+	}
+}
+
+// GetUserSettings returns the saved settings, the default ones if the user has not saved any.
+// The timezone saved with the user before the settings appeared is kept
+func (uuc *UserUseCase) GetUserSettings(telegramID int64) (types.UserSettings, error) {
+	settings, err := uuc.userRepository.GetUserSettings(telegramID)
+	switch {
+	case err == repository.UserSettingsDoNotExist:
+		settings = uuc.DefaultUserSettings(telegramID)
+		timezone, err := uuc.userRepository.GetTelegramUserTimezoneByTelegramUserID(telegramID)
+		switch {
+		case err == repository.UserDoesNotExist:
+		case err != nil:
+			return types.UserSettings{}, errors.Wrap(err, "GetUserSettings")
+		case timezone != nil && *timezone != "":
+			settings.Timezone = *timezone
+		}
+		return settings, nil
+	case err != nil:
+		return types.UserSettings{}, errors.Wrap(err, "GetUserSettings")
+	}
+	return settings, nil
+}
+
+// SetUserSettings saves valid settings
+// Error types = error, SettingsError
+func (uuc *UserUseCase) SetUserSettings(settings types.UserSettings) error {
+	if err := ValidateUserSettings(settings); err != nil {
+		return err
+	}
+	if err := uuc.userRepository.UpsertUserSettings(settings); err != nil {
+		return errors.Wrap(err, "SetUserSettings")
+	}
+	return nil
+}
+
+// ResetUserSettings forgets the settings, the defaults are used afterwards
+func (uuc *UserUseCase) ResetUserSettings(telegramID int64) error {
+	if err := uuc.userRepository.DeleteUserSettings(telegramID); err != nil {
+		return errors.Wrap(err, "ResetUserSettings")
+	}
+	return nil
+}
+
+func (uuc *UserUseCase) GetNotifiedUserSettings() ([]types.UserSettings, error) {
+	settings, err := uuc.userRepository.GetNotifiedUserSettings()
+	if err != nil {
+		return nil, errors.Wrap(err, "GetNotifiedUserSettings")
+	}
+	return settings, nil
+}
+
+// GetUserLocation returns the timezone of the user settings, the dates of the user are computed in it
+func (uuc *UserUseCase) GetUserLocation(telegramID int64) (*time.Location, error) {
+	settings, err := uuc.GetUserSettings(telegramID)
+	if err != nil {
+		return nil, err
+	}
+	location, err := time.LoadLocation(settings.Timezone)
+	if err != nil {
+		return nil, errors.Wrapf(err, "GetUserLocation: bad timezone of telegramID=%d", telegramID)
+	}
+	return location, nil
+}
@@ -0,0 +1,45 @@
+package usecase
+
+import (
+	"github.com/calendar-bot/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestValidateUserSettings(t *testing.T) {
+	valid := types.UserSettings{
+		TelegramUserID:   1,
+		Timezone:         "Europe/Moscow",
+		Language:         "en",
+		EventDuration:    90 * time.Minute,
+		ReminderLeadTime: 15 * time.Minute,
+		DigestTime:       9 * time.Hour,
+	}
+	assert.NoError(t, ValidateUserSettings(valid))
+
+	defaults := (&UserUseCase{defaultTimezone: "Europe/Moscow"}).DefaultUserSettings(1)
+	assert.NoError(t, ValidateUserSettings(defaults))
+
+	invalid := []struct {
+		change   func(settings *types.UserSettings)
+		expected error
+	}{
+		{func(s *types.UserSettings) { s.Timezone = "" }, BadTimezone},
+		{func(s *types.UserSettings) { s.Timezone = "Local" }, BadTimezone},
+		{func(s *types.UserSettings) { s.Timezone = "Mars/Olympus" }, BadTimezone},
+		{func(s *types.UserSettings) { s.Language = "de" }, BadLanguage},
+		{func(s *types.UserSettings) { s.EventDuration = 0 }, BadEventDuration},
+		{func(s *types.UserSettings) { s.EventDuration = 25 * time.Hour }, BadEventDuration},
+		{func(s *types.UserSettings) { s.EventDuration = 90 * time.Second }, BadEventDuration},
+		{func(s *types.UserSettings) { s.ReminderLeadTime = -time.Minute }, BadReminderLeadTime},
+		{func(s *types.UserSettings) { s.ReminderLeadTime = 48 * time.Hour }, BadReminderLeadTime},
+		{func(s *types.UserSettings) { s.DigestTime = 24 * time.Hour }, BadDigestTime},
+		{func(s *types.UserSettings) { s.DigestTime = 9*time.Hour + time.Second }, BadDigestTime},
+	}
+	for _, testCase := range invalid {
+		settings := valid
+		testCase.change(&settings)
+		assert.Equal(t, testCase.expected, ValidateUserSettings(settings))
+	}
+}
@@ -0,0 +1,49 @@
+package usecase
+
+import (
+	"github.com/calendar-bot/pkg/types"
+	"github.com/pkg/errors"
+)
+
+func (uuc *UserUseCase) AddUserGroup(group types.UserGroup) error {
+	if err := uuc.userRepository.AddUserGroup(group); err != nil {
+		return errors.Wrap(err, "AddUserGroup")
+	}
+	return nil
+}
+
+func (uuc *UserUseCase) GetUserGroups(telegramID int64) ([]types.UserGroup, error) {
+	groups, err := uuc.userRepository.GetUserGroups(telegramID)
+	if err != nil {
+		return nil, errors.Wrap(err, "GetUserGroups")
+	}
+	return groups, nil
+}
+
+// GetUserGroup returns nil if the user is not known in the group
+func (uuc *UserUseCase) GetUserGroup(telegramID int64, chatID int64) (*types.UserGroup, error) {
+	groups, err := uuc.GetUserGroups(telegramID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range groups {
+		if groups[i].ChatID == chatID {
+			return &groups[i], nil
+		}
+	}
+	return nil, nil
+}
+
+func (uuc *UserUseCase) DeleteUserGroup(telegramID int64, chatID int64) error {
+	if err := uuc.userRepository.DeleteUserGroup(telegramID, chatID); err != nil {
+		return errors.Wrap(err, "DeleteUserGroup")
+	}
+	return nil
+}
+
+func (uuc *UserUseCase) DeleteGroup(chatID int64) error {
+	if err := uuc.userRepository.DeleteGroup(chatID); err != nil {
+		return errors.Wrap(err, "DeleteGroup")
+	}
+	return nil
+}
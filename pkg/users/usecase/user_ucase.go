@@ -11,14 +11,16 @@ import (
 )
 
 type UserUseCase struct {
-	userRepository repository.UserRepository
-	oauthService   *oauth.Service
+	userRepository  repository.UserRepository
+	oauthService    *oauth.Service
+	defaultTimezone string
 }
 
-func NewUserUseCase(userRepo repository.UserRepository, service *oauth.Service) UserUseCase {
+func NewUserUseCase(userRepo repository.UserRepository, service *oauth.Service, defaultTimezone string) UserUseCase {
 	return UserUseCase{
-		userRepository: userRepo,
-		oauthService:   service,
+		userRepository:  userRepo,
+		oauthService:    service,
+		defaultTimezone: defaultTimezone,
 	}
 }
 
@@ -111,6 +113,9 @@ func (uuc *UserUseCase) TelegramCreateAuthenticatedUser(tgUserID int64, mailAuth
 	if err := uuc.userRepository.CreateUser(user); err != nil {
 		return errors.Wrapf(err, "db error, failed to authenticated user, telegramUserID=%d", tgUserID)
 	}
+	if err := uuc.userRepository.SetChatMemberAuthenticated(tgUserID, true); err != nil {
+		return errors.Wrapf(err, "db error, failed to mark chat member authenticated, telegramUserID=%d", tgUserID)
+	}
 
 	err = uuc.setOAuthAccessTokenByTelegramUserID(
 		tgUserID,
@@ -322,6 +327,9 @@ func (uuc *UserUseCase) DeleteLocalAuthenticatedUserByTelegramUserID(telegramID
 	if err := uuc.delOAuthAccessTokenByTelegramUserID(telegramID); err != nil {
 		return errors.Wrapf(err, "failed to delete acces token in redis by telegramUserID=%d", telegramID)
 	}
+	if err := uuc.userRepository.SetChatMemberAuthenticated(telegramID, false); err != nil {
+		return errors.WithStack(err)
+	}
 	switch err := uuc.userRepository.DeleteUserByTelegramUserID(telegramID); err {
 	case nil:
 		return nil